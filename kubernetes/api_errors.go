@@ -0,0 +1,143 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// apiError is the error of an operation of a resource or data source on an object, formatted as
+// `kubernetes: <verb> <kind>/<name>: <error>`. When the operation failed on a request to the
+// API server, the HTTP status code & Kubernetes reason follow the name, e.g.
+// `kubernetes: create deployment/default/web: 409 AlreadyExists: ...`, so tooling parsing the
+// output of Terraform can tell a forbidden request from a missing or conflicting object.
+type apiError struct {
+	Verb string
+	Kind string
+	Name string
+	Err  error
+}
+
+func (e *apiError) Error() string {
+	prefix := fmt.Sprintf("kubernetes: %s %s/%s: ", e.Verb, e.Kind, e.Name)
+	status, ok := e.Err.(errors.APIStatus)
+	if !ok || status.Status().Code == 0 {
+		// The status may be lost in an error formatted by the resource
+		return prefix + formatWebhookDenial(e.Err.Error(), nil)
+	}
+	s := status.Status()
+	return fmt.Sprintf("%s%d %s: %s", prefix, s.Code, s.Reason, formatWebhookDenial(e.Err.Error(), s.Details))
+}
+
+// webhookDenialPattern matches the message of the API server when an admission webhook rejects a request.
+var webhookDenialPattern = regexp.MustCompile(`admission webhook "([^"]+)" denied the request(?s)(.*)`)
+
+// formatWebhookDenial formats the rejection of a request by an admission webhook, e.g. by an OPA
+// Gatekeeper or Kyverno policy, so the webhook is named up front and each line of its message and
+// each cause in the details of the status is listed on its own. Other messages are returned as they are.
+func formatWebhookDenial(msg string, details *metav1.StatusDetails) string {
+	m := webhookDenialPattern.FindStringSubmatchIndex(msg)
+	if m == nil {
+		return msg
+	}
+	webhook := msg[m[2]:m[3]]
+	explanation := strings.TrimPrefix(strings.TrimSpace(msg[m[4]:m[5]]), ":")
+
+	var reasons []string
+	for _, line := range strings.Split(explanation, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			reasons = append(reasons, "\n   * "+line)
+		}
+	}
+	if details != nil {
+		for _, c := range details.Causes {
+			cause := c.Message
+			if c.Field != "" {
+				cause = c.Field + ": " + c.Message
+			}
+			if cause != "" && !strings.Contains(explanation, c.Message) {
+				reasons = append(reasons, "\n   * "+cause)
+			}
+		}
+	}
+	if len(reasons) == 0 || explanation == "without explanation" {
+		return fmt.Sprintf("%sdenied by admission webhook %q without explanation", msg[:m[0]], webhook)
+	}
+	return fmt.Sprintf("%sdenied by admission webhook %q:%s", msg[:m[0]], webhook, strings.Join(reasons, ""))
+}
+
+// Status returns the status of the failed API request, so the helpers of
+// k8s.io/apimachinery/pkg/api/errors (e.g. IsNotFound) keep working with the error.
+func (e *apiError) Status() metav1.Status {
+	if status, ok := e.Err.(errors.APIStatus); ok {
+		return status.Status()
+	}
+	return metav1.Status{
+		Status:  metav1.StatusFailure,
+		Reason:  metav1.StatusReasonUnknown,
+		Message: e.Err.Error(),
+	}
+}
+
+// wrapAPIErrors wraps the operations of each resource, so all their errors are apiErrors.
+func wrapAPIErrors(resources map[string]*schema.Resource) {
+	for typ, r := range resources {
+		kind := strings.TrimPrefix(typ, "kubernetes_")
+		if r.Create != nil {
+			r.Create = wrapCRUDErrors(r.Create, "create", kind)
+		}
+		if r.Read != nil {
+			r.Read = wrapCRUDErrors(r.Read, "read", kind)
+		}
+		if r.Update != nil {
+			r.Update = wrapCRUDErrors(r.Update, "update", kind)
+		}
+		if r.Delete != nil {
+			r.Delete = wrapCRUDErrors(r.Delete, "delete", kind)
+		}
+		if r.Exists != nil {
+			exists := r.Exists
+			r.Exists = func(d *schema.ResourceData, meta interface{}) (bool, error) {
+				ok, err := exists(d, meta)
+				return ok, wrapAPIError(err, d, "read", kind)
+			}
+		}
+	}
+}
+
+func wrapCRUDErrors(f func(*schema.ResourceData, interface{}) error, verb, kind string) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		return wrapAPIError(f(d, meta), d, verb, kind)
+	}
+}
+
+func wrapAPIError(err error, d *schema.ResourceData, verb, kind string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*apiError); ok {
+		return err
+	}
+	return &apiError{Verb: verb, Kind: kind, Name: objectName(d), Err: err}
+}
+
+// objectName returns the id of the object, or the one it's about to get from its metadata.
+func objectName(d *schema.ResourceData) string {
+	if d.Id() != "" {
+		return d.Id()
+	}
+	// Not every data source has a metadata block
+	namespace, _ := d.Get("metadata.0.namespace").(string)
+	name, _ := d.Get("metadata.0.name").(string)
+	if name == "" {
+		name, _ = d.Get("metadata.0.generate_name").(string)
+	}
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
@@ -0,0 +1,137 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestWrapAPIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "AlreadyExists", "code": 409,
+	"message": "namespaces \"team-a\" already exists"}`)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "Forbidden", "code": 403,
+	"message": "config maps \"settings\" is forbidden: User \"ci\" cannot get configmaps in the namespace \"team-a\""}`)
+		}
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &kubernetesProvider{conn: conn}
+	resources := Provider().(*schema.Provider).ResourcesMap
+
+	r := resources["kubernetes_namespace"]
+	d := r.TestResourceData()
+	d.Set("metadata", []interface{}{map[string]interface{}{"name": "team-a"}})
+	err = r.Create(d, meta)
+	expected := `kubernetes: create namespace/team-a: 409 AlreadyExists: namespaces "team-a" already exists`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, given: %v", expected, err)
+	}
+	if !errors.IsAlreadyExists(err) {
+		t.Fatalf("Expected the status of the request to be kept, given: %#v", err)
+	}
+
+	r = resources["kubernetes_config_map"]
+	d = r.TestResourceData()
+	d.SetId("team-a/settings")
+	err = r.Read(d, meta)
+	expected = `kubernetes: read config_map/team-a/settings: 403 Forbidden: config maps "settings" is forbidden: User "ci" cannot get configmaps in the namespace "team-a"`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, given: %v", expected, err)
+	}
+	if !errors.IsForbidden(err) {
+		t.Fatalf("Expected the status of the request to be kept, given: %#v", err)
+	}
+}
+
+func TestAPIErrorWithoutStatus(t *testing.T) {
+	d := resourceKubernetesConfigMap().TestResourceData()
+	d.Set("metadata", []interface{}{map[string]interface{}{"generate_name": "settings-", "namespace": "team-a"}})
+
+	err := wrapAPIError(fmt.Errorf("Failed to marshal update operations"), d, "update", "config_map")
+	expected := "kubernetes: update config_map/team-a/settings-: Failed to marshal update operations"
+	if err.Error() != expected {
+		t.Fatalf("Expected error %q, given: %q", expected, err)
+	}
+	if reason := errors.ReasonForError(err); reason != metav1.StatusReasonUnknown {
+		t.Fatalf("Expected an unknown reason, given: %q", reason)
+	}
+
+	if wrapAPIError(err, d, "create", "config_map") != err {
+		t.Fatal("Expected the error to be wrapped once")
+	}
+}
+
+func TestAPIErrorWebhookDenial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "Forbidden", "code": 403,
+	"message": "admission webhook \"validation.gatekeeper.sh\" denied the request: [must-have-owner] you must provide labels: {\"owner\"}\n[no-latest-tag] image nginx uses the latest tag",
+	"details": {"causes": [{"field": "metadata.labels", "message": "owner is required"}]}}`)
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := Provider().(*schema.Provider).ResourcesMap["kubernetes_namespace"]
+	d := r.TestResourceData()
+	d.Set("metadata", []interface{}{map[string]interface{}{"name": "team-a"}})
+
+	err = r.Create(d, &kubernetesProvider{conn: conn})
+	expected := `kubernetes: create namespace/team-a: 403 Forbidden: denied by admission webhook "validation.gatekeeper.sh":
+   * [must-have-owner] you must provide labels: {"owner"}
+   * [no-latest-tag] image nginx uses the latest tag
+   * metadata.labels: owner is required`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, given: %v", expected, err)
+	}
+	if !errors.IsForbidden(err) {
+		t.Fatalf("Expected the status of the request to be kept, given: %#v", err)
+	}
+}
+
+func TestFormatWebhookDenial(t *testing.T) {
+	cases := []struct {
+		Message  string
+		Expected string
+	}{
+		{
+			`Failed to update service: admission webhook "validate.kyverno.svc" denied the request: require-labels: label 'team' is required`,
+			"Failed to update service: denied by admission webhook \"validate.kyverno.svc\":\n   * require-labels: label 'team' is required",
+		},
+		{
+			`admission webhook "deny.example.com" denied the request without explanation`,
+			`denied by admission webhook "deny.example.com" without explanation`,
+		},
+		{
+			`namespaces "team-a" already exists`,
+			`namespaces "team-a" already exists`,
+		},
+	}
+
+	for i, tc := range cases {
+		if msg := formatWebhookDenial(tc.Message, nil); msg != tc.Expected {
+			t.Fatalf("%d: Expected %q, given: %q", i, tc.Expected, msg)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const (
+	binaryDataModeFull     = "full"
+	binaryDataModeChecksum = "checksum"
+
+	binaryDataChecksumPrefix = "sha256:"
+)
+
+// expandBase64EncodedMap decodes the base64 encoded values of binary data.
+func expandBase64EncodedMap(m map[string]interface{}) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(m))
+	for k, v := range m {
+		b, err := base64.StdEncoding.DecodeString(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode binary data %q: %s", k, err)
+		}
+		result[k] = b
+	}
+	return result, nil
+}
+
+// flattenBinaryData returns the binary data as it's kept in the state: base64 encoded
+// in the `full` mode, or only a checksum of each value in the `checksum` mode.
+func flattenBinaryData(in map[string][]byte, mode string) map[string]string {
+	result := make(map[string]string, len(in))
+	for k, v := range in {
+		if mode == binaryDataModeChecksum {
+			result[k] = binaryDataChecksum(v)
+			continue
+		}
+		result[k] = base64.StdEncoding.EncodeToString(v)
+	}
+	return result
+}
+
+func binaryDataChecksum(b []byte) string {
+	return fmt.Sprintf("%s%x", binaryDataChecksumPrefix, sha256.Sum256(b))
+}
+
+// suppressBinaryDataChecksumDiff compares the checksum kept in the state
+// with the checksum of the configured value when in the `checksum` mode.
+func suppressBinaryDataChecksumDiff(k, old, new string, d *schema.ResourceData) bool {
+	if d.Get("binary_data_mode").(string) != binaryDataModeChecksum || strings.HasSuffix(k, ".%") {
+		return false
+	}
+	if !strings.HasPrefix(old, binaryDataChecksumPrefix) {
+		return false
+	}
+	b, err := base64.StdEncoding.DecodeString(new)
+	if err != nil {
+		return false
+	}
+	return old == binaryDataChecksum(b)
+}
@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestFlattenBinaryData(t *testing.T) {
+	in := map[string][]byte{
+		"blob": {0xde, 0xad, 0xbe, 0xef},
+	}
+
+	full := flattenBinaryData(in, binaryDataModeFull)
+	if full["blob"] != "3q2+7w==" {
+		t.Fatalf("Expected the base64 encoded value, given: %q", full["blob"])
+	}
+
+	checksum := flattenBinaryData(in, binaryDataModeChecksum)
+	expected := "sha256:5f78c33274e43fa9de5659265c1d917e25c03722dcb0b8d27db8d5feaa813953"
+	if checksum["blob"] != expected {
+		t.Fatalf("Expected checksum %q, given: %q", expected, checksum["blob"])
+	}
+
+	out, err := expandBase64EncodedMap(map[string]interface{}{"blob": full["blob"]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("Binary data did not survive round trip.\nExpected: %#v\nGiven:    %#v", in, out)
+	}
+}
+
+func TestSuppressBinaryDataChecksumDiff(t *testing.T) {
+	s := resourceKubernetesConfigMap().Schema
+	payload := []byte("large binary payload")
+	encoded := base64.StdEncoding.EncodeToString(payload)
+
+	cases := []struct {
+		Mode     string
+		Key      string
+		Old      string
+		New      string
+		Expected bool
+	}{
+		{binaryDataModeChecksum, "binary_data.blob", binaryDataChecksum(payload), encoded, true},
+		{binaryDataModeChecksum, "binary_data.blob", binaryDataChecksum([]byte("drifted")), encoded, false},
+		{binaryDataModeChecksum, "binary_data.blob", binaryDataChecksum(payload), "not base64!", false},
+		{binaryDataModeChecksum, "binary_data.%", "1", "1", false},
+		{binaryDataModeFull, "binary_data.blob", binaryDataChecksum(payload), encoded, false},
+	}
+
+	for i, tc := range cases {
+		d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+			"binary_data_mode": tc.Mode,
+		})
+		if got := suppressBinaryDataChecksumDiff(tc.Key, tc.Old, tc.New, d); got != tc.Expected {
+			t.Fatalf("%d: Expected the diff of %s (%s mode) to be suppressed: %t, given: %t", i, tc.Key, tc.Mode, tc.Expected, got)
+		}
+	}
+}
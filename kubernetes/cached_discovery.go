@@ -58,6 +58,8 @@ type CachedDiscoveryClient struct {
 	invalidated bool
 	// fresh is true if all used cache files were ours
 	fresh bool
+	// invalidatedAt is when Invalidate() was last called
+	invalidatedAt time.Time
 }
 
 var _ discovery.CachedDiscoveryInterface = &CachedDiscoveryClient{}
@@ -249,9 +251,28 @@ func (d *CachedDiscoveryClient) Invalidate() {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
+	d.invalidate()
+}
+
+// InvalidateOlderThan invalidates the cache unless it was invalidated less than age ago, so retries
+// waiting for a resource to be served, e.g. by several resources at once, don't re-read all the
+// discovery docs of the API server on every attempt. It reports whether the cache was invalidated.
+func (d *CachedDiscoveryClient) InvalidateOlderThan(age time.Duration) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if time.Since(d.invalidatedAt) < age {
+		return false
+	}
+	d.invalidate()
+	return true
+}
+
+func (d *CachedDiscoveryClient) invalidate() {
 	d.ourFiles = map[string]struct{}{}
 	d.fresh = true
 	d.invalidated = true
+	d.invalidatedAt = time.Now()
 }
 
 // NewCachedDiscoveryClient creates a new DiscoveryClient.  cacheDirectory is the directory where discovery docs are held.  It must be unique per host:port combination to work well.
@@ -0,0 +1,26 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCachedDiscoveryClientInvalidateOlderThan(t *testing.T) {
+	d := NewCachedDiscoveryClient(nil, t.Name(), time.Minute)
+	d.fresh = false
+
+	if !d.InvalidateOlderThan(time.Minute) {
+		t.Fatal("Expected a cache which was never invalidated to be invalidated")
+	}
+	if !d.invalidated || !d.fresh {
+		t.Fatalf("Expected the cache files to be ignored, given: %#v", d)
+	}
+	if d.InvalidateOlderThan(time.Minute) {
+		t.Fatal("Expected a cache invalidated less than a minute ago not to be invalidated again")
+	}
+
+	d.invalidatedAt = time.Now().Add(-2 * time.Minute)
+	if !d.InvalidateOlderThan(time.Minute) {
+		t.Fatal("Expected a cache invalidated more than a minute ago to be invalidated")
+	}
+}
@@ -0,0 +1,105 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// commonLabelsSchema is the common_labels argument of the workload resources. These labels are set
+// at once on the metadata of the workload, on its selector and on the metadata of its pod template,
+// so they can't fall out of sync with each other. The selector of a workload can't be updated,
+// so changing them re-creates the workload.
+func commonLabelsSchema(objectName string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeMap,
+		Description:  fmt.Sprintf("Labels set on the metadata of the %s, its selector and the metadata of its pod template at once, so they can't be declared inconsistently. Changing them re-creates the %s, as its selector can't be updated. The keys can't also be set in `metadata.0.labels`, `spec.0.selector` or `spec.0.template.0.metadata.0.labels`, which stay available to set labels independently.", objectName, objectName),
+		Optional:     true,
+		ForceNew:     true,
+		Elem:         &schema.Schema{Type: schema.TypeString},
+		ValidateFunc: validateLabels,
+	}
+}
+
+// checkCommonLabels is meant to be called from CustomizeDiff of the workload resources,
+// before checkSelectorMatchesTemplateLabels. It fails the plan when a common label is also
+// set in one of the labels under keys, the value of such a label would be ambiguous.
+func checkCommonLabels(d *schema.ResourceDiff, keys ...string) error {
+	common := d.Get("common_labels").(map[string]interface{})
+	if len(common) == 0 {
+		return nil
+	}
+
+	var duplicates []string
+	for _, key := range keys {
+		labels := d.Get(key).(map[string]interface{})
+		for k := range common {
+			if _, ok := labels[k]; ok {
+				duplicates = append(duplicates, fmt.Sprintf("\n   * %s is also set in %s", k, key))
+			}
+		}
+	}
+	if len(duplicates) == 0 {
+		return nil
+	}
+	sort.Strings(duplicates)
+	return fmt.Errorf("Labels of common_labels can't also be set elsewhere, set each of them in one place:%s",
+		strings.Join(duplicates, ""))
+}
+
+// applyCommonLabels adds the common labels of the workload to its metadata, its selector & its pod template.
+func applyCommonLabels(d *schema.ResourceData, metadata *metav1.ObjectMeta, selector **metav1.LabelSelector, template *metav1.ObjectMeta) {
+	common := expandStringMap(d.Get("common_labels").(map[string]interface{}))
+	if len(common) == 0 {
+		return
+	}
+	if metadata != nil {
+		metadata.Labels = mergeStringMaps(metadata.Labels, common)
+	}
+	if selector != nil {
+		if *selector == nil {
+			*selector = &metav1.LabelSelector{}
+		}
+		(*selector).MatchLabels = mergeStringMaps((*selector).MatchLabels, common)
+	}
+	if template != nil {
+		template.Labels = mergeStringMaps(template.Labels, common)
+	}
+}
+
+// removeCommonLabels removes the common labels of the workload from the live object before it's flattened,
+// they're set in common_labels and not in the labels they're applied to.
+func removeCommonLabels(d *schema.ResourceData, metadata *metav1.ObjectMeta, selector *metav1.LabelSelector, template *metav1.ObjectMeta) {
+	for k := range d.Get("common_labels").(map[string]interface{}) {
+		if metadata != nil {
+			delete(metadata.Labels, k)
+		}
+		if selector != nil {
+			delete(selector.MatchLabels, k)
+		}
+		if template != nil {
+			delete(template.Labels, k)
+		}
+	}
+}
+
+// patchMetadataWithCommonLabels is patchMetadataWithDefaults for the workload resources. The configured labels are
+// diffed along with the common labels, which are on the object too, so they're never replaced along with the map.
+func patchMetadataWithCommonLabels(d *schema.ResourceData, meta interface{}) PatchOperations {
+	labels, annotations := providerDefaultMetadata(meta)
+	common := expandStringMap(d.Get("common_labels").(map[string]interface{}))
+	return patchMetadataOnto(d, mergeStringMaps(labels, common), annotations)
+}
+
+func mergeStringMaps(maps ...map[string]string) map[string]string {
+	out := make(map[string]string, 0)
+	for _, m := range maps {
+		for k, v := range m {
+			out[k] = v
+		}
+	}
+	return out
+}
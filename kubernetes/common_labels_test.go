@@ -0,0 +1,95 @@
+package kubernetes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentCommonLabelsDiff(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Labels        map[string]interface{}
+		ExpectedError string
+	}{
+		{"common only", map[string]interface{}{}, ""},
+		{"independent", map[string]interface{}{"tier": "frontend"}, ""},
+		{"duplicate", map[string]interface{}{"app": "web"}, "app is also set in spec.0.template.0.metadata.0.labels"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata":      []map[string]interface{}{{"name": "web"}},
+				"common_labels": map[string]interface{}{"app": "web"},
+				"spec": []map[string]interface{}{{
+					"template": []map[string]interface{}{{
+						"metadata": []map[string]interface{}{{"labels": tc.Labels}},
+					}},
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = resourceKubernetesDeployment().Diff(nil, terraform.NewResourceConfig(raw), &kubernetesProvider{})
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestApplyAndRemoveCommonLabels(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceKubernetesDeployment().Schema, map[string]interface{}{
+		"common_labels": map[string]interface{}{"app": "web"},
+	})
+
+	metadata := metav1.ObjectMeta{Labels: map[string]string{"team": "platform"}}
+	template := metav1.ObjectMeta{}
+	var selector *metav1.LabelSelector
+	applyCommonLabels(d, &metadata, &selector, &template)
+
+	if expected := map[string]string{"app": "web", "team": "platform"}; !reflect.DeepEqual(metadata.Labels, expected) {
+		t.Fatalf("Expected metadata labels %#v, given: %#v", expected, metadata.Labels)
+	}
+	if selector == nil || !reflect.DeepEqual(selector.MatchLabels, map[string]string{"app": "web"}) {
+		t.Fatalf("Expected the selector to match the common labels, given: %#v", selector)
+	}
+	if expected := map[string]string{"app": "web"}; !reflect.DeepEqual(template.Labels, expected) {
+		t.Fatalf("Expected template labels %#v, given: %#v", expected, template.Labels)
+	}
+
+	removeCommonLabels(d, &metadata, selector, &template)
+	if expected := map[string]string{"team": "platform"}; !reflect.DeepEqual(metadata.Labels, expected) {
+		t.Fatalf("Expected metadata labels %#v, given: %#v", expected, metadata.Labels)
+	}
+	if len(selector.MatchLabels) != 0 || len(template.Labels) != 0 {
+		t.Fatalf("Expected the common labels to be removed, given: %#v, %#v", selector.MatchLabels, template.Labels)
+	}
+}
+
+func TestPatchMetadataWithCommonLabels(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceKubernetesDeployment().Schema, map[string]interface{}{
+		"metadata":      []interface{}{map[string]interface{}{"name": "web", "labels": map[string]interface{}{"tier": "frontend"}}},
+		"common_labels": map[string]interface{}{"app": "web"},
+	})
+
+	// The common labels are on the object already, only the configured label is added
+	ops := patchMetadataWithCommonLabels(d, &kubernetesProvider{})
+	expected := PatchOperations{&AddOperation{Path: "/metadata/labels/tier", Value: "frontend"}}
+	if !reflect.DeepEqual(ops, expected) {
+		t.Fatalf("Expected %#v, given: %#v", expected, ops)
+	}
+}
@@ -0,0 +1,153 @@
+package kubernetes
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sSchema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// dataSourceKubernetesAPIResources lists the API resources served by the cluster,
+// from the same cached discovery data the provider picks API groups with, so modules
+// can check whether an API version or a custom resource is served before using it.
+func dataSourceKubernetesAPIResources() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesAPIResourcesRead,
+		Schema: map[string]*schema.Schema{
+			"group_version": {
+				Type:        schema.TypeString,
+				Description: "Only list the resources of this API group version, e.g. `autoscaling/v2` or `v1` for the core group.",
+				Optional:    true,
+			},
+			"group_versions": {
+				Type:        schema.TypeList,
+				Description: "The API group versions served by the cluster which have resources listed, sorted.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"resources": {
+				Type:        schema.TypeList,
+				Description: "The API resources served by the cluster, sorted by group version & name. Subresources like `deployments/scale` are left out.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_version": {
+							Type:        schema.TypeString,
+							Description: "The API group version of the resource, as used in `apiVersion`.",
+							Computed:    true,
+						},
+						"group": {
+							Type:        schema.TypeString,
+							Description: "The API group of the resource, empty for the core group.",
+							Computed:    true,
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Description: "The version of the API group of the resource.",
+							Computed:    true,
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Description: "The kind of the objects of the resource.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The plural name of the resource, as used in URLs & RBAC rules.",
+							Computed:    true,
+						},
+						"namespaced": {
+							Type:        schema.TypeBool,
+							Description: "Whether the objects of the resource belong to a namespace.",
+							Computed:    true,
+						},
+						"verbs": {
+							Type:        schema.TypeList,
+							Description: "The verbs supported by the resource, e.g. `get`, `list` or `watch`.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesAPIResourcesRead(d *schema.ResourceData, meta interface{}) error {
+	kp := meta.(*kubernetesProvider)
+
+	groupVersion := d.Get("group_version").(string)
+	log.Printf("[INFO] Reading API resources")
+	resLists, err := kp.discoClient.ServerResources()
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+
+	groupVersions, resources, err := flattenAPIResourceLists(resLists, groupVersion)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Received %d API resources", len(resources))
+
+	if groupVersion == "" {
+		d.SetId("api-resources")
+	} else {
+		d.SetId(groupVersion)
+	}
+	err = d.Set("group_versions", groupVersions)
+	if err != nil {
+		return err
+	}
+	err = d.Set("resources", resources)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// flattenAPIResourceLists flattens the discovered resources, of groupVersion only unless it's empty.
+func flattenAPIResourceLists(in []*metav1.APIResourceList, groupVersion string) ([]interface{}, []interface{}, error) {
+	sorted := make([]*metav1.APIResourceList, 0, len(in))
+	for _, l := range in {
+		if l == nil || (groupVersion != "" && l.GroupVersion != groupVersion) {
+			continue
+		}
+		sorted = append(sorted, l)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].GroupVersion < sorted[j].GroupVersion })
+
+	groupVersions := make([]interface{}, 0, len(sorted))
+	resources := make([]interface{}, 0)
+	for _, l := range sorted {
+		gv, err := k8sSchema.ParseGroupVersion(l.GroupVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+		groupVersions = append(groupVersions, l.GroupVersion)
+
+		apiResources := make([]metav1.APIResource, len(l.APIResources))
+		copy(apiResources, l.APIResources)
+		sort.Slice(apiResources, func(i, j int) bool { return apiResources[i].Name < apiResources[j].Name })
+		for _, r := range apiResources {
+			if strings.Contains(r.Name, "/") {
+				continue
+			}
+			resources = append(resources, map[string]interface{}{
+				"group_version": l.GroupVersion,
+				"group":         gv.Group,
+				"version":       gv.Version,
+				"kind":          r.Kind,
+				"name":          r.Name,
+				"namespaced":    r.Namespaced,
+				"verbs":         []string(r.Verbs),
+			})
+		}
+	}
+	return groupVersions, resources, nil
+}
@@ -0,0 +1,65 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFlattenAPIResourceLists(t *testing.T) {
+	in := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+				{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get"}},
+				{Name: "nodes", Kind: "Node", Verbs: metav1.Verbs{"get"}},
+			},
+		},
+		{
+			GroupVersion: "autoscaling/v2",
+			APIResources: []metav1.APIResource{
+				{Name: "horizontalpodautoscalers", Kind: "HorizontalPodAutoscaler", Namespaced: true, Verbs: metav1.Verbs{"create"}},
+			},
+		},
+	}
+
+	groupVersions, resources, err := flattenAPIResourceLists(in, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []interface{}{"autoscaling/v2", "v1"}; !reflect.DeepEqual(groupVersions, expected) {
+		t.Fatalf("Expected group versions %#v, given: %#v", expected, groupVersions)
+	}
+	var names []string
+	for _, r := range resources {
+		m := r.(map[string]interface{})
+		names = append(names, m["group_version"].(string)+" "+m["name"].(string))
+	}
+	if expected := []string{"autoscaling/v2 horizontalpodautoscalers", "v1 nodes", "v1 pods"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expected resources %#v, given: %#v", expected, names)
+	}
+	hpa := resources[0].(map[string]interface{})
+	if hpa["group"] != "autoscaling" || hpa["version"] != "v2" || hpa["kind"] != "HorizontalPodAutoscaler" || hpa["namespaced"] != true {
+		t.Fatalf("Unexpected resource: %#v", hpa)
+	}
+
+	groupVersions, resources, err = flattenAPIResourceLists(in, "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groupVersions) != 1 || len(resources) != 2 || resources[0].(map[string]interface{})["group"] != "" {
+		t.Fatalf("Expected the resources of the core group only, given: %#v", resources)
+	}
+
+	// The flattened resources fit the schema of the data source
+	d := schema.TestResourceDataRaw(t, dataSourceKubernetesAPIResources().Schema, map[string]interface{}{})
+	if err := d.Set("resources", resources); err != nil {
+		t.Fatal(err)
+	}
+	if verbs := d.Get("resources.1.verbs").([]interface{}); !reflect.DeepEqual(verbs, []interface{}{"get", "list"}) {
+		t.Fatalf("Unexpected verbs: %#v", verbs)
+	}
+}
@@ -0,0 +1,38 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesClusterRole() *schema.Resource {
+	dsSchema := datasourceSchemaFromResourceSchema(resourceKubernetesClusterRole().Schema)
+
+	addRequiredFieldsToSchema(dsSchema, "metadata")
+	addRequiredFieldsToSchema(dsSchema["metadata"].Elem.(*schema.Resource).Schema, "name")
+
+	return &schema.Resource{
+		Read: dataSourceKubernetesClusterRoleRead,
+
+		Schema: dsSchema,
+	}
+}
+
+func dataSourceKubernetesClusterRoleRead(d *schema.ResourceData, meta interface{}) error {
+	om := meta_v1.ObjectMeta{
+		Name: d.Get("metadata.0.name").(string),
+	}
+	d.SetId(buildId(om))
+
+	err := resourceKubernetesClusterRoleRead(d, meta)
+	if err != nil {
+		return err
+	}
+	// The read of the resource removes a missing object from the state
+	if d.Id() == "" {
+		return fmt.Errorf("Cluster role %q not found", om.Name)
+	}
+	return nil
+}
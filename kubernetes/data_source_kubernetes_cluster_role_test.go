@@ -0,0 +1,87 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesDataSourceClusterRole_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceClusterRoleConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("data.kubernetes_cluster_role.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_cluster_role.test", "metadata.0.self_link"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_cluster_role.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role.test", "rule.#", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role.test", "rule.0.api_groups.#", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role.test", "rule.0.resources.#", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role.test", "rule.0.resources.0", "pods"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role.test", "rule.0.verbs.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDataSourceClusterRole_builtIn(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceClusterRoleConfig_builtIn("view"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role.test", "metadata.0.name", "view"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_cluster_role.test", "rule.#"),
+				),
+			},
+			{
+				Config:      testAccKubernetesDataSourceClusterRoleConfig_builtIn("tf-acc-test-missing"),
+				ExpectError: regexp.MustCompile("not found"),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceClusterRoleConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_cluster_role" "test" {
+	metadata {
+		name = "%s"
+	}
+
+	rule {
+		api_groups = [""]
+		resources  = ["pods"]
+		verbs      = ["get", "list"]
+	}
+}
+
+data "kubernetes_cluster_role" "test" {
+	metadata {
+		name = "${kubernetes_cluster_role.test.metadata.0.name}"
+	}
+}
+`, name)
+}
+
+func testAccKubernetesDataSourceClusterRoleConfig_builtIn(name string) string {
+	return fmt.Sprintf(`
+data "kubernetes_cluster_role" "test" {
+	metadata {
+		name = "%s"
+	}
+}
+`, name)
+}
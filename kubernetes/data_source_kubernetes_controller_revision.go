@@ -0,0 +1,102 @@
+package kubernetes
+
+import (
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesControllerRevision() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesControllerRevisionRead,
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace to list the controller revisions in.",
+				Optional:    true,
+				Default:     "default",
+			},
+			"label_selector": {
+				Type:         schema.TypeString,
+				Description:  "A label query over the controller revisions, e.g. `app=web`. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+				Required:     true,
+				ValidateFunc: validateLabelSelectorString,
+			},
+			"field_selector": fieldSelectorSchema("controller revisions"),
+			"revisions": {
+				Type:        schema.TypeList,
+				Description: "Controller revisions matching the selector, ordered by revision number.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the controller revision.",
+							Computed:    true,
+						},
+						"revision": {
+							Type:        schema.TypeInt,
+							Description: "Revision number of the state represented by this controller revision.",
+							Computed:    true,
+						},
+						"owner_kind": {
+							Type:        schema.TypeString,
+							Description: "Kind of the controller owning this revision, e.g. `StatefulSet` or `DaemonSet`.",
+							Computed:    true,
+						},
+						"owner_name": {
+							Type:        schema.TypeString,
+							Description: "Name of the controller owning this revision.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesControllerRevisionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace := d.Get("namespace").(string)
+	selector := d.Get("label_selector").(string)
+	fieldSelector := d.Get("field_selector").(string)
+
+	log.Printf("[INFO] Listing controller revisions in %s matching %q %q", namespace, selector, fieldSelector)
+	out, err := conn.AppsV1().ControllerRevisions(namespace).List(meta_v1.ListOptions{
+		LabelSelector: selector,
+		FieldSelector: fieldSelector,
+	})
+	if err != nil {
+		return listSelectorError(err, "controller revisions", fieldSelector)
+	}
+	log.Printf("[INFO] Received %d controller revisions", len(out.Items))
+
+	sort.Slice(out.Items, func(i, j int) bool {
+		return out.Items[i].Revision < out.Items[j].Revision
+	})
+
+	revisions := make([]interface{}, len(out.Items))
+	for i, r := range out.Items {
+		m := map[string]interface{}{
+			"name":     r.Name,
+			"revision": int(r.Revision),
+		}
+		if owner := meta_v1.GetControllerOf(&r); owner != nil {
+			m["owner_kind"] = owner.Kind
+			m["owner_name"] = owner.Name
+		}
+		revisions[i] = m
+	}
+
+	err = d.Set("revisions", revisions)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(listId(namespace, selector, fieldSelector))
+	return nil
+}
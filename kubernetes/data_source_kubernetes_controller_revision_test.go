@@ -0,0 +1,120 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestAccKubernetesDataSourceControllerRevision_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceControllerRevisionConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_controller_revision.test", "revisions.#", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_controller_revision.test", "revisions.0.revision", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_controller_revision.test", "revisions.0.owner_kind", "StatefulSet"),
+					resource.TestCheckResourceAttr("data.kubernetes_controller_revision.test", "revisions.0.owner_name", name),
+					resource.TestCheckResourceAttrSet("data.kubernetes_controller_revision.test", "revisions.0.name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceControllerRevisionConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_stateful_set" "test" {
+	metadata {
+		name = "%[1]s"
+	}
+	spec {
+		replicas = 1
+		selector {
+			app = "%[1]s"
+		}
+		service_name = "%[1]s"
+		template {
+			metadata {
+				labels {
+					app = "%[1]s"
+				}
+			}
+			spec {
+				container {
+					image = "nginx:1.7.9"
+					name  = "tf-acc-test"
+				}
+			}
+		}
+	}
+}
+
+data "kubernetes_controller_revision" "test" {
+	namespace      = "${kubernetes_stateful_set.test.metadata.0.namespace}"
+	label_selector = "app=%[1]s"
+}
+`, name)
+}
+
+func TestDataSourceKubernetesControllerRevisionRead_fieldSelector(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		if query.Get("fieldSelector") == "metadata.name=web-1" {
+			fmt.Fprint(w, `{"kind": "ControllerRevisionList", "apiVersion": "apps/v1", "items": [
+	{"metadata": {"name": "web-1", "namespace": "default"}, "revision": 1}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "BadRequest", "code": 400,
+	"message": "field label not supported: revision"}`)
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &kubernetesProvider{conn: conn}
+	r := dataSourceKubernetesControllerRevision()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"label_selector": "app=web",
+		"field_selector": "metadata.name=web-1",
+	})
+	if err := r.Read(d, meta); err != nil {
+		t.Fatal(err)
+	}
+	if query.Get("labelSelector") != "app=web" {
+		t.Fatalf("Expected the label selector to be sent along, given: %v", query)
+	}
+	if d.Id() != "default/app=web/metadata.name=web-1" || d.Get("revisions.0.name") != "web-1" {
+		t.Fatalf("Unexpected data source %s: %#v", d.Id(), d.Get("revisions"))
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"label_selector": "app=web",
+		"field_selector": "revision=1",
+	})
+	err = r.Read(d, meta)
+	expected := `The API server doesn't support the field selector "revision=1" for controller revisions`
+	if err == nil || !strings.Contains(err.Error(), expected) {
+		t.Fatalf("Expected error to contain %q, given: %v", expected, err)
+	}
+}
@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -27,5 +29,13 @@ func dataSourceKubernetesDeploymentRead(d *schema.ResourceData, meta interface{}
 	}
 	d.SetId(buildId(om))
 
-	return resourceKubernetesDeploymentRead(d, meta)
+	err := resourceKubernetesDeploymentRead(d, meta)
+	if err != nil {
+		return err
+	}
+	// The read of the resource removes a missing object from the state
+	if d.Id() == "" {
+		return fmt.Errorf("Deployment %q not found", buildId(om))
+	}
+	return nil
 }
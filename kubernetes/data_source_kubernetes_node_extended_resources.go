@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// dataSourceKubernetesNodeExtendedResources reads the extended resources
+// (e.g. GPUs advertised by a device plugin) of a node, leaving out the
+// native ones like `cpu` & `memory`.
+func dataSourceKubernetesNodeExtendedResources() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesNodeExtendedResourcesRead,
+		Schema: map[string]*schema.Schema{
+			"metadata": metadataSchema("node", false),
+			"capacity": {
+				Type:        schema.TypeMap,
+				Description: "Total amount of the extended resources of the node, e.g. `nvidia.com/gpu`.",
+				Computed:    true,
+			},
+			"allocatable": {
+				Type:        schema.TypeMap,
+				Description: "Amount of the extended resources of the node which can be requested by pods.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesNodeExtendedResourcesRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Get("metadata.0.name").(string)
+	log.Printf("[INFO] Reading node %s", name)
+	node, err := conn.CoreV1().Nodes().Get(name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received node: %#v", node)
+	d.SetId(node.Name)
+
+	err = d.Set("metadata", flattenMetadata(node.ObjectMeta, d))
+	if err != nil {
+		return err
+	}
+	err = d.Set("capacity", flattenResourceList(filterExtendedResources(node.Status.Capacity)))
+	if err != nil {
+		return err
+	}
+	err = d.Set("allocatable", flattenResourceList(filterExtendedResources(node.Status.Allocatable)))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func filterExtendedResources(l api.ResourceList) api.ResourceList {
+	out := make(api.ResourceList)
+	for k, v := range l {
+		if isExtendedResourceName(k) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// isExtendedResourceName reports whether the resource is an extended one, i.e. it's
+// fully qualified outside of the `kubernetes.io` domain, like Kubernetes defines it.
+func isExtendedResourceName(name api.ResourceName) bool {
+	n := string(name)
+	if !strings.Contains(n, "/") || strings.Contains(n, "kubernetes.io/") {
+		return false
+	}
+	// Quotas of extended resources are prefixed with `requests.`
+	return !strings.HasPrefix(n, api.DefaultResourceRequestsPrefix)
+}
@@ -0,0 +1,79 @@
+package kubernetes
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	api "k8s.io/api/core/v1"
+	kresource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestAccKubernetesDataSourceNodeExtendedResources_basic(t *testing.T) {
+	if os.Getenv(resource.TestEnvVar) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.TestEnvVar)
+	}
+	// The node name is needed to build the config
+	testAccPreCheck(t)
+	node, err := getFirstNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceNodeExtendedResourcesConfig_basic(node.Name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_node_extended_resources.test", "metadata.0.name", node.Name),
+					resource.TestCheckNoResourceAttr("data.kubernetes_node_extended_resources.test", "capacity.cpu"),
+					resource.TestCheckNoResourceAttr("data.kubernetes_node_extended_resources.test", "allocatable.memory"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterExtendedResources(t *testing.T) {
+	in := api.ResourceList{
+		api.ResourceCPU:                      kresource.MustParse("4"),
+		api.ResourceMemory:                   kresource.MustParse("16Gi"),
+		api.ResourcePods:                     kresource.MustParse("110"),
+		"hugepages-2Mi":                      kresource.MustParse("0"),
+		"kubernetes.io/reserved":             kresource.MustParse("1"),
+		"requests.nvidia.com/gpu":            kresource.MustParse("1"),
+		"nvidia.com/gpu":                     kresource.MustParse("2"),
+		"devices.kubevirt.io/kvm":            kresource.MustParse("110"),
+		"example.com/dongle":                 kresource.MustParse("3"),
+		"scheduling.k8s.io/example-resource": kresource.MustParse("1"),
+	}
+
+	out := flattenResourceList(filterExtendedResources(in))
+	expected := map[string]string{
+		"nvidia.com/gpu":                     "2",
+		"devices.kubevirt.io/kvm":            "110",
+		"example.com/dongle":                 "3",
+		"scheduling.k8s.io/example-resource": "1",
+	}
+	if len(out) != len(expected) {
+		t.Fatalf("Expected extended resources %#v, given: %#v", expected, out)
+	}
+	for k, v := range expected {
+		if out[k] != v {
+			t.Fatalf("Expected extended resources %#v, given: %#v", expected, out)
+		}
+	}
+}
+
+func testAccKubernetesDataSourceNodeExtendedResourcesConfig_basic(name string) string {
+	return fmt.Sprintf(`
+data "kubernetes_node_extended_resources" "test" {
+	metadata {
+		name = "%s"
+	}
+}
+`, name)
+}
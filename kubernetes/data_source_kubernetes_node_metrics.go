@@ -0,0 +1,56 @@
+package kubernetes
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceKubernetesNodeMetrics() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesNodeMetricsRead,
+		Schema: map[string]*schema.Schema{
+			"metadata": metadataSchema("node metrics", false),
+			"timestamp": {
+				Type:        schema.TypeString,
+				Description: "The time at which the metrics were collected, in RFC3339 format.",
+				Computed:    true,
+			},
+			"window": {
+				Type:        schema.TypeString,
+				Description: "The time window the usage was measured over, e.g. `30s`.",
+				Computed:    true,
+			},
+			"usage": {
+				Type:        schema.TypeMap,
+				Description: "Resources used by the node, e.g. `cpu` & `memory`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesNodeMetricsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Get("metadata.0.name").(string)
+	var out nodeMetrics
+	err := getMetrics(conn, "nodes/"+name, &out)
+	if err != nil {
+		return err
+	}
+	d.SetId(name)
+
+	err = d.Set("metadata", flattenMetadata(out.ObjectMeta, d))
+	if err != nil {
+		return err
+	}
+	d.Set("timestamp", out.Timestamp.UTC().Format(time.RFC3339))
+	d.Set("window", out.Window.Duration.String())
+	err = d.Set("usage", flattenResourceList(out.Usage))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
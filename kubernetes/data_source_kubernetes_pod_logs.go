@@ -0,0 +1,103 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	api "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+func dataSourceKubernetesPodLogs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesPodLogsRead,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": {
+				Type:        schema.TypeList,
+				Description: "Metadata of the pod to read the logs of.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Description:  "Name of the pod.",
+							Required:     true,
+							ValidateFunc: validateName,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the pod.",
+							Optional:    true,
+							Default:     "default",
+						},
+					},
+				},
+			},
+			"container": {
+				Type:        schema.TypeString,
+				Description: "Name of the container to read the logs of. Can be omitted for pods with a single container.",
+				Optional:    true,
+			},
+			"previous": {
+				Type:        schema.TypeBool,
+				Description: "Read the logs of the previous, terminated instance of the container, e.g. of one which is crash looping.",
+				Optional:    true,
+				Default:     false,
+			},
+			"tail_lines": {
+				Type:         schema.TypeInt,
+				Description:  "Number of lines from the end of the logs to read. Defaults to all the logs.",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"logs": {
+				Type:        schema.TypeString,
+				Description: "The logs of the container.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesPodLogsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	om := meta_v1.ObjectMeta{
+		Namespace: d.Get("metadata.0.namespace").(string),
+		Name:      d.Get("metadata.0.name").(string),
+	}
+	opts := api.PodLogOptions{
+		Container: d.Get("container").(string),
+		Previous:  d.Get("previous").(bool),
+	}
+	if v, ok := d.GetOkExists("tail_lines"); ok {
+		lines := int64(v.(int))
+		opts.TailLines = &lines
+	}
+
+	logs, err := readPodLogs(conn, om, &opts)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(buildId(om) + "/" + opts.Container)
+	d.Set("logs", logs)
+	return nil
+}
+
+// readPodLogs reads the logs of a container of the pod through its log subresource.
+func readPodLogs(conn *kubernetes.Clientset, om meta_v1.ObjectMeta, opts *api.PodLogOptions) (string, error) {
+	log.Printf("[INFO] Reading logs of pod %s/%s: %#v", om.Namespace, om.Name, opts)
+	out, err := conn.CoreV1().Pods(om.Namespace).GetLogs(om.Name, opts).DoRaw()
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return "", fmt.Errorf("Failed to read the logs of pod %s/%s: %s", om.Namespace, om.Name, err)
+	}
+	log.Printf("[INFO] Received %d bytes of logs", len(out))
+	return string(out), nil
+}
@@ -0,0 +1,96 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	api "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestAccKubernetesDataSourcePodLogs_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourcePodLogsConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.kubernetes_pod_logs.test", "logs", regexp.MustCompile("^migrated\n$")),
+					resource.TestCheckResourceAttr("data.kubernetes_pod_logs.test", "id", "default/"+name+"/migrate"),
+				),
+			},
+		},
+	})
+}
+
+func TestReadPodLogs(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/namespaces/jobs/pods/migrate-x7k2p/log" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, "applying 0042_add_index\nmigrated\n")
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := int64(2)
+	om := meta_v1.ObjectMeta{Namespace: "jobs", Name: "migrate-x7k2p"}
+	logs, err := readPodLogs(conn, om, &api.PodLogOptions{Container: "migrate", TailLines: &lines})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logs != "applying 0042_add_index\nmigrated\n" {
+		t.Fatalf("Unexpected logs: %q", logs)
+	}
+	if query != "container=migrate&tailLines=2" {
+		t.Fatalf("Expected the container & the number of lines to be requested, given: %q", query)
+	}
+
+	_, err = readPodLogs(conn, meta_v1.ObjectMeta{Namespace: "jobs", Name: "gone"}, &api.PodLogOptions{})
+	if err == nil {
+		t.Fatal("Expected an error for the logs of a missing pod")
+	}
+}
+
+func testAccKubernetesDataSourcePodLogsConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    container {
+      image   = "busybox"
+      name    = "migrate"
+      command = ["sh", "-c", "echo migrated && sleep 3600"]
+    }
+  }
+}
+
+data "kubernetes_pod_logs" "test" {
+  metadata {
+    name = "${kubernetes_pod.test.metadata.0.name}"
+  }
+  container  = "migrate"
+  tail_lines = 10
+}
+`, name)
+}
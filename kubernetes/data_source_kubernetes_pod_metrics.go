@@ -0,0 +1,83 @@
+package kubernetes
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesPodMetrics() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesPodMetricsRead,
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("pod metrics", false),
+			"timestamp": {
+				Type:        schema.TypeString,
+				Description: "The time at which the metrics were collected, in RFC3339 format.",
+				Computed:    true,
+			},
+			"window": {
+				Type:        schema.TypeString,
+				Description: "The time window the usage was measured over, e.g. `30s`.",
+				Computed:    true,
+			},
+			"container": {
+				Type:        schema.TypeList,
+				Description: "Resource usage of each container of the pod.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the container.",
+							Computed:    true,
+						},
+						"usage": {
+							Type:        schema.TypeMap,
+							Description: "Resources used by the container, e.g. `cpu` & `memory`.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"usage": {
+				Type:        schema.TypeMap,
+				Description: "Resources used by all the containers of the pod together.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesPodMetricsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	om := meta_v1.ObjectMeta{
+		Namespace: d.Get("metadata.0.namespace").(string),
+		Name:      d.Get("metadata.0.name").(string),
+	}
+	var out podMetrics
+	err := getMetrics(conn, "namespaces/"+om.Namespace+"/pods/"+om.Name, &out)
+	if err != nil {
+		return err
+	}
+	d.SetId(buildId(om))
+
+	err = d.Set("metadata", flattenMetadata(out.ObjectMeta, d))
+	if err != nil {
+		return err
+	}
+	d.Set("timestamp", out.Timestamp.UTC().Format(time.RFC3339))
+	d.Set("window", out.Window.Duration.String())
+	err = d.Set("container", flattenContainerMetrics(out.Containers))
+	if err != nil {
+		return err
+	}
+	err = d.Set("usage", flattenResourceList(sumContainerUsage(out.Containers)))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+// Only scheduling.k8s.io/v1beta1 is vendored, which lacks the preemption policy,
+// so priority classes are read from the v1 API (Kubernetes 1.14+) into this.
+type priorityClass struct {
+	ObjectMeta       meta_v1.ObjectMeta `json:"metadata,omitempty"`
+	Value            int32              `json:"value"`
+	GlobalDefault    bool               `json:"globalDefault,omitempty"`
+	Description      string             `json:"description,omitempty"`
+	PreemptionPolicy string             `json:"preemptionPolicy,omitempty"`
+}
+
+const priorityClassesAPIPath = "/apis/scheduling.k8s.io/v1/priorityclasses"
+
+func dataSourceKubernetesPriorityClass() *schema.Resource {
+	dsSchema := datasourceSchemaFromResourceSchema(map[string]*schema.Schema{
+		"metadata": metadataSchema("priority class", false),
+	})
+	addRequiredFieldsToSchema(dsSchema, "metadata")
+	addRequiredFieldsToSchema(dsSchema["metadata"].Elem.(*schema.Resource).Schema, "name")
+
+	dsSchema["value"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "The priority of the pods using the priority class. The higher the value, the higher the priority.",
+		Computed:    true,
+	}
+	dsSchema["global_default"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Whether the priority class is used for the pods which don't name one.",
+		Computed:    true,
+	}
+	dsSchema["description"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "When the priority class should be used.",
+		Computed:    true,
+	}
+	dsSchema["preemption_policy"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Whether the pods using the priority class may preempt pods of lower priority: `PreemptLowerPriority` or `Never`.",
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Read: dataSourceKubernetesPriorityClassRead,
+
+		Schema: dsSchema,
+	}
+}
+
+func dataSourceKubernetesPriorityClassRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Get("metadata.0.name").(string)
+	pc, err := getPriorityClass(conn, name)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Received priority class: %#v", pc)
+	d.SetId(pc.ObjectMeta.Name)
+
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(pc.ObjectMeta, d, meta))
+	if err != nil {
+		return err
+	}
+	d.Set("value", int(pc.Value))
+	d.Set("global_default", pc.GlobalDefault)
+	d.Set("description", pc.Description)
+	d.Set("preemption_policy", priorityClassPreemptionPolicy(pc))
+	return nil
+}
+
+func getPriorityClass(conn *kubernetes.Clientset, name string) (*priorityClass, error) {
+	log.Printf("[INFO] Reading priority class %s", name)
+	raw, err := conn.SchedulingV1beta1().RESTClient().Get().AbsPath(priorityClassesAPIPath, name).DoRaw()
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("Priority class %q not found (priority classes are served by scheduling.k8s.io/v1 on Kubernetes 1.14+)", name)
+		}
+		return nil, err
+	}
+	var out priorityClass
+	err = json.Unmarshal(raw, &out)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode priority class %s: %s", name, err)
+	}
+	return &out, nil
+}
+
+// priorityClassPreemptionPolicy returns the preemption policy of the priority class.
+// API servers which predate the field (before 1.15) always let pods preempt lower priorities.
+func priorityClassPreemptionPolicy(pc *priorityClass) string {
+	if pc.PreemptionPolicy == "" {
+		return "PreemptLowerPriority"
+	}
+	return pc.PreemptionPolicy
+}
@@ -0,0 +1,86 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestAccKubernetesDataSourcePriorityClass_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourcePriorityClassConfig_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_priority_class.test", "metadata.0.name", "system-cluster-critical"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_priority_class.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_priority_class.test", "value", "2000000000"),
+					resource.TestCheckResourceAttr("data.kubernetes_priority_class.test", "global_default", "false"),
+					resource.TestCheckResourceAttr("data.kubernetes_priority_class.test", "preemption_policy", "PreemptLowerPriority"),
+				),
+			},
+		},
+	})
+}
+
+func TestGetPriorityClass(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/apis/scheduling.k8s.io/v1/priorityclasses/batch":
+			fmt.Fprint(w, `{"kind": "PriorityClass", "apiVersion": "scheduling.k8s.io/v1",
+	"metadata": {"name": "batch"}, "value": 1000, "description": "Batch jobs", "preemptionPolicy": "Never"}`)
+		case "/apis/scheduling.k8s.io/v1/priorityclasses/legacy":
+			fmt.Fprint(w, `{"kind": "PriorityClass", "apiVersion": "scheduling.k8s.io/v1",
+	"metadata": {"name": "legacy"}, "value": 10, "globalDefault": true}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound", "code": 404}`)
+		}
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pc, err := getPriorityClass(conn, "batch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pc.Value != 1000 || pc.GlobalDefault || pc.Description != "Batch jobs" || priorityClassPreemptionPolicy(pc) != "Never" {
+		t.Fatalf("Unexpected priority class: %#v", pc)
+	}
+
+	pc, err = getPriorityClass(conn, "legacy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pc.GlobalDefault || priorityClassPreemptionPolicy(pc) != "PreemptLowerPriority" {
+		t.Fatalf("Expected a global default which preempts lower priorities, given: %#v", pc)
+	}
+
+	_, err = getPriorityClass(conn, "missing")
+	if err == nil || !strings.Contains(err.Error(), `"missing" not found`) {
+		t.Fatalf("Expected a not found error, given: %v", err)
+	}
+}
+
+func testAccKubernetesDataSourcePriorityClassConfig_basic() string {
+	return `
+data "kubernetes_priority_class" "test" {
+	metadata {
+		name = "system-cluster-critical"
+	}
+}
+`
+}
@@ -1,6 +1,11 @@
 package kubernetes
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -17,6 +22,16 @@ func dataSourceKubernetesSecret() *schema.Resource {
 				Computed:    true,
 				Sensitive:   true,
 			},
+			"data_sha256": {
+				Type:        schema.TypeString,
+				Description: "SHA-256 checksum of the secret data, the same for the same data. Changes when the secret is rotated, e.g. to annotate a pod template with it and roll out its pods.",
+				Computed:    true,
+			},
+			"resource_version": {
+				Type:        schema.TypeString,
+				Description: "The resource version of the secret, changes on every update of the secret.",
+				Computed:    true,
+			},
 			"type": {
 				Type:        schema.TypeString,
 				Description: "Type of secret",
@@ -33,5 +48,36 @@ func dataSourceKubernetesSecretRead(d *schema.ResourceData, meta interface{}) er
 	}
 	d.SetId(buildId(om))
 
-	return resourceKubernetesSecretRead(d, meta)
+	err := resourceKubernetesSecretRead(d, meta)
+	if err != nil {
+		return err
+	}
+	// The read of the resource removes a missing object from the state
+	if d.Id() == "" {
+		return fmt.Errorf("Secret %q not found", buildId(om))
+	}
+
+	d.Set("resource_version", d.Get("metadata.0.resource_version"))
+	checksum, err := secretDataChecksum(d.Get("data").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+	d.Set("data_sha256", checksum)
+	return nil
+}
+
+// secretDataChecksum returns the hex encoded SHA-256 of the secret data.
+// The data is hashed as a JSON object, which has sorted keys & base64 encoded values,
+// so the checksum doesn't depend on the order of the keys and the values may be binary.
+func secretDataChecksum(data map[string]interface{}) (string, error) {
+	m := make(map[string][]byte, len(data))
+	for k, v := range data {
+		m[k] = []byte(v.(string))
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
 }
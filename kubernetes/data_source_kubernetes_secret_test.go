@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -32,12 +33,48 @@ func TestAccKubernetesDataSourceSecret_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("kubernetes_secret.test", "metadata.0.uid"),
 					resource.TestCheckResourceAttr("kubernetes_secret.test", "data.%", "2"),
 					resource.TestCheckResourceAttr("kubernetes_secret.test", "type", "Opaque"),
+					resource.TestCheckResourceAttrPair("data.kubernetes_secret.test", "resource_version", "kubernetes_secret.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttr("data.kubernetes_secret.test", "data.%", "2"),
+					resource.TestMatchResourceAttr("data.kubernetes_secret.test", "data_sha256", regexp.MustCompile("^[0-9a-f]{64}$")),
 				),
 			},
 		},
 	})
 }
 
+func TestSecretDataChecksum(t *testing.T) {
+	checksum := func(data map[string]interface{}) string {
+		sum, err := secretDataChecksum(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sum
+	}
+
+	data := map[string]interface{}{"username": "admin", "password": "s3cr3t", "key": "\x00\xff"}
+	sum := checksum(data)
+	for i := 0; i < 10; i++ {
+		if s := checksum(data); s != sum {
+			t.Fatalf("Expected the checksum of the same data to stay %s, given: %s", sum, s)
+		}
+	}
+
+	// Values aren't concatenated with their keys
+	if checksum(map[string]interface{}{"ab": "c"}) == checksum(map[string]interface{}{"a": "bc"}) {
+		t.Fatal("Expected different data to have a different checksum")
+	}
+	rotated := map[string]interface{}{"username": "admin", "password": "n3w", "key": "\x00\xff"}
+	if checksum(rotated) == sum {
+		t.Fatal("Expected the checksum to change when the data changes")
+	}
+
+	// sha256sum of {"a":"Yg=="}
+	expected := "3d791b164a808638da9a8df03924be2a41e34cd664e42231c00fe369e3588272"
+	if s := checksum(map[string]interface{}{"a": "b"}); s != expected {
+		t.Fatalf("Expected the checksum to be %s, given: %s", expected, s)
+	}
+}
+
 func testAccKubernetesDataSourceSecretConfig_basic(name string) string {
 	return testAccKubernetesSecretConfig_basic(name) + `
 data "kubernetes_secret" "test" {
@@ -0,0 +1,56 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	authv1 "k8s.io/api/authorization/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+func dataSourceKubernetesSelfSubjectAccessReview() *schema.Resource {
+	s := accessReviewFields()
+	s["fail_if_denied"].Description = "Fail reading the data source with the reason of the authorizer when the credentials of the provider aren't allowed to perform the action."
+	s["allowed"].Description = "Whether the credentials of the provider are allowed to perform the action."
+
+	return &schema.Resource{
+		Read:   dataSourceKubernetesSelfSubjectAccessReviewRead,
+		Schema: s,
+	}
+}
+
+func dataSourceKubernetesSelfSubjectAccessReviewRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	attrs := expandResourceAttributes(d)
+	status, err := reviewSelfSubjectAccess(conn, attrs)
+	if err != nil {
+		return err
+	}
+	if d.Get("fail_if_denied").(bool) && !status.Allowed {
+		return accessDeniedError("The credentials of the provider aren't", attrs, status)
+	}
+
+	d.SetId(resourceAttributesId(attrs))
+	setAccessReviewStatus(d, status)
+	return nil
+}
+
+// reviewSelfSubjectAccess asks the API server whether the credentials of the
+// provider are allowed to perform the action described by attrs.
+func reviewSelfSubjectAccess(conn *kubernetes.Clientset, attrs authv1.ResourceAttributes) (*authv1.SubjectAccessReviewStatus, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &attrs,
+		},
+	}
+	log.Printf("[INFO] Reviewing self subject access: %#v", attrs)
+	out, err := conn.AuthorizationV1().SelfSubjectAccessReviews().Create(&review)
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return nil, fmt.Errorf("Failed to review access to %s: %s", describeResourceAttributes(attrs), err)
+	}
+	log.Printf("[INFO] Received self subject access review status: %#v", out.Status)
+	return &out.Status, nil
+}
@@ -0,0 +1,105 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	authv1 "k8s.io/api/authorization/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestAccKubernetesDataSourceSelfSubjectAccessReview_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceSelfSubjectAccessReviewConfig_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_self_subject_access_review.test", "allowed", "true"),
+					resource.TestCheckResourceAttr("data.kubernetes_self_subject_access_review.test", "denied", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestReviewSelfSubjectAccess(t *testing.T) {
+	var given authv1.SelfSubjectAccessReview
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&given); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"kind": "SelfSubjectAccessReview", "apiVersion": "authorization.k8s.io/v1",
+	"status": {"allowed": false, "reason": "no RBAC policy matched"}}`)
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attrs := authv1.ResourceAttributes{
+		Verb:        "update",
+		Group:       "apps",
+		Resource:    "deployments",
+		Subresource: "scale",
+		Namespace:   "default",
+		Name:        "web",
+	}
+	status, err := reviewSelfSubjectAccess(conn, attrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if given.Spec.ResourceAttributes == nil || *given.Spec.ResourceAttributes != attrs {
+		t.Fatalf("Expected the review of %#v, given: %#v", attrs, given.Spec)
+	}
+	if status.Allowed || status.Reason != "no RBAC policy matched" {
+		t.Fatalf("Unexpected status: %#v", status)
+	}
+
+	err = accessDeniedError("The credentials of the provider aren't", attrs, status)
+	expected := `The credentials of the provider aren't allowed to update deployments.apps/scale "web" in namespace "default": no RBAC policy matched`
+	if err.Error() != expected {
+		t.Fatalf("Expected error %q, given: %q", expected, err)
+	}
+}
+
+func TestDescribeResourceAttributes(t *testing.T) {
+	cases := []struct {
+		Attrs    authv1.ResourceAttributes
+		Expected string
+	}{
+		{authv1.ResourceAttributes{Verb: "list", Resource: "nodes"}, "list nodes"},
+		{authv1.ResourceAttributes{Verb: "get", Resource: "pods", Subresource: "log", Namespace: "kube-system"}, `get pods/log in namespace "kube-system"`},
+		{authv1.ResourceAttributes{Verb: "*", Group: "*", Resource: "*"}, "* *.*"},
+	}
+
+	for _, tc := range cases {
+		if desc := describeResourceAttributes(tc.Attrs); desc != tc.Expected {
+			t.Fatalf("Expected %q, given: %q", tc.Expected, desc)
+		}
+	}
+}
+
+func testAccKubernetesDataSourceSelfSubjectAccessReviewConfig_basic() string {
+	return `
+data "kubernetes_self_subject_access_review" "test" {
+  verb      = "list"
+  resource  = "pods"
+  namespace = "default"
+}
+`
+}
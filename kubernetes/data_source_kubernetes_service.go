@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -26,5 +28,13 @@ func dataSourceKubernetesServiceRead(d *schema.ResourceData, meta interface{}) e
 	}
 	d.SetId(buildId(om))
 
-	return resourceKubernetesServiceRead(d, meta)
+	err := resourceKubernetesServiceRead(d, meta)
+	if err != nil {
+		return err
+	}
+	// The read of the resource removes a missing object from the state
+	if d.Id() == "" {
+		return fmt.Errorf("Service %q not found", buildId(om))
+	}
+	return nil
 }
@@ -0,0 +1,215 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+// The CSIStorageCapacity type (storage.k8s.io/v1, Kubernetes 1.24+) isn't vendored,
+// so the objects published by the CSI drivers are decoded into these.
+
+type csiStorageCapacity struct {
+	ObjectMeta        meta_v1.ObjectMeta     `json:"metadata,omitempty"`
+	NodeTopology      *meta_v1.LabelSelector `json:"nodeTopology,omitempty"`
+	StorageClassName  string                 `json:"storageClassName"`
+	Capacity          *resource.Quantity     `json:"capacity,omitempty"`
+	MaximumVolumeSize *resource.Quantity     `json:"maximumVolumeSize,omitempty"`
+}
+
+type csiStorageCapacityList struct {
+	Items []csiStorageCapacity `json:"items"`
+}
+
+const storageCapacityAPIPath = "/apis/storage.k8s.io/v1"
+
+func dataSourceKubernetesStorageCapacity() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesStorageCapacityRead,
+		Schema: map[string]*schema.Schema{
+			"storage_class_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the storage class the capacity is reported for.",
+				Required:    true,
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace the CSI driver publishes its capacity objects in. Defaults to all namespaces.",
+				Optional:    true,
+			},
+			"node_labels": {
+				Type:         schema.TypeMap,
+				Description:  "Labels of a node (e.g. `topology.kubernetes.io/zone`), only the capacity accessible from such a node is returned.",
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateLabels,
+			},
+			"capacity": {
+				Type:        schema.TypeList,
+				Description: "The matching capacity objects.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the capacity object.",
+							Computed:    true,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the capacity object.",
+							Computed:    true,
+						},
+						"node_topology": {
+							Type:        schema.TypeList,
+							Description: "The nodes which have access to the storage, as a label selector.",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: datasourceSchemaFromResourceSchema(labelSelectorFields()),
+							},
+						},
+						"capacity": {
+							Type:        schema.TypeString,
+							Description: "The available capacity, e.g. `100Gi`.",
+							Computed:    true,
+						},
+						"maximum_volume_size": {
+							Type:        schema.TypeString,
+							Description: "The largest volume which can be created from the capacity. Empty if the driver doesn't report it.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"maximum_volume_size": {
+				Type:        schema.TypeString,
+				Description: "The largest volume which can be created from any of the matching capacity objects. The capacity is used for objects which don't report a maximum volume size.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesStorageCapacityRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	className := d.Get("storage_class_name").(string)
+	namespace := d.Get("namespace").(string)
+	items, err := listStorageCapacities(conn, namespace)
+	if err != nil {
+		return err
+	}
+
+	nodeLabels := labels.Set(expandStringMap(d.Get("node_labels").(map[string]interface{})))
+	matches, err := filterStorageCapacities(items, className, nodeLabels)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Found %d capacity object(s) of storage class %s", len(matches), className)
+
+	if namespace == "" {
+		d.SetId(className)
+	} else {
+		d.SetId(namespace + "/" + className)
+	}
+	err = d.Set("capacity", flattenStorageCapacities(matches))
+	if err != nil {
+		return err
+	}
+	d.Set("maximum_volume_size", "")
+	if max := maximumVolumeSize(matches); max != nil {
+		d.Set("maximum_volume_size", max.String())
+	}
+
+	return nil
+}
+
+func listStorageCapacities(conn *kubernetes.Clientset, namespace string) ([]csiStorageCapacity, error) {
+	path := []string{storageCapacityAPIPath, "csistoragecapacities"}
+	if namespace != "" {
+		path = []string{storageCapacityAPIPath, "namespaces", namespace, "csistoragecapacities"}
+	}
+	log.Printf("[INFO] Listing storage capacities in namespace %q", namespace)
+	raw, err := conn.StorageV1().RESTClient().Get().AbsPath(path...).DoRaw()
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return nil, fmt.Errorf("Failed to list storage capacities, they are served by Kubernetes 1.24+: %s", err)
+	}
+	var list csiStorageCapacityList
+	err = json.Unmarshal(raw, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// filterStorageCapacities returns (sorted by namespace & name) the capacity objects of
+// the storage class which are accessible from a node with the given labels.
+// No labels match any capacity object.
+func filterStorageCapacities(items []csiStorageCapacity, className string, nodeLabels labels.Set) ([]csiStorageCapacity, error) {
+	out := make([]csiStorageCapacity, 0)
+	for _, c := range items {
+		if c.StorageClassName != className {
+			continue
+		}
+		if len(nodeLabels) > 0 {
+			// A missing topology means the storage isn't accessible from any node
+			selector, err := meta_v1.LabelSelectorAsSelector(c.NodeTopology)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid node topology of storage capacity %s: %s", buildId(c.ObjectMeta), err)
+			}
+			if !selector.Matches(nodeLabels) {
+				continue
+			}
+		}
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return buildId(out[i].ObjectMeta) < buildId(out[j].ObjectMeta)
+	})
+	return out, nil
+}
+
+// maximumVolumeSize mirrors how the scheduler checks a volume against the capacity:
+// the maximum volume size is used when reported, otherwise the capacity.
+func maximumVolumeSize(items []csiStorageCapacity) *resource.Quantity {
+	var max *resource.Quantity
+	for _, c := range items {
+		size := c.MaximumVolumeSize
+		if size == nil {
+			size = c.Capacity
+		}
+		if size != nil && (max == nil || size.Cmp(*max) > 0) {
+			max = size
+		}
+	}
+	return max
+}
+
+func flattenStorageCapacities(in []csiStorageCapacity) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, c := range in {
+		m := map[string]interface{}{
+			"name":      c.ObjectMeta.Name,
+			"namespace": c.ObjectMeta.Namespace,
+		}
+		if c.NodeTopology != nil {
+			m["node_topology"] = flattenLabelSelector(c.NodeTopology)
+		}
+		if c.Capacity != nil {
+			m["capacity"] = c.Capacity.String()
+		}
+		if c.MaximumVolumeSize != nil {
+			m["maximum_volume_size"] = c.MaximumVolumeSize.String()
+		}
+		att[i] = m
+	}
+	return att
+}
@@ -0,0 +1,76 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/labels"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestListAndFilterStorageCapacities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/storage.k8s.io/v1/namespaces/csi/csistoragecapacities" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind": "CSIStorageCapacityList", "apiVersion": "storage.k8s.io/v1", "items": [
+	{"metadata": {"name": "zone-b", "namespace": "csi"}, "storageClassName": "fast",
+	 "nodeTopology": {"matchLabels": {"topology.kubernetes.io/zone": "b"}}, "capacity": "500Gi"},
+	{"metadata": {"name": "zone-a", "namespace": "csi"}, "storageClassName": "fast",
+	 "nodeTopology": {"matchLabels": {"topology.kubernetes.io/zone": "a"}}, "capacity": "1Ti", "maximumVolumeSize": "200Gi"},
+	{"metadata": {"name": "unreachable", "namespace": "csi"}, "storageClassName": "fast", "capacity": "10Ti"},
+	{"metadata": {"name": "slow", "namespace": "csi"}, "storageClassName": "slow", "nodeTopology": {}, "capacity": "5Ti"}
+]}`)
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := listStorageCapacities(conn, "csi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("Expected 4 capacity objects, given: %#v", items)
+	}
+
+	cases := []struct {
+		ClassName       string
+		NodeLabels      labels.Set
+		ExpectedNames   []string
+		ExpectedMaxSize string
+	}{
+		{"fast", nil, []string{"unreachable", "zone-a", "zone-b"}, "10Ti"},
+		{"fast", labels.Set{"topology.kubernetes.io/zone": "a"}, []string{"zone-a"}, "200Gi"},
+		{"fast", labels.Set{"topology.kubernetes.io/zone": "c"}, []string{}, ""},
+		{"slow", labels.Set{"topology.kubernetes.io/zone": "c"}, []string{"slow"}, "5Ti"},
+	}
+	for i, tc := range cases {
+		matches, err := filterStorageCapacities(items, tc.ClassName, tc.NodeLabels)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := make([]string, len(matches))
+		for j, m := range matches {
+			names[j] = m.ObjectMeta.Name
+		}
+		if fmt.Sprint(names) != fmt.Sprint(tc.ExpectedNames) {
+			t.Fatalf("%d: Expected capacity objects %v, given: %v", i, tc.ExpectedNames, names)
+		}
+		max := ""
+		if q := maximumVolumeSize(matches); q != nil {
+			max = q.String()
+		}
+		if max != tc.ExpectedMaxSize {
+			t.Fatalf("%d: Expected maximum volume size %q, given: %q", i, tc.ExpectedMaxSize, max)
+		}
+	}
+}
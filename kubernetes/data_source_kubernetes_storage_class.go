@@ -1,30 +1,96 @@
 package kubernetes
 
 import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
 	"github.com/hashicorp/terraform/helper/schema"
+	api "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	betaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
 )
 
 func dataSourceKubernetesStorageClass() *schema.Resource {
+	dsSchema := datasourceSchemaFromResourceSchema(resourceKubernetesStorageClass().Schema)
+
+	// Without a name the default storage class of the cluster is looked up
+	dsSchema["metadata"].Optional = true
+	dsSchema["metadata"].MaxItems = 1
+	dsSchema["metadata"].Elem.(*schema.Resource).Schema["name"].Optional = true
+
+	dsSchema["is_default"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Whether this is the default storage class of the cluster, used by claims which don't name one",
+		Computed:    true,
+	}
+
 	return &schema.Resource{
 		Read: dataSourceKubernetesStorageClassRead,
-		Schema: map[string]*schema.Schema{
-			"metadata": metadataSchema("storage class", false),
-			"parameters": {
-				Type:        schema.TypeMap,
-				Description: "The parameters for the provisioner that should create volumes of this storage class",
-				Computed:    true,
-			},
-			"storage_provisioner": {
-				Type:        schema.TypeString,
-				Description: "Indicates the type of the provisioner",
-				Computed:    true,
-			},
-		},
+
+		Schema: dsSchema,
 	}
 }
 
 func dataSourceKubernetesStorageClassRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
 	name := d.Get("metadata.0.name").(string)
-	d.SetId(name)
-	return resourceKubernetesStorageClassRead(d, meta)
+	if name == "" {
+		log.Printf("[INFO] Looking up the default storage class")
+		list, err := conn.StorageV1().StorageClasses().List(metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("Failed to list storage classes: %s", err)
+		}
+		name, err = findDefaultStorageClass(list.Items)
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Found default storage class %s", name)
+	}
+
+	log.Printf("[INFO] Reading storage class %s", name)
+	storageClass, err := conn.StorageV1().StorageClasses().Get(name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received storage class: %#v", storageClass)
+	d.SetId(storageClass.Name)
+
+	err = setStorageClassAttributes(d, meta, storageClass)
+	if err != nil {
+		return err
+	}
+	// The annotation is an internal key, left out of the metadata attribute
+	d.Set("is_default", isDefaultStorageClass(storageClass.Annotations))
+	return nil
+}
+
+// findDefaultStorageClass returns the name of the storage class annotated as the default one.
+// Like the DefaultStorageClass admission plugin, it refuses to pick one of several defaults.
+func findDefaultStorageClass(classes []api.StorageClass) (string, error) {
+	var defaults []string
+	for _, c := range classes {
+		if isDefaultStorageClass(c.Annotations) {
+			defaults = append(defaults, c.Name)
+		}
+	}
+	switch len(defaults) {
+	case 0:
+		return "", fmt.Errorf("No default storage class found: none is annotated with %s=true", defaultStorageClassAnnotation)
+	case 1:
+		return defaults[0], nil
+	}
+	sort.Strings(defaults)
+	return "", fmt.Errorf("Found %d default storage classes (%s), set metadata.0.name to pick one", len(defaults), strings.Join(defaults, ", "))
+}
+
+func isDefaultStorageClass(annotations map[string]string) bool {
+	return annotations[defaultStorageClassAnnotation] == "true" || annotations[betaDefaultStorageClassAnnotation] == "true"
 }
@@ -2,10 +2,13 @@ package kubernetes
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	api "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestAccKubernetesDataSourceStorageClass_basic(t *testing.T) {
@@ -32,12 +35,77 @@ func TestAccKubernetesDataSourceStorageClass_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("data.kubernetes_storage_class.test", "storage_provisioner", "kubernetes.io/gce-pd"),
 					resource.TestCheckResourceAttr("data.kubernetes_storage_class.test", "parameters.%", "1"),
 					resource.TestCheckResourceAttr("data.kubernetes_storage_class.test", "parameters.type", "pd-ssd"),
+					resource.TestCheckResourceAttr("data.kubernetes_storage_class.test", "reclaim_policy", "Retain"),
+					resource.TestCheckResourceAttr("data.kubernetes_storage_class.test", "volume_binding_mode", "Immediate"),
+					resource.TestCheckResourceAttr("data.kubernetes_storage_class.test", "allow_volume_expansion", "false"),
+					resource.TestCheckResourceAttr("data.kubernetes_storage_class.test", "is_default", "false"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccKubernetesDataSourceStorageClass_default(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceStorageClassConfig_default(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kubernetes_storage_class.default", "metadata.0.name"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_storage_class.default", "storage_provisioner"),
+					resource.TestCheckResourceAttr("data.kubernetes_storage_class.default", "is_default", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestFindDefaultStorageClass(t *testing.T) {
+	class := func(name string, annotations map[string]string) api.StorageClass {
+		return api.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}}
+	}
+
+	name, err := findDefaultStorageClass([]api.StorageClass{
+		class("slow", nil),
+		class("standard", map[string]string{defaultStorageClassAnnotation: "true"}),
+		class("fast", map[string]string{defaultStorageClassAnnotation: "false"}),
+	})
+	if err != nil {
+		t.Fatalf("Expected a default storage class, given error: %s", err)
+	}
+	if name != "standard" {
+		t.Fatalf("Expected the default storage class to be standard, given: %s", name)
+	}
+
+	name, err = findDefaultStorageClass([]api.StorageClass{
+		class("gp2", map[string]string{betaDefaultStorageClassAnnotation: "true"}),
+	})
+	if err != nil || name != "gp2" {
+		t.Fatalf("Expected the beta annotation to mark the default storage class, given: %q, %v", name, err)
+	}
+
+	_, err = findDefaultStorageClass([]api.StorageClass{class("slow", nil)})
+	if err == nil || !strings.Contains(err.Error(), "No default storage class") {
+		t.Fatalf("Expected an error without a default storage class, given: %v", err)
+	}
+
+	_, err = findDefaultStorageClass([]api.StorageClass{
+		class("b", map[string]string{defaultStorageClassAnnotation: "true"}),
+		class("a", map[string]string{defaultStorageClassAnnotation: "true"}),
+	})
+	if err == nil || !strings.Contains(err.Error(), "(a, b)") {
+		t.Fatalf("Expected an error naming all the default storage classes, given: %v", err)
+	}
+}
+
+func testAccKubernetesDataSourceStorageClassConfig_default() string {
+	return `
+data "kubernetes_storage_class" "default" {}
+`
+}
+
 func testAccKubernetesDataSourceStorageClassConfig_basic(name string) string {
 	return testAccKubernetesStorageClassConfig_basic(name) + `
 data "kubernetes_storage_class" "test" {
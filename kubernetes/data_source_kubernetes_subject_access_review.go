@@ -0,0 +1,151 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	authv1 "k8s.io/api/authorization/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+func dataSourceKubernetesSubjectAccessReview() *schema.Resource {
+	s := accessReviewFields()
+	s["fail_if_denied"].Description = "Fail reading the data source with the reason of the authorizer when the subject isn't allowed to perform the action."
+	s["allowed"].Description = "Whether the subject is allowed to perform the action."
+
+	s["user"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Description:   "Name of the user to check the access of.",
+		Optional:      true,
+		ConflictsWith: []string{"service_account"},
+	}
+	s["groups"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Groups of the user to check the access of, or the groups to check the access of on their own.",
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+	s["service_account"] = &schema.Schema{
+		Type:          schema.TypeList,
+		Description:   "Service account to check the access of, with the groups Kubernetes gives it.",
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"user"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:         schema.TypeString,
+					Description:  "Name of the service account.",
+					Required:     true,
+					ValidateFunc: validateName,
+				},
+				"namespace": {
+					Type:        schema.TypeString,
+					Description: "Namespace of the service account.",
+					Optional:    true,
+					Default:     "default",
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceKubernetesSubjectAccessReviewRead,
+		Schema: s,
+	}
+}
+
+// accessReviewSubject is who a subject access review checks the access of.
+type accessReviewSubject struct {
+	User   string
+	Groups []string
+	// Description of the subject in messages, e.g. `User "jane"`
+	Description string
+}
+
+func dataSourceKubernetesSubjectAccessReviewRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	subject, err := expandAccessReviewSubject(d)
+	if err != nil {
+		return err
+	}
+	attrs := expandResourceAttributes(d)
+	status, err := reviewSubjectAccess(conn, subject, attrs)
+	if err != nil {
+		return err
+	}
+	if d.Get("fail_if_denied").(bool) && !status.Allowed {
+		verb := "isn't"
+		if subject.User == "" && len(subject.Groups) > 1 {
+			verb = "aren't"
+		}
+		return accessDeniedError(subject.Description+" "+verb, attrs, status)
+	}
+
+	d.SetId(subject.User + "/" + strings.Join(subject.Groups, ",") + "/" + resourceAttributesId(attrs))
+	setAccessReviewStatus(d, status)
+	return nil
+}
+
+func expandAccessReviewSubject(d *schema.ResourceData) (accessReviewSubject, error) {
+	subject := accessReviewSubject{
+		User:   d.Get("user").(string),
+		Groups: expandStringSlice(d.Get("groups").([]interface{})),
+	}
+	if subject.User != "" {
+		subject.Description = fmt.Sprintf("User %q", subject.User)
+	}
+
+	if l := d.Get("service_account").([]interface{}); len(l) > 0 && l[0] != nil {
+		sa := l[0].(map[string]interface{})
+		namespace, name := sa["namespace"].(string), sa["name"].(string)
+		// The user & groups the service account token authenticates as
+		subject.User = fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)
+		subject.Groups = append(subject.Groups,
+			"system:serviceaccounts", "system:serviceaccounts:"+namespace, "system:authenticated")
+		subject.Description = fmt.Sprintf("Service account %q", namespace+"/"+name)
+	}
+
+	if subject.User == "" {
+		if len(subject.Groups) == 0 {
+			return subject, fmt.Errorf("One of user, groups or service_account must be set to review the access of")
+		}
+		noun := "Group"
+		if len(subject.Groups) > 1 {
+			noun = "Groups"
+		}
+		subject.Description = noun + " " + strings.Join(quoteStrings(subject.Groups), ", ")
+	}
+	return subject, nil
+}
+
+// reviewSubjectAccess asks the API server whether the subject is allowed
+// to perform the action described by attrs.
+func reviewSubjectAccess(conn *kubernetes.Clientset, subject accessReviewSubject, attrs authv1.ResourceAttributes) (*authv1.SubjectAccessReviewStatus, error) {
+	review := authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attrs,
+			User:               subject.User,
+			Groups:             subject.Groups,
+		},
+	}
+	log.Printf("[INFO] Reviewing subject access of %s: %#v", subject.Description, attrs)
+	out, err := conn.AuthorizationV1().SubjectAccessReviews().Create(&review)
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return nil, fmt.Errorf("Failed to review access of %s to %s: %s", subject.Description, describeResourceAttributes(attrs), err)
+	}
+	log.Printf("[INFO] Received subject access review status: %#v", out.Status)
+	return &out.Status, nil
+}
+
+func quoteStrings(in []string) []string {
+	out := make([]string, len(in))
+	for i, s := range in {
+		out[i] = fmt.Sprintf("%q", s)
+	}
+	return out
+}
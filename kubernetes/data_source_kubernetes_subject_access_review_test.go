@@ -0,0 +1,132 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	authv1 "k8s.io/api/authorization/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestAccKubernetesDataSourceSubjectAccessReview_serviceAccount(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceSubjectAccessReviewConfig_serviceAccount(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_subject_access_review.test", "allowed", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandAccessReviewSubject(t *testing.T) {
+	cases := []struct {
+		Name                string
+		Raw                 map[string]interface{}
+		ExpectedUser        string
+		ExpectedGroups      []string
+		ExpectedDescription string
+		ExpectedError       bool
+	}{
+		{
+			"user",
+			map[string]interface{}{"user": "jane", "groups": []interface{}{"dev"}},
+			"jane", []string{"dev"}, `User "jane"`, false,
+		},
+		{
+			"groups",
+			map[string]interface{}{"groups": []interface{}{"dev", "ops"}},
+			"", []string{"dev", "ops"}, `Groups "dev", "ops"`, false,
+		},
+		{
+			"service account",
+			map[string]interface{}{"service_account": []interface{}{map[string]interface{}{"name": "ci", "namespace": "tenant"}}},
+			"system:serviceaccount:tenant:ci",
+			[]string{"system:serviceaccounts", "system:serviceaccounts:tenant", "system:authenticated"},
+			`Service account "tenant/ci"`, false,
+		},
+		{
+			"nobody",
+			map[string]interface{}{},
+			"", nil, "", true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceKubernetesSubjectAccessReview().Schema, tc.Raw)
+			subject, err := expandAccessReviewSubject(d)
+			if (err != nil) != tc.ExpectedError {
+				t.Fatalf("Expected error: %t, given: %v", tc.ExpectedError, err)
+			}
+			if tc.ExpectedError {
+				return
+			}
+			if subject.User != tc.ExpectedUser || !reflect.DeepEqual(subject.Groups, tc.ExpectedGroups) || subject.Description != tc.ExpectedDescription {
+				t.Fatalf("Unexpected subject: %#v", subject)
+			}
+		})
+	}
+}
+
+func TestReviewSubjectAccess(t *testing.T) {
+	var given authv1.SubjectAccessReview
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/apis/authorization.k8s.io/v1/subjectaccessreviews" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&given); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"kind": "SubjectAccessReview", "apiVersion": "authorization.k8s.io/v1",
+	"status": {"allowed": true, "reason": "RBAC: allowed by RoleBinding \"edit/tenant\""}}`)
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subject := accessReviewSubject{User: "jane", Groups: []string{"dev"}, Description: `User "jane"`}
+	attrs := authv1.ResourceAttributes{Verb: "create", Group: "apps", Resource: "deployments", Namespace: "tenant"}
+	status, err := reviewSubjectAccess(conn, subject, attrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if given.Spec.User != "jane" || !reflect.DeepEqual(given.Spec.Groups, []string{"dev"}) ||
+		given.Spec.ResourceAttributes == nil || *given.Spec.ResourceAttributes != attrs {
+		t.Fatalf("Unexpected review: %#v", given.Spec)
+	}
+	if !status.Allowed {
+		t.Fatalf("Expected the access to be allowed, given: %#v", status)
+	}
+}
+
+func testAccKubernetesDataSourceSubjectAccessReviewConfig_serviceAccount() string {
+	return `
+data "kubernetes_subject_access_review" "test" {
+  service_account {
+    name      = "default"
+    namespace = "default"
+  }
+
+  verb     = "delete"
+  resource = "namespaces"
+}
+`
+}
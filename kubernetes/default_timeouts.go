@@ -0,0 +1,74 @@
+package kubernetes
+
+import (
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// defaultTimeoutArguments maps the provider arguments to the timeouts of the resources they default.
+var defaultTimeoutArguments = map[string]string{
+	"default_create_timeout": schema.TimeoutCreate,
+	"default_update_timeout": schema.TimeoutUpdate,
+	"default_delete_timeout": schema.TimeoutDelete,
+}
+
+func defaultTimeoutSchema(operation string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "Timeout of the " + operation + " of every resource which doesn't set its own in a `timeouts` block, as a duration like `30m`. Defaults to the timeout of each resource.",
+		Optional:     true,
+		ValidateFunc: validateDuration,
+	}
+}
+
+// resourceTimeouts returns the timeouts the resources are defined with, so
+// setDefaultTimeouts can start over from them every time the provider is configured.
+func resourceTimeouts(resources map[string]*schema.Resource) map[string]*schema.ResourceTimeout {
+	timeouts := make(map[string]*schema.ResourceTimeout, len(resources))
+	for name, r := range resources {
+		timeouts[name] = r.Timeouts
+	}
+	return timeouts
+}
+
+// setDefaultTimeouts replaces the timeouts the resources are defined with by the default timeouts
+// of the provider. They're read from the resource definition when planning, so a `timeouts` block
+// of a resource still takes precedence. Operations a resource doesn't have aren't given a timeout.
+func setDefaultTimeouts(d *schema.ResourceData, resources map[string]*schema.Resource, own map[string]*schema.ResourceTimeout) {
+	defaults := make(map[string]time.Duration)
+	for arg, key := range defaultTimeoutArguments {
+		// Validated as a duration
+		if v, ok := d.GetOk(arg); ok {
+			defaults[key], _ = time.ParseDuration(v.(string))
+		}
+	}
+
+	for name, r := range resources {
+		r.Timeouts = own[name]
+		if len(defaults) == 0 {
+			continue
+		}
+
+		timeouts := &schema.ResourceTimeout{}
+		if own[name] != nil {
+			*timeouts = *own[name]
+		}
+		if v, ok := defaults[schema.TimeoutCreate]; ok && r.Create != nil {
+			timeouts.Create = ptrToDuration(v)
+		}
+		if v, ok := defaults[schema.TimeoutUpdate]; ok && r.Update != nil {
+			timeouts.Update = ptrToDuration(v)
+		}
+		if v, ok := defaults[schema.TimeoutDelete]; ok && r.Delete != nil {
+			timeouts.Delete = ptrToDuration(v)
+		}
+		r.Timeouts = timeouts
+	}
+	log.Printf("[DEBUG] Default timeouts of the resources: %v", defaults)
+}
+
+func ptrToDuration(d time.Duration) *time.Duration {
+	return &d
+}
@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestSetDefaultTimeouts(t *testing.T) {
+	p := Provider().(*schema.Provider)
+	own := resourceTimeouts(p.ResourcesMap)
+
+	d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"default_create_timeout": "30m",
+		"default_delete_timeout": "2m",
+	})
+	setDefaultTimeouts(d, p.ResourcesMap, own)
+
+	job := p.ResourcesMap["kubernetes_job"].Timeouts
+	if job.Create == nil || *job.Create != 30*time.Minute {
+		t.Fatalf("Expected the job to create within the default 30m, given: %v", job.Create)
+	}
+	if job.Delete == nil || *job.Delete != 2*time.Minute {
+		t.Fatalf("Expected the job to delete within the default 2m, given: %v", job.Delete)
+	}
+	if job.Update != nil {
+		t.Fatalf("Expected the job to have no update timeout, given: %v", *job.Update)
+	}
+	// The binding is never updated
+	binding := p.ResourcesMap["kubernetes_binding"].Timeouts
+	if binding.Create == nil || binding.Update != nil {
+		t.Fatalf("Expected the binding to only get create & delete timeouts, given: %#v", binding)
+	}
+
+	// A timeouts block takes precedence over the provider default
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"timeouts": []map[string]interface{}{{"create": "5m"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded schema.ResourceTimeout
+	err = decoded.ConfigDecode(p.ResourcesMap["kubernetes_job"], terraform.NewResourceConfig(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *decoded.Create != 5*time.Minute || *decoded.Delete != 2*time.Minute {
+		t.Fatalf("Expected a create timeout of 5m & a delete timeout of 2m, given: %v & %v", *decoded.Create, *decoded.Delete)
+	}
+
+	// Configuring the provider without defaults starts over from the timeouts of the resources
+	setDefaultTimeouts(schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{}), p.ResourcesMap, own)
+	job = p.ResourcesMap["kubernetes_job"].Timeouts
+	if *job.Create != 10*time.Minute || *job.Delete != time.Minute {
+		t.Fatalf("Expected the timeouts of the job resource, given: %#v", job)
+	}
+	if p.ResourcesMap["kubernetes_binding"].Timeouts != nil {
+		t.Fatal("Expected the binding to have no timeouts again")
+	}
+}
@@ -0,0 +1,129 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func deleteGracePeriodSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Description:  "Seconds the object is given to terminate gracefully when it's deleted. Only kinds which support graceful deletion, like pods, honor it, the others are deleted right away. 0 deletes it immediately. Defaults to the grace period of the object.",
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+	}
+}
+
+// podGracePeriodSchema is deleteGracePeriodSchema for the pods deleted or evicted by a resource.
+// A grace period of 0 force-deletes a pod, which has to be confirmed with `force` instead.
+func podGracePeriodSchema(forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Description:  "Seconds the pod is given to terminate gracefully, overriding its `termination_grace_period_seconds`. Must be at least 1, use `force` to delete the pod immediately. Defaults to the termination grace period of the pod.",
+		Optional:     true,
+		ForceNew:     forceNew,
+		ValidateFunc: validatePodGracePeriodSeconds,
+	}
+}
+
+// forcePodDeletionSchema returns the schema of `force`, which confirms the pod is to be deleted
+// immediately. The pod is then removed from the API without waiting for its containers to stop.
+func forcePodDeletionSchema(forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeBool,
+		Description:   "Whether to delete the pod immediately, with a grace period of 0. The pod is removed from the API without waiting for its containers to be stopped, which may keep running on the node, e.g. when it's unreachable, while a replacement of the pod is started. Defaults to `false`.",
+		Optional:      true,
+		ForceNew:      forceNew,
+		Default:       false,
+		ConflictsWith: []string{"grace_period_seconds"},
+	}
+}
+
+func validatePodGracePeriodSeconds(value interface{}, key string) (ws []string, es []error) {
+	v := value.(int)
+	switch {
+	case v < 0:
+		es = append(es, fmt.Errorf("%s must be greater than or equal to 0", key))
+	case v == 0:
+		es = append(es, fmt.Errorf("%s of 0 force-deletes the pod without waiting for its containers to stop, set force = true instead to confirm it", key))
+	}
+	return
+}
+
+// forcePodDeletion sets the grace period of the pod deletion to 0 when `force` is set.
+func forcePodDeletion(d *schema.ResourceData, opts *metav1.DeleteOptions) *metav1.DeleteOptions {
+	if d.Get("force").(bool) {
+		log.Printf("[WARN] Force-deleting the pod, its containers may keep running on the node")
+		opts.GracePeriodSeconds = ptrToInt64(0)
+	}
+	return opts
+}
+
+// deletePropagationPolicySchema returns the schema of `propagation_policy`,
+// defaultPolicy is used when it isn't set (empty for the default of the API server).
+func deletePropagationPolicySchema(defaultPolicy metav1.DeletionPropagation) *schema.Schema {
+	def := "the default of the API server"
+	if defaultPolicy != "" {
+		def = fmt.Sprintf("`%s`", defaultPolicy)
+	}
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: fmt.Sprintf("How the dependents of the object are deleted along with it: `Orphan` leaves them untouched, `Background` deletes them after the object, `Foreground` deletes them before the object. Defaults to %s.", def),
+		Optional:    true,
+		ValidateFunc: validateAttributeValueIsIn([]string{
+			string(metav1.DeletePropagationOrphan),
+			string(metav1.DeletePropagationBackground),
+			string(metav1.DeletePropagationForeground),
+		}),
+	}
+}
+
+// deleteOptions builds the options of a delete from the `grace_period_seconds`
+// & `propagation_policy` arguments of the resource.
+func deleteOptions(d *schema.ResourceData, defaultPolicy metav1.DeletionPropagation) *metav1.DeleteOptions {
+	opts := &metav1.DeleteOptions{}
+
+	policy := defaultPolicy
+	if v, ok := d.GetOk("propagation_policy"); ok {
+		policy = metav1.DeletionPropagation(v.(string))
+	}
+	if policy != "" {
+		opts.PropagationPolicy = &policy
+	}
+	if v, ok := d.GetOkExists("grace_period_seconds"); ok {
+		seconds := int64(v.(int))
+		opts.GracePeriodSeconds = &seconds
+	}
+
+	log.Printf("[DEBUG] Delete options: %#v", opts)
+	return opts
+}
+
+// isForegroundDeletion reports whether the object is kept until all of its dependents are gone.
+func isForegroundDeletion(opts *metav1.DeleteOptions) bool {
+	return opts.PropagationPolicy != nil && *opts.PropagationPolicy == metav1.DeletePropagationForeground
+}
+
+// waitForDeletion polls get until it returns a NotFound error,
+// e.g. until a foreground deletion removed all the dependents of the object.
+func waitForDeletion(timeout time.Duration, description string, get func() error) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		err := get()
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		log.Printf("[DEBUG] %s still exists", description)
+		return resource.RetryableError(fmt.Errorf("%s still exists", description))
+	})
+}
@@ -0,0 +1,115 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeleteOptions(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"grace_period_seconds": deleteGracePeriodSchema(),
+		"propagation_policy":   deletePropagationPolicySchema(metav1.DeletePropagationForeground),
+	}
+
+	cases := map[string]struct {
+		Config        map[string]interface{}
+		DefaultPolicy metav1.DeletionPropagation
+		Policy        string
+		GracePeriod   int64
+		Foreground    bool
+	}{
+		"server defaults": {
+			Config:      map[string]interface{}{},
+			GracePeriod: -1,
+		},
+		"resource default": {
+			Config:        map[string]interface{}{},
+			DefaultPolicy: metav1.DeletePropagationForeground,
+			Policy:        "Foreground",
+			GracePeriod:   -1,
+			Foreground:    true,
+		},
+		"configured": {
+			Config:        map[string]interface{}{"propagation_policy": "Background", "grace_period_seconds": 30},
+			DefaultPolicy: metav1.DeletePropagationForeground,
+			Policy:        "Background",
+			GracePeriod:   30,
+		},
+		"immediate": {
+			Config:      map[string]interface{}{"grace_period_seconds": 0},
+			GracePeriod: 0,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, s, tc.Config)
+			opts := deleteOptions(d, tc.DefaultPolicy)
+
+			policy := ""
+			if opts.PropagationPolicy != nil {
+				policy = string(*opts.PropagationPolicy)
+			}
+			if policy != tc.Policy {
+				t.Fatalf("Expected propagation policy %q, given: %q", tc.Policy, policy)
+			}
+
+			gracePeriod := int64(-1)
+			if opts.GracePeriodSeconds != nil {
+				gracePeriod = *opts.GracePeriodSeconds
+			}
+			if gracePeriod != tc.GracePeriod {
+				t.Fatalf("Expected grace period %d, given: %d", tc.GracePeriod, gracePeriod)
+			}
+
+			if isForegroundDeletion(opts) != tc.Foreground {
+				t.Fatalf("Expected foreground deletion to be %t", tc.Foreground)
+			}
+		})
+	}
+}
+
+func TestForcePodDeletion(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"grace_period_seconds": podGracePeriodSchema(false),
+		"force":                forcePodDeletionSchema(false),
+		"propagation_policy":   deletePropagationPolicySchema(""),
+	}
+
+	cases := map[string]struct {
+		Config      map[string]interface{}
+		GracePeriod int64
+	}{
+		"default":    {map[string]interface{}{}, -1},
+		"configured": {map[string]interface{}{"grace_period_seconds": 5}, 5},
+		"forced":     {map[string]interface{}{"force": true}, 0},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, s, tc.Config)
+			opts := forcePodDeletion(d, deleteOptions(d, ""))
+
+			gracePeriod := int64(-1)
+			if opts.GracePeriodSeconds != nil {
+				gracePeriod = *opts.GracePeriodSeconds
+			}
+			if gracePeriod != tc.GracePeriod {
+				t.Fatalf("Expected grace period %d, given: %d", tc.GracePeriod, gracePeriod)
+			}
+		})
+	}
+
+	// A grace period of 0 has to be confirmed with force
+	if _, es := validatePodGracePeriodSeconds(0, "grace_period_seconds"); len(es) == 0 {
+		t.Fatal("Expected an error for a grace period of 0")
+	}
+	if _, es := validatePodGracePeriodSeconds(-1, "grace_period_seconds"); len(es) == 0 {
+		t.Fatal("Expected an error for a negative grace period")
+	}
+	if _, es := validatePodGracePeriodSeconds(1, "grace_period_seconds"); len(es) != 0 {
+		t.Fatalf("Expected no error for a grace period of 1, given: %v", es)
+	}
+}
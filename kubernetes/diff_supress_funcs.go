@@ -1,6 +1,9 @@
 package kubernetes
 
 import (
+	"encoding/json"
+	"reflect"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -16,3 +19,15 @@ func suppressEquivalentResourceQuantity(k, old, new string, d *schema.ResourceDa
 	}
 	return oldQ.Cmp(newQ) == 0
 }
+
+// suppressEquivalentJSON ignores differences in formatting & key order between two JSON documents.
+func suppressEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
+	var oldV, newV interface{}
+	if err := json.Unmarshal([]byte(old), &oldV); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &newV); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldV, newV)
+}
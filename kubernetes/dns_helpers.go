@@ -0,0 +1,23 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// checkDNSConfig is meant to be called from CustomizeDiff of the resources with a pod spec,
+// at podSpecKey. With the `None` DNS policy the pod gets none of the DNS settings of the cluster,
+// only those of its DNS config: the API server rejects such a pod without a name server.
+// This fails the plan when the DNS config is missing altogether, name servers which aren't
+// known until apply read as empty so they're left to the API server.
+func checkDNSConfig(d *schema.ResourceDiff, podSpecKey string) error {
+	if d.Get(podSpecKey+".dns_policy").(string) != "None" {
+		return nil
+	}
+	if len(d.Get(podSpecKey+".dns_config").([]interface{})) > 0 {
+		return nil
+	}
+	return fmt.Errorf("%s.dns_policy None ignores the DNS settings of the cluster, %s.dns_config must set at least one of the nameservers of the pod",
+		podSpecKey, podSpecKey)
+}
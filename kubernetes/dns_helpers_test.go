@@ -0,0 +1,107 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestPodDNSConfig(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Policy        string
+		DNSConfig     []map[string]interface{}
+		ExpectedError string
+	}{
+		{
+			"search domain",
+			"ClusterFirst",
+			[]map[string]interface{}{{"searches": []string{"legacy.example.com"}, "options": map[string]interface{}{"ndots": "2"}}},
+			"",
+		},
+		{
+			"none",
+			"None",
+			[]map[string]interface{}{{"nameservers": []string{"10.0.0.10", "fd00::10"}}},
+			"",
+		},
+		{
+			"none without config",
+			"None",
+			nil,
+			"spec.0.dns_config must set at least one of the nameservers of the pod",
+		},
+		{
+			"invalid nameserver",
+			"None",
+			[]map[string]interface{}{{"nameservers": []string{"dns.example.com"}}},
+			"must be a valid IP address",
+		},
+		{
+			"too many nameservers",
+			"Default",
+			[]map[string]interface{}{{"nameservers": []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"}}},
+			"attribute supports 3 item maximum",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			spec := map[string]interface{}{
+				"container":  []map[string]interface{}{{"name": "web", "image": "nginx"}},
+				"dns_policy": tc.Policy,
+			}
+			if tc.DNSConfig != nil {
+				spec["dns_config"] = tc.DNSConfig
+			}
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec":     []map[string]interface{}{spec},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := terraform.NewResourceConfig(raw)
+
+			r := resourceKubernetesPod()
+			_, es := r.Validate(c)
+			if len(es) == 0 {
+				_, err = r.Diff(nil, c, &kubernetesProvider{})
+			} else {
+				err = es[0]
+			}
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestReplicationControllerDNSConfig(t *testing.T) {
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"metadata": []map[string]interface{}{{"name": "web"}},
+		"spec": []map[string]interface{}{{
+			"selector": map[string]interface{}{"app": "web"},
+			"template": []map[string]interface{}{{
+				"container":  []map[string]interface{}{{"name": "web", "image": "nginx"}},
+				"dns_policy": "None",
+			}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = resourceKubernetesReplicationController().Diff(nil, terraform.NewResourceConfig(raw), &kubernetesProvider{})
+	if err == nil || !strings.Contains(err.Error(), "spec.0.template.0.dns_config must set") {
+		t.Fatalf("Expected the missing DNS config to fail the plan, given: %v", err)
+	}
+}
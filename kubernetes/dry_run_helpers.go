@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+// buildPlannedObjectFunc assembles the API object planned by the diff from its expanded metadata.
+type buildPlannedObjectFunc func(metadata metav1.ObjectMeta) (interface{}, error)
+
+// restClientFunc returns the REST client of the API group & version of a resource, e.g. conn.CoreV1().RESTClient().
+type restClientFunc func(conn *kubernetes.Clientset) restclient.Interface
+
+func coreV1RESTClient(conn *kubernetes.Clientset) restclient.Interface {
+	return conn.CoreV1().RESTClient()
+}
+
+// plannedMetadataKeys are the keys of the metadata which are sent along with any object.
+var plannedMetadataKeys = []string{"metadata.0.name", "metadata.0.generate_name", "metadata.0.labels", "metadata.0.annotations"}
+
+// checkDryRun is meant to be called from CustomizeDiff. When the provider is configured with
+// dry_run_plan = true the object planned by the diff is sent with dryRun=All, a create is posted
+// to the collection of resource & an update puts the whole object, so admission webhooks, quotas
+// & validation fail the plan instead of the apply. The API server doesn't persist anything.
+//
+// The dry run is skipped when any value of the metadata or under the given keys is only known after
+// apply, the ResourceDiff reads those as zero values, when the object is re-created and when the
+// API server is older than 1.13: it doesn't know the dryRun parameter and would ignore it.
+func checkDryRun(d *schema.ResourceDiff, meta interface{}, client restClientFunc, resource string, build buildPlannedObjectFunc, keys ...string) error {
+	kp, ok := meta.(*kubernetesProvider)
+	if !ok || !kp.dryRunPlan {
+		return nil
+	}
+	// The old object is still around, the new one can't be created nor the old one updated
+	if d.Id() != "" && (d.HasChange("metadata.0.name") || d.HasChange("metadata.0.namespace")) {
+		log.Printf("[DEBUG] Skipping the dry run of the planned %s, it's re-created", resource)
+		return nil
+	}
+	if k, ok := plannedValueUnknown(d, append(keys, plannedMetadataKeys...)...); ok {
+		log.Printf("[DEBUG] Skipping the dry run of the planned %s, %s is only known after apply", resource, k)
+		return nil
+	}
+	supported, err := kp.serverSupportsDryRun()
+	if err != nil {
+		return err
+	}
+	if !supported {
+		log.Printf("[WARN] Skipping the dry run of the planned %s, the API server is older than 1.13", resource)
+		return nil
+	}
+
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
+	obj, err := build(metadata)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	// Unknown values of maps are read as is
+	if strings.Contains(string(data), config.UnknownVariableValue) {
+		log.Printf("[DEBUG] Skipping the dry run of the planned %s, a value is only known after apply", resource)
+		return nil
+	}
+
+	verb := "update"
+	req := client(kp.conn).Put().Namespace(metadata.Namespace).Resource(resource).Name(metadata.Name)
+	if d.Id() == "" {
+		verb = "create"
+		req = client(kp.conn).Post().Namespace(metadata.Namespace).Resource(resource)
+	}
+	log.Printf("[DEBUG] Dry running the %s of %s %q: %s", verb, resource, metadata.Name, data)
+	err = req.Param("dryRun", "All").Body(data).Do().Error()
+	if err != nil {
+		return fmt.Errorf("The dry run of the planned %s of %s %q failed: %s", verb, resource, metadata.Name, err)
+	}
+	return nil
+}
+
+// plannedValueUnknown returns the first key under the given ones whose planned value is only known after apply.
+// Only the unknown values of a create are told apart from zero values: the diff holds no key whose value doesn't change then.
+func plannedValueUnknown(d *schema.ResourceDiff, keys ...string) (string, bool) {
+	for _, prefix := range keys {
+		for _, k := range d.GetChangedKeysPrefix(prefix) {
+			// Elements of computed sets
+			if strings.Contains(k, "~") {
+				return k, true
+			}
+			v, ok := d.GetOk(k)
+			if v == config.UnknownVariableValue || (d.Id() == "" && !ok && !d.HasChange(k)) {
+				return k, true
+			}
+		}
+	}
+	return "", false
+}
+
+// serverSupportsDryRun reports whether the API server knows the dryRun parameter (Kubernetes 1.13+),
+// its version is only read once.
+func (kp *kubernetesProvider) serverSupportsDryRun() (bool, error) {
+	kp.mu.Lock()
+	defer kp.mu.Unlock()
+	if kp.dryRunSupported != nil {
+		return *kp.dryRunSupported, nil
+	}
+
+	ver, err := kp.conn.ServerVersion()
+	if err != nil {
+		return false, fmt.Errorf("Failed to read the server version for the dry run: %s", err)
+	}
+	// Managed clusters may report e.g. a minor version of "13+"
+	major, err := strconv.Atoi(strings.TrimSuffix(ver.Major, "+"))
+	if err != nil {
+		return false, fmt.Errorf("Failed to parse the server version %s: %s", ver, err)
+	}
+	minor, err := strconv.Atoi(strings.TrimSuffix(ver.Minor, "+"))
+	if err != nil {
+		return false, fmt.Errorf("Failed to parse the server version %s: %s", ver, err)
+	}
+	supported := major > 1 || (major == 1 && minor >= 13)
+	kp.dryRunSupported = &supported
+	return supported, nil
+}
@@ -0,0 +1,104 @@
+package kubernetes
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestCheckDryRun(t *testing.T) {
+	cases := []struct {
+		Name          string
+		DryRunPlan    bool
+		ServerMinor   string
+		ID            string
+		Data          string
+		Expected      string
+		ExpectedError string
+	}{
+		{"disabled", false, "13", "", "1", "", ""},
+		{"create", true, "13", "", "1", "POST /api/v1/namespaces/default/configmaps?dryRun=All {\"metadata\":{\"name\":\"test\",\"namespace\":\"default\",\"creationTimestamp\":null},\"data\":{\"key\":\"1\"}}", ""},
+		{"update", true, "14+", "default/test", "2", "PUT /api/v1/namespaces/default/configmaps/test?dryRun=All {\"metadata\":{\"name\":\"test\",\"namespace\":\"default\",\"creationTimestamp\":null},\"data\":{\"key\":\"2\"}}", ""},
+		{"denied", true, "13", "", "denied", "", `admission webhook "policy.example.com" denied the request`},
+		{"unknown value", true, "13", "", config.UnknownVariableValue, "", ""},
+		{"old server", true, "12", "", "1", "", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/version" {
+					fmt.Fprintf(w, `{"major": "1", "minor": %q}`, tc.ServerMinor)
+					return
+				}
+				body, _ := ioutil.ReadAll(r.Body)
+				// The diff of a new object is customized twice
+				request := fmt.Sprintf("%s %s %s", r.Method, r.URL.RequestURI(), strings.TrimSpace(string(body)))
+				if len(requests) == 0 || requests[len(requests)-1] != request {
+					requests = append(requests, request)
+				}
+				if strings.Contains(string(body), "denied") {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "code": 400,
+	"message": "admission webhook \"policy.example.com\" denied the request"}`)
+					return
+				}
+				fmt.Fprint(w, string(body))
+			}))
+			defer server.Close()
+
+			conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+			meta := &kubernetesProvider{conn: conn, dryRunPlan: tc.DryRunPlan}
+
+			var state *terraform.InstanceState
+			if tc.ID != "" {
+				state = &terraform.InstanceState{
+					ID: tc.ID,
+					Attributes: map[string]string{
+						"metadata.#":           "1",
+						"metadata.0.name":      "test",
+						"metadata.0.namespace": "default",
+						"data.%":               "1",
+						"data.key":             "1",
+					},
+				}
+			}
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "test"}},
+				"data":     map[string]interface{}{"key": tc.Data},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = resourceKubernetesConfigMap().Diff(state, terraform.NewResourceConfig(raw), meta)
+			if tc.ExpectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+					t.Fatalf("Expected an error containing %q, given: %v", tc.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			given := strings.Join(requests, "\n")
+			if given != tc.Expected {
+				t.Fatalf("Expected the requests:\n%s\nGiven:\n%s", tc.Expected, given)
+			}
+		})
+	}
+}
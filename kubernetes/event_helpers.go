@@ -71,3 +71,11 @@ func stringifyEvents(events []api.Event) string {
 	}
 	return output
 }
+
+func stringifyFinalizers(finalizers []string) string {
+	var output string
+	for _, f := range finalizers {
+		output += fmt.Sprintf("\n   * waiting for finalizer %q", f)
+	}
+	return output
+}
@@ -0,0 +1,53 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const (
+	immutableFieldBehaviorRecreate = "recreate"
+	immutableFieldBehaviorError    = "error"
+)
+
+// checkImmutableFieldChanges is meant to be called from CustomizeDiff.
+// When the provider is configured with immutable_field_behavior = "error"
+// a change to any of the (ForceNew) keys under the given prefixes fails the
+// plan instead of planning a destroy & re-create of the object,
+// e.g. when the live object drifted away from the configuration.
+func checkImmutableFieldChanges(d *schema.ResourceDiff, meta interface{}, kind string, prefixes ...string) error {
+	kp, ok := meta.(*kubernetesProvider)
+	if !ok || kp.immutableFieldBehavior != immutableFieldBehaviorError {
+		return nil
+	}
+	// Nothing to re-create yet
+	if d.Id() == "" {
+		return nil
+	}
+
+	var changes []string
+	for _, prefix := range prefixes {
+		for _, k := range d.GetChangedKeysPrefix(prefix) {
+			// Skip the counts of lists/sets/maps, the changed elements are listed anyway
+			if strings.HasSuffix(k, ".#") || strings.HasSuffix(k, ".%") {
+				continue
+			}
+			oldV, newV := d.GetChange(k)
+			changes = append(changes, fmt.Sprintf("\n   * %s: %v => %v", k, oldV, newV))
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	sort.Strings(changes)
+
+	return fmt.Errorf("The following immutable fields of %s %q differ from the configuration "+
+		"and would force the %s to be destroyed and re-created:%s\n\n"+
+		"The provider is configured with immutable_field_behavior = %q, so the re-create is not planned. "+
+		"Either update the configuration to match the live object "+
+		"or set immutable_field_behavior = %q to allow the re-create.",
+		kind, d.Id(), kind, strings.Join(changes, ""), immutableFieldBehaviorError, immutableFieldBehaviorRecreate)
+}
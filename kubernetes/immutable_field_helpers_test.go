@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestCheckImmutableFieldChanges(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"spec": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"size": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			return checkImmutableFieldChanges(diff, meta, "test object", "spec")
+		},
+	}
+
+	cases := []struct {
+		Name          string
+		Behavior      string
+		ID            string
+		ExpectedError string
+	}{
+		{"recreate", immutableFieldBehaviorRecreate, "default/test", ""},
+		{"error", immutableFieldBehaviorError, "default/test", "spec.0.size: 1Gi => 2Gi"},
+		{"error new object", immutableFieldBehaviorError, "", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var state *terraform.InstanceState
+			if tc.ID != "" {
+				state = &terraform.InstanceState{
+					ID: tc.ID,
+					Attributes: map[string]string{
+						"spec.#":      "1",
+						"spec.0.size": "1Gi",
+					},
+				}
+			}
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"spec": []map[string]interface{}{{"size": "2Gi"}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			meta := &kubernetesProvider{immutableFieldBehavior: tc.Behavior}
+
+			_, err = r.Diff(state, terraform.NewResourceConfig(raw), meta)
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error containing %q", tc.ExpectedError)
+			}
+			if !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %s", tc.ExpectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// normalizeImportIds wraps the import function of each resource, so the id given to
+// `terraform import` may be a bare name or `namespace/name` whatever the scope of the object.
+// The id may also be pinned to the UID of the object as `namespace/name/uid` (`/name/uid` when
+// cluster-scoped), the import then fails unless the live object has that UID, instead of adopting
+// an object re-created with the same name in the meantime.
+func normalizeImportIds(resources map[string]*schema.Resource) {
+	for _, r := range resources {
+		if r.Importer == nil || r.Importer.State == nil {
+			continue
+		}
+		namespaced := isNamespacedResource(r)
+		hasUID := hasMetadataUID(r)
+		state := r.Importer.State
+		read := r.Read
+		r.Importer.State = func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			id, uid := splitImportUID(d.Id())
+			if uid != "" && !hasUID {
+				return nil, fmt.Errorf("ID %q is pinned to a UID, but the UID of this object isn't read: import it by %q", d.Id(), id)
+			}
+			id, err := normalizeImportId(id, namespaced)
+			if err != nil {
+				return nil, err
+			}
+			d.SetId(id)
+			out, err := state(d, meta)
+			if err != nil || uid == "" {
+				return out, err
+			}
+			for _, rd := range out {
+				err = checkImportedUID(rd, meta, read, uid)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return out, nil
+		}
+	}
+}
+
+// splitImportUID splits the UID an imported id of the form `namespace/name/uid` is pinned to,
+// it returns the id as is along with an empty UID when it isn't pinned.
+func splitImportUID(id string) (string, string) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 || parts[2] == "" {
+		return id, ""
+	}
+	return parts[0] + "/" + parts[1], parts[2]
+}
+
+// checkImportedUID reads the imported object and fails unless its UID is the one its id is pinned to.
+func checkImportedUID(d *schema.ResourceData, meta interface{}, read schema.ReadFunc, uid string) error {
+	id := d.Id()
+	err := read(d, meta)
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("Cannot import %q: the object with UID %q doesn't exist", id, uid)
+	}
+	if live := d.Get("metadata.0.uid").(string); live != uid {
+		return fmt.Errorf("Cannot import %q: its UID is %q, not %q as pinned in the ID. "+
+			"The object was likely deleted and re-created with the same name, check it's the one to import "+
+			"and import it by its new UID or without one.", id, live, uid)
+	}
+	return nil
+}
+
+// hasMetadataUID reports whether the metadata of the resource exposes the UID of the objects.
+func hasMetadataUID(r *schema.Resource) bool {
+	s, ok := r.Schema["metadata"]
+	if !ok {
+		return false
+	}
+	metadata, ok := s.Elem.(*schema.Resource)
+	if !ok {
+		return false
+	}
+	_, ok = metadata.Schema["uid"]
+	return ok
+}
+
+// importStateWithDefaults returns the import function of a resource with arguments which
+// only live in the state (e.g. whether to wait for the object), it sets them to their defaults
+// so the first plan after the import doesn't show a diff for them. Like any other import
+// function it's given the id normalized to the scope of the resource by normalizeImportIds.
+func importStateWithDefaults(defaults map[string]interface{}) schema.StateFunc {
+	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		for k, v := range defaults {
+			err := d.Set(k, v)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to set %s of the imported object: %s", k, err)
+			}
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// isNamespacedResource reports whether the objects of the resource live in a namespace,
+// i.e. whether its metadata has a namespace. The namespace of cluster-scoped metadata
+// only exists to fail the validation, unlike a real one it's not ForceNew.
+func isNamespacedResource(r *schema.Resource) bool {
+	s, ok := r.Schema["metadata"]
+	if !ok {
+		return false
+	}
+	metadata, ok := s.Elem.(*schema.Resource)
+	if !ok {
+		return false
+	}
+	namespace, ok := metadata.Schema["namespace"]
+	return ok && namespace.ForceNew
+}
+
+// normalizeImportId turns an imported id into the id format of the resource:
+// `namespace/name` for namespaced objects, where a bare name refers to the default namespace,
+// and the bare name for cluster-scoped objects, where an empty namespace is dropped.
+func normalizeImportId(id string, namespaced bool) (string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) > 2 || parts[len(parts)-1] == "" {
+		return "", fmt.Errorf("Unexpected ID format (%q), expected %q or %q, optionally followed by %q.", id, "namespace/name", "name", "/uid")
+	}
+
+	if namespaced {
+		if len(parts) == 1 || parts[0] == "" {
+			return "default/" + parts[len(parts)-1], nil
+		}
+		return id, nil
+	}
+
+	if len(parts) == 2 && parts[0] != "" {
+		return "", fmt.Errorf("ID %q has a namespace, but the object is cluster-scoped: import it by its name (%q)", id, parts[1])
+	}
+	return parts[len(parts)-1], nil
+}
@@ -0,0 +1,229 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestNormalizeImportId(t *testing.T) {
+	cases := []struct {
+		id         string
+		namespaced bool
+		expected   string
+		err        bool
+	}{
+		{"kube-system/claim", true, "kube-system/claim", false},
+		{"claim", true, "default/claim", false},
+		{"/claim", true, "default/claim", false},
+		{"volume", false, "volume", false},
+		{"/volume", false, "volume", false},
+		{"kube-system/volume", false, "", true},
+		{"a/b/c", true, "", true},
+		{"kube-system/", true, "", true},
+		{"", false, "", true},
+	}
+
+	for _, tc := range cases {
+		id, err := normalizeImportId(tc.id, tc.namespaced)
+		if (err != nil) != tc.err {
+			t.Fatalf("%q: unexpected error: %v", tc.id, err)
+		}
+		if id != tc.expected {
+			t.Fatalf("%q: expected id %q, given: %q", tc.id, tc.expected, id)
+		}
+	}
+}
+
+func TestImportStateIds(t *testing.T) {
+	resources := Provider().(*schema.Provider).ResourcesMap
+
+	cases := []struct {
+		resource string
+		id       string
+		expected string
+	}{
+		{"kubernetes_persistent_volume_claim", "default/claim", "default/claim"},
+		{"kubernetes_persistent_volume_claim", "claim", "default/claim"},
+		{"kubernetes_persistent_volume", "volume", "volume"},
+		{"kubernetes_persistent_volume", "/volume", "volume"},
+		{"kubernetes_cluster_role", "role", "role"},
+	}
+
+	for _, tc := range cases {
+		r := resources[tc.resource]
+		d := r.TestResourceData()
+		d.SetId(tc.id)
+
+		out, err := r.Importer.State(d, nil)
+		if err != nil {
+			t.Fatalf("%s %q: %s", tc.resource, tc.id, err)
+		}
+		if len(out) != 1 || out[0].Id() != tc.expected {
+			t.Fatalf("%s %q: expected id %q, given: %#v", tc.resource, tc.id, tc.expected, out)
+		}
+	}
+
+	d := resources["kubernetes_persistent_volume"].TestResourceData()
+	d.SetId("default/volume")
+	if _, err := resources["kubernetes_persistent_volume"].Importer.State(d, nil); err == nil {
+		t.Fatal("Expected an error for a persistent volume imported with a namespace")
+	}
+
+	if !isNamespacedResource(resources["kubernetes_persistent_volume_claim"]) {
+		t.Fatal("Expected persistent volume claims to be namespaced")
+	}
+	if isNamespacedResource(resources["kubernetes_persistent_volume"]) {
+		t.Fatal("Expected persistent volumes to be cluster-scoped")
+	}
+}
+
+func TestImportStatePinnedToUID(t *testing.T) {
+	reads := 0
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("config map", false),
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			reads++
+			namespace, name, err := idParts(d.Id())
+			if err != nil {
+				return err
+			}
+			if name == "gone" {
+				d.SetId("")
+				return nil
+			}
+			return d.Set("metadata", []interface{}{map[string]interface{}{
+				"namespace": namespace,
+				"name":      name,
+				"uid":       "6f0c3b1e",
+			}})
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+	normalizeImportIds(map[string]*schema.Resource{"kubernetes_config_map": r})
+
+	cases := []struct {
+		id       string
+		expected string
+		reads    int
+		err      string
+	}{
+		{"kube-system/settings", "kube-system/settings", 0, ""},
+		{"kube-system/settings/6f0c3b1e", "kube-system/settings", 1, ""},
+		{"/settings/6f0c3b1e", "default/settings", 1, ""},
+		{"kube-system/settings/a81d9c42", "", 1, `its UID is "6f0c3b1e", not "a81d9c42"`},
+		{"kube-system/gone/6f0c3b1e", "", 1, "doesn't exist"},
+	}
+
+	for _, tc := range cases {
+		reads = 0
+		d := r.TestResourceData()
+		d.SetId(tc.id)
+
+		out, err := r.Importer.State(d, nil)
+		if reads != tc.reads {
+			t.Fatalf("%q: expected %d reads, given: %d", tc.id, tc.reads, reads)
+		}
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("%q: expected error to contain %q, given: %v", tc.id, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: %s", tc.id, err)
+		}
+		if len(out) != 1 || out[0].Id() != tc.expected {
+			t.Fatalf("%q: expected id %q, given: %#v", tc.id, tc.expected, out)
+		}
+	}
+
+	// The UID can only be checked when the metadata has one
+	pr := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"metadata": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{"name": {Type: schema.TypeString, Required: true}},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{State: schema.ImportStatePassthrough},
+	}
+	normalizeImportIds(map[string]*schema.Resource{"kubernetes_mutating_namespace_pod_security": pr})
+	d := pr.TestResourceData()
+	d.SetId("/apps/6f0c3b1e")
+	if _, err := pr.Importer.State(d, nil); err == nil {
+		t.Fatal("Expected an error for an id pinned to a UID which isn't read")
+	}
+}
+
+func TestImportStateWithDefaults(t *testing.T) {
+	resources := Provider().(*schema.Provider).ResourcesMap
+
+	cases := []struct {
+		resource string
+		id       string
+		expected string
+		defaults map[string]string
+	}{
+		// Namespaced
+		{"kubernetes_persistent_volume_claim", "claim", "default/claim", map[string]string{"wait_until_bound": "true", "adopt_existing": "false", "wait_for_volume_release": "false"}},
+		{"kubernetes_deployment", "kube-system/web", "kube-system/web", map[string]string{"wait_for_rollout": "true", "patch_strategy": patchStrategyJSON}},
+		// Cluster-scoped
+		{"kubernetes_custom_resource_definition", "crontabs.stable.example.com", "crontabs.stable.example.com", map[string]string{"wait_for_established": "true"}},
+		{"kubernetes_custom_resource_definition", "/crontabs.stable.example.com", "crontabs.stable.example.com", map[string]string{"wait_for_established": "true"}},
+	}
+
+	for _, tc := range cases {
+		r := resources[tc.resource]
+		d := r.TestResourceData()
+		d.SetId(tc.id)
+
+		out, err := r.Importer.State(d, nil)
+		if err != nil {
+			t.Fatalf("%s %q: %s", tc.resource, tc.id, err)
+		}
+		if len(out) != 1 || out[0].Id() != tc.expected {
+			t.Fatalf("%s %q: expected id %q, given: %#v", tc.resource, tc.id, tc.expected, out)
+		}
+		attrs := out[0].State().Attributes
+		for k, v := range tc.defaults {
+			if attrs[k] != v {
+				t.Fatalf("%s %q: expected %s to be %q, given: %q", tc.resource, tc.id, k, v, attrs[k])
+			}
+		}
+	}
+
+	// The defaults aren't set when the id doesn't fit the scope
+	d := resources["kubernetes_custom_resource_definition"].TestResourceData()
+	d.SetId("default/crontabs.stable.example.com")
+	if _, err := resources["kubernetes_custom_resource_definition"].Importer.State(d, nil); err == nil {
+		t.Fatal("Expected an error for a custom resource definition imported with a namespace")
+	}
+
+	d = (&schema.Resource{Schema: map[string]*schema.Schema{}}).TestResourceData()
+	if _, err := importStateWithDefaults(map[string]interface{}{"unknown": true})(d, nil); err == nil {
+		t.Fatal("Expected an error for a default of an unknown argument")
+	}
+}
+
+func TestIdParts(t *testing.T) {
+	namespace, name, err := idParts("kube-system/role")
+	if err != nil || namespace != "kube-system" || name != "role" {
+		t.Fatalf("Unexpected parts: %q, %q, %v", namespace, name, err)
+	}
+	namespace, name, err = idParts("role")
+	if err != nil || namespace != "" || name != "role" {
+		t.Fatalf("Unexpected parts of a bare name: %q, %q, %v", namespace, name, err)
+	}
+	if _, _, err := idParts("a/b/c"); err == nil {
+		t.Fatal("Expected an error for an id with more than two parts")
+	}
+}
@@ -0,0 +1,37 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// fieldSelectorSchema is the field_selector argument of the data sources listing objects,
+// passed along with their label_selector as the fieldSelector of the list request.
+func fieldSelectorSchema(objectName string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  fmt.Sprintf("A field query over the %s, e.g. `metadata.name=web`. Only some fields can be selected on, depending on the kind of the objects. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/field-selectors/", objectName),
+		Optional:     true,
+		ValidateFunc: validateFieldSelectorString,
+	}
+}
+
+// listSelectorError explains the rejection of a list request by its field selector. The syntax of
+// the selector is validated at plan time, but which fields are supported is only known to the API
+// server, which rejects the others with a generic 400 Bad Request "field label not supported" error.
+func listSelectorError(err error, objectName, fieldSelector string) error {
+	if fieldSelector != "" && errors.IsBadRequest(err) {
+		return fmt.Errorf("The API server doesn't support the field selector %q for %s: %s", fieldSelector, objectName, err)
+	}
+	return err
+}
+
+// listId is the id of a data source listing the objects matching the selectors in a namespace.
+func listId(namespace, labelSelector, fieldSelector string) string {
+	if fieldSelector == "" {
+		return fmt.Sprintf("%s/%s", namespace, labelSelector)
+	}
+	return fmt.Sprintf("%s/%s/%s", namespace, labelSelector, fieldSelector)
+}
@@ -0,0 +1,95 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+// The metrics.k8s.io types & client aren't vendored,
+// so the objects served by metrics-server are decoded into these.
+
+type nodeMetrics struct {
+	meta_v1.TypeMeta `json:",inline"`
+	ObjectMeta       meta_v1.ObjectMeta `json:"metadata,omitempty"`
+	Timestamp        meta_v1.Time       `json:"timestamp"`
+	Window           meta_v1.Duration   `json:"window"`
+	Usage            api.ResourceList   `json:"usage"`
+}
+
+type podMetrics struct {
+	meta_v1.TypeMeta `json:",inline"`
+	ObjectMeta       meta_v1.ObjectMeta `json:"metadata,omitempty"`
+	Timestamp        meta_v1.Time       `json:"timestamp"`
+	Window           meta_v1.Duration   `json:"window"`
+	Containers       []containerMetrics `json:"containers"`
+}
+
+type containerMetrics struct {
+	Name  string           `json:"name"`
+	Usage api.ResourceList `json:"usage"`
+}
+
+const metricsAPIPath = "/apis/metrics.k8s.io/v1beta1"
+
+// getMetrics reads an object of the metrics.k8s.io aggregated API, e.g. "nodes/<name>"
+func getMetrics(conn *kubernetes.Clientset, path string, out interface{}) error {
+	log.Printf("[INFO] Reading metrics %s", path)
+	result := conn.CoreV1().RESTClient().Get().AbsPath(metricsAPIPath, path).Do()
+	raw, err := result.Raw()
+	if err != nil {
+		// Unlike DoRaw, decodes the status of the failed request
+		err = result.Error()
+		log.Printf("[DEBUG] Received error: %#v", err)
+		if isMissingMetricsObject(err) {
+			return err
+		}
+		if errors.IsNotFound(err) || errors.IsServiceUnavailable(err) {
+			// Also returned when the metrics API isn't registered or its backend is down
+			return fmt.Errorf("Failed to read metrics %s, make sure metrics-server is installed and running: %s", path, err)
+		}
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
+
+// isMissingMetricsObject reports whether err is the 404 of metrics-server for a pod or node it has
+// no metrics of, e.g. one which doesn't exist. Its status names the object in the details, unlike
+// the 404 of an API server without the metrics API.
+func isMissingMetricsObject(err error) bool {
+	status, ok := err.(errors.APIStatus)
+	if !ok || !errors.IsNotFound(err) {
+		return false
+	}
+	details := status.Status().Details
+	return details != nil && details.Group == "metrics.k8s.io" && details.Name != ""
+}
+
+// sumContainerUsage returns the total resource usage of all the containers of a pod
+func sumContainerUsage(containers []containerMetrics) api.ResourceList {
+	total := api.ResourceList{}
+	for _, c := range containers {
+		for name, q := range c.Usage {
+			sum := total[name]
+			sum.Add(q)
+			total[name] = sum
+		}
+	}
+	return total
+}
+
+func flattenContainerMetrics(in []containerMetrics) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, c := range in {
+		att[i] = map[string]interface{}{
+			"name":  c.Name,
+			"usage": flattenResourceList(c.Usage),
+		}
+	}
+	return att
+}
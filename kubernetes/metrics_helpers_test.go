@@ -0,0 +1,76 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestGetMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/apis/metrics.k8s.io/v1beta1/namespaces/default/pods/web":
+			fmt.Fprint(w, `{
+	"kind": "PodMetrics",
+	"apiVersion": "metrics.k8s.io/v1beta1",
+	"metadata": {"name": "web", "namespace": "default"},
+	"timestamp": "2018-07-01T12:30:00Z",
+	"window": "30s",
+	"containers": [
+		{"name": "app", "usage": {"cpu": "250m", "memory": "64Mi"}},
+		{"name": "sidecar", "usage": {"cpu": "50m", "memory": "16Mi"}}
+	]
+}`)
+		case "/apis/metrics.k8s.io/v1beta1/nodes/gone":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "message": "nodes.metrics.k8s.io \"gone\" not found", "reason": "NotFound", "details": {"name": "gone", "group": "metrics.k8s.io", "kind": "nodes"}, "code": 404}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound", "code": 404}`)
+		}
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pm podMetrics
+	err = getMetrics(conn, "namespaces/default/pods/web", &pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pm.ObjectMeta.Name != "web" || len(pm.Containers) != 2 || pm.Window.Duration.String() != "30s" {
+		t.Fatalf("Unexpected pod metrics: %#v", pm)
+	}
+	usage := flattenResourceList(sumContainerUsage(pm.Containers))
+	if usage["cpu"] != "300m" || usage["memory"] != "80Mi" {
+		t.Fatalf("Unexpected total usage: %#v", usage)
+	}
+
+	var nm nodeMetrics
+	err = getMetrics(conn, "nodes/missing", &nm)
+	if err == nil {
+		t.Fatal("Expected an error when the metrics API isn't available")
+	}
+	if !strings.Contains(err.Error(), "metrics-server") {
+		t.Fatalf("Expected the error to point to metrics-server, given: %s", err)
+	}
+
+	// metrics-server itself is reachable, the node is missing
+	err = getMetrics(conn, "nodes/gone", &nm)
+	if !errors.IsNotFound(err) {
+		t.Fatalf("Expected a NotFound error for a missing node, given: %v", err)
+	}
+	if strings.Contains(err.Error(), "metrics-server") {
+		t.Fatalf("Expected the error not to point to metrics-server for a missing node, given: %s", err)
+	}
+}
@@ -0,0 +1,36 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	controlledObjectBehaviorWarn  = "warn"
+	controlledObjectBehaviorError = "error"
+)
+
+// checkControllerOwner is meant to be called from Read of the resources whose objects
+// are commonly created by controllers, e.g. the pods of a replica set. Terraform & the
+// controller keep reverting each other's changes to such an object, so its controller
+// is logged as a warning, or fails the read when the provider is configured with
+// controlled_object_behavior = "error".
+func checkControllerOwner(kind string, om metav1.ObjectMeta, meta interface{}) error {
+	owner := metav1.GetControllerOf(&om)
+	if owner == nil {
+		return nil
+	}
+	controlled := fmt.Sprintf("%s %s is controlled by %s %s", kind, buildId(om), owner.Kind, owner.Name)
+
+	kp, ok := meta.(*kubernetesProvider)
+	if !ok || kp.controlledObjectBehavior != controlledObjectBehaviorError {
+		log.Printf("[WARN] %s, changes made through Terraform may be reverted by the controller", controlled)
+		return nil
+	}
+	return fmt.Errorf("%s, so it's managed by the controller. "+
+		"Manage the %s instead and remove the %s from the state with `terraform state rm`, "+
+		"or set controlled_object_behavior = %q to only log a warning.",
+		controlled, owner.Kind, kind, controlledObjectBehaviorWarn)
+}
@@ -0,0 +1,51 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckControllerOwner(t *testing.T) {
+	owned := metav1.ObjectMeta{
+		Name:      "web-7d9f8-x2k4p",
+		Namespace: "default",
+		OwnerReferences: []metav1.OwnerReference{
+			{Kind: "Node", Name: "node-1"},
+			{Kind: "ReplicaSet", Name: "web-7d9f8", Controller: ptrToBool(true)},
+		},
+	}
+	notControlled := metav1.ObjectMeta{
+		Name:            "web",
+		Namespace:       "default",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Node", Name: "node-1", Controller: ptrToBool(false)}},
+	}
+
+	cases := []struct {
+		Name          string
+		Metadata      metav1.ObjectMeta
+		Behavior      string
+		ExpectedError string
+	}{
+		{"warn", owned, controlledObjectBehaviorWarn, ""},
+		{"error", owned, controlledObjectBehaviorError, "Pod default/web-7d9f8-x2k4p is controlled by ReplicaSet web-7d9f8, so it's managed by the controller"},
+		{"not controlled", notControlled, controlledObjectBehaviorError, ""},
+		{"no owner", metav1.ObjectMeta{Name: "web", Namespace: "default"}, controlledObjectBehaviorError, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := checkControllerOwner("Pod", tc.Metadata, &kubernetesProvider{controlledObjectBehavior: tc.Behavior})
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
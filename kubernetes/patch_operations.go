@@ -67,6 +67,12 @@ func escapeJsonPointer(path string) string {
 	return path
 }
 
+func unescapeJsonPointer(path string) string {
+	path = strings.Replace(path, "~1", "/", -1)
+	path = strings.Replace(path, "~0", "~", -1)
+	return path
+}
+
 type PatchOperations []PatchOperation
 
 func (po PatchOperations) MarshalJSON() ([]byte, error) {
@@ -0,0 +1,321 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	restclient "k8s.io/client-go/rest"
+)
+
+const (
+	patchStrategyJSON      = "json"
+	patchStrategyStrategic = "strategic"
+	patchStrategyApply     = "apply"
+)
+
+// applyPatchType is the patch type of server-side apply (Kubernetes 1.16+), the vendored
+// k8s.io/apimachinery/pkg/types has no ApplyPatchType yet.
+const applyPatchType = pkgApi.PatchType("application/apply-patch+yaml")
+
+// terraformFieldManager is the field manager of the fields set through server-side apply.
+const terraformFieldManager = "terraform"
+
+func patchStrategySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "How updates are sent to the API server. `json` replaces the changed parts of the object (like the whole `spec`) with a JSON patch. `strategic` sends a strategic merge patch which merges lists (e.g. containers, env, ports) by the keys Kubernetes defines for them, so entries added to a shared object by others are kept. `apply` creates & updates the object with server-side apply (Kubernetes 1.16+) as the `terraform` field manager, which fails on fields owned by other field managers, see `force_conflicts`.",
+		Optional:     true,
+		Default:      patchStrategyJSON,
+		ValidateFunc: validateAttributeValueIsIn([]string{patchStrategyJSON, patchStrategyStrategic, patchStrategyApply}),
+	}
+}
+
+func forceConflictsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Whether the server-side apply of `patch_strategy = \"apply\"` takes over the fields owned by other field managers, instead of failing with a conflict which names them. Terraform then becomes the authoritative owner of those fields: a change by another manager is reverted by the next apply.",
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+// serverSideApply applies obj, the whole configured object along with its apiVersion & kind, to resource
+// through client, which must be the REST client of the API group of obj, and decodes the live object
+// into out. A field owned by another field manager fails the apply with a conflict, unless force_conflicts
+// is set. The resource version is left out, the apply isn't based on the version read by the refresh.
+func serverSideApply(d *schema.ResourceData, client restclient.Interface, resource string, obj metav1.Object, out runtime.Object) error {
+	obj.SetResourceVersion("")
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	req := client.Patch(applyPatchType).
+		Namespace(obj.GetNamespace()).
+		Resource(resource).
+		Name(obj.GetName()).
+		Param("fieldManager", terraformFieldManager)
+	if d.Get("force_conflicts").(bool) {
+		req = req.Param("force", "true")
+	}
+	log.Printf("[INFO] Applying %s %q: %s", resource, obj.GetName(), data)
+	return req.Body(data).Do().Into(out)
+}
+
+// buildObjectFunc assembles the API object from its expanded metadata and the
+// raw value of the spec-like attribute (e.g. `spec` or `template`).
+type buildObjectFunc func(metadata metav1.ObjectMeta, spec []interface{}) (interface{}, error)
+
+// strategicMergePatchForChanges returns a strategic merge patch which turns the object
+// described by the previous configuration into the one described by the current configuration.
+func strategicMergePatchForChanges(d *schema.ResourceData, meta interface{}, specKey string, build buildObjectFunc) (pkgApi.PatchType, []byte, error) {
+	oldMetadata, newMetadata := d.GetChange("metadata")
+	oldSpec, newSpec := d.GetChange(specKey)
+
+	original, err := build(expandMetadataWithDefaults(oldMetadata.([]interface{}), meta), oldSpec.([]interface{}))
+	if err != nil {
+		return "", nil, err
+	}
+	modified, err := build(expandMetadataWithDefaults(newMetadata.([]interface{}), meta), newSpec.([]interface{}))
+	if err != nil {
+		return "", nil, err
+	}
+
+	originalJSON, err := json.Marshal(original)
+	if err != nil {
+		return "", nil, err
+	}
+	modifiedJSON, err := json.Marshal(modified)
+	if err != nil {
+		return "", nil, err
+	}
+
+	data, err := createTwoWayMergePatch(originalJSON, modifiedJSON, original)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to create strategic merge patch: %s", err)
+	}
+	return pkgApi.StrategicMergePatchType, data, nil
+}
+
+// createTwoWayMergePatch creates a strategic merge patch which turns the JSON document original into modified.
+// Like the API server does, the patch strategy and merge key of each list are taken from the
+// `patchStrategy` & `patchMergeKey` struct tags of the API type of dataStruct, e.g. v1.Deployment{}.
+// It has the signature of CreateTwoWayMergePatch of k8s.io/apimachinery/pkg/util/strategicpatch,
+// which replaces it once vendored (see TODO.md).
+//
+//   - Lists with a merge key are merged item by item, removed items are deleted
+//     with a `$patch: delete` directive and the order is kept via `$setElementOrder`.
+//   - Lists of primitives with the merge strategy only get the added values,
+//     removed ones are listed in a `$deleteFromPrimitiveList` directive.
+//   - Any other list is replaced as a whole when it changed.
+//   - Removed keys of maps & structs are set to null.
+func createTwoWayMergePatch(original, modified []byte, dataStruct interface{}) ([]byte, error) {
+	t := reflect.TypeOf(dataStruct)
+	if t == nil || indirectType(t).Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct, but received a %T", dataStruct)
+	}
+
+	o := make(map[string]interface{})
+	if err := json.Unmarshal(original, &o); err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(modified, &m); err != nil {
+		return nil, err
+	}
+
+	patch := diffJSONMaps(o, m, t)
+	return json.Marshal(patch)
+}
+
+func toJSONMap(obj interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{})
+	err = json.Unmarshal(b, &m)
+	return m, err
+}
+
+func diffJSONMaps(o, m map[string]interface{}, t reflect.Type) map[string]interface{} {
+	patch := make(map[string]interface{})
+
+	for k, mv := range m {
+		ov, ok := o[k]
+		if !ok {
+			patch[k] = mv
+			continue
+		}
+		if reflect.DeepEqual(ov, mv) {
+			continue
+		}
+
+		ft, strategy, mergeKey := lookupPatchMeta(t, k)
+		switch mv := mv.(type) {
+		case map[string]interface{}:
+			ov, ok := ov.(map[string]interface{})
+			if !ok || ft == nil {
+				patch[k] = mv
+				continue
+			}
+			patch[k] = diffJSONMaps(ov, mv, ft)
+		case []interface{}:
+			ov, ok := ov.([]interface{})
+			if !ok || ft == nil || ft.Kind() != reflect.Slice || !hasPatchStrategy(strategy, "merge") {
+				patch[k] = mv
+				continue
+			}
+			if mergeKey == "" {
+				added, deleted := diffPrimitiveLists(ov, mv)
+				if len(added) > 0 {
+					patch[k] = added
+				}
+				if len(deleted) > 0 {
+					patch["$deleteFromPrimitiveList/"+k] = deleted
+				}
+				continue
+			}
+			items, order, ok := diffMergingLists(ov, mv, indirectType(ft.Elem()), mergeKey)
+			if !ok {
+				patch[k] = mv
+				continue
+			}
+			patch[k] = items
+			patch["$setElementOrder/"+k] = order
+		default:
+			patch[k] = mv
+		}
+	}
+
+	for k := range o {
+		if _, ok := m[k]; !ok {
+			patch[k] = nil
+		}
+	}
+
+	return patch
+}
+
+// diffMergingLists diffs two lists of objects identified by mergeKey.
+// It reports false if any of the items isn't an object with a merge key.
+func diffMergingLists(o, m []interface{}, t reflect.Type, mergeKey string) ([]interface{}, []interface{}, bool) {
+	originalItems := make(map[string]map[string]interface{}, len(o))
+	for _, v := range o {
+		item, ok := v.(map[string]interface{})
+		if !ok || item[mergeKey] == nil {
+			return nil, nil, false
+		}
+		originalItems[fmt.Sprintf("%v", item[mergeKey])] = item
+	}
+
+	items := make([]interface{}, 0)
+	order := make([]interface{}, 0, len(m))
+	seen := make(map[string]bool, len(m))
+	for _, v := range m {
+		item, ok := v.(map[string]interface{})
+		if !ok || item[mergeKey] == nil {
+			return nil, nil, false
+		}
+		key := fmt.Sprintf("%v", item[mergeKey])
+		seen[key] = true
+		order = append(order, map[string]interface{}{mergeKey: item[mergeKey]})
+
+		originalItem, ok := originalItems[key]
+		if !ok {
+			items = append(items, item)
+			continue
+		}
+		itemPatch := diffJSONMaps(originalItem, item, t)
+		if len(itemPatch) > 0 {
+			itemPatch[mergeKey] = item[mergeKey]
+			items = append(items, itemPatch)
+		}
+	}
+
+	for _, v := range o {
+		item := v.(map[string]interface{})
+		if !seen[fmt.Sprintf("%v", item[mergeKey])] {
+			items = append(items, map[string]interface{}{
+				mergeKey: item[mergeKey],
+				"$patch": "delete",
+			})
+		}
+	}
+
+	return items, order, true
+}
+
+func diffPrimitiveLists(o, m []interface{}) ([]interface{}, []interface{}) {
+	added := make([]interface{}, 0)
+	for _, v := range m {
+		if !containsValue(o, v) {
+			added = append(added, v)
+		}
+	}
+	deleted := make([]interface{}, 0)
+	for _, v := range o {
+		if !containsValue(m, v) {
+			deleted = append(deleted, v)
+		}
+	}
+	return added, deleted
+}
+
+func containsValue(l []interface{}, v interface{}) bool {
+	for _, lv := range l {
+		if reflect.DeepEqual(lv, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupPatchMeta finds the type and the patch strategy & merge key of the
+// field serialized under the given JSON key. The type is nil if unknown.
+func lookupPatchMeta(t reflect.Type, key string) (reflect.Type, string, string) {
+	t = indirectType(t)
+	switch t.Kind() {
+	case reflect.Map:
+		return indirectType(t.Elem()), "", ""
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := strings.Split(f.Tag.Get("json"), ",")[0]
+			if name == "" && f.Anonymous {
+				// Inlined struct, like TypeMeta
+				if ft, strategy, mergeKey := lookupPatchMeta(f.Type, key); ft != nil {
+					return ft, strategy, mergeKey
+				}
+				continue
+			}
+			if name == key {
+				return indirectType(f.Type), f.Tag.Get("patchStrategy"), f.Tag.Get("patchMergeKey")
+			}
+		}
+	}
+	return nil, "", ""
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func hasPatchStrategy(strategies, strategy string) bool {
+	for _, s := range strings.Split(strategies, ",") {
+		if s == strategy {
+			return true
+		}
+	}
+	return false
+}
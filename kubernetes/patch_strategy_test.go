@@ -0,0 +1,183 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestCreateTwoWayMergePatch(t *testing.T) {
+	podWithContainers := func(containers ...v1.Container) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec:       v1.PodSpec{Containers: containers},
+		}
+	}
+
+	cases := []struct {
+		Name     string
+		Original interface{}
+		Modified interface{}
+		Expected string
+	}{
+		{
+			"no changes",
+			podWithContainers(v1.Container{Name: "app", Image: "nginx:1.7.8"}),
+			podWithContainers(v1.Container{Name: "app", Image: "nginx:1.7.8"}),
+			`{}`,
+		},
+		{
+			"merge containers by name",
+			podWithContainers(
+				v1.Container{Name: "app", Image: "nginx:1.7.8"},
+				v1.Container{Name: "sidecar", Image: "busybox"},
+			),
+			podWithContainers(
+				v1.Container{Name: "app", Image: "nginx:1.7.9"},
+				v1.Container{Name: "sidecar", Image: "busybox"},
+			),
+			`{"spec": {
+				"containers": [{"name": "app", "image": "nginx:1.7.9"}],
+				"$setElementOrder/containers": [{"name": "app"}, {"name": "sidecar"}]
+			}}`,
+		},
+		{
+			"delete env by name",
+			podWithContainers(v1.Container{Name: "app", Env: []v1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}}}),
+			podWithContainers(v1.Container{Name: "app", Env: []v1.EnvVar{{Name: "B", Value: "3"}}}),
+			`{"spec": {
+				"containers": [{
+					"name": "app",
+					"env": [{"name": "B", "value": "3"}, {"name": "A", "$patch": "delete"}],
+					"$setElementOrder/env": [{"name": "B"}]
+				}],
+				"$setElementOrder/containers": [{"name": "app"}]
+			}}`,
+		},
+		{
+			"replace list without merge strategy",
+			podWithContainers(v1.Container{Name: "app", Args: []string{"-a"}}),
+			podWithContainers(v1.Container{Name: "app", Args: []string{"-b"}}),
+			`{"spec": {
+				"containers": [{"name": "app", "args": ["-b"]}],
+				"$setElementOrder/containers": [{"name": "app"}]
+			}}`,
+		},
+		{
+			"removed map keys are nulled",
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "api"}}},
+			`{"metadata": {"labels": {"app": "api", "tier": null}}}`,
+		},
+		{
+			"merge primitive list",
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Finalizers: []string{"a", "b"}}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Finalizers: []string{"b", "c"}}},
+			`{"metadata": {"finalizers": ["c"], "$deleteFromPrimitiveList/finalizers": ["a"]}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			original, err := json.Marshal(tc.Original)
+			if err != nil {
+				t.Fatal(err)
+			}
+			modified, err := json.Marshal(tc.Modified)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := createTwoWayMergePatch(original, modified, tc.Original)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var given, expected interface{}
+			if err := json.Unmarshal(out, &given); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tc.Expected), &expected); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(given, expected) {
+				t.Fatalf("Unexpected patch.\nExpected: %s\nGiven:    %s", tc.Expected, string(out))
+			}
+		})
+	}
+}
+
+func TestCreateTwoWayMergePatch_badArguments(t *testing.T) {
+	_, err := createTwoWayMergePatch([]byte(`{}`), []byte(`{}`), "pod")
+	if err == nil {
+		t.Fatal("Expected an error when the data struct isn't a struct")
+	}
+	_, err = createTwoWayMergePatch([]byte(`{}`), []byte(`[]`), v1.Pod{})
+	if err == nil {
+		t.Fatal("Expected an error when a document isn't an object")
+	}
+}
+
+func TestServerSideApply(t *testing.T) {
+	cases := []struct {
+		Name           string
+		ForceConflicts bool
+		Expected       string
+	}{
+		{"default", false, "PATCH /apis/apps/v1/namespaces/default/replicasets/test?fieldManager=terraform application/apply-patch+yaml"},
+		{"force conflicts", true, "PATCH /apis/apps/v1/namespaces/default/replicasets/test?fieldManager=terraform&force=true application/apply-patch+yaml"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var request string
+			var body map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request = fmt.Sprintf("%s %s %s", r.Method, r.URL.RequestURI(), r.Header.Get("Content-Type"))
+				data, _ := ioutil.ReadAll(r.Body)
+				if err := json.Unmarshal(data, &body); err != nil {
+					t.Errorf("Failed to decode the apply patch %s: %s", data, err)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"metadata": {"name": "test", "namespace": "default", "resourceVersion": "2"}}`)
+			}))
+			defer server.Close()
+
+			conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := schema.TestResourceDataRaw(t, resourceKubernetesReplicaSet().Schema, map[string]interface{}{
+				"force_conflicts": tc.ForceConflicts,
+			})
+			rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", ResourceVersion: "1"}}
+
+			out, err := applyReplicaSet(d, conn, rs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if request != tc.Expected {
+				t.Fatalf("Expected the request %q, given: %q", tc.Expected, request)
+			}
+			if body["apiVersion"] != "apps/v1" || body["kind"] != "ReplicaSet" {
+				t.Fatalf("Expected the apply patch to name apps/v1 ReplicaSet, given: %#v", body)
+			}
+			if _, ok := body["metadata"].(map[string]interface{})["resourceVersion"]; ok {
+				t.Fatalf("Expected the apply patch without a resource version, given: %#v", body)
+			}
+			if out.ResourceVersion != "2" {
+				t.Fatalf("Expected the applied replica set to be decoded, given: %#v", out)
+			}
+		})
+	}
+}
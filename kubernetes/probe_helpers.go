@@ -0,0 +1,47 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// probeHandlers are the keys of the handlers of a probe, Kubernetes requires exactly one of them.
+var probeHandlers = []string{"exec", "http_get", "tcp_socket"}
+
+// checkProbes is meant to be called from CustomizeDiff of the resources with a pod spec,
+// at podSpecKey. It fails the plan with the offending probes of the containers which don't
+// configure exactly one handler, which the API server would only reject at apply.
+func checkProbes(d *schema.ResourceDiff, podSpecKey string) error {
+	var invalid []string
+	for _, key := range []string{"init_container", "container"} {
+		for i, c := range d.Get(podSpecKey + "." + key).([]interface{}) {
+			cm, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, probe := range []string{"liveness_probe", "readiness_probe"} {
+				l, _ := cm[probe].([]interface{})
+				if len(l) == 0 || l[0] == nil {
+					continue
+				}
+				pm := l[0].(map[string]interface{})
+				handlers := 0
+				for _, h := range probeHandlers {
+					if v, ok := pm[h].([]interface{}); ok && len(v) > 0 {
+						handlers++
+					}
+				}
+				if handlers != 1 {
+					invalid = append(invalid, fmt.Sprintf("\n   * %s.%s.%d.%s: exactly one of %s must be specified, given %d",
+						podSpecKey, key, i, probe, strings.Join(probeHandlers, ", "), handlers))
+				}
+			}
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	return fmt.Errorf("Invalid probes:%s", strings.Join(invalid, ""))
+}
@@ -0,0 +1,71 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestPodProbes(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Probe         map[string]interface{}
+		ExpectedError string
+	}{
+		{
+			"http_get",
+			map[string]interface{}{"http_get": []map[string]interface{}{{"path": "/healthz", "port": "8080"}}},
+			"",
+		},
+		{
+			"tcp_socket",
+			map[string]interface{}{"tcp_socket": []map[string]interface{}{{"port": "3306"}}},
+			"",
+		},
+		{
+			"exec",
+			map[string]interface{}{"exec": []map[string]interface{}{{"command": []string{"cat", "/tmp/healthy"}}}},
+			"",
+		},
+		{
+			"no handler",
+			map[string]interface{}{"period_seconds": 10},
+			"spec.0.container.0.liveness_probe: exactly one of exec, http_get, tcp_socket must be specified, given 0",
+		},
+		{
+			"two handlers",
+			map[string]interface{}{
+				"http_get":   []map[string]interface{}{{"path": "/healthz", "port": "8080"}},
+				"tcp_socket": []map[string]interface{}{{"port": "8080"}},
+			},
+			"spec.0.container.0.liveness_probe: exactly one of exec, http_get, tcp_socket must be specified, given 2",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec": []map[string]interface{}{{
+					"container": []map[string]interface{}{{"name": "web", "image": "nginx", "liveness_probe": []map[string]interface{}{tc.Probe}}},
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = resourceKubernetesPod().Diff(nil, terraform.NewResourceConfig(raw), &kubernetesProvider{})
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 
 	"path/filepath"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/go-homedir"
 	"k8s.io/client-go/discovery"
@@ -23,16 +27,28 @@ import (
 	khomedir "k8s.io/client-go/util/homedir"
 )
 
+// providerVersion is set at build time, e.g.
+// go install -ldflags "-X github.com/sl1pm4t/terraform-provider-kubernetes/kubernetes.providerVersion=1.3.0"
+var providerVersion = "dev"
+
 type kubernetesProvider struct {
 	cfg               *restclient.Config
 	conn              *kubernetes.Clientset
 	discoveryCacheDir string
 	discoClient       *CachedDiscoveryClient
 	mu                sync.Mutex
+
+	defaultLabels            map[string]string
+	defaultAnnotations       map[string]string
+	immutableFieldBehavior   string
+	controlledObjectBehavior string
+	warningEventLimit        int
+	dryRunPlan               bool
+	dryRunSupported          *bool
 }
 
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"host": {
 				Type:        schema.TypeString,
@@ -143,42 +159,147 @@ func Provider() terraform.ResourceProvider {
 				},
 				Description: "",
 			},
+			"impersonate_user": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"impersonate_service_account"},
+				Description:   "Username to impersonate for the operations, like `kubectl --as`.",
+			},
+			"impersonate_groups": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Groups to impersonate for the operations, like `kubectl --as-group`.",
+			},
+			"impersonate_service_account": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"impersonate_user"},
+				ValidateFunc:  validateNamespacedName,
+				Description:   "Service account to impersonate for the operations, given as `<namespace>/<name>`.",
+			},
+			"user_agent": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_USER_AGENT", ""),
+				Description: "User-Agent sent with every request to the API server, as shown in its audit logs & metrics. Defaults to `terraform-provider-kubernetes/<version>` followed by the Terraform version.",
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KUBE_PROXY_URL", ""),
+				ValidateFunc: validateProxyURL,
+				Description:  "URL of the HTTP(S) or SOCKS5 proxy used to reach the API server, e.g. `socks5://localhost:1080`. Hosts listed in the `NO_PROXY` env var are reached directly.",
+			},
+			"warning_event_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of the most recent warning events of an object to include in error messages, e.g. when a pod fails to be scheduled.",
+			},
+			"immutable_field_behavior": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      immutableFieldBehaviorRecreate,
+				ValidateFunc: validateAttributeValueIsIn([]string{immutableFieldBehaviorRecreate, immutableFieldBehaviorError}),
+				Description:  "What to do when an immutable field differs from the configuration. `recreate` plans to destroy and re-create the object, `error` fails the plan with an explanation instead. Only honored for the spec of persistent volume claims and the `ip_families` of services.",
+			},
+			"controlled_object_behavior": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      controlledObjectBehaviorWarn,
+				ValidateFunc: validateAttributeValueIsIn([]string{controlledObjectBehaviorWarn, controlledObjectBehaviorError}),
+				Description:  "What to do when reading an object controlled by another object, e.g. a pod of a replica set. `warn` logs the controller as a warning, `error` fails the read so the object isn't managed along with its controller.",
+			},
+			"dry_run_plan": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_DRY_RUN_PLAN", false),
+				Description: "Whether to send the planned create or update of config maps, namespaces, resource quotas & secrets to the API server with `dryRun=All`, so admission webhooks, quotas & validation fail the plan instead of the apply. Nothing is persisted. Needs Kubernetes 1.13+, older API servers are skipped. Objects with values only known after apply or which are re-created aren't checked. Set `KUBE_DRY_RUN_PLAN=false` to plan offline.",
+			},
+			"default_create_timeout": defaultTimeoutSchema("create"),
+			"default_update_timeout": defaultTimeoutSchema("update"),
+			"default_delete_timeout": defaultTimeoutSchema("delete"),
+			"default_labels": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateLabels,
+				Description:  "Labels added to the metadata of every resource managed by this provider. Labels set on the resource take precedence.",
+			},
+			"default_annotations": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateAnnotations,
+				Description:  "Annotations added to the metadata of every resource managed by this provider. Annotations set on the resource take precedence.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"kubernetes_deployment":    dataSourceKubernetesDeployment(),
-			"kubernetes_secret":        dataSourceKubernetesSecret(),
-			"kubernetes_service":       dataSourceKubernetesService(),
-			"kubernetes_storage_class": dataSourceKubernetesStorageClass(),
+			"kubernetes_api_resources":              dataSourceKubernetesAPIResources(),
+			"kubernetes_cluster_role":               dataSourceKubernetesClusterRole(),
+			"kubernetes_controller_revision":        dataSourceKubernetesControllerRevision(),
+			"kubernetes_deployment":                 dataSourceKubernetesDeployment(),
+			"kubernetes_node_extended_resources":    dataSourceKubernetesNodeExtendedResources(),
+			"kubernetes_node_metrics":               dataSourceKubernetesNodeMetrics(),
+			"kubernetes_pod_logs":                   dataSourceKubernetesPodLogs(),
+			"kubernetes_pod_metrics":                dataSourceKubernetesPodMetrics(),
+			"kubernetes_priority_class":             dataSourceKubernetesPriorityClass(),
+			"kubernetes_secret":                     dataSourceKubernetesSecret(),
+			"kubernetes_self_subject_access_review": dataSourceKubernetesSelfSubjectAccessReview(),
+			"kubernetes_service":                    dataSourceKubernetesService(),
+			"kubernetes_storage_capacity":           dataSourceKubernetesStorageCapacity(),
+			"kubernetes_storage_class":              dataSourceKubernetesStorageClass(),
+			"kubernetes_subject_access_review":      dataSourceKubernetesSubjectAccessReview(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"kubernetes_cluster_role":              resourceKubernetesClusterRole(),
-			"kubernetes_cluster_role_binding":      resourceKubernetesClusterRoleBinding(),
-			"kubernetes_config_map":                resourceKubernetesConfigMap(),
-			"kubernetes_horizontal_pod_autoscaler": resourceKubernetesHorizontalPodAutoscaler(),
-			"kubernetes_job":                       resourceKubernetesJob(),
-			"kubernetes_cron_job":                  resourceKubernetesCronJob(),
-			"kubernetes_ingress":                   resourceKubernetesIngress(),
-			"kubernetes_limit_range":               resourceKubernetesLimitRange(),
-			"kubernetes_namespace":                 resourceKubernetesNamespace(),
-			"kubernetes_persistent_volume":         resourceKubernetesPersistentVolume(),
-			"kubernetes_persistent_volume_claim":   resourceKubernetesPersistentVolumeClaim(),
-			"kubernetes_pod":                       resourceKubernetesPod(),
-			"kubernetes_replication_controller":    resourceKubernetesReplicationController(),
-			"kubernetes_role":                      resourceKubernetesRole(),
-			"kubernetes_role_binding":              resourceKubernetesRoleBinding(),
-			"kubernetes_deployment":                resourceKubernetesDeployment(),
-			"kubernetes_daemonset":                 resourceKubernetesDaemonSet(),
-			"kubernetes_resource_quota":            resourceKubernetesResourceQuota(),
-			"kubernetes_secret":                    resourceKubernetesSecret(),
-			"kubernetes_service":                   resourceKubernetesService(),
-			"kubernetes_service_account":           resourceKubernetesServiceAccount(),
-			"kubernetes_stateful_set":              resourceKubernetesStatefulSet(),
-			"kubernetes_storage_class":             resourceKubernetesStorageClass(),
+			"kubernetes_binding":                          resourceKubernetesBinding(),
+			"kubernetes_cluster_role":                     resourceKubernetesClusterRole(),
+			"kubernetes_cluster_role_binding":             resourceKubernetesClusterRoleBinding(),
+			"kubernetes_config_map":                       resourceKubernetesConfigMap(),
+			"kubernetes_eviction":                         resourceKubernetesEviction(),
+			"kubernetes_horizontal_pod_autoscaler":        resourceKubernetesHorizontalPodAutoscaler(),
+			"kubernetes_horizontal_pod_autoscaler_v2":     resourceKubernetesHorizontalPodAutoscalerV2(),
+			"kubernetes_job":                              resourceKubernetesJob(),
+			"kubernetes_cron_job":                         resourceKubernetesCronJob(),
+			"kubernetes_custom_resource_definition":       resourceKubernetesCustomResourceDefinition(),
+			"kubernetes_ingress":                          resourceKubernetesIngress(),
+			"kubernetes_limit_range":                      resourceKubernetesLimitRange(),
+			"kubernetes_mutating_namespace_labels":        resourceKubernetesMutatingNamespaceLabels(),
+			"kubernetes_mutating_namespace_pod_security":  resourceKubernetesMutatingNamespacePodSecurity(),
+			"kubernetes_namespace":                        resourceKubernetesNamespace(),
+			"kubernetes_namespace_default_network_policy": resourceKubernetesNamespaceDefaultNetworkPolicy(),
+			"kubernetes_persistent_volume":                resourceKubernetesPersistentVolume(),
+			"kubernetes_persistent_volume_claim":          resourceKubernetesPersistentVolumeClaim(),
+			"kubernetes_pod":                              resourceKubernetesPod(),
+			"kubernetes_pod_template":                     resourceKubernetesPodTemplate(),
+			"kubernetes_replica_set":                      resourceKubernetesReplicaSet(),
+			"kubernetes_replication_controller":           resourceKubernetesReplicationController(),
+			"kubernetes_role":                             resourceKubernetesRole(),
+			"kubernetes_role_binding":                     resourceKubernetesRoleBinding(),
+			"kubernetes_deployment":                       resourceKubernetesDeployment(),
+			"kubernetes_daemonset":                        resourceKubernetesDaemonSet(),
+			"kubernetes_resource_quota":                   resourceKubernetesResourceQuota(),
+			"kubernetes_secret":                           resourceKubernetesSecret(),
+			"kubernetes_service":                          resourceKubernetesService(),
+			"kubernetes_service_account":                  resourceKubernetesServiceAccount(),
+			"kubernetes_stateful_set":                     resourceKubernetesStatefulSet(),
+			"kubernetes_storage_class":                    resourceKubernetesStorageClass(),
+			"kubernetes_wait":                             resourceKubernetesWait(),
 		},
-		ConfigureFunc: providerConfigure,
 	}
+	timeouts := resourceTimeouts(p.ResourcesMap)
+	p.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		setDefaultTimeouts(d, p.ResourcesMap, timeouts)
+		return providerConfigure(d)
+	}
+	normalizeImportIds(p.ResourcesMap)
+	wrapAPIErrors(p.ResourcesMap)
+	wrapAPIErrors(p.DataSourcesMap)
+	return p
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
@@ -198,7 +319,10 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	}
 
 	// Overriding with static configuration
-	cfg.UserAgent = fmt.Sprintf("HashiCorp/1.0 Terraform/%s", terraform.VersionString())
+	cfg.UserAgent = defaultUserAgent()
+	if v, ok := d.GetOk("user_agent"); ok {
+		cfg.UserAgent = v.(string)
+	}
 
 	if v, ok := d.GetOk("host"); ok {
 		cfg.Host = v.(string)
@@ -226,15 +350,26 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	}
 
 	if v, ok := d.GetOk("exec"); ok {
-		exec := &clientcmdapi.ExecConfig{}
-		spec := v.(*schema.Set).List()[0].(map[string]interface{})
-		exec.APIVersion = spec["api_version"].(string)
-		exec.Command = spec["command"].(string)
-		exec.Args = expandStringSlice(spec["args"].([]interface{}))
-		for kk, vv := range spec["env"].(map[string]interface{}) {
-			exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: kk, Value: vv.(string)})
+		cfg.ExecProvider = expandExecConfig(v.(*schema.Set).List()[0].(map[string]interface{}))
+	}
+
+	if v, ok := d.GetOk("proxy_url"); ok {
+		proxyURL, err := url.Parse(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse proxy_url: %s", err)
 		}
-		cfg.ExecProvider = exec
+		cfg.WrapTransport = proxyWrapTransport(proxyURL, proxyEnvNoProxy())
+	}
+
+	if v, ok := d.GetOk("impersonate_user"); ok {
+		cfg.Impersonate.UserName = v.(string)
+	}
+	if v, ok := d.GetOk("impersonate_service_account"); ok {
+		parts := strings.SplitN(v.(string), "/", 2)
+		cfg.Impersonate.UserName = fmt.Sprintf("system:serviceaccount:%s:%s", parts[0], parts[1])
+	}
+	if v, ok := d.GetOk("impersonate_groups"); ok {
+		cfg.Impersonate.Groups = expandStringSlice(v.([]interface{}))
 	}
 
 	k, err := kubernetes.NewForConfig(cfg)
@@ -243,8 +378,15 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	}
 
 	providerInstance := &kubernetesProvider{
-		conn: k,
-		cfg:  cfg,
+		conn:               k,
+		cfg:                cfg,
+		defaultLabels:      expandStringMap(d.Get("default_labels").(map[string]interface{})),
+		defaultAnnotations: expandStringMap(d.Get("default_annotations").(map[string]interface{})),
+
+		immutableFieldBehavior:   d.Get("immutable_field_behavior").(string),
+		controlledObjectBehavior: d.Get("controlled_object_behavior").(string),
+		warningEventLimit:        d.Get("warning_event_limit").(int),
+		dryRunPlan:               d.Get("dry_run_plan").(bool),
 	}
 
 	err = providerInstance.prepareDiscoveryCacheClient(d)
@@ -255,6 +397,33 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	return providerInstance, err
 }
 
+// expandExecConfig builds the config of the exec credential plugin.
+// client-go caches one authenticator (and the credential it returned,
+// until it expires) per distinct exec config, shared by all the clients
+// created during the run. The env vars are sorted so the same configuration
+// always results in the same config and the plugin isn't re-run needlessly.
+func expandExecConfig(spec map[string]interface{}) *clientcmdapi.ExecConfig {
+	exec := &clientcmdapi.ExecConfig{}
+	exec.APIVersion = spec["api_version"].(string)
+	exec.Command = spec["command"].(string)
+	exec.Args = expandStringSlice(spec["args"].([]interface{}))
+
+	env := spec["env"].(map[string]interface{})
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: name, Value: env[name].(string)})
+	}
+	return exec
+}
+
+func defaultUserAgent() string {
+	return fmt.Sprintf("terraform-provider-kubernetes/%s HashiCorp/1.0 Terraform/%s", providerVersion, terraform.VersionString())
+}
+
 func (p *kubernetesProvider) prepareDiscoveryCacheClient(d *schema.ResourceData) error {
 	// The more groups you have, the more discovery requests you need to make.
 	// given 25 groups (our groups + a few custom resources) with one-ish version each, discovery needs to make 50 requests
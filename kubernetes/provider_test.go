@@ -2,9 +2,15 @@ package kubernetes
 
 import (
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -13,6 +19,8 @@ import (
 	"github.com/terraform-providers/terraform-provider-google/google"
 	api "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
@@ -56,6 +64,200 @@ func TestProvider_configure(t *testing.T) {
 	}
 }
 
+func TestProvider_configureUserAgent(t *testing.T) {
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+
+	cases := map[string]struct {
+		Config   map[string]interface{}
+		Expected string
+	}{
+		"default": {
+			Config:   map[string]interface{}{},
+			Expected: defaultUserAgent(),
+		},
+		"override": {
+			Config:   map[string]interface{}{"user_agent": "platform-pipeline/2.1"},
+			Expected: "platform-pipeline/2.1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := config.NewRawConfig(tc.Config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := Provider().(*schema.Provider)
+			if err := p.Configure(terraform.NewResourceConfig(c)); err != nil {
+				t.Fatal(err)
+			}
+			ua := p.Meta().(*kubernetesProvider).cfg.UserAgent
+			if ua != tc.Expected {
+				t.Fatalf("Expected User-Agent %q, given: %q", tc.Expected, ua)
+			}
+		})
+	}
+
+	if !strings.HasPrefix(defaultUserAgent(), "terraform-provider-kubernetes/") {
+		t.Fatalf("Expected the default User-Agent to identify the provider, given: %q", defaultUserAgent())
+	}
+}
+
+func TestProvider_configureExecCredentialCaching(t *testing.T) {
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+
+	dir, err := ioutil.TempDir("", "tf-kubernetes-exec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The fake plugin records every invocation and returns a long lived token
+	counterFile := filepath.Join(dir, "invocations")
+	command := filepath.Join(dir, "fake-credential-plugin")
+	script := `#!/bin/sh
+echo invoked >> "$COUNTER_FILE"
+echo '{"apiVersion": "client.authentication.k8s.io/v1beta1", "kind": "ExecCredential", "status": {"token": "fake-token", "expirationTimestamp": "2099-01-01T00:00:00Z"}}'
+`
+	if err := ioutil.WriteFile(command, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fake-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/version":
+			fmt.Fprint(w, `{"major": "1", "minor": "11", "gitVersion": "v1.11.0"}`)
+		default:
+			fmt.Fprint(w, `{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "default"}}`)
+		}
+	}))
+	defer server.Close()
+
+	c, err := config.NewRawConfig(map[string]interface{}{
+		"host":             server.URL,
+		"load_config_file": false,
+		"exec": []map[string]interface{}{
+			{
+				"api_version": "client.authentication.k8s.io/v1beta1",
+				"command":     command,
+				"env": map[string]interface{}{
+					"COUNTER_FILE": counterFile,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := Provider().(*schema.Provider)
+	err = p.Configure(terraform.NewResourceConfig(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn := p.Meta().(*kubernetesProvider).conn
+	for i := 0; i < 3; i++ {
+		if _, err := conn.CoreV1().Namespaces().Get("default", metav1.GetOptions{}); err != nil {
+			t.Fatalf("Request %d failed: %s", i, err)
+		}
+	}
+
+	out, err := ioutil.ReadFile(counterFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(out), "invoked"); n != 1 {
+		t.Fatalf("Expected the credential plugin to be invoked once, given: %d", n)
+	}
+}
+
+func TestProvider_readRemovesObjectsDeletedOutOfBand(t *testing.T) {
+	// Discovery advertises the groups the workload resources look up before
+	// reading, every object is missing.
+	discovery := map[string]string{
+		"/api":    `{"kind":"APIVersions","versions":["v1"]}`,
+		"/apis":   `{"kind":"APIGroupList","groups":[{"name":"apps","versions":[{"groupVersion":"apps/v1","version":"v1"}]},{"name":"batch","versions":[{"groupVersion":"batch/v1beta1","version":"v1beta1"}]}]}`,
+		"/api/v1": `{"kind":"APIResourceList","groupVersion":"v1","resources":[]}`,
+		"/apis/apps/v1": `{"kind":"APIResourceList","groupVersion":"apps/v1","resources":[` +
+			`{"name":"daemonsets","namespaced":true,"kind":"DaemonSet"},` +
+			`{"name":"deployments","namespaced":true,"kind":"Deployment"},` +
+			`{"name":"statefulsets","namespaced":true,"kind":"StatefulSet"}]}`,
+		"/apis/batch/v1beta1": `{"kind":"APIResourceList","groupVersion":"batch/v1beta1","resources":[{"name":"cronjobs","namespaced":true,"kind":"CronJob"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if body, ok := discovery[r.URL.Path]; ok {
+			fmt.Fprint(w, body)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+	}))
+	defer server.Close()
+
+	cfg := &restclient.Config{Host: server.URL}
+	conn, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "tf-kubernetes-discovery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	meta := &kubernetesProvider{
+		conn:        conn,
+		discoClient: NewCachedDiscoveryClient(conn.Discovery(), dir, time.Minute),
+	}
+
+	// These resources don't read any object
+	skipped := map[string]bool{
+		"kubernetes_eviction": true,
+		"kubernetes_wait":     true,
+	}
+
+	for name, r := range Provider().(*schema.Provider).ResourcesMap {
+		if skipped[name] {
+			continue
+		}
+		d := r.TestResourceData()
+		id := "gone"
+		if isNamespacedResource(r) {
+			id = "default/gone"
+		}
+		d.SetId(id)
+
+		if err := r.Read(d, meta); err != nil {
+			t.Fatalf("%s: expected a missing object to be removed from the state, given: %s", name, err)
+		}
+		if d.Id() != "" {
+			t.Fatalf("%s: expected the id of a missing object to be cleared, given: %q", name, d.Id())
+		}
+	}
+
+	// The data sources reading through the resources must fail instead
+	for _, name := range []string{"kubernetes_cluster_role", "kubernetes_deployment", "kubernetes_secret", "kubernetes_service"} {
+		r := Provider().(*schema.Provider).DataSourcesMap[name]
+		metadata := map[string]interface{}{"name": "gone"}
+		if _, ok := r.Schema["metadata"].Elem.(*schema.Resource).Schema["namespace"]; ok {
+			metadata["namespace"] = "default"
+		}
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"metadata": []interface{}{metadata}})
+
+		err := r.Read(d, meta)
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Fatalf("%s: expected a missing object to fail the data source, given: %v", name, err)
+		}
+	}
+}
+
 func unsetEnv(t *testing.T) func() {
 	e := getEnv()
 
@@ -92,6 +294,12 @@ func unsetEnv(t *testing.T) func() {
 	if err := os.Unsetenv("KUBE_CLUSTER_CA_CERT_DATA"); err != nil {
 		t.Fatalf("Error unsetting env var KUBE_CLUSTER_CA_CERT_DATA: %s", err)
 	}
+	if err := os.Unsetenv("KUBE_USER_AGENT"); err != nil {
+		t.Fatalf("Error unsetting env var KUBE_USER_AGENT: %s", err)
+	}
+	if err := os.Unsetenv("KUBE_PROXY_URL"); err != nil {
+		t.Fatalf("Error unsetting env var KUBE_PROXY_URL: %s", err)
+	}
 
 	return func() {
 		if err := os.Setenv("KUBE_CONFIG", e.Config); err != nil {
@@ -127,6 +335,12 @@ func unsetEnv(t *testing.T) func() {
 		if err := os.Setenv("KUBE_CLUSTER_CA_CERT_DATA", e.ClusterCACertData); err != nil {
 			t.Fatalf("Error resetting env var KUBE_CLUSTER_CA_CERT_DATA: %s", err)
 		}
+		if err := os.Setenv("KUBE_USER_AGENT", e.UserAgent); err != nil {
+			t.Fatalf("Error resetting env var KUBE_USER_AGENT: %s", err)
+		}
+		if err := os.Setenv("KUBE_PROXY_URL", e.ProxyURL); err != nil {
+			t.Fatalf("Error resetting env var KUBE_PROXY_URL: %s", err)
+		}
 	}
 }
 
@@ -141,6 +355,8 @@ func getEnv() *currentEnv {
 		ClientCertData:    os.Getenv("KUBE_CLIENT_CERT_DATA"),
 		ClientKeyData:     os.Getenv("KUBE_CLIENT_KEY_DATA"),
 		ClusterCACertData: os.Getenv("KUBE_CLUSTER_CA_CERT_DATA"),
+		UserAgent:         os.Getenv("KUBE_USER_AGENT"),
+		ProxyURL:          os.Getenv("KUBE_PROXY_URL"),
 	}
 	if cfg := os.Getenv("KUBE_CONFIG"); cfg != "" {
 		e.Config = cfg
@@ -270,4 +486,6 @@ type currentEnv struct {
 	ClientCertData    string
 	ClientKeyData     string
 	ClusterCACertData string
+	UserAgent         string
+	ProxyURL          string
 }
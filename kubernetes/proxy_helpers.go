@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// proxyEnvNoProxy returns the hosts which must not be proxied, like other tools read it.
+func proxyEnvNoProxy() string {
+	if v := os.Getenv("NO_PROXY"); v != "" {
+		return v
+	}
+	return os.Getenv("no_proxy")
+}
+
+// proxyWrapTransport returns a WrapTransport func which sends all requests
+// through proxyURL, except for the hosts listed in noProxy.
+// The vendored client-go has no Proxy setting in its rest.Config, so the proxy
+// is set on a copy of the underlying transport instead. The copy keeps the
+// transport client-go caches for other clients untouched.
+func proxyWrapTransport(proxyURL *url.URL, noProxy string) func(rt http.RoundTripper) http.RoundTripper {
+	proxy := proxyFunc(proxyURL, noProxy)
+	return func(rt http.RoundTripper) http.RoundTripper {
+		t, ok := rt.(*http.Transport)
+		if !ok {
+			log.Printf("[WARN] Unable to set proxy on transport of type %T", rt)
+			return rt
+		}
+		t = t.Clone()
+		t.Proxy = proxy
+		return t
+	}
+}
+
+func proxyFunc(proxyURL *url.URL, noProxy string) func(req *http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		if matchesNoProxy(req.URL.Host, noProxy) {
+			return nil, nil
+		}
+		return proxyURL, nil
+	}
+}
+
+// matchesNoProxy reports whether host (with an optional port) is matched by
+// the comma separated noProxy list. Like in the NO_PROXY env var which curl &
+// Go understand, entries can be `*`, IP addresses, CIDR ranges (e.g. the
+// service range of the cluster) or domain names, which also match subdomains.
+func matchesNoProxy(host, noProxy string) bool {
+	hostname, port := host, ""
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		hostname, port = h, p
+	}
+	hostname = strings.ToLower(hostname)
+	ip := net.ParseIP(hostname)
+
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if h, p, err := net.SplitHostPort(entry); err == nil {
+			if p != port {
+				continue
+			}
+			entry = h
+		}
+		if entryIP := net.ParseIP(entry); entryIP != nil {
+			if ip != nil && entryIP.Equal(ip) {
+				return true
+			}
+			continue
+		}
+		domain := strings.TrimPrefix(entry, ".")
+		if hostname == domain || strings.HasSuffix(hostname, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package kubernetes
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestMatchesNoProxy(t *testing.T) {
+	noProxy := "localhost, .svc.cluster.local,10.96.0.0/12,192.168.1.10,internal.example.com:8443"
+
+	matching := []string{
+		"localhost",
+		"localhost:6443",
+		"kubernetes.default.svc.cluster.local",
+		"10.96.0.1:443",
+		"10.100.20.3",
+		"192.168.1.10:6443",
+		"internal.example.com:8443",
+		"api.internal.example.com:8443",
+	}
+	for _, host := range matching {
+		if !matchesNoProxy(host, noProxy) {
+			t.Fatalf("Expected %q to bypass the proxy", host)
+		}
+	}
+
+	proxied := []string{
+		"api.example.com",
+		"10.112.0.1:443",
+		"192.168.1.11",
+		"internal.example.com:443",
+		"notlocalhost",
+	}
+	for _, host := range proxied {
+		if matchesNoProxy(host, noProxy) {
+			t.Fatalf("Expected %q to be proxied", host)
+		}
+	}
+
+	if !matchesNoProxy("api.example.com:443", "*") {
+		t.Fatal("Expected * to bypass the proxy for all hosts")
+	}
+	if matchesNoProxy("api.example.com:443", "") {
+		t.Fatal("Expected an empty list to proxy all hosts")
+	}
+}
+
+func TestProxyWrapTransport(t *testing.T) {
+	proxyURL, _ := url.Parse("socks5://bastion.example.com:1080")
+	base := &http.Transport{}
+
+	rt := proxyWrapTransport(proxyURL, "10.0.0.0/8")(base)
+	transport, ok := rt.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected an *http.Transport, given: %T", rt)
+	}
+	if transport == base || base.Proxy != nil {
+		t.Fatal("Expected the proxy to be set on a copy of the transport")
+	}
+
+	cases := map[string]*url.URL{
+		"https://api.example.com:6443/api": proxyURL,
+		"https://10.0.0.1:443/api":         nil,
+	}
+	for u, expected := range cases {
+		req, _ := http.NewRequest("GET", u, nil)
+		given, err := transport.Proxy(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if given != expected {
+			t.Fatalf("Expected proxy %v for %s, given: %v", expected, u, given)
+		}
+	}
+}
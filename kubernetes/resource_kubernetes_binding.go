@@ -0,0 +1,149 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+// resourceKubernetesBinding binds a pending pod to a node through its binding
+// subresource, like a scheduler does. A pod can only be bound once, so changing
+// any argument binds a pod again and destroying the resource doesn't do anything.
+func resourceKubernetesBinding() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesBindingCreate,
+		Read:   resourceKubernetesBindingRead,
+		Delete: resourceKubernetesBindingDelete,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": {
+				Type:        schema.TypeList,
+				Description: "Metadata of the pod to bind.",
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Description:  "Name of the pod to bind.",
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateName,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the pod to bind.",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     "default",
+						},
+					},
+				},
+			},
+			"target": {
+				Type:        schema.TypeList,
+				Description: "The node to bind the pod to.",
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Description:  "Name of the node.",
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateDNSSubdomain,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesBindingCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	binding := api.Binding{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      d.Get("metadata.0.name").(string),
+			Namespace: d.Get("metadata.0.namespace").(string),
+		},
+		Target: api.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Node",
+			Name:       d.Get("target.0.name").(string),
+		},
+	}
+
+	log.Printf("[INFO] Binding pod: %#v", binding)
+	err := bindPod(conn, &binding)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Pod %s/%s bound to node %s", binding.Namespace, binding.Name, binding.Target.Name)
+	d.SetId(buildId(binding.ObjectMeta))
+
+	return resourceKubernetesBindingRead(d, meta)
+}
+
+func resourceKubernetesBindingRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Reading pod %s", name)
+	pod, err := conn.CoreV1().Pods(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The binding is gone along with the pod
+			log.Printf("[INFO] Bound pod %s not found, removing the binding from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+
+	// A pod which got recreated under the same name may run on another node
+	err = d.Set("target", []interface{}{map[string]interface{}{
+		"name": pod.Spec.NodeName,
+	}})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesBindingDelete(d *schema.ResourceData, meta interface{}) error {
+	// Pods can't be unbound, they stay on their node until they're deleted
+	d.SetId("")
+	return nil
+}
+
+// bindPod binds the pod to the target node. The API server rejects the binding
+// with 409 Conflict if the pod is already bound, to any node.
+func bindPod(conn *kubernetes.Clientset, binding *api.Binding) error {
+	err := conn.CoreV1().Pods(binding.Namespace).Bind(binding)
+	if err != nil {
+		if errors.IsConflict(err) {
+			return fmt.Errorf("Pod %s/%s can't be bound to node %s, it's already bound: %s",
+				binding.Namespace, binding.Name, binding.Target.Name, err)
+		}
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("Pod %s/%s to bind not found", binding.Namespace, binding.Name)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	api "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestAccKubernetesBinding_basic(t *testing.T) {
+	if os.Getenv(resource.TestEnvVar) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.TestEnvVar)
+	}
+	// The pod must be created unscheduled and the node name is needed to build the config
+	testAccPreCheck(t)
+	node, err := getFirstNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	conn := testAccProvider.Meta().(*kubernetesProvider).conn
+	_, err = conn.CoreV1().Pods("default").Create(&api.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: podName},
+		Spec: api.PodSpec{
+			// No such scheduler exists, the pod stays pending until it's bound
+			SchedulerName: "tf-acc-test-manual",
+			Containers:    []api.Container{{Name: "containername", Image: "nginx:1.7.9"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.CoreV1().Pods("default").Delete(podName, &meta_v1.DeleteOptions{GracePeriodSeconds: ptrToInt64(0)})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesBindingConfig_basic(podName, node.Name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_binding.test", "metadata.0.name", podName),
+					resource.TestCheckResourceAttr("kubernetes_binding.test", "target.0.name", node.Name),
+					testAccCheckKubernetesPodBound("default", podName, node.Name),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesPodBound(namespace, name, nodeName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+		pod, err := conn.CoreV1().Pods(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if pod.Spec.NodeName != nodeName {
+			return fmt.Errorf("Expected pod %s/%s to be bound to node %q, given: %q", namespace, name, nodeName, pod.Spec.NodeName)
+		}
+		return nil
+	}
+}
+
+func TestBindPod(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/pods/pending/binding":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Success", "code": 201}`)
+		case "/api/v1/namespaces/default/pods/running/binding":
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "Conflict", "code": 409,
+	"message": "Operation cannot be fulfilled on pods/binding \"running\": pod running is already assigned to node \"node-1\""}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound", "code": 404}`)
+		}
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	binding := func(name string) *api.Binding {
+		return &api.Binding{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "default"},
+			Target:     api.ObjectReference{Kind: "Node", Name: "node-2"},
+		}
+	}
+	if err := bindPod(conn, binding("pending")); err != nil {
+		t.Fatalf("Expected the pending pod to be bound, given: %s", err)
+	}
+	err = bindPod(conn, binding("running"))
+	if err == nil || !strings.Contains(err.Error(), "already bound") || !strings.Contains(err.Error(), `"node-1"`) {
+		t.Fatalf("Expected the conflict to be surfaced, given: %v", err)
+	}
+	err = bindPod(conn, binding("missing"))
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("Expected a not found error, given: %v", err)
+	}
+}
+
+func testAccKubernetesBindingConfig_basic(podName, nodeName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_binding" "test" {
+	metadata {
+		name = "%s"
+	}
+	target {
+		name = "%s"
+	}
+}
+`, podName, nodeName)
+}
@@ -20,13 +20,49 @@ func resourceKubernetesClusterRole() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			if len(diff.Get("aggregation_rule").([]interface{})) == 0 {
+				if len(diff.Get("rule").([]interface{})) == 0 {
+					return fmt.Errorf("rule: at least one rule is required, unless the rules are aggregated with aggregation_rule")
+				}
+				return nil
+			}
+			// The rules of an aggregated cluster role are rewritten by the controller once its selectors change
+			if diff.HasChange("aggregation_rule") {
+				return diff.SetNewComputed("rule")
+			}
+			return nil
+		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": metadataSchema("cluster role", true),
+			"metadata":             clusterScopedMetadataSchema("cluster role", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
+			"aggregation_rule": {
+				Type:          schema.TypeList,
+				Description:   "Describes how to build the rules of this ClusterRole: the rules of the cluster roles matched by any of the selectors are aggregated into it by the controller manager. The rules are then managed by the controller and can't be set in `rule`.",
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"rule"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster_role_selectors": {
+							Type:        schema.TypeList,
+							Description: "Selectors of the cluster roles whose rules are aggregated into this ClusterRole.",
+							Required:    true,
+							MinItems:    1,
+							Elem: &schema.Resource{
+								Schema: labelSelectorFields(),
+							},
+						},
+					},
+				},
+			},
 			"rule": {
 				Type:        schema.TypeList,
-				Description: "List of PolicyRules for this ClusterRole",
-				Required:    true,
+				Description: "List of PolicyRules for this ClusterRole. Required unless `aggregation_rule` is set, the rules are then populated by the controller manager.",
+				Optional:    true,
+				Computed:    true,
 				MinItems:    1,
 				Elem: &schema.Resource{
 					Schema: policyRuleFields(),
@@ -39,10 +75,11 @@ func resourceKubernetesClusterRole() *schema.Resource {
 func resourceKubernetesClusterRoleCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	cRole := api.ClusterRole{
-		ObjectMeta: metadata,
-		Rules:      expandClusterRoleRule(d.Get("rule").([]interface{})),
+		ObjectMeta:      metadata,
+		AggregationRule: expandClusterRoleAggregationRule(d.Get("aggregation_rule").([]interface{})),
+		Rules:           expandClusterRoleRule(d.Get("rule").([]interface{})),
 	}
 	log.Printf("[INFO] Creating new cluster role: %#v", cRole)
 	out, err := conn.RbacV1().ClusterRoles().Create(&cRole)
@@ -50,7 +87,7 @@ func resourceKubernetesClusterRoleCreate(d *schema.ResourceData, meta interface{
 		return err
 	}
 	log.Printf("[INFO] Submitted new cluster role: %#v", out)
-	d.SetId(buildId(out.ObjectMeta))
+	d.SetId(out.ObjectMeta.Name)
 
 	return resourceKubernetesClusterRoleRead(d, meta)
 }
@@ -65,11 +102,20 @@ func resourceKubernetesClusterRoleRead(d *schema.ResourceData, meta interface{})
 	log.Printf("[INFO] Reading cluster role %s", name)
 	cRole, err := conn.RbacV1().ClusterRoles().Get(name, metav1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Cluster role %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received cluster role: %#v", cRole)
-	err = d.Set("metadata", flattenMetadata(cRole.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(cRole.ObjectMeta, d, meta))
+	if err != nil {
+		return err
+	}
+	err = d.Set("aggregation_rule", flattenClusterRoleAggregationRule(cRole.AggregationRule))
 	if err != nil {
 		return err
 	}
@@ -86,10 +132,11 @@ func resourceKubernetesClusterRoleUpdate(d *schema.ResourceData, meta interface{
 		return err
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	cRole := api.ClusterRole{
-		ObjectMeta: metadata,
-		Rules:      expandClusterRoleRule(d.Get("rule").([]interface{})),
+		ObjectMeta:      metadata,
+		AggregationRule: expandClusterRoleAggregationRule(d.Get("aggregation_rule").([]interface{})),
+		Rules:           expandClusterRoleRule(d.Get("rule").([]interface{})),
 	}
 
 	log.Printf("[INFO] Updating cluster role %q: %v", name, cRole)
@@ -98,7 +145,7 @@ func resourceKubernetesClusterRoleUpdate(d *schema.ResourceData, meta interface{
 		return fmt.Errorf("Failed to update cluster role: %s", err)
 	}
 	log.Printf("[INFO] Submitted updated cluster role: %#v", out)
-	d.SetId(buildId(out.ObjectMeta))
+	d.SetId(out.ObjectMeta.Name)
 
 	return resourceKubernetesClusterRoleRead(d, meta)
 }
@@ -111,7 +158,7 @@ func resourceKubernetesClusterRoleDelete(d *schema.ResourceData, meta interface{
 		return err
 	}
 	log.Printf("[INFO] Deleting cluster role: %#v", name)
-	err = conn.RbacV1().ClusterRoles().Delete(name, &metav1.DeleteOptions{})
+	err = conn.RbacV1().ClusterRoles().Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
@@ -22,7 +22,9 @@ func resourceKubernetesClusterRoleBinding() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": metadataSchema("cluster role binding", true),
+			"metadata":             clusterScopedMetadataSchema("cluster role binding", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"role_ref": {
 				Type:        schema.TypeList,
 				Description: "RoleRef can only reference a ClusterRole in the global namespace. If the RoleRef cannot be resolved, the Authorizer must return an error. See official documentation: https://v1-9.docs.kubernetes.io/docs/reference/generated/kubernetes-api/v1.9/#roleref-v1-rbac",
@@ -49,7 +51,7 @@ func resourceKubernetesClusterRoleBinding() *schema.Resource {
 func resourceKubernetesClusterRoleBindingCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	crb := api.ClusterRoleBinding{
 		ObjectMeta: metadata,
 		RoleRef:    expandRoleRef(d.Get("role_ref").([]interface{})[0]),
@@ -61,7 +63,7 @@ func resourceKubernetesClusterRoleBindingCreate(d *schema.ResourceData, meta int
 		return err
 	}
 	log.Printf("[INFO] Submitted new cluster role binding: %#v", out)
-	d.SetId(buildId(out.ObjectMeta))
+	d.SetId(out.ObjectMeta.Name)
 
 	return resourceKubernetesClusterRoleBindingRead(d, meta)
 }
@@ -76,11 +78,16 @@ func resourceKubernetesClusterRoleBindingRead(d *schema.ResourceData, meta inter
 	log.Printf("[INFO] Reading cluster role binding %s", name)
 	crb, err := conn.RbacV1().ClusterRoleBindings().Get(name, metav1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Cluster role binding %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received cluster role binding: %#v", crb)
-	err = d.Set("metadata", flattenMetadata(crb.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(crb.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -98,7 +105,7 @@ func resourceKubernetesClusterRoleBindingUpdate(d *schema.ResourceData, meta int
 		return err
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	crb := api.ClusterRoleBinding{
 		ObjectMeta: metadata,
 		RoleRef:    expandRoleRef(d.Get("role_ref").([]interface{})[0]),
@@ -111,7 +118,7 @@ func resourceKubernetesClusterRoleBindingUpdate(d *schema.ResourceData, meta int
 		return fmt.Errorf("Failed to update cluster role binding: %s", err)
 	}
 	log.Printf("[INFO] Submitted updated cluster role binding: %#v", out)
-	d.SetId(buildId(out.ObjectMeta))
+	d.SetId(out.ObjectMeta.Name)
 
 	return resourceKubernetesClusterRoleBindingRead(d, meta)
 }
@@ -124,7 +131,7 @@ func resourceKubernetesClusterRoleBindingDelete(d *schema.ResourceData, meta int
 		return err
 	}
 	log.Printf("[INFO] Deleting cluster role binding: %#v", name)
-	err = conn.RbacV1().ClusterRoleBindings().Delete(name, &metav1.DeleteOptions{})
+	err = conn.RbacV1().ClusterRoleBindings().Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
@@ -2,8 +2,11 @@ package kubernetes
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
@@ -53,6 +56,107 @@ func TestAccKubernetesClusterRole_basic(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesClusterRole_aggregationRule(t *testing.T) {
+	var conf api.ClusterRole
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_cluster_role.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesClusterRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesClusterRoleConfig_aggregationRule(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesClusterRoleExists("kubernetes_cluster_role.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role.test", "aggregation_rule.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role.test", "aggregation_rule.0.cluster_role_selectors.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_cluster_role.test", "aggregation_rule.0.cluster_role_selectors.0.match_labels.%", "1"),
+				),
+			},
+			{
+				// The rules aggregated by the controller don't bounce
+				Config:   testAccKubernetesClusterRoleConfig_aggregationRule(name),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestClusterRoleRulesDiff(t *testing.T) {
+	aggregationRule := []map[string]interface{}{{
+		"cluster_role_selectors": []map[string]interface{}{{
+			"match_labels": map[string]interface{}{"rbac.example.com/aggregate-to-monitoring": "true"},
+		}},
+	}}
+	rule := []map[string]interface{}{{"api_groups": []string{""}, "resources": []string{"pods"}, "verbs": []string{"get"}}}
+	cases := []struct {
+		Name          string
+		Config        map[string]interface{}
+		ExpectedError string
+	}{
+		{"rules", map[string]interface{}{"rule": rule}, ""},
+		{"aggregated", map[string]interface{}{"aggregation_rule": aggregationRule}, ""},
+		{"neither", map[string]interface{}{}, "at least one rule is required"},
+		{"both", map[string]interface{}{"rule": rule, "aggregation_rule": aggregationRule}, "conflicts with"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			raw := map[string]interface{}{"metadata": []map[string]interface{}{{"name": "monitoring"}}}
+			for k, v := range tc.Config {
+				raw[k] = v
+			}
+			rc, err := config.NewRawConfig(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := terraform.NewResourceConfig(rc)
+
+			r := resourceKubernetesClusterRole()
+			_, errs := r.Validate(c)
+			if len(errs) == 0 {
+				_, err = r.Diff(nil, c, &kubernetesProvider{})
+			} else {
+				err = errs[0]
+			}
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestExpandFlattenClusterRoleAggregationRule(t *testing.T) {
+	in := &api.AggregationRule{ClusterRoleSelectors: []meta_v1.LabelSelector{
+		{MatchLabels: map[string]string{"rbac.example.com/aggregate-to-monitoring": "true"}},
+		{},
+	}}
+	flattened := flattenClusterRoleAggregationRule(in)
+	if n := len(flattened[0].(map[string]interface{})["cluster_role_selectors"].([]interface{})); n != 2 {
+		t.Fatalf("Expected 2 selectors, given: %d", n)
+	}
+	out := expandClusterRoleAggregationRule([]interface{}{map[string]interface{}{
+		"cluster_role_selectors": []interface{}{
+			map[string]interface{}{"match_labels": map[string]interface{}{"rbac.example.com/aggregate-to-monitoring": "true"}},
+			map[string]interface{}{},
+		},
+	}})
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("Expected %#v, given: %#v", in, out)
+	}
+	if out := expandClusterRoleAggregationRule([]interface{}{}); out != nil {
+		t.Fatalf("Expected no aggregation rule, given: %#v", out)
+	}
+}
+
 func TestAccKubernetesClusterRole_importBasic(t *testing.T) {
 	resourceName := "kubernetes_cluster_role.test"
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
@@ -152,3 +256,19 @@ resource "kubernetes_cluster_role" "test" {
 	}
 }`, name)
 }
+
+func testAccKubernetesClusterRoleConfig_aggregationRule(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_cluster_role" "test" {
+	metadata {
+		name = "%s"
+	}
+	aggregation_rule {
+		cluster_role_selectors {
+			match_labels {
+				"rbac.authorization.k8s.io/aggregate-to-view" = "true"
+			}
+		}
+	}
+}`, name)
+}
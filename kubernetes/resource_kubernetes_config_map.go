@@ -9,6 +9,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesConfigMap() *schema.Resource {
@@ -19,16 +20,45 @@ func resourceKubernetesConfigMap() *schema.Resource {
 		Update: resourceKubernetesConfigMapUpdate,
 		Delete: resourceKubernetesConfigMapDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateWithDefaults(map[string]interface{}{"binary_data_mode": binaryDataModeFull}),
+		},
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			return checkDryRun(diff, meta, coreV1RESTClient, "configmaps", func(metadata metav1.ObjectMeta) (interface{}, error) {
+				binaryData, err := expandBase64EncodedMap(diff.Get("binary_data").(map[string]interface{}))
+				if err != nil {
+					return nil, err
+				}
+				return &api.ConfigMap{
+					ObjectMeta: metadata,
+					Data:       expandStringMap(diff.Get("data").(map[string]interface{})),
+					BinaryData: binaryData,
+				}, nil
+			}, "data", "binary_data")
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("config map", true),
+			"metadata":             namespacedMetadataSchema("config map", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"data": {
 				Type:        schema.TypeMap,
 				Description: "A map of the configuration data.",
 				Optional:    true,
 			},
+			"binary_data": {
+				Type:             schema.TypeMap,
+				Description:      "A map of binary data, each value base64 encoded. Its keys must not overlap with the keys of `data`.",
+				Optional:         true,
+				ValidateFunc:     validateBase64EncodedMap,
+				DiffSuppressFunc: suppressBinaryDataChecksumDiff,
+			},
+			"binary_data_mode": {
+				Type:         schema.TypeString,
+				Description:  "How `binary_data` is kept in the state. `full` keeps the base64 encoded values. `checksum` only keeps a SHA256 checksum of each value, which keeps the state small for large payloads while changes & drift are still detected.",
+				Optional:     true,
+				Default:      binaryDataModeFull,
+				ValidateFunc: validateAttributeValueIsIn([]string{binaryDataModeFull, binaryDataModeChecksum}),
+			},
 		},
 	}
 }
@@ -36,10 +66,15 @@ func resourceKubernetesConfigMap() *schema.Resource {
 func resourceKubernetesConfigMapCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
+	binaryData, err := expandBase64EncodedMap(d.Get("binary_data").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
 	cfgMap := api.ConfigMap{
 		ObjectMeta: metadata,
 		Data:       expandStringMap(d.Get("data").(map[string]interface{})),
+		BinaryData: binaryData,
 	}
 	log.Printf("[INFO] Creating new config map: %#v", cfgMap)
 	out, err := conn.CoreV1().ConfigMaps(metadata.Namespace).Create(&cfgMap)
@@ -62,15 +97,27 @@ func resourceKubernetesConfigMapRead(d *schema.ResourceData, meta interface{}) e
 	log.Printf("[INFO] Reading config map %s", name)
 	cfgMap, err := conn.CoreV1().ConfigMaps(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Config map %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received config map: %#v", cfgMap)
-	err = d.Set("metadata", flattenMetadata(cfgMap.ObjectMeta, d))
+
+	err = checkControllerOwner("ConfigMap", cfgMap.ObjectMeta, meta)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(cfgMap.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
 	d.Set("data", cfgMap.Data)
+	d.Set("binary_data", flattenBinaryData(cfgMap.BinaryData, d.Get("binary_data_mode").(string)))
 
 	return nil
 }
@@ -83,18 +130,25 @@ func resourceKubernetesConfigMapUpdate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadataWithDefaults(d, meta)
 	if d.HasChange("data") {
 		oldV, newV := d.GetChange("data")
 		diffOps := diffStringMap("/data/", oldV.(map[string]interface{}), newV.(map[string]interface{}))
 		ops = append(ops, diffOps...)
 	}
+	if d.HasChange("binary_data") {
+		// Values which are unchanged (or whose checksum matches) are the same on both
+		// sides of the change, so only the changed values are sent in their base64 form.
+		oldV, newV := d.GetChange("binary_data")
+		diffOps := diffStringMap("/binaryData/", oldV.(map[string]interface{}), newV.(map[string]interface{}))
+		ops = append(ops, diffOps...)
+	}
 	data, err := ops.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("Failed to marshal update operations: %s", err)
 	}
 	log.Printf("[INFO] Updating config map %q: %v", name, string(data))
-	out, err := conn.CoreV1().ConfigMaps(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	out, err := patchConfigMapOnLatest(conn, namespace, name, data)
 	if err != nil {
 		return fmt.Errorf("Failed to update Config Map: %s", err)
 	}
@@ -112,7 +166,7 @@ func resourceKubernetesConfigMapDelete(d *schema.ResourceData, meta interface{})
 		return err
 	}
 	log.Printf("[INFO] Deleting config map: %#v", name)
-	err = conn.CoreV1().ConfigMaps(namespace).Delete(name, &metav1.DeleteOptions{})
+	err = conn.CoreV1().ConfigMaps(namespace).Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
@@ -141,3 +195,15 @@ func resourceKubernetesConfigMapExists(d *schema.ResourceData, meta interface{})
 	}
 	return true, err
 }
+
+// patchConfigMapOnLatest applies the JSON patch data of an update to the latest version of the config map, see patchOnLatest.
+func patchConfigMapOnLatest(conn *kubernetes.Clientset, namespace, name string, data []byte) (out *api.ConfigMap, err error) {
+	client := conn.CoreV1().ConfigMaps(namespace)
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (metav1.Object, error) {
+		return client.Get(name, metav1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, pkgApi.JSONPatchType, data)
+		return err
+	})
+	return out, err
+}
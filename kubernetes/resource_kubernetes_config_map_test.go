@@ -188,6 +188,54 @@ func TestAccKubernetesConfigMap_importGeneratedName(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesConfigMap_binaryData(t *testing.T) {
+	var conf api.ConfigMap
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_config_map.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesConfigMapDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesConfigMapConfig_binaryData(name, "full", "3q2+7w=="),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesConfigMapExists("kubernetes_config_map.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_config_map.test", "binary_data.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_config_map.test", "binary_data.blob", "3q2+7w=="),
+					testAccCheckConfigMapBinaryData(&conf, "blob", []byte{0xde, 0xad, 0xbe, 0xef}),
+				),
+			},
+			{
+				Config: testAccKubernetesConfigMapConfig_binaryData(name, "checksum", "3q2+7w=="),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesConfigMapExists("kubernetes_config_map.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_config_map.test", "binary_data_mode", "checksum"),
+					resource.TestCheckResourceAttr("kubernetes_config_map.test", "binary_data.blob", "sha256:5f78c33274e43fa9de5659265c1d917e25c03722dcb0b8d27db8d5feaa813953"),
+					testAccCheckConfigMapBinaryData(&conf, "blob", []byte{0xde, 0xad, 0xbe, 0xef}),
+				),
+			},
+			{
+				Config: testAccKubernetesConfigMapConfig_binaryData(name, "checksum", "yv66vg=="),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesConfigMapExists("kubernetes_config_map.test", &conf),
+					testAccCheckConfigMapBinaryData(&conf, "blob", []byte{0xca, 0xfe, 0xba, 0xbe}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckConfigMapBinaryData(m *api.ConfigMap, key string, expected []byte) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if !reflect.DeepEqual(m.BinaryData[key], expected) {
+			return fmt.Errorf("%s binary data don't match.\nExpected: %#v\nGiven: %#v", key, expected, m.BinaryData[key])
+		}
+		return nil
+	}
+}
+
 func testAccCheckConfigMapData(m *api.ConfigMap, expected map[string]string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if len(expected) == 0 && len(m.Data) == 0 {
@@ -329,3 +377,17 @@ resource "kubernetes_config_map" "test" {
 	}
 }`, prefix)
 }
+
+func testAccKubernetesConfigMapConfig_binaryData(name, mode, value string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_config_map" "test" {
+	metadata {
+		name = "%s"
+	}
+	binary_data_mode = "%s"
+	binary_data {
+		blob = "%s"
+	}
+}
+`, name, mode, value)
+}
@@ -29,8 +29,28 @@ func resourceKubernetesCronJob() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			if err := checkVolumeMountsReferenceVolumes(diff, "spec.0.job_template.0.spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkProjectedVolumeSources(diff, "spec.0.job_template.0.spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkProbes(diff, "spec.0.job_template.0.spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkTolerations(diff, "spec.0.job_template.0.spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			return checkDNSConfig(diff, "spec.0.job_template.0.spec.0.template.0.spec.0")
+		},
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("cronjob", true),
+			"metadata":             namespacedMetadataSchema("cronjob", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec of the cron job owned by the cluster",
@@ -48,11 +68,12 @@ func resourceKubernetesCronJobCreate(d *schema.ResourceData, meta interface{}) e
 	kp := meta.(*kubernetesProvider)
 	conn := kp.conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec, err := expandCronJobSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return err
 	}
+	clearUnsetSecurityContextFields(d, "spec.0.job_template.0.spec.0.template.0.spec.0.", &spec.JobTemplate.Spec.Template.Spec)
 	spec.JobTemplate.ObjectMeta.Annotations = metadata.Annotations
 
 	job := v1beta1.CronJob{
@@ -104,11 +125,12 @@ func resourceKubernetesCronJobUpdate(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec, err := expandCronJobSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return err
 	}
+	clearUnsetSecurityContextFields(d, "spec.0.job_template.0.spec.0.template.0.spec.0.", &spec.JobTemplate.Spec.Template.Spec)
 	spec.JobTemplate.ObjectMeta.Annotations = metadata.Annotations
 
 	cronjob := &v1beta1.CronJob{
@@ -160,6 +182,11 @@ func resourceKubernetesCronJobRead(d *schema.ResourceData, meta interface{}) err
 	log.Printf("[INFO] Reading cron job %s", name)
 	job, err := readCronJob(kp, namespace, name)
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Cron job %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
@@ -187,7 +214,7 @@ func resourceKubernetesCronJobRead(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
-	err = d.Set("metadata", flattenMetadata(job.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(job.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -221,10 +248,10 @@ func resourceKubernetesCronJobDelete(d *schema.ResourceData, meta interface{}) e
 	}
 	switch apiGroup {
 	case batchV1beta1:
-		err = conn.BatchV1beta1().CronJobs(namespace).Delete(name, nil)
+		err = conn.BatchV1beta1().CronJobs(namespace).Delete(name, deleteOptions(d, ""))
 
 	case batchV2alpha1:
-		err = conn.BatchV2alpha1().CronJobs(namespace).Delete(name, nil)
+		err = conn.BatchV2alpha1().CronJobs(namespace).Delete(name, deleteOptions(d, ""))
 
 	default:
 		err = cronJobNotSupportedError
@@ -233,7 +260,7 @@ func resourceKubernetesCronJobDelete(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
-	err = resource.Retry(1*time.Minute, func() *resource.RetryError {
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		_, err := readCronJob(kp, namespace, name)
 		if err != nil {
 			if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
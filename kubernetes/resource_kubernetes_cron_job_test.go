@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"k8s.io/api/batch/v1beta1"
+	api "k8s.io/api/core/v1"
 )
 
 func TestAccKubernetesCronJob_basic(t *testing.T) {
@@ -97,6 +100,107 @@ func TestAccKubernetesCronJob_extra(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesCronJob_suspend(t *testing.T) {
+	var conf v1beta1.CronJob
+	var uid string
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_cron_job.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesCronJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesCronJobConfig_suspend(name, "1 0 * * *", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesCronJobExists("kubernetes_cron_job.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_cron_job.test", "spec.0.suspend", "true"),
+					resource.TestCheckResourceAttr("kubernetes_cron_job.test", "spec.0.schedule", "1 0 * * *"),
+					func(s *terraform.State) error {
+						uid = string(conf.UID)
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccKubernetesCronJobConfig_suspend(name, "*/5 * * * *", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesCronJobExists("kubernetes_cron_job.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_cron_job.test", "spec.0.suspend", "false"),
+					resource.TestCheckResourceAttr("kubernetes_cron_job.test", "spec.0.schedule", "*/5 * * * *"),
+					func(s *terraform.State) error {
+						if string(conf.UID) != uid {
+							return fmt.Errorf("Expected the cron job to be resumed in place, given a new cron job %s", conf.UID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestCronJobSuspendAndScheduleDiff(t *testing.T) {
+	r := resourceKubernetesCronJob()
+	spec := func(schedule string, suspend bool) map[string]interface{} {
+		return map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{"name": "backup"}},
+			"spec": []interface{}{map[string]interface{}{
+				"schedule": schedule,
+				"suspend":  suspend,
+				"job_template": []interface{}{map[string]interface{}{
+					"spec": []interface{}{map[string]interface{}{
+						"template": []interface{}{map[string]interface{}{
+							"spec": []interface{}{map[string]interface{}{
+								"container": []interface{}{map[string]interface{}{"name": "backup", "image": "alpine"}},
+							}},
+						}},
+					}},
+				}},
+			}},
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, spec("1 0 * * *", true))
+	d.SetId("default/backup")
+
+	raw, err := config.NewRawConfig(spec("*/5 * * * *", false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfig(raw), &kubernetesProvider{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{"spec.0.suspend", "spec.0.schedule"} {
+		if _, ok := diff.Attributes[k]; !ok {
+			t.Fatalf("Expected %s to change, given: %#v", k, diff.Attributes)
+		}
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("Expected the cron job to be updated in place, given: %#v", diff.Attributes)
+	}
+}
+
+func TestFlattenCronJobSpecSuspend(t *testing.T) {
+	r := resourceKubernetesCronJob()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+
+	for _, suspend := range []*bool{ptrToBool(true), ptrToBool(false), nil} {
+		in := v1beta1.CronJobSpec{Suspend: suspend}
+		in.JobTemplate.Spec.Template.Spec.Containers = []api.Container{{Name: "backup", Image: "alpine"}}
+		spec, err := flattenCronJobSpec(in, d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := suspend != nil && *suspend
+		if given := spec[0].(map[string]interface{})["suspend"]; given != expected {
+			t.Fatalf("Expected suspend to be %t, given: %#v", expected, given)
+		}
+	}
+}
+
 func testAccCheckKubernetesCronJobDestroy(s *terraform.State) error {
 	kp := testAccProvider.Meta().(*kubernetesProvider)
 
@@ -262,3 +366,29 @@ resource "kubernetes_cron_job" "test" {
 	}
 }`, name)
 }
+
+func testAccKubernetesCronJobConfig_suspend(name, schedule string, suspend bool) string {
+	return fmt.Sprintf(`
+resource "kubernetes_cron_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		schedule = "%s"
+		suspend  = %t
+		job_template {
+			spec {
+				template {
+					spec {
+						container {
+							name = "hello"
+							image = "alpine"
+							command = ["echo", "'hello'"]
+						}
+					}
+				}
+			}
+		}
+	}
+}`, name, schedule, suspend)
+}
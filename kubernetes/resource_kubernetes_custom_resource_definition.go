@@ -0,0 +1,374 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+func resourceKubernetesCustomResourceDefinition() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesCustomResourceDefinitionCreate,
+		Read:   resourceKubernetesCustomResourceDefinitionRead,
+		Exists: resourceKubernetesCustomResourceDefinitionExists,
+		Update: resourceKubernetesCustomResourceDefinitionUpdate,
+		Delete: resourceKubernetesCustomResourceDefinitionDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateWithDefaults(map[string]interface{}{"wait_for_established": true}),
+		},
+		CustomizeDiff: resourceKubernetesCustomResourceDefinitionCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata":             clusterScopedMetadataSchema("custom resource definition", false),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Spec describes how the custom resources are served. The name of the definition must be `<names.plural>.<group>`.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group": {
+							Type:         schema.TypeString,
+							Description:  "The API group of the custom resources, e.g. `stable.example.com`. They are served under `/apis/<group>/...`.",
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateDNSSubdomain,
+						},
+						"names": {
+							Type:        schema.TypeList,
+							Description: "The names used to serve the custom resources.",
+							Required:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"plural": {
+										Type:        schema.TypeString,
+										Description: "The plural name of the resource used in the URL, e.g. `crontabs`. Must be all lowercase.",
+										Required:    true,
+										ForceNew:    true,
+									},
+									"singular": {
+										Type:        schema.TypeString,
+										Description: "The singular name of the resource, e.g. `crontab`. Defaults to the lowercased `kind`.",
+										Optional:    true,
+										ForceNew:    true,
+										Computed:    true,
+									},
+									"kind": {
+										Type:        schema.TypeString,
+										Description: "The CamelCased kind of the resource, e.g. `CronTab`.",
+										Required:    true,
+										ForceNew:    true,
+									},
+									"list_kind": {
+										Type:        schema.TypeString,
+										Description: "The kind of lists of the resource. Defaults to `<kind>List`.",
+										Optional:    true,
+										ForceNew:    true,
+										Computed:    true,
+									},
+									"short_names": {
+										Type:        schema.TypeList,
+										Description: "Short names of the resource, e.g. for `kubectl get ct`.",
+										Optional:    true,
+										ForceNew:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+									"categories": {
+										Type:        schema.TypeList,
+										Description: "Grouped resources the resource belongs to, e.g. `all`.",
+										Optional:    true,
+										ForceNew:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+						"scope": {
+							Type:         schema.TypeString,
+							Description:  "Whether the custom resources are `Namespaced` or `Cluster` scoped.",
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateAttributeValueIsIn([]string{"Namespaced", "Cluster"}),
+						},
+						"version": {
+							Type:        schema.TypeList,
+							Description: "The API versions the custom resources are served in. Exactly one of them must be the storage version. Can be updated in place.",
+							Required:    true,
+							MinItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Description: "Name of the version, e.g. `v1` or `v1beta1`.",
+										Required:    true,
+									},
+									"served": {
+										Type:        schema.TypeBool,
+										Description: "Whether the version is served by the API server.",
+										Optional:    true,
+										Default:     true,
+									},
+									"storage": {
+										Type:        schema.TypeBool,
+										Description: "Whether the custom resources are persisted in this version.",
+										Required:    true,
+									},
+									"schema": {
+										Type:             schema.TypeString,
+										Description:      "The OpenAPI v3 schema the custom resources of this version are validated & pruned with, as JSON (e.g. from `jsonencode` or `file`).",
+										Optional:         true,
+										ValidateFunc:     validateJSON,
+										DiffSuppressFunc: suppressEquivalentJSON,
+									},
+									"status_subresource": {
+										Type:        schema.TypeBool,
+										Description: "Whether the `/status` subresource is served, which makes the API server ignore changes to `status` through the main resource.",
+										Optional:    true,
+										Default:     false,
+									},
+									"scale_subresource": {
+										Type:        schema.TypeList,
+										Description: "Serves the `/scale` subresource, e.g. for autoscalers.",
+										Optional:    true,
+										MaxItems:    1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"spec_replicas_path": {
+													Type:        schema.TypeString,
+													Description: "JSON path of the desired replicas in the custom resource, e.g. `.spec.replicas`.",
+													Required:    true,
+												},
+												"status_replicas_path": {
+													Type:        schema.TypeString,
+													Description: "JSON path of the observed replicas in the custom resource, e.g. `.status.replicas`.",
+													Required:    true,
+												},
+												"label_selector_path": {
+													Type:        schema.TypeString,
+													Description: "JSON path of the label selector (in its string form) of the replicas, e.g. `.status.labelSelector`.",
+													Optional:    true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"wait_for_established": {
+				Type:        schema.TypeBool,
+				Description: "Whether to wait for the API server to serve the custom resources on create, so they can be created right away.",
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesCustomResourceDefinitionCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
+	spec, err := expandCustomResourceDefinitionSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return err
+	}
+	crd := customResourceDefinition{
+		ObjectMeta: metadata,
+		Spec:       spec,
+	}
+	crd.APIVersion = "apiextensions.k8s.io/v1"
+	crd.Kind = "CustomResourceDefinition"
+
+	data, err := json.Marshal(crd)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal custom resource definition: %s", err)
+	}
+	log.Printf("[INFO] Creating new custom resource definition: %s", string(data))
+	raw, err := conn.CoreV1().RESTClient().Post().AbsPath(customResourceDefinitionsAPIPath).Body(data).DoRaw()
+	if err != nil {
+		return err
+	}
+	var out customResourceDefinition
+	err = json.Unmarshal(raw, &out)
+	if err != nil {
+		return fmt.Errorf("Failed to decode created custom resource definition: %s", err)
+	}
+	log.Printf("[INFO] Submitted new custom resource definition: %#v", out)
+	d.SetId(out.ObjectMeta.Name)
+
+	if d.Get("wait_for_established").(bool) {
+		log.Printf("[DEBUG] Waiting for custom resource definition %s to be established", d.Id())
+
+		stateConf := &resource.StateChangeConf{
+			Target:  []string{"Established"},
+			Pending: []string{"Pending"},
+			Timeout: d.Timeout(schema.TimeoutCreate),
+			Refresh: func() (interface{}, string, error) {
+				crd, err := getCustomResourceDefinition(conn, out.ObjectMeta.Name)
+				if err != nil {
+					log.Printf("[ERROR] Received error: %#v", err)
+					return crd, "Error", err
+				}
+				state, err := customResourceDefinitionEstablishedState(crd)
+				log.Printf("[DEBUG] Custom resource definition %s is %s", d.Id(), state)
+				return crd, state, err
+			},
+		}
+		_, err = stateConf.WaitForState()
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceKubernetesCustomResourceDefinitionRead(d, meta)
+}
+
+func resourceKubernetesCustomResourceDefinitionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Id()
+	crd, err := getCustomResourceDefinition(conn, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Custom resource definition %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received custom resource definition: %#v", crd)
+
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(crd.ObjectMeta, d, meta))
+	if err != nil {
+		return err
+	}
+	spec, err := flattenCustomResourceDefinitionSpec(crd.Spec)
+	if err != nil {
+		return err
+	}
+	err = d.Set("spec", spec)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesCustomResourceDefinitionUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Id()
+	ops := patchMetadataWithDefaults(d, meta)
+	if d.HasChange("spec.0.version") {
+		versions, err := expandCustomResourceDefinitionVersions(d.Get("spec.0.version").([]interface{}))
+		if err != nil {
+			return err
+		}
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/spec/versions",
+			Value: versions,
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+
+	log.Printf("[INFO] Updating custom resource definition %s: %s", name, string(data))
+	raw, err := conn.CoreV1().RESTClient().Patch(pkgApi.JSONPatchType).AbsPath(customResourceDefinitionsAPIPath, name).Body(data).DoRaw()
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Submitted updated custom resource definition: %s", string(raw))
+
+	return resourceKubernetesCustomResourceDefinitionRead(d, meta)
+}
+
+func resourceKubernetesCustomResourceDefinitionDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Id()
+	data, err := json.Marshal(deleteOptions(d, ""))
+	if err != nil {
+		return err
+	}
+	// All the custom resources are deleted along with their definition
+	log.Printf("[INFO] Deleting custom resource definition: %s", name)
+	_, err = conn.CoreV1().RESTClient().Delete().AbsPath(customResourceDefinitionsAPIPath, name).Body(data).DoRaw()
+	if err != nil {
+		return err
+	}
+
+	err = waitForDeletion(d.Timeout(schema.TimeoutDelete), fmt.Sprintf("Custom resource definition %s", name), func() error {
+		_, err := getCustomResourceDefinition(conn, name)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Custom resource definition %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesCustomResourceDefinitionExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Id()
+	log.Printf("[INFO] Checking custom resource definition %s", name)
+	_, err := getCustomResourceDefinition(conn, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+func resourceKubernetesCustomResourceDefinitionCustomizeDiff(df *schema.ResourceDiff, meta interface{}) error {
+	storageVersions := 0
+	for _, v := range df.Get("spec.0.version").([]interface{}) {
+		if v.(map[string]interface{})["storage"].(bool) {
+			storageVersions++
+		}
+	}
+	if storageVersions != 1 {
+		return fmt.Errorf("Exactly one spec.0.version must have storage = true, given: %d", storageVersions)
+	}
+	return nil
+}
+
+func getCustomResourceDefinition(conn *kubernetes.Clientset, name string) (*customResourceDefinition, error) {
+	log.Printf("[INFO] Reading custom resource definition %s", name)
+	raw, err := conn.CoreV1().RESTClient().Get().AbsPath(customResourceDefinitionsAPIPath, name).DoRaw()
+	if err != nil {
+		return nil, err
+	}
+	var out customResourceDefinition
+	err = json.Unmarshal(raw, &out)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode custom resource definition %s: %s", name, err)
+	}
+	return &out, nil
+}
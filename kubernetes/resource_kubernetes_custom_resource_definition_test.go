@@ -0,0 +1,175 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestAccKubernetesCustomResourceDefinition_basic(t *testing.T) {
+	var conf customResourceDefinition
+	plural := fmt.Sprintf("tfacctest%s", acctest.RandStringFromCharSet(10, "abcdefghijklmnopqrstuvwxyz"))
+	name := plural + ".tf-acc-test.example.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_custom_resource_definition.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesCustomResourceDefinitionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesCustomResourceDefinitionConfig_basic(plural, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesCustomResourceDefinitionExists("kubernetes_custom_resource_definition.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_custom_resource_definition.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("kubernetes_custom_resource_definition.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("kubernetes_custom_resource_definition.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("kubernetes_custom_resource_definition.test", "spec.0.group", "tf-acc-test.example.com"),
+					resource.TestCheckResourceAttr("kubernetes_custom_resource_definition.test", "spec.0.scope", "Namespaced"),
+					resource.TestCheckResourceAttr("kubernetes_custom_resource_definition.test", "spec.0.names.0.plural", plural),
+					resource.TestCheckResourceAttr("kubernetes_custom_resource_definition.test", "spec.0.names.0.kind", "TfAccTest"),
+					resource.TestCheckResourceAttr("kubernetes_custom_resource_definition.test", "spec.0.names.0.list_kind", "TfAccTestList"),
+					resource.TestCheckResourceAttr("kubernetes_custom_resource_definition.test", "spec.0.version.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_custom_resource_definition.test", "spec.0.version.0.name", "v1"),
+					resource.TestCheckResourceAttr("kubernetes_custom_resource_definition.test", "spec.0.version.0.storage", "true"),
+					resource.TestCheckResourceAttr("kubernetes_custom_resource_definition.test", "spec.0.version.0.status_subresource", "false"),
+					testAccCheckKubernetesCustomResourceDefinitionEstablished(&conf),
+				),
+			},
+			{
+				Config: testAccKubernetesCustomResourceDefinitionConfig_basic(plural, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesCustomResourceDefinitionExists("kubernetes_custom_resource_definition.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_custom_resource_definition.test", "spec.0.version.0.status_subresource", "true"),
+					resource.TestCheckResourceAttr("kubernetes_custom_resource_definition.test", "spec.0.version.0.scale_subresource.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_custom_resource_definition.test", "spec.0.version.0.scale_subresource.0.spec_replicas_path", ".spec.replicas"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesCustomResourceDefinition_importBasic(t *testing.T) {
+	resourceName := "kubernetes_custom_resource_definition.test"
+	plural := fmt.Sprintf("tfacctest%s", acctest.RandStringFromCharSet(10, "abcdefghijklmnopqrstuvwxyz"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesCustomResourceDefinitionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesCustomResourceDefinitionConfig_basic(plural, false),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesCustomResourceDefinitionEstablished(obj *customResourceDefinition) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		state, err := customResourceDefinitionEstablishedState(obj)
+		if err != nil {
+			return err
+		}
+		if state != "Established" {
+			return fmt.Errorf("Expected custom resource definition %s to be established, given: %s", obj.ObjectMeta.Name, state)
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesCustomResourceDefinitionDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_custom_resource_definition" {
+			continue
+		}
+
+		_, err := getCustomResourceDefinition(conn, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Custom resource definition still exists: %s", rs.Primary.ID)
+		}
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesCustomResourceDefinitionExists(n string, obj *customResourceDefinition) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+		out, err := getCustomResourceDefinition(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesCustomResourceDefinitionConfig_basic(plural string, subresources bool) string {
+	extra := ""
+	if subresources {
+		extra = `
+			status_subresource = true
+			scale_subresource {
+				spec_replicas_path   = ".spec.replicas"
+				status_replicas_path = ".status.replicas"
+			}`
+	}
+	return fmt.Sprintf(`
+resource "kubernetes_custom_resource_definition" "test" {
+	metadata {
+		name = "%[1]s.tf-acc-test.example.com"
+	}
+	spec {
+		group = "tf-acc-test.example.com"
+		scope = "Namespaced"
+		names {
+			plural = "%[1]s"
+			kind   = "TfAccTest"
+		}
+		version {
+			name    = "v1"
+			storage = true
+			schema  = <<EOF
+{
+  "type": "object",
+  "properties": {
+    "spec": {
+      "type": "object",
+      "properties": {
+        "replicas": {"type": "integer"}
+      }
+    },
+    "status": {
+      "type": "object",
+      "x-kubernetes-preserve-unknown-fields": true
+    }
+  }
+}
+EOF
+%[2]s
+		}
+	}
+}`, plural, extra)
+}
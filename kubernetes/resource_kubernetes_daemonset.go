@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 	"k8s.io/api/apps/v1"
 	"k8s.io/api/apps/v1beta2"
@@ -34,6 +35,27 @@ func resourceKubernetesDaemonSet() *schema.Resource {
 		},
 		SchemaVersion: 1,
 		MigrateState:  resourceKubernetesDaemonSetStateUpgrader,
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			if err := checkVolumeMountsReferenceVolumes(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkProjectedVolumeSources(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkProbes(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkTolerations(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkDNSConfig(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkCommonLabels(diff, "metadata.0.labels", "spec.0.selector", "spec.0.template.0.metadata.0.labels"); err != nil {
+				return err
+			}
+			return checkSelectorMatchesTemplateLabels(diff, "daemon set", "spec.0.selector", "spec.0.template.0.metadata.0.labels")
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -42,7 +64,16 @@ func resourceKubernetesDaemonSet() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("daemonset", true),
+			"metadata":             namespacedMetadataSchema("daemonset", true),
+			"status":               workloadStatusSchema("daemon set"),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(metav1.DeletePropagationForeground),
+			"common_labels":        commonLabelsSchema("daemon set"),
+			"restart_trigger": {
+				Type:        schema.TypeString,
+				Description: "Arbitrary value whose change rolls out the pods of the daemon set again, like `kubectl rollout restart`. It's set as the `kubectl.kubernetes.io/restartedAt` annotation of the pod template, unless that annotation is configured. Changing it waits until the pods of the new revision are available on all the nodes, unless the update strategy is OnDelete.",
+				Optional:    true,
+			},
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the specification of the desired behavior of the daemonset. More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#spec-and-status",
@@ -56,10 +87,17 @@ func resourceKubernetesDaemonSet() *schema.Resource {
 							Optional:    true,
 							Default:     0,
 						},
+						"revision_history_limit": {
+							Type:         schema.TypeInt,
+							Description:  "The number of old ControllerRevisions to retain to allow rollback. Defaults to 10.",
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
 						"selector": {
 							Type:        schema.TypeMap,
 							Description: "A label query over pods that should match the Replicas count. If Selector is empty, it is defaulted to the labels present on the Pod template. Label keys and values that must match in order to be controlled by this deployment, if empty defaulted to labels on Pod template. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
-							Required:    true,
+							Optional:    true,
 						},
 						"strategy": {
 							Type:        schema.TypeList,
@@ -145,12 +183,15 @@ func resourceKubernetesDaemonSet() *schema.Resource {
 	}
 }
 
-func buildDaemonSetObject(d *schema.ResourceData) (*v1.DaemonSet, error) {
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+func buildDaemonSetObject(d *schema.ResourceData, meta interface{}) (*v1.DaemonSet, error) {
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec, err := expandDaemonSetSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return nil, err
 	}
+	clearUnsetSecurityContextFields(d, "spec.0.template.0.spec.0.", &spec.Template.Spec)
+	setRestartTrigger(&spec.Template, d.Get("restart_trigger").(string))
+	applyCommonLabels(d, &metadata, &spec.Selector, &spec.Template.ObjectMeta)
 	if metadata.Namespace == "" {
 		metadata.Namespace = "default"
 	}
@@ -167,7 +208,7 @@ func resourceKubernetesDaemonSetCreate(d *schema.ResourceData, meta interface{})
 	kp := meta.(*kubernetesProvider)
 	conn := kp.conn
 
-	daemonset, err := buildDaemonSetObject(d)
+	daemonset, err := buildDaemonSetObject(d, meta)
 	if err != nil {
 		return err
 	}
@@ -214,18 +255,29 @@ func resourceKubernetesDaemonSetRead(d *schema.ResourceData, meta interface{}) e
 
 	daemonset, err := readDaemonSet(kp, namespace, name)
 	if err != nil {
+		if kerrors.IsNotFound(err) {
+			log.Printf("[INFO] Daemon set %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received daemonset: %#v", daemonset)
 
+	err = checkControllerOwner("DaemonSet", daemonset.ObjectMeta, meta)
+	if err != nil {
+		return err
+	}
+
+	removeCommonLabels(d, &daemonset.ObjectMeta, daemonset.Spec.Selector, &daemonset.Spec.Template.ObjectMeta)
 	daemonset.ObjectMeta.Labels = reconcileTopLevelLabels(
 		daemonset.ObjectMeta.Labels,
 		expandMetadata(d.Get("metadata").([]interface{})),
 		expandMetadata(d.Get("spec.0.template.0.metadata").([]interface{})),
 	)
 
-	err = d.Set("metadata", flattenMetadata(daemonset.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(daemonset.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -240,6 +292,11 @@ func resourceKubernetesDaemonSetRead(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
+	err = d.Set("status", flattenWorkloadStatus(daemonset.Status.ObservedGeneration))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -285,7 +342,7 @@ func resourceKubernetesDaemonSetUpdate(d *schema.ResourceData, meta interface{})
 	conn := kp.conn
 	namespace, name, err := idParts(d.Id())
 
-	daemonset, err := buildDaemonSetObject(d)
+	daemonset, err := buildDaemonSetObject(d, meta)
 	if err != nil {
 		return err
 	}
@@ -342,8 +399,10 @@ func resourceKubernetesDaemonSetUpdate(d *schema.ResourceData, meta interface{})
 	}
 	log.Printf("[INFO] Submitted updated daemonset: %#v", out)
 
+	// A restart is only complete once the pods of the new revision replaced the old ones
 	err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
-		waitForDaemonSetReplicasFunc(kp, namespace, name))
+		waitForDaemonSetReplicasFunc(kp, namespace, name, d.HasChange("restart_trigger")))
+	err = withUnreadyPods(err, conn, namespace, out.Spec.Selector)
 	if err != nil {
 		return err
 	}
@@ -361,21 +420,35 @@ func resourceKubernetesDaemonSetDelete(d *schema.ResourceData, meta interface{})
 	}
 	log.Printf("[INFO] Deleting daemonset: %#v", name)
 
-	policy := metav1.DeletePropagationForeground
+	opts := deleteOptions(d, metav1.DeletePropagationForeground)
 	apiGroup, err := kp.highestSupportedAPIGroup(daemonSetResourceGroupName, daemonSetAPIGroups...)
 	if err != nil {
 		return err
 	}
 	switch apiGroup {
 	case appsV1:
-		conn.AppsV1().DaemonSets(namespace).Delete(name, &metav1.DeleteOptions{PropagationPolicy: &policy})
+		err = conn.AppsV1().DaemonSets(namespace).Delete(name, opts)
 	case appsV1beta2:
-		conn.AppsV1beta2().DaemonSets(namespace).Delete(name, &metav1.DeleteOptions{PropagationPolicy: &policy})
+		err = conn.AppsV1beta2().DaemonSets(namespace).Delete(name, opts)
 	case extensionsV1beta1:
-		conn.ExtensionsV1beta1().DaemonSets(namespace).Delete(name, &metav1.DeleteOptions{PropagationPolicy: &policy})
+		err = conn.ExtensionsV1beta1().DaemonSets(namespace).Delete(name, opts)
 	default:
 		err = daemonSetNotSupportedError
 	}
+	if err != nil {
+		return err
+	}
+
+	// The daemonset is only removed once its pods are gone
+	if isForegroundDeletion(opts) {
+		err = waitForDeletion(d.Timeout(schema.TimeoutDelete), fmt.Sprintf("DaemonSet %s", d.Id()), func() error {
+			_, err := readDaemonSet(kp, namespace, name)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
 
 	log.Printf("[INFO] DaemonSet %s deleted", name)
 
@@ -466,22 +539,50 @@ func migrateDaemonSetStateV0toV1(is *terraform.InstanceState) (*terraform.Instan
 	return is, nil
 }
 
-func waitForDaemonSetReplicasFunc(kp *kubernetesProvider, ns, name string) resource.RetryFunc {
+func waitForDaemonSetReplicasFunc(kp *kubernetesProvider, ns, name string, updated bool) resource.RetryFunc {
 	return func() *resource.RetryError {
 		daemonSet, err := readDaemonSet(kp, ns, name)
 		if err != nil {
 			return resource.NonRetryableError(err)
 		}
 
-		desiredReplicas := daemonSet.Status.DesiredNumberScheduled
 		log.Printf("[DEBUG] Current number of labelled replicas of %q: %d (of %d)\n",
-			daemonSet.GetName(), daemonSet.Status.CurrentNumberScheduled, desiredReplicas)
+			daemonSet.GetName(), daemonSet.Status.CurrentNumberScheduled, daemonSet.Status.DesiredNumberScheduled)
 
-		if daemonSet.Status.CurrentNumberScheduled == desiredReplicas {
+		done, msg := daemonSetRolloutStatus(daemonSet, updated)
+		if done {
 			return nil
 		}
-
-		return resource.RetryableError(fmt.Errorf("Waiting for %d replicas of %q to be scheduled (%d)",
-			desiredReplicas, daemonSet.GetName(), daemonSet.Status.CurrentNumberScheduled))
+		return resource.RetryableError(errors.New(msg))
 	}
 }
+
+// daemonSetRolloutStatus reports whether the daemon set is scheduled on all the nodes it should run on,
+// or else what it's waiting for. When updated is set the pods of the latest revision must also be
+// available on all of them, like `kubectl rollout status`, unless they're only replaced once deleted.
+func daemonSetRolloutStatus(daemonSet *v1.DaemonSet, updated bool) (bool, string) {
+	// The status is stale until the controller caught up with the latest spec
+	return rolloutStatus(daemonSet.GetName(),
+		observedGenerationCheck(daemonSet.Generation, daemonSet.Status.ObservedGeneration),
+		func() string {
+			desired := daemonSet.Status.DesiredNumberScheduled
+			if daemonSet.Status.CurrentNumberScheduled != desired {
+				return fmt.Sprintf("%d of %d replicas scheduled", daemonSet.Status.CurrentNumberScheduled, desired)
+			}
+			return ""
+		},
+		func() string {
+			if !updated || daemonSet.Spec.UpdateStrategy.Type == v1.OnDeleteDaemonSetStrategyType {
+				return ""
+			}
+			desired := daemonSet.Status.DesiredNumberScheduled
+			if daemonSet.Status.UpdatedNumberScheduled < desired {
+				return fmt.Sprintf("%d of %d replicas updated", daemonSet.Status.UpdatedNumberScheduled, desired)
+			}
+			if daemonSet.Status.NumberAvailable < desired {
+				return fmt.Sprintf("%d of %d updated replicas available", daemonSet.Status.NumberAvailable, desired)
+			}
+			return ""
+		},
+	)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	appsv1 "k8s.io/api/apps/v1"
 )
@@ -61,6 +62,7 @@ func TestAccKubernetesDaemonSet_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("kubernetes_daemonset.test", "metadata.0.resource_version"),
 					resource.TestCheckResourceAttrSet("kubernetes_daemonset.test", "metadata.0.self_link"),
 					resource.TestCheckResourceAttrSet("kubernetes_daemonset.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("kubernetes_daemonset.test", "spec.0.revision_history_limit", "10"),
 					resource.TestCheckResourceAttr("kubernetes_daemonset.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.8"),
 					resource.TestCheckResourceAttr("kubernetes_daemonset.test", "spec.0.template.0.spec.0.container.0.name", "tf-acc-test"),
 				),
@@ -82,6 +84,7 @@ func TestAccKubernetesDaemonSet_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("kubernetes_daemonset.test", "metadata.0.resource_version"),
 					resource.TestCheckResourceAttrSet("kubernetes_daemonset.test", "metadata.0.self_link"),
 					resource.TestCheckResourceAttrSet("kubernetes_daemonset.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("kubernetes_daemonset.test", "spec.0.revision_history_limit", "3"),
 					resource.TestCheckResourceAttr("kubernetes_daemonset.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.9"),
 					resource.TestCheckResourceAttr("kubernetes_daemonset.test", "spec.0.template.0.spec.0.container.0.name", "tf-acc-test"),
 				),
@@ -90,6 +93,32 @@ func TestAccKubernetesDaemonSet_basic(t *testing.T) {
 	})
 }
 
+func TestDaemonSetSpecRevisionHistoryLimit(t *testing.T) {
+	spec, err := expandDaemonSetSpec([]interface{}{map[string]interface{}{
+		"min_ready_seconds":      0,
+		"revision_history_limit": 0,
+		"selector":               map[string]interface{}{"app": "agent"},
+		"strategy":               []interface{}{},
+		"template":               []interface{}{},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 0 keeps no old revisions, it's not left to the default
+	if spec.RevisionHistoryLimit == nil || *spec.RevisionHistoryLimit != 0 {
+		t.Fatalf("Expected a revision history limit of 0, given: %v", spec.RevisionHistoryLimit)
+	}
+
+	spec.RevisionHistoryLimit = nil
+	flattened, err := flattenDaemonSetSpec(spec, schema.TestResourceDataRaw(t, resourceKubernetesDaemonSet().Schema, map[string]interface{}{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := flattened[0].(map[string]interface{})["revision_history_limit"]; v != 10 {
+		t.Fatalf("Expected an unset revision history limit to read as the default 10, given: %v", v)
+	}
+}
+
 func TestAccKubernetesDaemonSet_importBasic(t *testing.T) {
 	resourceName := "kubernetes_daemonset.test"
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
@@ -194,6 +223,49 @@ func TestAccKubernetesDaemonSet_noTopLevelLabels(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesDaemonSet_restartTrigger(t *testing.T) {
+	var conf appsv1.DaemonSet
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesDaemonSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDaemonSetConfig_restartTrigger(name, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDaemonSetExists("kubernetes_daemonset.test", &conf),
+					testAccCheckKubernetesDaemonSetRestartedAt(&conf, "1"),
+					resource.TestCheckResourceAttr("kubernetes_daemonset.test", "restart_trigger", "1"),
+					resource.TestCheckResourceAttr("kubernetes_daemonset.test", "spec.0.template.0.metadata.0.annotations.%", "0"),
+				),
+			},
+			{
+				Config: testAccKubernetesDaemonSetConfig_restartTrigger(name, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDaemonSetExists("kubernetes_daemonset.test", &conf),
+					testAccCheckKubernetesDaemonSetRestartedAt(&conf, "2"),
+					resource.TestCheckResourceAttr("kubernetes_daemonset.test", "restart_trigger", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesDaemonSetRestartedAt(obj *appsv1.DaemonSet, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if v := obj.Spec.Template.Annotations[restartedAtAnnotation]; v != expected {
+			return fmt.Errorf("Expected the pod template to be annotated with %s = %q, given: %q", restartedAtAnnotation, expected, v)
+		}
+		// The pods of the restart were awaited
+		if obj.Status.UpdatedNumberScheduled != obj.Status.DesiredNumberScheduled || obj.Status.ObservedGeneration != obj.Generation {
+			return fmt.Errorf("Expected the rollout of generation %d to be complete, given status: %#v", obj.Generation, obj.Status)
+		}
+		return nil
+	}
+}
+
 func testAccCheckKubernetesDaemonSetDestroy(s *terraform.State) error {
 	kp := testAccProvider.Meta().(*kubernetesProvider)
 
@@ -325,6 +397,7 @@ resource "kubernetes_daemonset" "test" {
     name = "%s"
   }
   spec {
+    revision_history_limit = 3
     selector {
       TestLabelOne = "one"
       TestLabelTwo = "two"
@@ -490,3 +563,32 @@ resource "kubernetes_daemonset" "test" {
 }
 `, depName, imageName)
 }
+
+func testAccKubernetesDaemonSetConfig_restartTrigger(name, trigger string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_daemonset" "test" {
+  metadata {
+    name = "%s"
+  }
+  restart_trigger = "%s"
+  spec {
+    selector {
+      foo = "bar"
+    }
+    template {
+      metadata {
+        labels {
+          foo = "bar"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:1.7.8"
+          name  = "tf-acc-test"
+        }
+      }
+    }
+  }
+}
+`, name, trigger)
+}
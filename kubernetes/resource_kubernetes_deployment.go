@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 	appsv1 "k8s.io/api/apps/v1"
 	appsv1beta1 "k8s.io/api/apps/v1beta1"
@@ -17,6 +18,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 )
 
 const deploymentsResourceGroupName = "deployments"
@@ -33,10 +35,35 @@ func resourceKubernetesDeployment() *schema.Resource {
 		Update: resourceKubernetesDeploymentUpdate,
 		Delete: resourceKubernetesDeploymentDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateWithDefaults(map[string]interface{}{
+				"wait_for_rollout": true,
+				"patch_strategy":   patchStrategyJSON,
+				"force_conflicts":  false,
+			}),
 		},
 		SchemaVersion: 2,
 		MigrateState:  resourceKubernetesDeploymentStateUpgrader,
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			if err := checkVolumeMountsReferenceVolumes(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkProjectedVolumeSources(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkProbes(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkTolerations(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkDNSConfig(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkCommonLabels(diff, "metadata.0.labels", "spec.0.selector", "spec.0.template.0.metadata.0.labels"); err != nil {
+				return err
+			}
+			return checkSelectorMatchesTemplateLabels(diff, "deployment", "spec.0.selector", "spec.0.template.0.metadata.0.labels")
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -45,12 +72,29 @@ func resourceKubernetesDeployment() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("deployment", true),
+			"metadata":             namespacedMetadataSchema("deployment", true),
+			"status":               workloadStatusSchema("deployment"),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(metav1.DeletePropagationForeground),
+			"patch_strategy":       patchStrategySchema(),
+			"force_conflicts":      forceConflictsSchema(),
+			"common_labels":        commonLabelsSchema("deployment"),
 			"name": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Removed:  "To better match the Kubernetes API, the name attribute should be configured under the metadata block. Please update your Terraform configuration.",
 			},
+			"restart_trigger": {
+				Type:        schema.TypeString,
+				Description: "Arbitrary value whose change rolls out the pods of the deployment again, like `kubectl rollout restart`. It's set as the `kubectl.kubernetes.io/restartedAt` annotation of the pod template, unless that annotation is configured.",
+				Optional:    true,
+			},
+			"wait_for_rollout": {
+				Type:        schema.TypeBool,
+				Description: "Whether to wait on create & update until all the replicas are updated & available. Replicas are only available once ready for `min_ready_seconds`, the wait is extended by that window. Fails as soon as the deployment exceeds its `progress_deadline_seconds`. Otherwise only the scheduling of the replicas is awaited.",
+				Optional:    true,
+				Default:     true,
+			},
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the specification of the desired behavior of the deployment. More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#spec-and-status",
@@ -78,15 +122,16 @@ func resourceKubernetesDeployment() *schema.Resource {
 						},
 						"replicas": {
 							Type:        schema.TypeInt,
-							Description: "The number of desired replicas. Defaults to 1. More info: http://kubernetes.io/docs/user-guide/replication-controller#what-is-a-replication-controller",
+							Description: "The number of desired replicas. When it's not set the deployment is created with 1 replica and the live number of replicas is never changed, so it can be owned by e.g. a horizontal pod autoscaler. Changes are applied through the `scale` subresource. More info: http://kubernetes.io/docs/user-guide/replication-controller#what-is-a-replication-controller",
 							Optional:    true,
-							Default:     1,
+							Computed:    true,
 						},
 						"revision_history_limit": {
-							Type:        schema.TypeInt,
-							Description: "The number of old ReplicaSets to retain to allow rollback. Defaults to 10.",
-							Optional:    true,
-							Default:     10,
+							Type:         schema.TypeInt,
+							Description:  "The number of old ReplicaSets to retain to allow rollback. Defaults to 10.",
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(0),
 						},
 						"selector": {
 							Type:        schema.TypeMap,
@@ -191,11 +236,15 @@ func resourceKubernetesDeploymentCreate(d *schema.ResourceData, meta interface{}
 	kp := meta.(*kubernetesProvider)
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec, err := expandDeploymentSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return err
 	}
+	clearUnsetSecurityContextFields(d, "spec.0.template.0.spec.0.", &spec.Template.Spec)
+	setRestartTrigger(&spec.Template, d.Get("restart_trigger").(string))
+	applyCommonLabels(d, &metadata, &spec.Selector, &spec.Template.ObjectMeta)
+	spec.Replicas = configuredDeploymentReplicas(d)
 	if metadata.Namespace == "" {
 		metadata.Namespace = "default"
 	}
@@ -208,71 +257,76 @@ func resourceKubernetesDeploymentCreate(d *schema.ResourceData, meta interface{}
 	outDeploymentV1 := &appsv1.Deployment{}
 
 	log.Printf("[INFO] Creating new deployment: %#v", deployment)
-	apiGroup, err := kp.highestSupportedAPIGroup(deploymentsResourceGroupName, deploymentsAPIGroups...)
-	if err != nil {
-		return err
-	}
-	switch apiGroup {
-	case appsV1:
-		// Push deployment to API, and capture resultant object
-		outDeploymentV1, err = conn.AppsV1().Deployments(metadata.Namespace).Create(&deployment)
-
-	case appsV1beta2:
-		beta := &appsv1beta2.Deployment{}
-		err = Convert(&deployment, beta)
+	if d.Get("patch_strategy").(string) == patchStrategyApply {
+		outDeploymentV1, err = applyDeployment(d, conn, &deployment)
+	} else {
+		var apiGroup APIGroup
+		apiGroup, err = kp.highestSupportedAPIGroup(deploymentsResourceGroupName, deploymentsAPIGroups...)
 		if err != nil {
-			break
+			return err
 		}
+		switch apiGroup {
+		case appsV1:
+			// Push deployment to API, and capture resultant object
+			outDeploymentV1, err = conn.AppsV1().Deployments(metadata.Namespace).Create(&deployment)
+
+		case appsV1beta2:
+			beta := &appsv1beta2.Deployment{}
+			err = Convert(&deployment, beta)
+			if err != nil {
+				break
+			}
 
-		out, err2 := conn.AppsV1beta2().Deployments(metadata.Namespace).Create(beta)
-		if err2 != nil {
-			err = err2
-			break
-		}
+			out, err2 := conn.AppsV1beta2().Deployments(metadata.Namespace).Create(beta)
+			if err2 != nil {
+				err = err2
+				break
+			}
 
-		err = Convert(out, outDeploymentV1)
-		if err != nil {
-			break
-		}
+			err = Convert(out, outDeploymentV1)
+			if err != nil {
+				break
+			}
 
-	case appsV1beta1:
-		beta := &appsv1beta1.Deployment{}
-		err = Convert(&deployment, beta)
-		if err != nil {
-			break
-		}
+		case appsV1beta1:
+			beta := &appsv1beta1.Deployment{}
+			err = Convert(&deployment, beta)
+			if err != nil {
+				break
+			}
 
-		var outDeploymentV1beta1 *appsv1beta1.Deployment
-		outDeploymentV1beta1, err = conn.AppsV1beta1().Deployments(metadata.Namespace).Create(beta)
-		if err != nil {
-			break
-		}
+			var outDeploymentV1beta1 *appsv1beta1.Deployment
+			outDeploymentV1beta1, err = conn.AppsV1beta1().Deployments(metadata.Namespace).Create(beta)
+			if err != nil {
+				break
+			}
 
-		err = Convert(outDeploymentV1beta1, outDeploymentV1)
-		if err != nil {
-			break
-		}
+			err = Convert(outDeploymentV1beta1, outDeploymentV1)
+			if err != nil {
+				break
+			}
 
-	case extensionsV1beta1:
-		beta := &extensionsv1beta1.Deployment{}
-		err = Convert(&deployment, beta)
-		if err != nil {
-			break
-		}
+		case extensionsV1beta1:
+			beta := &extensionsv1beta1.Deployment{}
+			err = Convert(&deployment, beta)
+			if err != nil {
+				break
+			}
 
-		var outDeploymentV1beta1 *extensionsv1beta1.Deployment
-		outDeploymentV1beta1, err = conn.ExtensionsV1beta1().Deployments(metadata.Namespace).Create(beta)
-		if err != nil {
-			break
-		}
+			var outDeploymentV1beta1 *extensionsv1beta1.Deployment
+			outDeploymentV1beta1, err = conn.ExtensionsV1beta1().Deployments(metadata.Namespace).Create(beta)
+			if err != nil {
+				break
+			}
 
-		err = Convert(outDeploymentV1beta1, outDeploymentV1)
-		if err != nil {
-			break
-		}
+			err = Convert(outDeploymentV1beta1, outDeploymentV1)
+			if err != nil {
+				break
+			}
 
-	default:
-		err = deploymentNotSupportedError
+		default:
+			err = deploymentNotSupportedError
+		}
 	}
 	if err != nil {
 		return fmt.Errorf("Failed to create deployment: %s", err)
@@ -281,32 +335,27 @@ func resourceKubernetesDeploymentCreate(d *schema.ResourceData, meta interface{}
 	log.Printf("[INFO] Created deployment: %s", outDeploymentV1.ObjectMeta.SelfLink)
 
 	d.SetId(buildId(outDeploymentV1.ObjectMeta))
-	// deployment.ObjectMeta.Labels = reconcileTopLevelLabels(
-	// 	deployment.ObjectMeta.Labels,
-	// 	expandMetadata(d.Get("metadata").([]interface{})),
-	// 	expandMetadata(d.Get("spec.0.template.0.metadata").([]interface{})),
-	// )
-	// err = d.Set("metadata", flattenMetadata(out.ObjectMeta, d))
-	// if err != nil {
-	// 	return err
-	// }
-
-	log.Printf("[DEBUG] Waiting for deployment %s to schedule %d replicas",
-		d.Id(), *outDeploymentV1.Spec.Replicas)
-	// 10 mins should be sufficient for scheduling ~10k replicas
-	err = resource.Retry(d.Timeout(schema.TimeoutCreate),
-		waitForDeploymentReplicasFunc(
-			kp,
-			outDeploymentV1.GetNamespace(),
-			outDeploymentV1.GetName(),
-		),
-	)
+
+	if d.Get("wait_for_rollout").(bool) {
+		log.Printf("[DEBUG] Waiting for the rollout of deployment %s", d.Id())
+		err = waitForDeploymentRollout(d.Timeout(schema.TimeoutCreate), kp, outDeploymentV1.ObjectMeta,
+			int32(d.Get("spec.0.min_ready_seconds").(int)))
+	} else {
+		log.Printf("[DEBUG] Waiting for deployment %s to schedule %d replicas",
+			d.Id(), *outDeploymentV1.Spec.Replicas)
+		// 10 mins should be sufficient for scheduling ~10k replicas
+		err = resource.Retry(d.Timeout(schema.TimeoutCreate),
+			waitForDeploymentReplicasFunc(
+				kp,
+				outDeploymentV1.GetNamespace(),
+				outDeploymentV1.GetName(),
+			),
+		)
+		err = withUnreadyPods(err, kp.conn, outDeploymentV1.GetNamespace(), outDeploymentV1.Spec.Selector)
+	}
 	if err != nil {
 		return err
 	}
-	// We could wait for all pods to actually reach Ready state
-	// but that means checking each pod status separately (which can be expensive at scale)
-	// as there's no aggregate data available from the API
 
 	log.Printf("[INFO] Submitted new deployment: %#v", outDeploymentV1)
 
@@ -319,17 +368,23 @@ func resourceKubernetesDeploymentRead(d *schema.ResourceData, meta interface{})
 	namespace, name, err := idParts(d.Id())
 	deployment, err := readDeployment(kp, namespace, name)
 	if err != nil {
+		if kerrors.IsNotFound(err) {
+			log.Printf("[INFO] Deployment %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received deployment: %#v", deployment)
 
+	removeCommonLabels(d, &deployment.ObjectMeta, deployment.Spec.Selector, &deployment.Spec.Template.ObjectMeta)
 	deployment.ObjectMeta.Labels = reconcileTopLevelLabels(
 		deployment.ObjectMeta.Labels,
 		expandMetadata(d.Get("metadata").([]interface{})),
 		expandMetadata(d.Get("spec.0.template.0.metadata").([]interface{})),
 	)
-	err = d.Set("metadata", flattenMetadata(deployment.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(deployment.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -344,6 +399,11 @@ func resourceKubernetesDeploymentRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	err = d.Set("status", flattenWorkloadStatus(deployment.Status.ObservedGeneration))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -351,34 +411,113 @@ func resourceKubernetesDeploymentUpdate(d *schema.ResourceData, meta interface{}
 	kp := meta.(*kubernetesProvider)
 	namespace, name, err := idParts(d.Id())
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	// Changed replicas are set through the scale subresource & left out of the patch
+	scaled := d.HasChange("spec.0.replicas")
+	if scaled {
+		err = scaleWorkload(kp, deploymentsResourceGroupName, deploymentsAPIGroups, namespace, name, int32(d.Get("spec.0.replicas").(int)))
+		if err != nil {
+			return err
+		}
+	}
 
-	if d.HasChange("spec") {
-		spec, err := expandDeploymentSpec(d.Get("spec").([]interface{}))
+	var out *appsv1.Deployment
+	if d.Get("patch_strategy").(string) == patchStrategyApply {
+		var spec appsv1.DeploymentSpec
+		spec, err = expandDeploymentSpec(d.Get("spec").([]interface{}))
 		if err != nil {
 			return err
 		}
+		clearUnsetSecurityContextFields(d, "spec.0.template.0.spec.0.", &spec.Template.Spec)
+		setRestartTrigger(&spec.Template, d.Get("restart_trigger").(string))
+		metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
+		applyCommonLabels(d, &metadata, &spec.Selector, &spec.Template.ObjectMeta)
+		spec.Replicas = configuredDeploymentReplicas(d)
+		metadata.Namespace = namespace
+		log.Printf("[INFO] Updating deployment %q", name)
+		out, err = applyDeployment(d, kp.conn, &appsv1.Deployment{ObjectMeta: metadata, Spec: spec})
+	} else {
+		// The patch is built for the live deployment of each attempt, see patchDeploymentOnLatest
+		var patchType pkgApi.PatchType
+		var build func(live *appsv1.Deployment) ([]byte, error)
+		if d.Get("patch_strategy").(string) == patchStrategyStrategic {
+			var data []byte
+			patchType, data, err = strategicMergePatchForChanges(d, meta, "spec", func(m metav1.ObjectMeta, s []interface{}) (interface{}, error) {
+				spec, err := expandDeploymentSpec(s)
+				if err != nil {
+					return nil, err
+				}
+				return &appsv1.Deployment{ObjectMeta: m, Spec: spec}, nil
+			})
+			if err != nil {
+				return err
+			}
+			if scaled {
+				data, err = withoutReplicasInMergePatch(data)
+				if err != nil {
+					return fmt.Errorf("Failed to leave the scaled replicas out of the patch: %s", err)
+				}
+			}
+			// The annotation is only patched when the trigger changes, the live one is kept otherwise
+			_, configured := d.Get("spec.0.template.0.metadata.0.annotations").(map[string]interface{})[restartedAtAnnotation]
+			if d.HasChange("restart_trigger") && !configured {
+				data, err = patchRestartTrigger(data, d.Get("restart_trigger").(string))
+				if err != nil {
+					return fmt.Errorf("Failed to add the restart trigger to the patch: %s", err)
+				}
+			}
+			build = func(*appsv1.Deployment) ([]byte, error) {
+				return data, nil
+			}
+		} else {
+			patchType = pkgApi.JSONPatchType
+			build = func(live *appsv1.Deployment) ([]byte, error) {
+				ops := patchMetadataWithCommonLabels(d, meta)
+
+				if (d.HasChange("spec") && (!scaled || specChangedBesidesReplicas(d))) || d.HasChange("restart_trigger") {
+					spec, err := expandDeploymentSpec(d.Get("spec").([]interface{}))
+					if err != nil {
+						return nil, err
+					}
+					clearUnsetSecurityContextFields(d, "spec.0.template.0.spec.0.", &spec.Template.Spec)
+					setRestartTrigger(&spec.Template, d.Get("restart_trigger").(string))
+					applyCommonLabels(d, nil, &spec.Selector, &spec.Template.ObjectMeta)
+					// Unchanged replicas may have been scaled since the refresh, e.g. by an autoscaler,
+					// the live number is replaced along with the spec rather than reverted.
+					if !d.HasChange("spec.0.replicas") {
+						spec.Replicas = live.Spec.Replicas
+					}
+
+					ops = append(ops, &ReplaceOperation{
+						Path:  "/spec",
+						Value: spec,
+					})
+				}
+				data, err := ops.MarshalJSON()
+				if err != nil {
+					return nil, fmt.Errorf("Failed to marshal update operations: %s", err)
+				}
+				return data, nil
+			}
+		}
+		log.Printf("[INFO] Updating deployment %q", name)
 
-		ops = append(ops, &ReplaceOperation{
-			Path:  "/spec",
-			Value: spec,
-		})
+		out, err = patchDeploymentOnLatest(d, kp, patchType, build)
 	}
-	data, err := ops.MarshalJSON()
-	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
-	}
-	log.Printf("[INFO] Updating deployment %q: %v", name, string(data))
-
-	out, err := resourceKubernetesPatchDeployment(d, kp, data)
 	if err != nil {
 		return err
 	}
 
 	log.Printf("[INFO] Submitted updated deployment: %#v", out)
 
-	err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
-		waitForDeploymentReplicasFunc(kp, namespace, name))
+	if d.Get("wait_for_rollout").(bool) {
+		log.Printf("[DEBUG] Waiting for the rollout of deployment %s", d.Id())
+		err = waitForDeploymentRollout(d.Timeout(schema.TimeoutUpdate), kp, out.ObjectMeta,
+			int32(d.Get("spec.0.min_ready_seconds").(int)))
+	} else {
+		err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
+			waitForDeploymentReplicasFunc(kp, namespace, name))
+		err = withUnreadyPods(err, kp.conn, namespace, out.Spec.Selector)
+	}
 	if err != nil {
 		return err
 	}
@@ -386,7 +525,43 @@ func resourceKubernetesDeploymentUpdate(d *schema.ResourceData, meta interface{}
 	return resourceKubernetesDeploymentRead(d, meta)
 }
 
-func resourceKubernetesPatchDeployment(d *schema.ResourceData, kp *kubernetesProvider, data []byte) (deployment *appsv1.Deployment, err error) {
+// patchDeploymentOnLatest patches the latest version of the deployment with the patch build returns for it,
+// which is rebuilt after a conflict, see patchOnLatest.
+func patchDeploymentOnLatest(d *schema.ResourceData, kp *kubernetesProvider, patchType pkgApi.PatchType, build func(live *appsv1.Deployment) ([]byte, error)) (out *appsv1.Deployment, err error) {
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	err = retryOnConflict(func() error {
+		live, err := readDeployment(kp, namespace, name)
+		if err != nil {
+			return err
+		}
+		data, err := build(live)
+		if err != nil {
+			return err
+		}
+		data, err = patchOnVersion(patchType, data, live)
+		if err != nil {
+			return fmt.Errorf("Failed to build the patch for resource version %s: %s", live.ResourceVersion, err)
+		}
+		log.Printf("[DEBUG] Patching deployment %q at resource version %s: %s", name, live.ResourceVersion, data)
+		out, err = resourceKubernetesPatchDeployment(d, kp, patchType, data)
+		return err
+	})
+	return out, err
+}
+
+// applyDeployment creates or updates the deployment with server-side apply, see serverSideApply.
+// Server-side apply needs Kubernetes 1.16+, which always serves apps/v1.
+func applyDeployment(d *schema.ResourceData, conn *kubernetes.Clientset, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	deployment.TypeMeta = metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}
+	out := &appsv1.Deployment{}
+	err := serverSideApply(d, conn.AppsV1().RESTClient(), "deployments", deployment, out)
+	return out, err
+}
+
+func resourceKubernetesPatchDeployment(d *schema.ResourceData, kp *kubernetesProvider, patchType pkgApi.PatchType, data []byte) (deployment *appsv1.Deployment, err error) {
 	conn := kp.conn
 	deployment = &appsv1.Deployment{}
 
@@ -398,13 +573,13 @@ func resourceKubernetesPatchDeployment(d *schema.ResourceData, kp *kubernetesPro
 
 	switch apiGroup {
 	case appsV1:
-		deployment, err = conn.AppsV1().Deployments(namespace).Patch(name, pkgApi.JSONPatchType, data)
+		deployment, err = conn.AppsV1().Deployments(namespace).Patch(name, patchType, data)
 		if err != nil {
 			return
 		}
 
 	case appsV1beta2:
-		beta, err := conn.AppsV1beta2().Deployments(namespace).Patch(name, pkgApi.JSONPatchType, data)
+		beta, err := conn.AppsV1beta2().Deployments(namespace).Patch(name, patchType, data)
 		if err != nil {
 			return nil, err
 		}
@@ -415,7 +590,7 @@ func resourceKubernetesPatchDeployment(d *schema.ResourceData, kp *kubernetesPro
 		}
 
 	case appsV1beta1:
-		beta, err := conn.AppsV1beta1().Deployments(namespace).Patch(name, pkgApi.JSONPatchType, data)
+		beta, err := conn.AppsV1beta1().Deployments(namespace).Patch(name, patchType, data)
 		if err != nil {
 			return nil, err
 		}
@@ -426,7 +601,7 @@ func resourceKubernetesPatchDeployment(d *schema.ResourceData, kp *kubernetesPro
 		}
 
 	case extensionsV1beta1:
-		beta, err := conn.ExtensionsV1beta1().Deployments(namespace).Patch(name, pkgApi.JSONPatchType, data)
+		beta, err := conn.ExtensionsV1beta1().Deployments(namespace).Patch(name, patchType, data)
 		if err != nil {
 			return nil, err
 		}
@@ -460,7 +635,7 @@ func resourceKubernetesDeploymentDelete(d *schema.ResourceData, meta interface{}
 	if err != nil {
 		return err
 	}
-	_, err = resourceKubernetesPatchDeployment(d, kp, data)
+	_, err = resourceKubernetesPatchDeployment(d, kp, pkgApi.JSONPatchType, data)
 	if err != nil {
 		return err
 	}
@@ -477,31 +652,37 @@ func resourceKubernetesDeploymentDelete(d *schema.ResourceData, meta interface{}
 		return err
 	}
 
-	policy := metav1.DeletePropagationForeground
+	opts := deleteOptions(d, metav1.DeletePropagationForeground)
 	apiGroup, err := kp.highestSupportedAPIGroup(deploymentsResourceGroupName, deploymentsAPIGroups...)
 	if err != nil {
 		return err
 	}
 	switch apiGroup {
 	case appsV1:
-		err = conn.AppsV1().Deployments(namespace).Delete(name, &metav1.DeleteOptions{
-			PropagationPolicy: &policy,
-		})
+		err = conn.AppsV1().Deployments(namespace).Delete(name, opts)
 	case appsV1beta2:
-		err = conn.AppsV1beta2().Deployments(namespace).Delete(name, &metav1.DeleteOptions{
-			PropagationPolicy: &policy,
-		})
+		err = conn.AppsV1beta2().Deployments(namespace).Delete(name, opts)
 	case appsV1beta1:
-		err = conn.AppsV1beta1().Deployments(namespace).Delete(name, &metav1.DeleteOptions{
-			PropagationPolicy: &policy,
-		})
+		err = conn.AppsV1beta1().Deployments(namespace).Delete(name, opts)
 	case extensionsV1beta1:
-		err = conn.ExtensionsV1beta1().Deployments(namespace).Delete(name, &metav1.DeleteOptions{
-			PropagationPolicy: &policy,
-		})
+		err = conn.ExtensionsV1beta1().Deployments(namespace).Delete(name, opts)
 	default:
 		err = deploymentNotSupportedError
 	}
+	if err != nil {
+		return err
+	}
+
+	// The deployment is only removed once its replica sets & their pods are gone
+	if isForegroundDeletion(opts) {
+		err = waitForDeletion(d.Timeout(schema.TimeoutDelete), fmt.Sprintf("Deployment %s", d.Id()), func() error {
+			_, err := readDeployment(kp, namespace, name)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
 
 	log.Printf("[INFO] Deployment %s deleted", name)
 
@@ -571,6 +752,16 @@ func readDeployment(kp *kubernetesProvider, namespace, name string) (dep *appsv1
 	return dep, err
 }
 
+// configuredDeploymentReplicas returns the configured replicas of the deployment to create, nil
+// when they're not configured so they're defaulted by the API server. Setting them to 0 is kept.
+func configuredDeploymentReplicas(d *schema.ResourceData) *int32 {
+	v, ok := d.GetOkExists("spec.0.replicas")
+	if !ok {
+		return nil
+	}
+	return ptrToInt32(int32(v.(int)))
+}
+
 // func waitForDeploymentReplicasFunc(conn *kubernetes.Clientset, ns, name string) resource.RetryFunc {
 func waitForDeploymentReplicasFunc(kp *kubernetesProvider, ns, name string) resource.RetryFunc {
 	return func() *resource.RetryError {
@@ -593,6 +784,101 @@ func waitForDeploymentReplicasFunc(kp *kubernetesProvider, ns, name string) reso
 	}
 }
 
+// waitForDeploymentRollout waits until all the replicas of the deployment are updated & available.
+// It fails right away once the deployment controller reports the progress deadline as exceeded,
+// with the warning events of the deployment.
+// Replicas are only available once ready for minReadySeconds, the timeout is extended by that window
+// so the last replicas to get ready can still become available.
+func waitForDeploymentRollout(timeout time.Duration, kp *kubernetesProvider, metadata metav1.ObjectMeta, minReadySeconds int32) error {
+	if minReadySeconds > 0 {
+		log.Printf("[DEBUG] Extending the rollout wait of deployment %s/%s by min_ready_seconds (%ds)",
+			metadata.Namespace, metadata.Name, minReadySeconds)
+		timeout += time.Duration(minReadySeconds) * time.Second
+	}
+	var last *appsv1.Deployment
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		deployment, err := readDeployment(kp, metadata.Namespace, metadata.Name)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		last = deployment
+
+		done, msg, err := deploymentRolloutStatus(deployment)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if done {
+			return nil
+		}
+		log.Printf("[DEBUG] %s", msg)
+		return resource.RetryableError(errors.New(msg))
+	})
+	if err != nil {
+		lastWarnings, wErr := getLastWarningsForObject(kp.conn, metadata, "Deployment", kp.warningEventLimit)
+		if wErr != nil {
+			return wErr
+		}
+		err = fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+		if last != nil {
+			err = withUnreadyPods(err, kp.conn, metadata.Namespace, last.Spec.Selector)
+		}
+		return err
+	}
+	return nil
+}
+
+// deploymentRolloutStatus reports whether the rollout of the deployment is complete, like
+// `kubectl rollout status`, or else what it's waiting for.
+func deploymentRolloutStatus(deployment *appsv1.Deployment) (bool, string, error) {
+	name := deployment.GetName()
+	// The conditions are stale until the controller observed the latest generation,
+	// e.g. the exceeded deadline of the rollout an apply just fixed
+	if deployment.Generation <= deployment.Status.ObservedGeneration {
+		// The rollout of a paused deployment doesn't progress until it's resumed
+		if deployment.Spec.Paused {
+			return true, "", nil
+		}
+		for _, c := range deployment.Status.Conditions {
+			if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+				return false, "", fmt.Errorf("Deployment %q exceeded its progress deadline: %s", name, c.Message)
+			}
+		}
+	}
+
+	var desiredReplicas int32 = 1
+	if deployment.Spec.Replicas != nil {
+		desiredReplicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	done, msg := rolloutStatus(name,
+		observedGenerationCheck(deployment.Generation, status.ObservedGeneration),
+		func() string {
+			if status.UpdatedReplicas < desiredReplicas {
+				return fmt.Sprintf("%d of %d replicas updated", status.UpdatedReplicas, desiredReplicas)
+			}
+			return ""
+		},
+		func() string {
+			if status.Replicas > status.UpdatedReplicas {
+				return fmt.Sprintf("%d old replicas pending termination", status.Replicas-status.UpdatedReplicas)
+			}
+			return ""
+		},
+		func() string {
+			if status.AvailableReplicas >= status.UpdatedReplicas {
+				return ""
+			}
+			if status.ReadyReplicas > status.AvailableReplicas && deployment.Spec.MinReadySeconds > 0 {
+				return fmt.Sprintf("%d of %d updated replicas available, %d ready replicas become available once ready for min_ready_seconds (%ds)",
+					status.AvailableReplicas, status.UpdatedReplicas, status.ReadyReplicas-status.AvailableReplicas,
+					deployment.Spec.MinReadySeconds)
+			}
+			return fmt.Sprintf("%d of %d updated replicas available", status.AvailableReplicas, status.UpdatedReplicas)
+		},
+	)
+	return done, msg, nil
+}
+
 func resourceKubernetesDeploymentStateUpgrader(
 	v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
 	if is.Empty() {
@@ -2,13 +2,20 @@ package kubernetes
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestAccKubernetesDeployment_minimal(t *testing.T) {
@@ -66,6 +73,7 @@ func TestAccKubernetesDeployment_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("kubernetes_deployment.test", "metadata.0.resource_version"),
 					resource.TestCheckResourceAttrSet("kubernetes_deployment.test", "metadata.0.self_link"),
 					resource.TestCheckResourceAttrSet("kubernetes_deployment.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttrPair("kubernetes_deployment.test", "status.0.observed_generation", "kubernetes_deployment.test", "metadata.0.generation"),
 					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.8"),
 					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.spec.0.container.0.name", "tf-acc-test"),
 				),
@@ -332,6 +340,231 @@ func TestAccKubernetesDeployment_with_empty_dir_volume(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesDeployment_progressDeadlineExceeded(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesDeploymentConfig_progressDeadline(name),
+				ExpectError: regexp.MustCompile("exceeded its progress deadline"),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeployment_restartTrigger(t *testing.T) {
+	var conf appsv1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentConfig_restartTrigger(name, "json", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					testAccCheckKubernetesDeploymentRestartedAt(&conf, "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "restart_trigger", "1"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "spec.0.template.0.metadata.0.annotations.%", "0"),
+				),
+			},
+			{
+				Config: testAccKubernetesDeploymentConfig_restartTrigger(name, "json", "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					testAccCheckKubernetesDeploymentRestartedAt(&conf, "2"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "restart_trigger", "2"),
+				),
+			},
+			{
+				Config: testAccKubernetesDeploymentConfig_restartTrigger(name, "strategic", "3"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentExists("kubernetes_deployment.test", &conf),
+					testAccCheckKubernetesDeploymentRestartedAt(&conf, "3"),
+					resource.TestCheckResourceAttr("kubernetes_deployment.test", "restart_trigger", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestDeploymentRolloutStatus(t *testing.T) {
+	replicas := int32(3)
+	cases := []struct {
+		Generation int64
+		Paused     bool
+		Status     appsv1.DeploymentStatus
+		Done       bool
+		Err        bool
+	}{
+		// Not observed yet
+		{2, false, appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}, false, false},
+		{2, false, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 1}, false, false},
+		// Old replicas are still terminating
+		{2, false, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 3, AvailableReplicas: 3}, false, false},
+		{2, false, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2}, false, false},
+		{2, false, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}, true, false},
+		{2, true, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 0}, true, false},
+		{2, false, appsv1.DeploymentStatus{
+			ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: "True"},
+				{Type: appsv1.DeploymentProgressing, Status: "False", Reason: "ProgressDeadlineExceeded", Message: `ReplicaSet "web-5d4f" has timed out progressing.`},
+			},
+		}, false, true},
+		// The exceeded deadline of the previous generation, the rollout an apply just fixed
+		{3, false, appsv1.DeploymentStatus{
+			ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Status: "False", Reason: "ProgressDeadlineExceeded", Message: `ReplicaSet "web-5d4f" has timed out progressing.`},
+			},
+		}, false, false},
+	}
+
+	for i, tc := range cases {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Generation: tc.Generation},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas, Paused: tc.Paused},
+			Status:     tc.Status,
+		}
+		done, msg, err := deploymentRolloutStatus(deployment)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: unexpected error: %v", i, err)
+		}
+		if done != tc.Done {
+			t.Fatalf("%d: expected done to be %t, given: %t (%s)", i, tc.Done, done, msg)
+		}
+		if !done && !tc.Err && msg == "" {
+			t.Fatalf("%d: expected a message about what the rollout is waiting for", i)
+		}
+	}
+}
+
+func TestDeploymentRolloutStatusMinReadySeconds(t *testing.T) {
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas, MinReadySeconds: 300},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 1},
+	}
+	done, msg, err := deploymentRolloutStatus(deployment)
+	if err != nil || done {
+		t.Fatalf("Expected the rollout to wait, given: %t, %v", done, err)
+	}
+	expected := "2 ready replicas become available once ready for min_ready_seconds (300s)"
+	if !strings.Contains(msg, expected) {
+		t.Fatalf("Expected the message to contain %q, given: %q", expected, msg)
+	}
+}
+
+func TestSetRestartTrigger(t *testing.T) {
+	cases := []struct {
+		Annotations map[string]string
+		Trigger     string
+		Expected    map[string]string
+	}{
+		{nil, "", nil},
+		{nil, "1", map[string]string{restartedAtAnnotation: "1"}},
+		{map[string]string{"a": "b"}, "1", map[string]string{"a": "b", restartedAtAnnotation: "1"}},
+		// The configured annotation wins
+		{map[string]string{restartedAtAnnotation: "2018-09-01T10:00:00Z"}, "1", map[string]string{restartedAtAnnotation: "2018-09-01T10:00:00Z"}},
+	}
+
+	for i, tc := range cases {
+		template := &v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: tc.Annotations}}
+		setRestartTrigger(template, tc.Trigger)
+		if !reflect.DeepEqual(template.Annotations, tc.Expected) {
+			t.Fatalf("%d: expected annotations %#v, given: %#v", i, tc.Expected, template.Annotations)
+		}
+	}
+}
+
+func TestPatchRestartTrigger(t *testing.T) {
+	cases := []struct {
+		Patch    string
+		Trigger  string
+		Expected string
+	}{
+		{`{}`, "1", `{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":"1"}}}}}`},
+		{`{}`, "", `{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":null}}}}}`},
+		{
+			`{"spec":{"replicas":2,"template":{"metadata":{"annotations":{"a":"b"}}}}}`, "2",
+			`{"spec":{"replicas":2,"template":{"metadata":{"annotations":{"a":"b","kubectl.kubernetes.io/restartedAt":"2"}}}}}`,
+		},
+	}
+
+	for i, tc := range cases {
+		patch, err := patchRestartTrigger([]byte(tc.Patch), tc.Trigger)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if string(patch) != tc.Expected {
+			t.Fatalf("%d: expected patch %s, given: %s", i, tc.Expected, patch)
+		}
+	}
+}
+
+func TestDeploymentReplicasOwnedElsewhere(t *testing.T) {
+	r := resourceKubernetesDeployment()
+	spec := map[string]interface{}{
+		"template": []interface{}{map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{"labels": map[string]interface{}{"app": "web"}}},
+		}},
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"spec": []interface{}{spec}})
+	if replicas := configuredDeploymentReplicas(d); replicas != nil {
+		t.Fatalf("Expected replicas to be left to the API server, given: %d", *replicas)
+	}
+	spec["replicas"] = 0
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"spec": []interface{}{spec}})
+	if replicas := configuredDeploymentReplicas(d); replicas == nil || *replicas != 0 {
+		t.Fatalf("Expected the deployment to be created without replicas, given: %v", replicas)
+	}
+
+	// Replicas scaled by an autoscaler don't show up in the plan unless they're configured
+	spec["replicas"] = 5
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "web"}},
+		"spec":     []interface{}{spec},
+	})
+	d.SetId("default/web")
+	state := d.State()
+	for _, tc := range []struct {
+		Replicas interface{}
+		Changed  bool
+	}{{nil, false}, {5, false}, {2, true}} {
+		s := map[string]interface{}{"template": spec["template"]}
+		if tc.Replicas != nil {
+			s["replicas"] = tc.Replicas
+		}
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"metadata": []map[string]interface{}{{"name": "web"}},
+			"spec":     []map[string]interface{}{s},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		diff, err := r.Diff(state, terraform.NewResourceConfig(raw), &kubernetesProvider{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		changed := false
+		if diff != nil {
+			_, changed = diff.Attributes["spec.0.replicas"]
+		}
+		if changed != tc.Changed {
+			t.Fatalf("Expected replicas %v to be changed: %t, given: %#v", tc.Replicas, tc.Changed, diff.Attributes["spec.0.replicas"])
+		}
+	}
+}
+
 func pause() resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		time.Sleep(1 * time.Minute)
@@ -384,6 +617,19 @@ func testAccCheckKubernetesDeploymentExists(n string, obj *appsv1.Deployment) re
 	}
 }
 
+func testAccCheckKubernetesDeploymentRestartedAt(obj *appsv1.Deployment, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if v := obj.Spec.Template.Annotations[restartedAtAnnotation]; v != expected {
+			return fmt.Errorf("Expected the pod template to be annotated with %s = %q, given: %q", restartedAtAnnotation, expected, v)
+		}
+		// The rollout of the restart was awaited
+		if obj.Status.UpdatedReplicas != *obj.Spec.Replicas || obj.Status.ObservedGeneration != obj.Generation {
+			return fmt.Errorf("Expected the rollout of generation %d to be complete, given status: %#v", obj.Generation, obj.Status)
+		}
+		return nil
+	}
+}
+
 func testAccKubernetesDeploymentConfig_minimal(name string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_deployment" "test" {
@@ -846,3 +1092,64 @@ resource "kubernetes_deployment" "test" {
 }
 `, depName)
 }
+
+func testAccKubernetesDeploymentConfig_progressDeadline(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    replicas                  = 1
+    progress_deadline_seconds = 10
+    selector {
+      foo = "bar"
+    }
+    template {
+      metadata {
+        labels {
+          foo = "bar"
+        }
+      }
+      spec {
+        container {
+          image = "tf-acc-test.invalid/does-not-exist:1.0"
+          name  = "tf-acc-test"
+        }
+      }
+    }
+  }
+}
+`, name)
+}
+
+func testAccKubernetesDeploymentConfig_restartTrigger(name, patchStrategy, trigger string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_deployment" "test" {
+  metadata {
+    name = "%s"
+  }
+  patch_strategy  = "%s"
+  restart_trigger = "%s"
+  spec {
+    replicas = 2
+    selector {
+      foo = "bar"
+    }
+    template {
+      metadata {
+        labels {
+          foo = "bar"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:1.7.8"
+          name  = "tf-acc-test"
+        }
+      }
+    }
+  }
+}
+`, name, patchStrategy, trigger)
+}
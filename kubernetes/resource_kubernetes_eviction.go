@@ -0,0 +1,126 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	policy "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+// resourceKubernetesEviction evicts a pod through its eviction subresource,
+// which respects the pod disruption budgets. It's an action: the eviction
+// happens on create, changing any argument (e.g. triggers) evicts again
+// and destroying the resource doesn't do anything.
+func resourceKubernetesEviction() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesEvictionCreate,
+		Read:   resourceKubernetesEvictionRead,
+		Delete: resourceKubernetesEvictionDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": {
+				Type:        schema.TypeList,
+				Description: "Metadata of the pod to evict.",
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Description:  "Name of the pod to evict.",
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateName,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the pod to evict.",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     "default",
+						},
+					},
+				},
+			},
+			"grace_period_seconds": podGracePeriodSchema(true),
+			"force":                forcePodDeletionSchema(true),
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values which evict the pod again when they change.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesEvictionCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	eviction := policy.Eviction{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      d.Get("metadata.0.name").(string),
+			Namespace: d.Get("metadata.0.namespace").(string),
+		},
+	}
+	if v, ok := d.GetOk("grace_period_seconds"); ok {
+		eviction.DeleteOptions = &meta_v1.DeleteOptions{
+			GracePeriodSeconds: ptrToInt64(int64(v.(int))),
+		}
+	}
+	if d.Get("force").(bool) {
+		eviction.DeleteOptions = forcePodDeletion(d, &meta_v1.DeleteOptions{})
+	}
+
+	log.Printf("[INFO] Evicting pod: %#v", eviction)
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), evictPodFunc(conn, &eviction))
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Pod %s/%s evicted", eviction.Namespace, eviction.Name)
+	d.SetId(buildId(eviction.ObjectMeta))
+
+	return resourceKubernetesEvictionRead(d, meta)
+}
+
+func resourceKubernetesEvictionRead(d *schema.ResourceData, meta interface{}) error {
+	// The eviction is a one-off action, there's nothing to read back
+	return nil
+}
+
+func resourceKubernetesEvictionDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// evictPodFunc retries evictions rejected with 429 Too Many Requests,
+// which is how the API server reports that a pod disruption budget
+// doesn't allow the pod to be disrupted at the moment.
+func evictPodFunc(conn *kubernetes.Clientset, eviction *policy.Eviction) resource.RetryFunc {
+	return func() *resource.RetryError {
+		err := conn.CoreV1().Pods(eviction.Namespace).Evict(eviction)
+		if err == nil {
+			return nil
+		}
+		if errors.IsTooManyRequests(err) {
+			log.Printf("[DEBUG] Eviction of pod %s/%s blocked: %s", eviction.Namespace, eviction.Name, err)
+			return resource.RetryableError(fmt.Errorf("Eviction of pod %s/%s is blocked by a pod disruption budget: %s",
+				eviction.Namespace, eviction.Name, err))
+		}
+		if errors.IsNotFound(err) {
+			return resource.NonRetryableError(fmt.Errorf("Pod %s/%s to evict not found", eviction.Namespace, eviction.Name))
+		}
+		return resource.NonRetryableError(err)
+	}
+}
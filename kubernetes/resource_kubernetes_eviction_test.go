@@ -0,0 +1,129 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	policy "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestAccKubernetesEviction_basic(t *testing.T) {
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesEvictionConfig_basic(podName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_eviction.test", "metadata.0.name", podName),
+					resource.TestCheckResourceAttr("kubernetes_eviction.test", "metadata.0.namespace", "default"),
+					resource.TestCheckResourceAttr("kubernetes_eviction.test", "force", "true"),
+					testAccCheckKubernetesPodEvicted("default", podName),
+				),
+				// The evicted pod is planned to be created again
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesPodEvicted(namespace, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+		pod, err := conn.CoreV1().Pods(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if pod.DeletionTimestamp == nil {
+			return fmt.Errorf("Pod %s/%s still exists and isn't terminating", namespace, name)
+		}
+		return nil
+	}
+}
+
+func TestEvictPodFunc(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/pods/web/eviction":
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "TooManyRequests", "code": 429,
+	"message": "Cannot evict pod as it would violate the pod's disruption budget."}`)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Success", "code": 201}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound", "code": 404}`)
+		}
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eviction := &policy.Eviction{ObjectMeta: meta_v1.ObjectMeta{Name: "web", Namespace: "default"}}
+	retryErr := evictPodFunc(conn, eviction)()
+	if retryErr == nil || !retryErr.Retryable {
+		t.Fatalf("Expected a retryable error when blocked by a disruption budget, given: %#v", retryErr)
+	}
+	if !strings.Contains(retryErr.Err.Error(), "disruption budget") {
+		t.Fatalf("Expected the error to mention the disruption budget, given: %s", retryErr.Err)
+	}
+	if retryErr := evictPodFunc(conn, eviction)(); retryErr != nil {
+		t.Fatalf("Expected the second eviction to succeed, given: %s", retryErr.Err)
+	}
+
+	missing := &policy.Eviction{ObjectMeta: meta_v1.ObjectMeta{Name: "missing", Namespace: "default"}}
+	retryErr = evictPodFunc(conn, missing)()
+	if retryErr == nil || retryErr.Retryable {
+		t.Fatalf("Expected a non-retryable error for a missing pod, given: %#v", retryErr)
+	}
+}
+
+func testAccKubernetesEvictionConfig_basic(podName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+	metadata {
+		name = "%s"
+	}
+
+	spec {
+		container {
+			image = "nginx:1.7.9"
+			name  = "containername"
+		}
+	}
+}
+
+resource "kubernetes_eviction" "test" {
+	metadata {
+		name      = "${kubernetes_pod.test.metadata.0.name}"
+		namespace = "${kubernetes_pod.test.metadata.0.namespace}"
+	}
+	force = true
+}
+`, podName)
+}
@@ -9,6 +9,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesHorizontalPodAutoscaler() *schema.Resource {
@@ -23,7 +24,9 @@ func resourceKubernetesHorizontalPodAutoscaler() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("horizontal pod autoscaler", true),
+			"metadata":             namespacedMetadataSchema("horizontal pod autoscaler", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Behaviour of the autoscaler. More info: https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#spec-and-status",
@@ -83,7 +86,7 @@ func resourceKubernetesHorizontalPodAutoscaler() *schema.Resource {
 func resourceKubernetesHorizontalPodAutoscalerCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	svc := api.HorizontalPodAutoscaler{
 		ObjectMeta: metadata,
 		Spec:       expandHorizontalPodAutoscalerSpec(d.Get("spec").([]interface{})),
@@ -110,11 +113,16 @@ func resourceKubernetesHorizontalPodAutoscalerRead(d *schema.ResourceData, meta
 	log.Printf("[INFO] Reading horizontal pod autoscaler %s", name)
 	svc, err := conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Get(name, meta_v1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Horizontal pod autoscaler %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received horizontal pod autoscaler: %#v", svc)
-	err = d.Set("metadata", flattenMetadata(svc.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(svc.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -137,7 +145,7 @@ func resourceKubernetesHorizontalPodAutoscalerUpdate(d *schema.ResourceData, met
 		return err
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadataWithDefaults(d, meta)
 	if d.HasChange("spec") {
 		diffOps := patchHorizontalPodAutoscalerSpec("spec.0.", "/spec", d)
 		ops = append(ops, diffOps...)
@@ -147,7 +155,7 @@ func resourceKubernetesHorizontalPodAutoscalerUpdate(d *schema.ResourceData, met
 		return fmt.Errorf("Failed to marshal update operations: %s", err)
 	}
 	log.Printf("[INFO] Updating horizontal pod autoscaler %q: %v", name, string(data))
-	out, err := conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	out, err := patchHorizontalPodAutoscalerOnLatest(conn, namespace, name, data)
 	if err != nil {
 		return fmt.Errorf("Failed to update horizontal pod autoscaler: %s", err)
 	}
@@ -165,7 +173,7 @@ func resourceKubernetesHorizontalPodAutoscalerDelete(d *schema.ResourceData, met
 		return err
 	}
 	log.Printf("[INFO] Deleting horizontal pod autoscaler: %#v", name)
-	err = conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Delete(name, &meta_v1.DeleteOptions{})
+	err = conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
@@ -194,3 +202,15 @@ func resourceKubernetesHorizontalPodAutoscalerExists(d *schema.ResourceData, met
 	}
 	return true, err
 }
+
+// patchHorizontalPodAutoscalerOnLatest applies the JSON patch data of an update to the latest version of the horizontal pod autoscaler, see patchOnLatest.
+func patchHorizontalPodAutoscalerOnLatest(conn *kubernetes.Clientset, namespace, name string, data []byte) (out *api.HorizontalPodAutoscaler, err error) {
+	client := conn.AutoscalingV1().HorizontalPodAutoscalers(namespace)
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (meta_v1.Object, error) {
+		return client.Get(name, meta_v1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, pkgApi.JSONPatchType, data)
+		return err
+	})
+	return out, err
+}
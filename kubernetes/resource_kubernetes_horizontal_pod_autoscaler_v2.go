@@ -0,0 +1,385 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	api "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+func resourceKubernetesHorizontalPodAutoscalerV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesHorizontalPodAutoscalerV2Create,
+		Read:   resourceKubernetesHorizontalPodAutoscalerV2Read,
+		Exists: resourceKubernetesHorizontalPodAutoscalerV2Exists,
+		Update: resourceKubernetesHorizontalPodAutoscalerV2Update,
+		Delete: resourceKubernetesHorizontalPodAutoscalerV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata":             namespacedMetadataSchema("horizontal pod autoscaler", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Behaviour of the autoscaler. More info: https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#spec-and-status",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_replicas": {
+							Type:        schema.TypeInt,
+							Description: "Upper limit for the number of pods that can be set by the autoscaler.",
+							Required:    true,
+						},
+						"metric": {
+							Type:        schema.TypeList,
+							Description: "The specifications for which to use to calculate the desired replica count. The desired replica count is calculated by taking the maximum across all metrics.",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: metricSpecFields(),
+							},
+						},
+						"min_replicas": {
+							Type:        schema.TypeInt,
+							Description: "Lower limit for the number of pods that can be set by the autoscaler, defaults to `1`.",
+							Optional:    true,
+							Default:     1,
+						},
+						"scale_target_ref": {
+							Type:        schema.TypeList,
+							Description: "Reference to scaled resource. e.g. Replication Controller",
+							Required:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: crossVersionObjectReferenceFields(),
+							},
+						},
+					},
+				},
+			},
+			"status": {
+				Type:        schema.TypeList,
+				Description: "Current information about the autoscaler, as observed by the controller.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"current_replicas": {
+							Type:        schema.TypeInt,
+							Description: "Current number of replicas of pods managed by this autoscaler.",
+							Computed:    true,
+						},
+						"desired_replicas": {
+							Type:        schema.TypeInt,
+							Description: "Desired number of replicas of pods managed by this autoscaler.",
+							Computed:    true,
+						},
+						"last_scale_time": {
+							Type:        schema.TypeString,
+							Description: "Last time the autoscaler scaled the number of pods, in RFC3339 format.",
+							Computed:    true,
+						},
+						"observed_generation": {
+							Type:        schema.TypeInt,
+							Description: "Most recent generation observed by this autoscaler.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func metricSpecFields() map[string]*schema.Schema {
+	quantity := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:             schema.TypeString,
+			Description:      description,
+			Optional:         true,
+			ValidateFunc:     validateResourceQuantity,
+			DiffSuppressFunc: suppressEquivalentResourceQuantity,
+		}
+	}
+
+	return map[string]*schema.Schema{
+		"type": {
+			Type:         schema.TypeString,
+			Description:  "The type of metric source. Valid options are `Object`, `Pods`, `Resource` and `External`, the block of the same name must be set.",
+			Required:     true,
+			ValidateFunc: validateAttributeValueIsIn([]string{"Object", "Pods", "Resource", "External"}),
+		},
+		"external": {
+			Type:        schema.TypeList,
+			Description: "A global metric that is not associated with any Kubernetes object.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"metric_name": {
+						Type:        schema.TypeString,
+						Description: "Name of the metric in question.",
+						Required:    true,
+					},
+					"metric_selector": {
+						Type:        schema.TypeList,
+						Description: "Label selector for the metric, used to narrow down the returned series. When unset, just the metric_name will be used to gather metrics.",
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: labelSelectorFields(),
+						},
+					},
+					"target_average_value": quantity("Target per-pod value of the global metric. Conflicts with `target_value`."),
+					"target_value":         quantity("Target value of the metric. Conflicts with `target_average_value`."),
+				},
+			},
+		},
+		"object": {
+			Type:        schema.TypeList,
+			Description: "A metric describing a single Kubernetes object, e.g. hits-per-second on an Ingress object.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"metric_name": {
+						Type:        schema.TypeString,
+						Description: "Name of the metric in question.",
+						Required:    true,
+					},
+					"target": {
+						Type:        schema.TypeList,
+						Description: "The described Kubernetes object.",
+						Required:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: crossVersionObjectReferenceFields(),
+						},
+					},
+					"target_value": {
+						Type:             schema.TypeString,
+						Description:      "Target value of the metric.",
+						Required:         true,
+						ValidateFunc:     validateResourceQuantity,
+						DiffSuppressFunc: suppressEquivalentResourceQuantity,
+					},
+				},
+			},
+		},
+		"pods": {
+			Type:        schema.TypeList,
+			Description: "A metric describing each pod in the current scale target, e.g. transactions-processed-per-second. The values will be averaged together before being compared to the target value.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"metric_name": {
+						Type:        schema.TypeString,
+						Description: "Name of the metric in question.",
+						Required:    true,
+					},
+					"target_average_value": {
+						Type:             schema.TypeString,
+						Description:      "Target value of the average of the metric across all relevant pods.",
+						Required:         true,
+						ValidateFunc:     validateResourceQuantity,
+						DiffSuppressFunc: suppressEquivalentResourceQuantity,
+					},
+				},
+			},
+		},
+		"resource": {
+			Type:        schema.TypeList,
+			Description: "A resource metric (such as CPU or memory) known to Kubernetes, as specified in requests and limits, describing each pod in the current scale target.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Description: "Name of the resource in question, e.g. `cpu` or `memory`.",
+						Required:    true,
+					},
+					"target_average_utilization": {
+						Type:         schema.TypeInt,
+						Description:  "Target value of the average of the resource metric across all relevant pods, represented as a percentage of the requested value of the resource for the pods. Conflicts with `target_average_value`.",
+						Optional:     true,
+						ValidateFunc: validatePositiveInteger,
+					},
+					"target_average_value": quantity("Target value of the average of the resource metric across all relevant pods, as a raw value. Conflicts with `target_average_utilization`."),
+				},
+			},
+		},
+	}
+}
+
+func crossVersionObjectReferenceFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"api_version": {
+			Type:        schema.TypeString,
+			Description: "API version of the referent",
+			Optional:    true,
+		},
+		"kind": {
+			Type:        schema.TypeString,
+			Description: "Kind of the referent. e.g. `ReplicationController`. More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#types-kinds",
+			Required:    true,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
+			Required:    true,
+		},
+	}
+}
+
+func resourceKubernetesHorizontalPodAutoscalerV2Create(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
+	spec, err := expandHorizontalPodAutoscalerV2Spec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return err
+	}
+	hpa := api.HorizontalPodAutoscaler{
+		ObjectMeta: metadata,
+		Spec:       spec,
+	}
+	log.Printf("[INFO] Creating new horizontal pod autoscaler: %#v", hpa)
+	out, err := conn.AutoscalingV2beta1().HorizontalPodAutoscalers(metadata.Namespace).Create(&hpa)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Submitted new horizontal pod autoscaler: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesHorizontalPodAutoscalerV2Read(d, meta)
+}
+
+func resourceKubernetesHorizontalPodAutoscalerV2Read(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Reading horizontal pod autoscaler %s", name)
+	hpa, err := conn.AutoscalingV2beta1().HorizontalPodAutoscalers(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Horizontal pod autoscaler %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received horizontal pod autoscaler: %#v", hpa)
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(hpa.ObjectMeta, d, meta))
+	if err != nil {
+		return err
+	}
+
+	flattened := flattenHorizontalPodAutoscalerV2Spec(hpa.Spec)
+	log.Printf("[DEBUG] Flattened horizontal pod autoscaler spec: %#v", flattened)
+	err = d.Set("spec", flattened)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("status", flattenHorizontalPodAutoscalerV2Status(hpa.Status))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesHorizontalPodAutoscalerV2Update(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ops := patchMetadataWithDefaults(d, meta)
+	if d.HasChange("spec") {
+		diffOps, err := patchHorizontalPodAutoscalerV2Spec("spec.0.", "/spec", d)
+		if err != nil {
+			return err
+		}
+		ops = append(ops, diffOps...)
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating horizontal pod autoscaler %q: %v", name, string(data))
+	out, err := patchHorizontalPodAutoscalerV2OnLatest(conn, namespace, name, data)
+	if err != nil {
+		return fmt.Errorf("Failed to update horizontal pod autoscaler: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated horizontal pod autoscaler: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesHorizontalPodAutoscalerV2Read(d, meta)
+}
+
+func resourceKubernetesHorizontalPodAutoscalerV2Delete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Deleting horizontal pod autoscaler: %#v", name)
+	err = conn.AutoscalingV2beta1().HorizontalPodAutoscalers(namespace).Delete(name, deleteOptions(d, ""))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Horizontal Pod Autoscaler %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesHorizontalPodAutoscalerV2Exists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking horizontal pod autoscaler %s", name)
+	_, err = conn.AutoscalingV2beta1().HorizontalPodAutoscalers(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+// patchHorizontalPodAutoscalerV2OnLatest applies the JSON patch data of an update to the latest version of the horizontal pod autoscaler, see patchOnLatest.
+func patchHorizontalPodAutoscalerV2OnLatest(conn *kubernetes.Clientset, namespace, name string, data []byte) (out *api.HorizontalPodAutoscaler, err error) {
+	client := conn.AutoscalingV2beta1().HorizontalPodAutoscalers(namespace)
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (meta_v1.Object, error) {
+		return client.Get(name, meta_v1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, pkgApi.JSONPatchType, data)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,178 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	api "k8s.io/api/autoscaling/v2beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesHorizontalPodAutoscalerV2_basic(t *testing.T) {
+	var conf api.HorizontalPodAutoscaler
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_horizontal_pod_autoscaler_v2.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesHorizontalPodAutoscalerV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesHorizontalPodAutoscalerV2Config_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesHorizontalPodAutoscalerV2Exists("kubernetes_horizontal_pod_autoscaler_v2.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "spec.0.max_replicas", "10"),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "spec.0.min_replicas", "1"),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "spec.0.metric.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "spec.0.metric.0.type", "Resource"),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "spec.0.metric.0.resource.0.name", "cpu"),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "spec.0.metric.0.resource.0.target_average_utilization", "50"),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "status.#", "1"),
+				),
+			},
+			{
+				Config: testAccKubernetesHorizontalPodAutoscalerV2Config_metricsModified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesHorizontalPodAutoscalerV2Exists("kubernetes_horizontal_pod_autoscaler_v2.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "spec.0.max_replicas", "5"),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "spec.0.metric.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "spec.0.metric.0.resource.0.target_average_utilization", "70"),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "spec.0.metric.1.type", "Pods"),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "spec.0.metric.1.pods.0.metric_name", "packets-per-second"),
+					resource.TestCheckResourceAttr("kubernetes_horizontal_pod_autoscaler_v2.test", "spec.0.metric.1.pods.0.target_average_value", "1k"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesHorizontalPodAutoscalerV2_importBasic(t *testing.T) {
+	resourceName := "kubernetes_horizontal_pod_autoscaler_v2.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesHorizontalPodAutoscalerV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesHorizontalPodAutoscalerV2Config_basic(name),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesHorizontalPodAutoscalerV2Destroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_horizontal_pod_autoscaler_v2" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.AutoscalingV2beta1().HorizontalPodAutoscalers(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Horizontal Pod Autoscaler still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesHorizontalPodAutoscalerV2Exists(n string, obj *api.HorizontalPodAutoscaler) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.AutoscalingV2beta1().HorizontalPodAutoscalers(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesHorizontalPodAutoscalerV2Config_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_horizontal_pod_autoscaler_v2" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		max_replicas = 10
+		scale_target_ref {
+			api_version = "apps/v1"
+			kind        = "Deployment"
+			name        = "TerraformAccTest"
+		}
+		metric {
+			type = "Resource"
+			resource {
+				name                       = "cpu"
+				target_average_utilization = 50
+			}
+		}
+	}
+}
+`, name)
+}
+
+func testAccKubernetesHorizontalPodAutoscalerV2Config_metricsModified(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_horizontal_pod_autoscaler_v2" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		max_replicas = 5
+		scale_target_ref {
+			api_version = "apps/v1"
+			kind        = "Deployment"
+			name        = "TerraformAccTest"
+		}
+		metric {
+			type = "Resource"
+			resource {
+				name                       = "cpu"
+				target_average_utilization = 70
+			}
+		}
+		metric {
+			type = "Pods"
+			pods {
+				metric_name          = "packets-per-second"
+				target_average_value = "1k"
+			}
+		}
+	}
+}
+`, name)
+}
@@ -23,7 +23,9 @@ func resourceKubernetesIngress() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("ingress", true),
+			"metadata":             namespacedMetadataSchema("ingress", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the behavior of an ingress. https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#spec-and-status",
@@ -117,7 +119,7 @@ func resourceKubernetesIngress() *schema.Resource {
 func resourceKubernetesIngressCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	ing := &v1beta1.Ingress{
 		Spec: expandIngressSpec(d.Get("spec").([]interface{})),
 	}
@@ -144,11 +146,16 @@ func resourceKubernetesIngressRead(d *schema.ResourceData, meta interface{}) err
 	log.Printf("[INFO] Reading ingress %s", name)
 	ing, err := conn.ExtensionsV1beta1().Ingresses(namespace).Get(name, meta_v1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Ingress %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received ingress: %#v", ing)
-	err = d.Set("metadata", flattenMetadata(ing.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(ing.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -176,7 +183,7 @@ func resourceKubernetesIngressUpdate(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec := expandIngressSpec(d.Get("spec").([]interface{}))
 
 	if metadata.Namespace == "" {
@@ -206,7 +213,7 @@ func resourceKubernetesIngressDelete(d *schema.ResourceData, meta interface{}) e
 	}
 
 	log.Printf("[INFO] Deleting ingress: %#v", name)
-	err = conn.ExtensionsV1beta1().Ingresses(namespace).Delete(name, &meta_v1.DeleteOptions{})
+	err = conn.ExtensionsV1beta1().Ingresses(namespace).Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
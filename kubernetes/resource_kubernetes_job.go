@@ -1,6 +1,7 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
@@ -8,23 +9,69 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesJob() *schema.Resource {
 	s := &schema.Resource{
 		Create: resourceKubernetesJobCreate,
 		Read:   resourceKubernetesJobRead,
-		// Update: resourceKubernetesJobUpdate,
+		Update: resourceKubernetesJobUpdate,
 		Delete: resourceKubernetesJobDelete,
 		Exists: resourceKubernetesJobExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateWithDefaults(map[string]interface{}{"wait_for_completion": false}),
+		},
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			if err := checkJobSpec(diff); err != nil {
+				return err
+			}
+			if err := checkVolumeMountsReferenceVolumes(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkProjectedVolumeSources(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkProbes(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkTolerations(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkDNSConfig(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			// Without a manual selector, the selector & its label are generated
+			if !diff.Get("spec.0.manual_selector").(bool) {
+				return nil
+			}
+			return checkSelectorMatchesTemplateLabels(diff, "job", "spec.0.selector.0.match_labels", "spec.0.template.0.metadata.0.labels")
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("job", true),
+			"metadata":             namespacedMetadataSchema("job", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			// The API server orphans the pods of a job by default, they'd keep running after a restart_trigger
+			"propagation_policy": deletePropagationPolicySchema(metav1.DeletePropagationBackground),
+			"restart_trigger": {
+				Type:        schema.TypeString,
+				Description: "Arbitrary value whose change re-creates the job, i.e. runs it again. Combine with `metadata.generate_name` to give each run a fresh name.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Description: "Whether to wait for the job to complete on create. Fails if the job fails.",
+				Optional:    true,
+				Default:     false,
+			},
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec of the job owned by the cluster",
@@ -32,7 +79,7 @@ func resourceKubernetesJob() *schema.Resource {
 				ForceNew:    true,
 				MaxItems:    1,
 				Elem: &schema.Resource{
-					Schema: jobSpecFields(),
+					Schema: jobResourceSpecFields(),
 				},
 			},
 		},
@@ -44,11 +91,12 @@ func resourceKubernetesJob() *schema.Resource {
 func resourceKubernetesJobCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec, err := expandJobSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return err
 	}
+	clearUnsetSecurityContextFields(d, "spec.0.template.0.spec.0.", &spec.Template.Spec)
 
 	job := batchv1.Job{
 		ObjectMeta: metadata,
@@ -57,7 +105,7 @@ func resourceKubernetesJobCreate(d *schema.ResourceData, meta interface{}) error
 
 	log.Printf("[INFO] Creating new job: %#v", job)
 
-	out, err := conn.BatchV1().Jobs(metadata.Namespace).Create(&job)
+	out, err := createJob(conn, &job, expandJobSpecExtras(d.Get("spec").([]interface{})))
 	if err != nil {
 		return err
 	}
@@ -65,9 +113,118 @@ func resourceKubernetesJobCreate(d *schema.ResourceData, meta interface{}) error
 
 	d.SetId(buildId(out.ObjectMeta))
 
+	if d.Get("wait_for_completion").(bool) {
+		log.Printf("[DEBUG] Waiting for job %s to complete", d.Id())
+
+		stateConf := &resource.StateChangeConf{
+			Target:  []string{"Complete"},
+			Pending: []string{"Running"},
+			Timeout: d.Timeout(schema.TimeoutCreate),
+			Refresh: func() (interface{}, string, error) {
+				job, err := conn.BatchV1().Jobs(out.Namespace).Get(out.Name, metav1.GetOptions{})
+				if err != nil {
+					log.Printf("[ERROR] Received error: %#v", err)
+					return job, "Error", err
+				}
+				state, err := jobCompletionState(job)
+				log.Printf("[DEBUG] Job %s is %s", d.Id(), state)
+				return job, state, err
+			},
+		}
+		_, err = stateConf.WaitForState()
+		if err != nil {
+			lastWarnings, wErr := getLastWarningsForObject(conn, out.ObjectMeta, "Job", meta.(*kubernetesProvider).warningEventLimit)
+			if wErr != nil {
+				return wErr
+			}
+			err = fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+			return withUnreadyPods(err, conn, out.Namespace, out.Spec.Selector)
+		}
+	}
+
 	return resourceKubernetesJobRead(d, meta)
 }
 
+// createJob creates the job through the vendored types, unless it uses
+// fields they don't have.
+func createJob(conn *kubernetes.Clientset, job *batchv1.Job, extras jobSpecExtras) (*batchv1.Job, error) {
+	if extras == (jobSpecExtras{}) {
+		return conn.BatchV1().Jobs(job.Namespace).Create(job)
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	var body map[string]interface{}
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		return nil, err
+	}
+	spec := body["spec"].(map[string]interface{})
+	if extras.CompletionMode != "" {
+		spec["completionMode"] = extras.CompletionMode
+	}
+	if extras.Suspend != nil {
+		spec["suspend"] = *extras.Suspend
+	}
+	data, err = json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := conn.BatchV1().RESTClient().Post().Namespace(job.Namespace).Resource("jobs").Body(data).DoRaw()
+	if err != nil {
+		return nil, err
+	}
+	out, _, err := decodeJob(raw)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode job %s: %s", buildId(job.ObjectMeta), err)
+	}
+	return out, nil
+}
+
+// maxIndexedJobCompletions is the limit of the API server on the
+// completions & the parallelism of an Indexed job.
+const maxIndexedJobCompletions = 100000
+
+// checkJobSpec fails the plan of a job the API server would reject,
+// or which the provider would wait for forever.
+func checkJobSpec(d *schema.ResourceDiff) error {
+	// An Indexed job always has a completions count, it defaults to 1 and must be positive
+	if d.Get("spec.0.completion_mode").(string) == jobCompletionModeIndexed {
+		// Unknown counts read as 0
+		for _, k := range []string{"completions", "parallelism"} {
+			if v := d.Get("spec.0." + k).(int); v > maxIndexedJobCompletions {
+				return fmt.Errorf("spec.0.%s of an Indexed job must be at most %d, given: %d", k, maxIndexedJobCompletions, v)
+			}
+		}
+	}
+
+	// A suspended job doesn't run, waiting for it is only done on create
+	if d.Id() == "" && d.Get("wait_for_completion").(bool) && d.Get("spec.0.suspend").(bool) {
+		return fmt.Errorf("wait_for_completion can't be set on a job created with spec.0.suspend = true, it wouldn't complete")
+	}
+	return nil
+}
+
+// jobCompletionState reports whether the job is still running or has completed.
+// A failed job is returned as an error, so waiting for it stops right away.
+func jobCompletionState(job *batchv1.Job) (string, error) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return "Complete", nil
+		case batchv1.JobFailed:
+			return "Failed", fmt.Errorf("Job %s/%s failed: %s", job.Namespace, job.Name, c.Message)
+		}
+	}
+	return "Running", nil
+}
+
 func resourceKubernetesJobUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -76,23 +233,31 @@ func resourceKubernetesJobUpdate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadataWithDefaults(d, meta)
 
 	if d.HasChange("spec") {
-		// specOps, err := patchJobSpec("/spec", "spec.0.", d)
-		// if err != nil {
-		// 	return err
-		// }
-		// ops = append(ops, specOps...)
-
 		spec, err := expandJobSpec(d.Get("spec").([]interface{}))
 		if err != nil {
 			return err
 		}
+		clearUnsetSecurityContextFields(d, "spec.0.template.0.spec.0.", &spec.Template.Spec)
 		ops = append(ops, &ReplaceOperation{
 			Path:  "/spec",
 			Value: spec,
 		})
+		// The replaced spec drops the fields the vendored types don't have, the
+		// completion mode can't change though, so it's sent again as it is
+		extras := expandJobSpecExtras(d.Get("spec").([]interface{}))
+		if extras.CompletionMode != "" {
+			ops = append(ops, &AddOperation{
+				Path:  "/spec/completionMode",
+				Value: extras.CompletionMode,
+			})
+		}
+		ops = append(ops, &AddOperation{
+			Path:  "/spec/suspend",
+			Value: d.Get("spec.0.suspend").(bool),
+		})
 	}
 
 	data, err := ops.MarshalJSON()
@@ -100,22 +265,9 @@ func resourceKubernetesJobUpdate(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Failed to marshal update operations: %s", err)
 	}
 
-	// metadata := expandMetadata(d.Get("metadata").([]interface{}))
-	// spec, err := expandJobSpec(d.Get("spec").([]interface{}))
-	// if err != nil {
-	// 	return err
-	// }
-	// spec.Template.ObjectMeta.Annotations = metadata.Annotations
-
-	// job := batchv1.Job{
-	// 	ObjectMeta: metadata,
-	// 	Spec:       spec,
-	// }
-
 	log.Printf("[INFO] Updating job %s: %#v", d.Id(), ops)
 
-	out, err := conn.BatchV1().Jobs(namespace).Patch(name, pkgApi.JSONPatchType, data)
-	// out, err := conn.BatchV1().Jobs(namespace).Update(&job)
+	out, err := patchJobOnLatest(conn, namespace, name, data)
 	if err != nil {
 		return err
 	}
@@ -134,13 +286,28 @@ func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	log.Printf("[INFO] Reading job %s", name)
-	job, err := conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
+	// The job is read raw to get at spec fields the vendored types don't have
+	raw, err := conn.BatchV1().RESTClient().Get().Namespace(namespace).Resource("jobs").Name(name).DoRaw()
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Job %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
+	job, extras, err := decodeJob(raw)
+	if err != nil {
+		return fmt.Errorf("Failed to decode job %s: %s", d.Id(), err)
+	}
 	log.Printf("[INFO] Received job: %#v", job)
 
+	err = checkControllerOwner("Job", job.ObjectMeta, meta)
+	if err != nil {
+		return err
+	}
+
 	// Remove server-generated labels unless using manual selector
 	if _, ok := d.GetOk("spec.0.manual_selector"); !ok {
 		labels := job.ObjectMeta.Labels
@@ -165,7 +332,7 @@ func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
 		expandMetadata(d.Get("metadata").([]interface{})),
 		expandMetadata(d.Get("spec.0.template.0.metadata").([]interface{})),
 	)
-	err = d.Set("metadata", flattenMetadata(job.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(job.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -174,6 +341,7 @@ func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return err
 	}
+	flattenJobSpecExtras(extras.Spec, jobSpec)
 
 	err = d.Set("spec", jobSpec)
 	if err != nil {
@@ -192,12 +360,12 @@ func resourceKubernetesJobDelete(d *schema.ResourceData, meta interface{}) error
 	}
 
 	log.Printf("[INFO] Deleting job: %#v", name)
-	err = conn.BatchV1().Jobs(namespace).Delete(name, nil)
+	err = conn.BatchV1().Jobs(namespace).Delete(name, deleteOptions(d, metav1.DeletePropagationBackground))
 	if err != nil {
 		return err
 	}
 
-	err = resource.Retry(1*time.Minute, func() *resource.RetryError {
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		_, err := conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
 		if err != nil {
 			if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
@@ -237,3 +405,15 @@ func resourceKubernetesJobExists(d *schema.ResourceData, meta interface{}) (bool
 	}
 	return true, err
 }
+
+// patchJobOnLatest applies the JSON patch data of an update to the latest version of the job, see patchOnLatest.
+func patchJobOnLatest(conn *kubernetes.Clientset, namespace, name string, data []byte) (out *batchv1.Job, err error) {
+	client := conn.BatchV1().Jobs(namespace)
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (metav1.Object, error) {
+		return client.Get(name, metav1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, pkgApi.JSONPatchType, data)
+		return err
+	})
+	return out, err
+}
@@ -1,14 +1,23 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	api "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
 )
 
 func TestAccKubernetesJob_basic(t *testing.T) {
@@ -53,6 +62,256 @@ func TestAccKubernetesJob_basic(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesJob_restartTrigger(t *testing.T) {
+	var first, second api.Job
+	prefix := fmt.Sprintf("tf-acc-test-%s-", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_restartTrigger(prefix, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &first),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "restart_trigger", "1"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "wait_for_completion", "true"),
+				),
+			},
+			{
+				Config: testAccKubernetesJobConfig_restartTrigger(prefix, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &second),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "restart_trigger", "2"),
+					func(s *terraform.State) error {
+						if first.Name == second.Name {
+							return fmt.Errorf("Expected the job to be re-created with a new name, given: %s", second.Name)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_indexedSuspended(t *testing.T) {
+	var conf api.Job
+	var uid string
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_indexed(name, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.completion_mode", "Indexed"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.completions", "3"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.suspend", "true"),
+					func(s *terraform.State) error {
+						uid = string(conf.UID)
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccKubernetesJobConfig_indexed(name, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.completion_mode", "Indexed"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.suspend", "false"),
+					func(s *terraform.State) error {
+						if string(conf.UID) != uid {
+							return fmt.Errorf("Expected the job to be resumed in place, given a new job %s", conf.UID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestCreateJob(t *testing.T) {
+	var given map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/apis/batch/v1/namespaces/default/jobs" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&given); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"kind": "Job", "apiVersion": "batch/v1",
+	"metadata": {"name": "work", "namespace": "default"},
+	"spec": {"completions": 3, "completionMode": "Indexed", "suspend": true}}`)
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := api.Job{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "work", Namespace: "default"},
+		Spec:       api.JobSpec{Completions: ptrToInt32(3)},
+	}
+	extras := jobSpecExtras{CompletionMode: jobCompletionModeIndexed, Suspend: ptrToBool(true)}
+	out, err := createJob(conn, &job, extras)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := given["spec"].(map[string]interface{})
+	if spec["completionMode"] != "Indexed" || spec["suspend"] != true || spec["completions"] != float64(3) {
+		t.Fatalf("Unexpected spec sent: %#v", spec)
+	}
+	if out.Name != "work" || *out.Spec.Completions != 3 {
+		t.Fatalf("Unexpected job returned: %#v", out)
+	}
+}
+
+func TestFlattenJobSpecExtras(t *testing.T) {
+	cases := []struct {
+		Raw                    string
+		ExpectedCompletionMode string
+		ExpectedSuspend        bool
+	}{
+		// Returned by clusters older than 1.21
+		{`{"spec": {}}`, "NonIndexed", false},
+		{`{"spec": {"completionMode": "NonIndexed", "suspend": false}}`, "NonIndexed", false},
+		{`{"spec": {"completionMode": "Indexed", "suspend": true}}`, "Indexed", true},
+	}
+
+	for i, tc := range cases {
+		_, extras, err := decodeJob([]byte(tc.Raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec := []interface{}{map[string]interface{}{}}
+		flattenJobSpecExtras(extras.Spec, spec)
+		att := spec[0].(map[string]interface{})
+		if att["completion_mode"] != tc.ExpectedCompletionMode || att["suspend"] != tc.ExpectedSuspend {
+			t.Fatalf("%d: Expected %s & suspend %t, given: %#v", i, tc.ExpectedCompletionMode, tc.ExpectedSuspend, att)
+		}
+
+		// The defaults are left out, so such a job is created through the vendored types
+		if expanded := expandJobSpecExtras(spec); tc.ExpectedCompletionMode == "NonIndexed" && !tc.ExpectedSuspend && expanded != (jobSpecExtras{}) {
+			t.Fatalf("%d: Expected no extras for the defaults, given: %#v", i, expanded)
+		}
+	}
+}
+
+func TestJobSpecDiff(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Spec          map[string]interface{}
+		Wait          bool
+		ExpectedError string
+	}{
+		{"indexed", map[string]interface{}{"completion_mode": "Indexed", "completions": 5}, false, ""},
+		{"too many completions", map[string]interface{}{"completion_mode": "Indexed", "completions": 100001}, false, "spec.0.completions of an Indexed job must be at most 100000"},
+		{"many non-indexed completions", map[string]interface{}{"completions": 100001}, false, ""},
+		{"invalid mode", map[string]interface{}{"completion_mode": "Sequential"}, false, "completion_mode"},
+		{"suspended", map[string]interface{}{"suspend": true}, false, ""},
+		{"waiting for a suspended job", map[string]interface{}{"suspend": true}, true, "wait_for_completion can't be set"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			spec := tc.Spec
+			spec["template"] = []map[string]interface{}{{
+				"spec": []map[string]interface{}{{
+					"container": []map[string]interface{}{{"name": "work", "image": "alpine"}},
+				}},
+			}}
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata":            []map[string]interface{}{{"name": "work"}},
+				"wait_for_completion": tc.Wait,
+				"spec":                []map[string]interface{}{spec},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := resourceKubernetesJob()
+			c := terraform.NewResourceConfig(raw)
+			_, err = r.Diff(nil, c, &kubernetesProvider{})
+			if err == nil {
+				// Validation isn't part of the diff
+				_, es := r.Validate(c)
+				if len(es) > 0 {
+					err = es[0]
+				}
+			}
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestJobCompletionState(t *testing.T) {
+	cases := []struct {
+		Conditions    []api.JobCondition
+		ExpectedState string
+		ExpectedError bool
+	}{
+		{nil, "Running", false},
+		{[]api.JobCondition{{Type: api.JobComplete, Status: corev1.ConditionFalse}}, "Running", false},
+		{[]api.JobCondition{{Type: api.JobComplete, Status: corev1.ConditionTrue}}, "Complete", false},
+		{[]api.JobCondition{{Type: api.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}, "Failed", true},
+	}
+
+	for i, tc := range cases {
+		job := &api.Job{Status: api.JobStatus{Conditions: tc.Conditions}}
+		state, err := jobCompletionState(job)
+		if state != tc.ExpectedState {
+			t.Fatalf("%d: Expected state %q, given: %q", i, tc.ExpectedState, state)
+		}
+		if (err != nil) != tc.ExpectedError {
+			t.Fatalf("%d: Expected error: %t, given: %v", i, tc.ExpectedError, err)
+		}
+	}
+}
+
+func TestJobSpecRestartPolicy(t *testing.T) {
+	restartPolicy := jobSpecFields()["template"].Elem.(*schema.Resource).
+		Schema["spec"].Elem.(*schema.Resource).
+		Schema["restart_policy"]
+
+	if restartPolicy.Default != "OnFailure" {
+		t.Fatalf("Expected the restart policy of jobs to default to OnFailure, given: %v", restartPolicy.Default)
+	}
+	for _, policy := range []string{"OnFailure", "Never"} {
+		if _, es := restartPolicy.ValidateFunc(policy, "restart_policy"); len(es) > 0 {
+			t.Fatalf("Expected restart policy %q to be valid, given: %v", policy, es)
+		}
+	}
+	if _, es := restartPolicy.ValidateFunc("Always", "restart_policy"); len(es) == 0 {
+		t.Fatal("Expected restart policy Always to be invalid for jobs")
+	}
+
+	// The pod spec shared with the other resources isn't restricted
+	podRestartPolicy := podSpecFields(false)["restart_policy"]
+	if _, es := podRestartPolicy.ValidateFunc("Always", "restart_policy"); len(es) > 0 {
+		t.Fatalf("Expected restart policy Always to be valid for pods, given: %v", es)
+	}
+}
+
 func testAccCheckKubernetesJobDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*kubernetesProvider).conn
 
@@ -149,3 +408,50 @@ resource "kubernetes_job" "test" {
 	}
 }`, name)
 }
+
+func testAccKubernetesJobConfig_restartTrigger(prefix, trigger string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		generate_name = "%s"
+	}
+	restart_trigger = "%s"
+	wait_for_completion = true
+	spec {
+		template {
+			spec {
+				container {
+					name = "hello"
+					image = "alpine"
+					command = ["echo", "'hello'"]
+				}
+				restart_policy = "Never"
+			}
+		}
+	}
+}`, prefix, trigger)
+}
+
+func testAccKubernetesJobConfig_indexed(name string, suspend bool) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		completion_mode = "Indexed"
+		completions = 3
+		parallelism = 3
+		suspend = %t
+		template {
+			spec {
+				container {
+					name = "hello"
+					image = "alpine"
+					command = ["sh", "-c", "echo $JOB_COMPLETION_INDEX"]
+				}
+			}
+		}
+	}
+}`, name, suspend)
+}
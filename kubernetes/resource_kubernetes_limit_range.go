@@ -9,6 +9,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesLimitRange() *schema.Resource {
@@ -23,7 +24,9 @@ func resourceKubernetesLimitRange() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("limit range", true),
+			"metadata":             namespacedMetadataSchema("limit range", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the limits enforced. More info: https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#spec-and-status",
@@ -81,7 +84,7 @@ func resourceKubernetesLimitRange() *schema.Resource {
 func resourceKubernetesLimitRangeCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec, err := expandLimitRangeSpec(d.Get("spec").([]interface{}), d.IsNewResource())
 	if err != nil {
 		return err
@@ -111,12 +114,17 @@ func resourceKubernetesLimitRangeRead(d *schema.ResourceData, meta interface{})
 	log.Printf("[INFO] Reading limit range %s", name)
 	limitRange, err := conn.CoreV1().LimitRanges(namespace).Get(name, meta_v1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Limit range %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received limit range: %#v", limitRange)
 
-	err = d.Set("metadata", flattenMetadata(limitRange.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(limitRange.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -136,7 +144,7 @@ func resourceKubernetesLimitRangeUpdate(d *schema.ResourceData, meta interface{}
 		return err
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadataWithDefaults(d, meta)
 	if d.HasChange("spec") {
 		spec, err := expandLimitRangeSpec(d.Get("spec").([]interface{}), d.IsNewResource())
 		if err != nil {
@@ -152,7 +160,7 @@ func resourceKubernetesLimitRangeUpdate(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("Failed to marshal update operations: %s", err)
 	}
 	log.Printf("[INFO] Updating limit range %q: %v", name, string(data))
-	out, err := conn.CoreV1().LimitRanges(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	out, err := patchLimitRangeOnLatest(conn, namespace, name, data)
 	if err != nil {
 		return fmt.Errorf("Failed to update limit range: %s", err)
 	}
@@ -171,7 +179,7 @@ func resourceKubernetesLimitRangeDelete(d *schema.ResourceData, meta interface{}
 	}
 
 	log.Printf("[INFO] Deleting limit range: %#v", name)
-	err = conn.CoreV1().LimitRanges(namespace).Delete(name, &meta_v1.DeleteOptions{})
+	err = conn.CoreV1().LimitRanges(namespace).Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
@@ -200,3 +208,15 @@ func resourceKubernetesLimitRangeExists(d *schema.ResourceData, meta interface{}
 	}
 	return true, err
 }
+
+// patchLimitRangeOnLatest applies the JSON patch data of an update to the latest version of the limit range, see patchOnLatest.
+func patchLimitRangeOnLatest(conn *kubernetes.Clientset, namespace, name string, data []byte) (out *api.LimitRange, err error) {
+	client := conn.CoreV1().LimitRanges(namespace)
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (meta_v1.Object, error) {
+		return client.Get(name, meta_v1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, pkgApi.JSONPatchType, data)
+		return err
+	})
+	return out, err
+}
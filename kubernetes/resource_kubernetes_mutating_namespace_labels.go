@@ -0,0 +1,224 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+// resourceKubernetesMutatingNamespaceLabels manages a subset of the labels and
+// annotations of a namespace that already exists in the cluster (e.g. kube-system).
+// The namespace itself is never created or deleted, only the listed keys are
+// added on create and removed on destroy.
+func resourceKubernetesMutatingNamespaceLabels() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesMutatingNamespaceLabelsCreate,
+		Read:   resourceKubernetesMutatingNamespaceLabelsRead,
+		Exists: resourceKubernetesMutatingNamespaceLabelsExists,
+		Update: resourceKubernetesMutatingNamespaceLabelsUpdate,
+		Delete: resourceKubernetesMutatingNamespaceLabelsDelete,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": {
+				Type:        schema.TypeList,
+				Description: "Metadata of the existing namespace to patch.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"annotations": {
+							Type:         schema.TypeMap,
+							Description:  "Annotations to add to the namespace. Only these keys are managed, any other annotations on the namespace are left untouched.",
+							Optional:     true,
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							ValidateFunc: validateAnnotations,
+						},
+						"labels": {
+							Type:         schema.TypeMap,
+							Description:  "Labels to add to the namespace. Only these keys are managed, any other labels on the namespace are left untouched.",
+							Optional:     true,
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							ValidateFunc: validateLabels,
+						},
+						"name": {
+							Type:         schema.TypeString,
+							Description:  "Name of the existing namespace to patch.",
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateName,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesMutatingNamespaceLabelsCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Get("metadata.0.name").(string)
+	_, err := conn.CoreV1().Namespaces().Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("Namespace %q does not exist, it must be created before its labels can be managed", name)
+		}
+		return err
+	}
+
+	data, err := namespaceMetadataMergePatch(
+		map[string]interface{}{}, d.Get("metadata.0.labels").(map[string]interface{}),
+		map[string]interface{}{}, d.Get("metadata.0.annotations").(map[string]interface{}),
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Adding labels to namespace %s: %s", name, string(data))
+	out, err := conn.CoreV1().Namespaces().Patch(name, pkgApi.MergePatchType, data)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Submitted namespace labels: %#v", out)
+	d.SetId(out.Name)
+
+	return resourceKubernetesMutatingNamespaceLabelsRead(d, meta)
+}
+
+func resourceKubernetesMutatingNamespaceLabelsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Id()
+	log.Printf("[INFO] Reading namespace %s", name)
+	namespace, err := conn.CoreV1().Namespaces().Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Namespace %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received namespace: %#v", namespace)
+
+	m := map[string]interface{}{
+		"name":        namespace.Name,
+		"labels":      filterManagedKeys(namespace.Labels, d.Get("metadata.0.labels").(map[string]interface{})),
+		"annotations": filterManagedKeys(namespace.Annotations, d.Get("metadata.0.annotations").(map[string]interface{})),
+	}
+	err = d.Set("metadata", []interface{}{m})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesMutatingNamespaceLabelsUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	oldLabels, newLabels := d.GetChange("metadata.0.labels")
+	oldAnnotations, newAnnotations := d.GetChange("metadata.0.annotations")
+	data, err := namespaceMetadataMergePatch(
+		oldLabels.(map[string]interface{}), newLabels.(map[string]interface{}),
+		oldAnnotations.(map[string]interface{}), newAnnotations.(map[string]interface{}),
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating labels of namespace %s: %s", d.Id(), string(data))
+	out, err := conn.CoreV1().Namespaces().Patch(d.Id(), pkgApi.MergePatchType, data)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Submitted updated namespace labels: %#v", out)
+
+	return resourceKubernetesMutatingNamespaceLabelsRead(d, meta)
+}
+
+func resourceKubernetesMutatingNamespaceLabelsDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Id()
+	data, err := namespaceMetadataMergePatch(
+		d.Get("metadata.0.labels").(map[string]interface{}), map[string]interface{}{},
+		d.Get("metadata.0.annotations").(map[string]interface{}), map[string]interface{}{},
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Removing labels from namespace %s: %s", name, string(data))
+	_, err = conn.CoreV1().Namespaces().Patch(name, pkgApi.MergePatchType, data)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	log.Printf("[INFO] Labels removed from namespace %s", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesMutatingNamespaceLabelsExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Id()
+	log.Printf("[INFO] Checking namespace %s", name)
+	_, err := conn.CoreV1().Namespaces().Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+// namespaceMetadataMergePatch builds a JSON merge patch which sets the new
+// labels & annotations and removes the keys which are no longer configured.
+// A merge patch is used (instead of a JSON patch) since the namespace
+// may not have any labels or annotations to add to yet.
+func namespaceMetadataMergePatch(oldLabels, newLabels, oldAnnotations, newAnnotations map[string]interface{}) ([]byte, error) {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels":      diffStringMapMergePatch(oldLabels, newLabels),
+			"annotations": diffStringMapMergePatch(oldAnnotations, newAnnotations),
+		},
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	return data, nil
+}
+
+func diffStringMapMergePatch(oldV, newV map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, 0)
+	for k := range oldV {
+		if _, ok := newV[k]; !ok {
+			// null removes the key in a merge patch
+			out[k] = nil
+		}
+	}
+	for k, v := range newV {
+		out[k] = v
+	}
+	return out
+}
+
+func filterManagedKeys(in map[string]string, managed map[string]interface{}) map[string]string {
+	out := make(map[string]string, 0)
+	for k := range managed {
+		if v, ok := in[k]; ok {
+			out[k] = v
+		}
+	}
+	return out
+}
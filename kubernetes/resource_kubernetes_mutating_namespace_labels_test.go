@@ -0,0 +1,97 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesMutatingNamespaceLabels_basic(t *testing.T) {
+	labelKey := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesMutatingNamespaceLabelsDestroy(labelKey),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesMutatingNamespaceLabelsConfig_basic(labelKey, "one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_labels.test", "metadata.0.name", "default"),
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_labels.test", "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_labels.test", "metadata.0.labels."+labelKey, "one"),
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_labels.test", "metadata.0.annotations.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_labels.test", "metadata.0.annotations."+labelKey, "one"),
+					testAccCheckKubernetesNamespaceLabel("default", labelKey, "one"),
+				),
+			},
+			{
+				Config: testAccKubernetesMutatingNamespaceLabelsConfig_basic(labelKey, "two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_labels.test", "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_labels.test", "metadata.0.labels."+labelKey, "two"),
+					testAccCheckKubernetesNamespaceLabel("default", labelKey, "two"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesNamespaceLabel(namespace, key, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+		out, err := conn.CoreV1().Namespaces().Get(namespace, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if out.Labels[key] != value {
+			return fmt.Errorf("Expected label %q of namespace %s to be %q, given: %q", key, namespace, value, out.Labels[key])
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesMutatingNamespaceLabelsDestroy(key string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "kubernetes_mutating_namespace_labels" {
+				continue
+			}
+
+			// The namespace itself must survive the destroy
+			resp, err := conn.CoreV1().Namespaces().Get(rs.Primary.ID, meta_v1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if _, ok := resp.Labels[key]; ok {
+				return fmt.Errorf("Label %q still exists on namespace %s", key, rs.Primary.ID)
+			}
+			if _, ok := resp.Annotations[key]; ok {
+				return fmt.Errorf("Annotation %q still exists on namespace %s", key, rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccKubernetesMutatingNamespaceLabelsConfig_basic(key, value string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_mutating_namespace_labels" "test" {
+	metadata {
+		name = "default"
+		labels {
+			%[1]s = "%[2]s"
+		}
+		annotations {
+			%[1]s = "%[2]s"
+		}
+	}
+}`, key, value)
+}
@@ -0,0 +1,225 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+const podSecurityLabelPrefix = "pod-security.kubernetes.io/"
+
+// podSecurityModes are the modes of Pod Security Admission, each set by a level & a version label
+var podSecurityModes = []string{"enforce", "audit", "warn"}
+
+var podSecurityVersionPattern = regexp.MustCompile(`^(latest|v1\.[0-9]+)$`)
+
+// resourceKubernetesMutatingNamespacePodSecurity manages the Pod Security Admission labels
+// of a namespace that already exists in the cluster, like kubernetes_mutating_namespace_labels
+// does for any label. Only the labels of the configured modes are added on create and removed
+// on destroy.
+func resourceKubernetesMutatingNamespacePodSecurity() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"metadata": {
+			Type:        schema.TypeList,
+			Description: "Metadata of the existing namespace to label.",
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:         schema.TypeString,
+						Description:  "Name of the existing namespace to label.",
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validateName,
+					},
+				},
+			},
+		},
+	}
+	for _, mode := range podSecurityModes {
+		s[mode] = podSecurityModeSchema(mode)
+	}
+
+	return &schema.Resource{
+		Create: resourceKubernetesMutatingNamespacePodSecurityCreate,
+		Read:   resourceKubernetesMutatingNamespacePodSecurityRead,
+		Exists: resourceKubernetesMutatingNamespaceLabelsExists,
+		Update: resourceKubernetesMutatingNamespacePodSecurityUpdate,
+		Delete: resourceKubernetesMutatingNamespacePodSecurityDelete,
+		Schema: s,
+	}
+}
+
+func podSecurityModeSchema(mode string) *schema.Schema {
+	descriptions := map[string]string{
+		"enforce": "Pods violating the level are rejected.",
+		"audit":   "Pods violating the level are allowed, the violation is recorded in the audit log.",
+		"warn":    "Pods violating the level are allowed, the user is warned about the violation.",
+	}
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: fmt.Sprintf("Sets the %s%s label of the namespace. %s", podSecurityLabelPrefix, mode, descriptions[mode]),
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"level": {
+					Type:         schema.TypeString,
+					Description:  "The Pod Security Standard to check the pods of the namespace against. One of `privileged`, `baseline`, `restricted`.",
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"privileged", "baseline", "restricted"}, false),
+				},
+				"version": {
+					Type:         schema.TypeString,
+					Description:  "The Kubernetes minor version of the standard, e.g. `v1.25`, or `latest`. Defaults to `latest`.",
+					Optional:     true,
+					Default:      "latest",
+					ValidateFunc: validation.StringMatch(podSecurityVersionPattern, "must be `latest` or a Kubernetes minor version like `v1.25`"),
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesMutatingNamespacePodSecurityCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Get("metadata.0.name").(string)
+	_, err := conn.CoreV1().Namespaces().Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("Namespace %q does not exist, it must be created before its pod security labels can be managed", name)
+		}
+		return err
+	}
+
+	_, labels := podSecurityLabelsChange(d)
+	data, err := namespaceMetadataMergePatch(map[string]interface{}{}, labels, map[string]interface{}{}, map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Adding pod security labels to namespace %s: %s", name, string(data))
+	out, err := conn.CoreV1().Namespaces().Patch(name, pkgApi.MergePatchType, data)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Submitted namespace pod security labels: %#v", out)
+	d.SetId(out.Name)
+
+	return resourceKubernetesMutatingNamespacePodSecurityRead(d, meta)
+}
+
+func resourceKubernetesMutatingNamespacePodSecurityRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Id()
+	log.Printf("[INFO] Reading namespace %s", name)
+	namespace, err := conn.CoreV1().Namespaces().Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Namespace %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received namespace: %#v", namespace)
+
+	err = d.Set("metadata", []interface{}{map[string]interface{}{"name": namespace.Name}})
+	if err != nil {
+		return err
+	}
+	// Only the managed modes are read, others may be labelled outside of Terraform
+	for _, mode := range podSecurityModes {
+		if len(d.Get(mode).([]interface{})) == 0 {
+			continue
+		}
+		err = d.Set(mode, flattenPodSecurityMode(mode, namespace.Labels))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceKubernetesMutatingNamespacePodSecurityUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	oldLabels, newLabels := podSecurityLabelsChange(d)
+	data, err := namespaceMetadataMergePatch(oldLabels, newLabels, map[string]interface{}{}, map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating pod security labels of namespace %s: %s", d.Id(), string(data))
+	out, err := conn.CoreV1().Namespaces().Patch(d.Id(), pkgApi.MergePatchType, data)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Submitted updated namespace pod security labels: %#v", out)
+
+	return resourceKubernetesMutatingNamespacePodSecurityRead(d, meta)
+}
+
+func resourceKubernetesMutatingNamespacePodSecurityDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	name := d.Id()
+	_, labels := podSecurityLabelsChange(d)
+	data, err := namespaceMetadataMergePatch(labels, map[string]interface{}{}, map[string]interface{}{}, map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Removing pod security labels from namespace %s: %s", name, string(data))
+	_, err = conn.CoreV1().Namespaces().Patch(name, pkgApi.MergePatchType, data)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	log.Printf("[INFO] Pod security labels removed from namespace %s", name)
+
+	d.SetId("")
+	return nil
+}
+
+// podSecurityLabelsChange returns the namespace labels of the previous & the configured modes
+func podSecurityLabelsChange(d *schema.ResourceData) (map[string]interface{}, map[string]interface{}) {
+	oldLabels := make(map[string]interface{}, 0)
+	newLabels := make(map[string]interface{}, 0)
+	for _, mode := range podSecurityModes {
+		o, n := d.GetChange(mode)
+		expandPodSecurityMode(mode, o.([]interface{}), oldLabels)
+		expandPodSecurityMode(mode, n.([]interface{}), newLabels)
+	}
+	return oldLabels, newLabels
+}
+
+func expandPodSecurityMode(mode string, in []interface{}, labels map[string]interface{}) {
+	if len(in) == 0 || in[0] == nil {
+		return
+	}
+	m := in[0].(map[string]interface{})
+	labels[podSecurityLabelPrefix+mode] = m["level"]
+	labels[podSecurityLabelPrefix+mode+"-version"] = m["version"]
+}
+
+func flattenPodSecurityMode(mode string, labels map[string]string) []interface{} {
+	level, ok := labels[podSecurityLabelPrefix+mode]
+	if !ok {
+		return []interface{}{}
+	}
+	return []interface{}{map[string]interface{}{
+		"level":   level,
+		"version": labels[podSecurityLabelPrefix+mode+"-version"],
+	}}
+}
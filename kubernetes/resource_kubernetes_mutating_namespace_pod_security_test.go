@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesMutatingNamespacePodSecurity_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesMutatingNamespacePodSecurityDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesMutatingNamespacePodSecurityConfig_basic("baseline"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_pod_security.test", "metadata.0.name", "default"),
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_pod_security.test", "enforce.0.level", "baseline"),
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_pod_security.test", "enforce.0.version", "v1.25"),
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_pod_security.test", "warn.0.level", "restricted"),
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_pod_security.test", "warn.0.version", "latest"),
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_pod_security.test", "audit.#", "0"),
+					testAccCheckKubernetesNamespaceLabel("default", "pod-security.kubernetes.io/enforce", "baseline"),
+					testAccCheckKubernetesNamespaceLabel("default", "pod-security.kubernetes.io/enforce-version", "v1.25"),
+					testAccCheckKubernetesNamespaceLabel("default", "pod-security.kubernetes.io/warn", "restricted"),
+				),
+			},
+			{
+				Config: testAccKubernetesMutatingNamespacePodSecurityConfig_basic("privileged"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_mutating_namespace_pod_security.test", "enforce.0.level", "privileged"),
+					testAccCheckKubernetesNamespaceLabel("default", "pod-security.kubernetes.io/enforce", "privileged"),
+				),
+			},
+		},
+	})
+}
+
+func TestPodSecurityLabelsChange(t *testing.T) {
+	r := resourceKubernetesMutatingNamespacePodSecurity()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "apps"}},
+		"enforce":  []interface{}{map[string]interface{}{"level": "restricted", "version": "v1.25"}},
+		"audit":    []interface{}{map[string]interface{}{"level": "baseline"}},
+	})
+
+	_, labels := podSecurityLabelsChange(d)
+	expected := map[string]interface{}{
+		"pod-security.kubernetes.io/enforce":         "restricted",
+		"pod-security.kubernetes.io/enforce-version": "v1.25",
+		"pod-security.kubernetes.io/audit":           "baseline",
+		"pod-security.kubernetes.io/audit-version":   "latest",
+	}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Fatalf("Expected labels %#v, given: %#v", expected, labels)
+	}
+
+	namespaceLabels := map[string]string{
+		"pod-security.kubernetes.io/enforce":         "restricted",
+		"pod-security.kubernetes.io/enforce-version": "v1.25",
+	}
+	if flattened := flattenPodSecurityMode("warn", namespaceLabels); len(flattened) != 0 {
+		t.Fatalf("Expected no warn mode, given: %#v", flattened)
+	}
+	flattened := flattenPodSecurityMode("enforce", namespaceLabels)
+	if !reflect.DeepEqual(flattened, []interface{}{map[string]interface{}{"level": "restricted", "version": "v1.25"}}) {
+		t.Fatalf("Unexpected enforce mode: %#v", flattened)
+	}
+}
+
+func TestPodSecurityModeValidation(t *testing.T) {
+	cases := []struct {
+		Level   string
+		Version string
+		Valid   bool
+	}{
+		{"restricted", "latest", true},
+		{"baseline", "v1.27", true},
+		{"strict", "latest", false},
+		{"privileged", "1.25", false},
+	}
+
+	s := podSecurityModeSchema("enforce").Elem.(*schema.Resource).Schema
+	for _, tc := range cases {
+		_, levelErrors := s["level"].ValidateFunc(tc.Level, "level")
+		_, versionErrors := s["version"].ValidateFunc(tc.Version, "version")
+		if valid := len(levelErrors) == 0 && len(versionErrors) == 0; valid != tc.Valid {
+			t.Fatalf("Expected %s/%s to be valid: %t, given errors: %v %v", tc.Level, tc.Version, tc.Valid, levelErrors, versionErrors)
+		}
+	}
+}
+
+func testAccCheckKubernetesMutatingNamespacePodSecurityDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_mutating_namespace_pod_security" {
+			continue
+		}
+
+		// The namespace itself must survive the destroy
+		resp, err := conn.CoreV1().Namespaces().Get(rs.Primary.ID, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		for _, mode := range podSecurityModes {
+			if _, ok := resp.Labels[podSecurityLabelPrefix+mode]; ok {
+				return fmt.Errorf("Pod security label %q still exists on namespace %s", podSecurityLabelPrefix+mode, rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccKubernetesMutatingNamespacePodSecurityConfig_basic(level string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_mutating_namespace_pod_security" "test" {
+	metadata {
+		name = "default"
+	}
+	enforce {
+		level   = "%s"
+		version = "v1.25"
+	}
+	warn {
+		level = "restricted"
+	}
+}`, level)
+}
@@ -1,6 +1,7 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesNamespace() *schema.Resource {
@@ -23,9 +25,20 @@ func resourceKubernetesNamespace() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			return checkDryRun(diff, meta, coreV1RESTClient, "namespaces", func(metadata meta_v1.ObjectMeta) (interface{}, error) {
+				return &api.Namespace{ObjectMeta: metadata}, nil
+			})
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": metadataSchema("namespace", true),
+			"metadata":             clusterScopedMetadataSchema("namespace", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 		},
 	}
 }
@@ -33,7 +46,7 @@ func resourceKubernetesNamespace() *schema.Resource {
 func resourceKubernetesNamespaceCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	namespace := api.Namespace{
 		ObjectMeta: metadata,
 	}
@@ -55,11 +68,16 @@ func resourceKubernetesNamespaceRead(d *schema.ResourceData, meta interface{}) e
 	log.Printf("[INFO] Reading namespace %s", name)
 	namespace, err := conn.CoreV1().Namespaces().Get(name, meta_v1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Namespace %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received namespace: %#v", namespace)
-	err = d.Set("metadata", flattenMetadata(namespace.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(namespace.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -70,14 +88,14 @@ func resourceKubernetesNamespaceRead(d *schema.ResourceData, meta interface{}) e
 func resourceKubernetesNamespaceUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadataWithDefaults(d, meta)
 	data, err := ops.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("Failed to marshal update operations: %s", err)
 	}
 
 	log.Printf("[INFO] Updating namespace: %s", ops)
-	out, err := conn.CoreV1().Namespaces().Patch(d.Id(), pkgApi.JSONPatchType, data)
+	out, err := patchNamespaceOnLatest(conn, d.Id(), data)
 	if err != nil {
 		return err
 	}
@@ -92,7 +110,7 @@ func resourceKubernetesNamespaceDelete(d *schema.ResourceData, meta interface{})
 
 	name := d.Id()
 	log.Printf("[INFO] Deleting namespace: %#v", name)
-	err := conn.CoreV1().Namespaces().Delete(name, &meta_v1.DeleteOptions{})
+	err := conn.CoreV1().Namespaces().Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
@@ -100,7 +118,7 @@ func resourceKubernetesNamespaceDelete(d *schema.ResourceData, meta interface{})
 	stateConf := &resource.StateChangeConf{
 		Target:  []string{},
 		Pending: []string{"Terminating"},
-		Timeout: 5 * time.Minute,
+		Timeout: d.Timeout(schema.TimeoutDelete),
 		Refresh: func() (interface{}, string, error) {
 			out, err := conn.CoreV1().Namespaces().Get(name, meta_v1.GetOptions{})
 			if err != nil {
@@ -118,7 +136,34 @@ func resourceKubernetesNamespaceDelete(d *schema.ResourceData, meta interface{})
 	}
 	_, err = stateConf.WaitForState()
 	if err != nil {
-		return err
+		// Surface why the namespace is stuck in Terminating,
+		// typically leftover objects blocking the finalizers to complete
+		var finalizers []string
+		out, gErr := conn.CoreV1().Namespaces().Get(name, meta_v1.GetOptions{})
+		if gErr == nil {
+			finalizers = append(finalizers, out.ObjectMeta.Finalizers...)
+			for _, f := range out.Spec.Finalizers {
+				finalizers = append(finalizers, string(f))
+			}
+		}
+
+		// The conditions list the kinds of objects which are left over
+		// and the finalizers they are waiting for
+		var remaining []string
+		raw, rErr := conn.CoreV1().RESTClient().Get().Resource("namespaces").Name(name).DoRaw()
+		if rErr == nil {
+			remaining, rErr = namespaceRemainingContent(raw)
+		}
+		if rErr != nil {
+			log.Printf("[WARN] Failed to read conditions of namespace %s: %s", name, rErr)
+		}
+
+		lastWarnings, wErr := getLastWarningsForObject(conn, meta_v1.ObjectMeta{Name: name}, "Namespace", meta.(*kubernetesProvider).warningEventLimit)
+		if wErr != nil {
+			return wErr
+		}
+
+		return fmt.Errorf("%s%s%s%s", err, stringifyNamespaceRemainingContent(remaining), stringifyFinalizers(finalizers), stringifyEvents(lastWarnings))
 	}
 	log.Printf("[INFO] Namespace %s deleted", name)
 
@@ -141,3 +186,53 @@ func resourceKubernetesNamespaceExists(d *schema.ResourceData, meta interface{})
 	log.Printf("[INFO] Namespace %s exists", name)
 	return true, err
 }
+
+// namespaceRemainingContent returns the messages of the NamespaceContentRemaining &
+// NamespaceFinalizersRemaining conditions of a raw namespace object (Kubernetes 1.16+).
+// The vendored API types predate namespace conditions, hence the raw JSON.
+func namespaceRemainingContent(raw []byte) ([]string, error) {
+	var ns struct {
+		Status struct {
+			Conditions []struct {
+				Type    string `json:"type"`
+				Status  string `json:"status"`
+				Message string `json:"message"`
+			} `json:"conditions"`
+		} `json:"status"`
+	}
+	err := json.Unmarshal(raw, &ns)
+	if err != nil {
+		return nil, err
+	}
+
+	var remaining []string
+	for _, c := range ns.Status.Conditions {
+		if c.Status != string(api.ConditionTrue) {
+			continue
+		}
+		if c.Type == "NamespaceContentRemaining" || c.Type == "NamespaceFinalizersRemaining" {
+			remaining = append(remaining, c.Message)
+		}
+	}
+	return remaining, nil
+}
+
+func stringifyNamespaceRemainingContent(remaining []string) string {
+	var output string
+	for _, r := range remaining {
+		output += fmt.Sprintf("\n   * %s", r)
+	}
+	return output
+}
+
+// patchNamespaceOnLatest applies the JSON patch data of an update to the latest version of the namespace, see patchOnLatest.
+func patchNamespaceOnLatest(conn *kubernetes.Clientset, name string, data []byte) (out *api.Namespace, err error) {
+	client := conn.CoreV1().Namespaces()
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (meta_v1.Object, error) {
+		return client.Get(name, meta_v1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, pkgApi.JSONPatchType, data)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,192 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+const defaultNetworkPolicyName = "default-deny-all"
+
+// resourceKubernetesNamespaceDefaultNetworkPolicy installs a network policy
+// selecting every pod of a namespace without allowing any traffic, i.e. the
+// usual "default deny" baseline which other network policies then open up.
+func resourceKubernetesNamespaceDefaultNetworkPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceKubernetesNamespaceDefaultNetworkPolicyCreate,
+		Read:          resourceKubernetesNamespaceDefaultNetworkPolicyRead,
+		Exists:        resourceKubernetesNamespaceDefaultNetworkPolicyExists,
+		Update:        resourceKubernetesNamespaceDefaultNetworkPolicyUpdate,
+		Delete:        resourceKubernetesNamespaceDefaultNetworkPolicyDelete,
+		CustomizeDiff: resourceKubernetesNamespaceDefaultNetworkPolicyCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata":             namespacedMetadataSchema("network policy", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
+			"deny_ingress": {
+				Type:        schema.TypeBool,
+				Description: "Deny all ingress traffic to the pods of the namespace.",
+				Optional:    true,
+				Default:     true,
+			},
+			"deny_egress": {
+				Type:        schema.TypeBool,
+				Description: "Deny all egress traffic from the pods of the namespace.",
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesNamespaceDefaultNetworkPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
+	if metadata.Name == "" && metadata.GenerateName == "" {
+		metadata.Name = defaultNetworkPolicyName
+	}
+	policy := networking.NetworkPolicy{
+		ObjectMeta: metadata,
+		Spec:       expandDefaultDenyNetworkPolicySpec(d.Get("deny_ingress").(bool), d.Get("deny_egress").(bool)),
+	}
+	log.Printf("[INFO] Creating new network policy: %#v", policy)
+	out, err := conn.NetworkingV1().NetworkPolicies(metadata.Namespace).Create(&policy)
+	if err != nil {
+		return fmt.Errorf("Failed to create network policy: %s", err)
+	}
+	log.Printf("[INFO] Submitted new network policy: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesNamespaceDefaultNetworkPolicyRead(d, meta)
+}
+
+func resourceKubernetesNamespaceDefaultNetworkPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Reading network policy %s", name)
+	policy, err := conn.NetworkingV1().NetworkPolicies(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Network policy %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received network policy: %#v", policy)
+
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(policy.ObjectMeta, d, meta))
+	if err != nil {
+		return err
+	}
+
+	denyIngress, denyEgress := flattenDefaultDenyNetworkPolicySpec(policy.Spec)
+	d.Set("deny_ingress", denyIngress)
+	d.Set("deny_egress", denyEgress)
+
+	return nil
+}
+
+func resourceKubernetesNamespaceDefaultNetworkPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ops := patchMetadataWithDefaults(d, meta)
+	if d.HasChange("deny_ingress") || d.HasChange("deny_egress") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/spec",
+			Value: expandDefaultDenyNetworkPolicySpec(d.Get("deny_ingress").(bool), d.Get("deny_egress").(bool)),
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating network policy %q: %v", name, string(data))
+	out, err := patchNetworkPolicyOnLatest(conn, namespace, name, data)
+	if err != nil {
+		return fmt.Errorf("Failed to update network policy: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated network policy: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesNamespaceDefaultNetworkPolicyRead(d, meta)
+}
+
+func resourceKubernetesNamespaceDefaultNetworkPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting network policy: %#v", name)
+	err = conn.NetworkingV1().NetworkPolicies(namespace).Delete(name, deleteOptions(d, ""))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Network policy %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesNamespaceDefaultNetworkPolicyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking network policy %s", name)
+	_, err = conn.NetworkingV1().NetworkPolicies(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+func resourceKubernetesNamespaceDefaultNetworkPolicyCustomizeDiff(df *schema.ResourceDiff, meta interface{}) error {
+	if !df.Get("deny_ingress").(bool) && !df.Get("deny_egress").(bool) {
+		return fmt.Errorf("At least one of deny_ingress or deny_egress must be true, otherwise the network policy doesn't deny anything")
+	}
+	return nil
+}
+
+// patchNetworkPolicyOnLatest applies the JSON patch data of an update to the latest version of the network policy, see patchOnLatest.
+func patchNetworkPolicyOnLatest(conn *kubernetes.Clientset, namespace, name string, data []byte) (out *networking.NetworkPolicy, err error) {
+	client := conn.NetworkingV1().NetworkPolicies(namespace)
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (meta_v1.Object, error) {
+		return client.Get(name, meta_v1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, pkgApi.JSONPatchType, data)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,146 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesNamespaceDefaultNetworkPolicy_basic(t *testing.T) {
+	var conf networking.NetworkPolicy
+	nsName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_namespace_default_network_policy.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesNamespaceDefaultNetworkPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesNamespaceDefaultNetworkPolicyConfig_basic(nsName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesNamespaceDefaultNetworkPolicyExists("kubernetes_namespace_default_network_policy.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_namespace_default_network_policy.test", "metadata.0.name", "default-deny-all"),
+					resource.TestCheckResourceAttr("kubernetes_namespace_default_network_policy.test", "metadata.0.namespace", nsName),
+					resource.TestCheckResourceAttr("kubernetes_namespace_default_network_policy.test", "deny_ingress", "true"),
+					resource.TestCheckResourceAttr("kubernetes_namespace_default_network_policy.test", "deny_egress", "true"),
+				),
+			},
+			{
+				Config: testAccKubernetesNamespaceDefaultNetworkPolicyConfig_ingressOnly(nsName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesNamespaceDefaultNetworkPolicyExists("kubernetes_namespace_default_network_policy.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_namespace_default_network_policy.test", "deny_ingress", "true"),
+					resource.TestCheckResourceAttr("kubernetes_namespace_default_network_policy.test", "deny_egress", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesNamespaceDefaultNetworkPolicy_importBasic(t *testing.T) {
+	resourceName := "kubernetes_namespace_default_network_policy.test"
+	nsName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesNamespaceDefaultNetworkPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesNamespaceDefaultNetworkPolicyConfig_basic(nsName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesNamespaceDefaultNetworkPolicyDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_namespace_default_network_policy" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.NetworkingV1().NetworkPolicies(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Network policy still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesNamespaceDefaultNetworkPolicyExists(n string, obj *networking.NetworkPolicy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.NetworkingV1().NetworkPolicies(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesNamespaceDefaultNetworkPolicyConfig_basic(nsName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_namespace" "test" {
+	metadata {
+		name = "%s"
+	}
+}
+
+resource "kubernetes_namespace_default_network_policy" "test" {
+	metadata {
+		namespace = "${kubernetes_namespace.test.metadata.0.name}"
+	}
+}
+`, nsName)
+}
+
+func testAccKubernetesNamespaceDefaultNetworkPolicyConfig_ingressOnly(nsName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_namespace" "test" {
+	metadata {
+		name = "%s"
+	}
+}
+
+resource "kubernetes_namespace_default_network_policy" "test" {
+	metadata {
+		namespace = "${kubernetes_namespace.test.metadata.0.name}"
+	}
+	deny_egress = false
+}
+`, nsName)
+}
@@ -429,3 +429,40 @@ resource "kubernetes_namespace" "test" {
 	}
 }`, nsName)
 }
+
+func TestNamespaceRemainingContent(t *testing.T) {
+	raw := []byte(`{
+	"kind": "Namespace",
+	"metadata": {"name": "stuck"},
+	"status": {
+		"phase": "Terminating",
+		"conditions": [
+			{"type": "NamespaceDeletionDiscoveryFailure", "status": "False", "message": "All resources successfully discovered"},
+			{"type": "NamespaceContentRemaining", "status": "True", "message": "Some resources are remaining: widgets.example.com has 2 resource instances"},
+			{"type": "NamespaceFinalizersRemaining", "status": "True", "message": "Some content in the namespace has finalizers remaining: example.com/cleanup in 2 resource instances"},
+			{"type": "NamespaceDeletionContentFailure", "status": "False", "message": "All content successfully deleted"}
+		]
+	}
+}`)
+	expected := []string{
+		"Some resources are remaining: widgets.example.com has 2 resource instances",
+		"Some content in the namespace has finalizers remaining: example.com/cleanup in 2 resource instances",
+	}
+
+	remaining, err := namespaceRemainingContent(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(remaining, expected) {
+		t.Fatalf("Expected %q, given %q", expected, remaining)
+	}
+
+	// Clusters older than 1.16 don't report any conditions
+	remaining, err = namespaceRemainingContent([]byte(`{"status": {"phase": "Terminating"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 0 {
+		t.Fatalf("Expected no remaining content, given %q", remaining)
+	}
+}
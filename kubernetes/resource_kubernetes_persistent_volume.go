@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesPersistentVolume() *schema.Resource {
@@ -25,6 +26,9 @@ func resourceKubernetesPersistentVolume() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
 		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
 			if diff.Id() == "" {
 				// We only care about updates, not creation
@@ -64,7 +68,9 @@ func resourceKubernetesPersistentVolume() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": metadataSchema("persistent volume", false),
+			"metadata":             clusterScopedMetadataSchema("persistent volume", false),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec of the persistent volume owned by the cluster",
@@ -119,7 +125,7 @@ func resourceKubernetesPersistentVolume() *schema.Resource {
 func resourceKubernetesPersistentVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec, err := expandPersistentVolumeSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return err
@@ -139,7 +145,7 @@ func resourceKubernetesPersistentVolumeCreate(d *schema.ResourceData, meta inter
 	stateConf := &resource.StateChangeConf{
 		Target:  []string{"Available", "Bound"},
 		Pending: []string{"Pending"},
-		Timeout: 5 * time.Minute,
+		Timeout: d.Timeout(schema.TimeoutCreate),
 		Refresh: func() (interface{}, string, error) {
 			out, err := conn.CoreV1().PersistentVolumes().Get(metadata.Name, meta_v1.GetOptions{})
 			if err != nil {
@@ -170,11 +176,16 @@ func resourceKubernetesPersistentVolumeRead(d *schema.ResourceData, meta interfa
 	log.Printf("[INFO] Reading persistent volume %s", name)
 	volume, err := conn.CoreV1().PersistentVolumes().Get(name, meta_v1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Persistent volume %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received persistent volume: %#v", volume)
-	err = d.Set("metadata", flattenMetadata(volume.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(volume.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -189,7 +200,7 @@ func resourceKubernetesPersistentVolumeRead(d *schema.ResourceData, meta interfa
 func resourceKubernetesPersistentVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadataWithDefaults(d, meta)
 	if d.HasChange("spec") {
 		specOps, err := patchPersistentVolumeSpec("/spec", "spec", d)
 		if err != nil {
@@ -203,7 +214,7 @@ func resourceKubernetesPersistentVolumeUpdate(d *schema.ResourceData, meta inter
 	}
 
 	log.Printf("[INFO] Updating persistent volume %s: %s", d.Id(), ops)
-	out, err := conn.CoreV1().PersistentVolumes().Patch(d.Id(), pkgApi.JSONPatchType, data)
+	out, err := patchPersistentVolumeOnLatest(conn, d.Id(), data)
 	if err != nil {
 		return err
 	}
@@ -218,7 +229,7 @@ func resourceKubernetesPersistentVolumeDelete(d *schema.ResourceData, meta inter
 
 	name := d.Id()
 	log.Printf("[INFO] Deleting persistent volume: %#v", name)
-	err := conn.CoreV1().PersistentVolumes().Delete(name, &meta_v1.DeleteOptions{})
+	err := conn.CoreV1().PersistentVolumes().Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
@@ -243,3 +254,15 @@ func resourceKubernetesPersistentVolumeExists(d *schema.ResourceData, meta inter
 	}
 	return true, err
 }
+
+// patchPersistentVolumeOnLatest applies the JSON patch data of an update to the latest version of the persistent volume, see patchOnLatest.
+func patchPersistentVolumeOnLatest(conn *kubernetes.Clientset, name string, data []byte) (out *api.PersistentVolume, err error) {
+	client := conn.CoreV1().PersistentVolumes()
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (meta_v1.Object, error) {
+		return client.Get(name, meta_v1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, pkgApi.JSONPatchType, data)
+		return err
+	})
+	return out, err
+}
@@ -1,19 +1,46 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
 )
 
+// persistentVolumeClaimImmutableFields are the fields of the spec of a claim which can't be updated,
+// all of it but for its volume attributes class and an increase of its storage request.
+var persistentVolumeClaimImmutableFields = []string{
+	"spec.0.access_modes", "spec.0.resources", "spec.0.selector",
+	"spec.0.volume_name", "spec.0.storage_class_name", "spec.0.volume_mode",
+}
+
+// persistentVolumeClaimReplacedFields are the immutable fields any change of which re-creates the claim,
+// the changes of the requests are planned by checkPersistentVolumeClaimResize.
+var persistentVolumeClaimReplacedFields = []string{
+	"spec.0.access_modes", "spec.0.resources.0.limits", "spec.0.selector",
+	"spec.0.volume_name", "spec.0.storage_class_name", "spec.0.volume_mode",
+}
+
 func resourceKubernetesPersistentVolumeClaim() *schema.Resource {
+	s := persistentVolumeClaimSpecFields(false)
+	s["applied_spec"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "The immutable fields of the spec as last applied by Terraform, to tell a change of the live claim from a change of the configuration.",
+		Computed:    true,
+	}
+
 	return &schema.Resource{
 		Create: resourceKubernetesPersistentVolumeClaimCreate,
 		Read:   resourceKubernetesPersistentVolumeClaimRead,
@@ -21,28 +48,254 @@ func resourceKubernetesPersistentVolumeClaim() *schema.Resource {
 		Update: resourceKubernetesPersistentVolumeClaimUpdate,
 		Delete: resourceKubernetesPersistentVolumeClaimDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				d.Set("wait_until_bound", true)
-				return []*schema.ResourceData{d}, nil
-			},
+			State: importStateWithDefaults(map[string]interface{}{
+				"wait_until_bound":        true,
+				"wait_until_resized":      true,
+				"adopt_existing":          false,
+				"wait_for_volume_release": false,
+			}),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
+		CustomizeDiff: resourceKubernetesPersistentVolumeClaimCustomizeDiff,
+
+		Schema: s,
+	}
+}
+
+func resourceKubernetesPersistentVolumeClaimCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	err := checkImmutableFieldChanges(diff, meta, "persistent volume claim", persistentVolumeClaimReplacedFields...)
+	if err != nil {
+		return err
+	}
+	err = checkPersistentVolumeClaimDrift(diff)
+	if err != nil {
+		return err
+	}
+	err = checkPersistentVolumeClaimResize(diff, meta)
+	if err != nil {
+		return err
+	}
+	return checkPersistentVolumeClaimSelector(diff, meta)
+}
+
+// checkPersistentVolumeClaimResize plans the changes of the requests of a claim. Only an increase of
+// the storage request is updated in place, it expands the volume, which the storage class of the claim
+// must allow. A decrease fails the plan, as volumes can't be shrunk, any other change re-creates the claim.
+func checkPersistentVolumeClaimResize(diff *schema.ResourceDiff, meta interface{}) error {
+	const key = "spec.0.resources.0.requests"
+	if diff.Id() == "" || !diff.HasChange(key) {
+		return nil
+	}
+	oldV, newV := diff.GetChange(key)
+	oldRequests, newRequests := oldV.(map[string]interface{}), newV.(map[string]interface{})
+	for k := range mergeStringMaps(expandStringMap(oldRequests), expandStringMap(newRequests)) {
+		if k != "storage" && !suppressEquivalentResourceQuantity(k, fmt.Sprint(oldRequests[k]), fmt.Sprint(newRequests[k]), nil) {
+			return forceNewPersistentVolumeClaimRequests(diff, meta)
+		}
+	}
+
+	oldStorage, _ := oldRequests["storage"].(string)
+	newStorage, _ := newRequests["storage"].(string)
+	if newStorage == config.UnknownVariableValue {
+		return nil
+	}
+	oldQ, err := k8sresource.ParseQuantity(oldStorage)
+	if err != nil {
+		return forceNewPersistentVolumeClaimRequests(diff, meta)
+	}
+	newQ, err := k8sresource.ParseQuantity(newStorage)
+	if err != nil {
+		return forceNewPersistentVolumeClaimRequests(diff, meta)
+	}
+	name := diff.Get("metadata.0.name")
+	switch newQ.Cmp(oldQ) {
+	case 0:
+		return nil
+	case -1:
+		return fmt.Errorf("The storage request of persistent volume claim %q can't be decreased from %s to %s, "+
+			"Kubernetes only expands volumes. If the claim was expanded outside of Terraform, update the configuration "+
+			"to its size, otherwise restore the size or taint the claim (terraform taint) to re-create it, "+
+			"which loses the data of its volume.", name, oldStorage, newStorage)
+	}
+
+	className := diff.Get("spec.0.storage_class_name").(string)
+	if className == "" || className == config.UnknownVariableValue {
+		return nil
+	}
+	kp, ok := meta.(*kubernetesProvider)
+	if !ok || kp.conn == nil {
+		return nil
+	}
+	class, err := kp.conn.StorageV1().StorageClasses().Get(className, meta_v1.GetOptions{})
+	if err != nil {
+		log.Printf("[WARN] Can't read storage class %q to check it allows volume expansion: %s", className, err)
+		return nil
+	}
+	if class.AllowVolumeExpansion == nil || !*class.AllowVolumeExpansion {
+		return fmt.Errorf("Persistent volume claim %q can't be expanded from %s to %s: its storage class %q doesn't allow volume expansion. "+
+			"Either set allow_volume_expansion = true on the storage class, restore the size "+
+			"or taint the claim (terraform taint) to re-create it, which loses the data of its volume.",
+			name, oldStorage, newStorage, className)
+	}
+	return nil
+}
+
+// checkPersistentVolumeClaimDrift fails the plan when it would re-create a claim only because its
+// immutable fields were changed outside of Terraform, e.g. by expanding the volume, since the re-create
+// would lose the data of the volume. These fields still match the applied_spec in the configuration,
+// but not on the live claim. Changing the configuration of an immutable field still re-creates the claim.
+func checkPersistentVolumeClaimDrift(diff *schema.ResourceDiff) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	// Claims created before applied_spec was tracked
+	applied := diff.Get("applied_spec").(map[string]interface{})
+	if len(applied) == 0 {
+		return nil
+	}
+
+	var drifts []string
+	for _, prefix := range persistentVolumeClaimImmutableFields {
+		for _, k := range diff.GetChangedKeysPrefix(prefix) {
+			if strings.HasSuffix(k, ".#") || strings.HasSuffix(k, ".%") {
+				continue
+			}
+			oldV, newV := diff.GetChange(k)
+			appliedV, _ := applied[k].(string)
+			if newV == config.UnknownVariableValue || fmt.Sprint(newV) != appliedV || fmt.Sprint(oldV) == appliedV {
+				continue
+			}
+			drifts = append(drifts, fmt.Sprintf("\n   * %s: %v => %v", k, appliedV, oldV))
+		}
+	}
+	if len(drifts) == 0 {
+		return nil
+	}
+	sort.Strings(drifts)
+
+	return fmt.Errorf("The following immutable fields of persistent volume claim %q were changed outside of Terraform "+
+		"since it was applied (applied => live):%s\n\n"+
+		"Terraform won't re-create the claim to revert them, that would lose the data of its volume. "+
+		"Either update the configuration to match the live claim "+
+		"or taint the claim (terraform taint) to re-create it.",
+		diff.Id(), strings.Join(drifts, ""))
+}
 
-		Schema: persistentVolumeClaimSpecFields(false),
+// persistentVolumeClaimAppliedSpec snapshots the immutable fields of the spec in the state, as flatmap attributes.
+func persistentVolumeClaimAppliedSpec(d *schema.ResourceData) map[string]interface{} {
+	applied := make(map[string]interface{}, 0)
+	for k, v := range d.State().Attributes {
+		if strings.HasSuffix(k, ".#") || strings.HasSuffix(k, ".%") {
+			continue
+		}
+		for _, prefix := range persistentVolumeClaimImmutableFields {
+			if strings.HasPrefix(k, prefix) {
+				applied[k] = v
+			}
+		}
 	}
+	return applied
+}
+
+// checkPersistentVolumeClaimSelector fails a claim which selects volumes by labels from a storage class
+// of a provisioner when no Available volume of the class matches the selector. Volumes are never
+// provisioned for a claim with a selector, so such a claim stays Pending until a matching volume of
+// the class exists, which is hard to tell from a slow provisioner.
+func checkPersistentVolumeClaimSelector(diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" && !diff.HasChange("spec.0.selector") && !diff.HasChange("spec.0.storage_class_name") {
+		return nil
+	}
+	selector := diff.Get("spec.0.selector").([]interface{})
+	if len(selector) == 0 || selector[0] == nil {
+		return nil
+	}
+	s := selector[0].(map[string]interface{})
+	if len(s["match_labels"].(map[string]interface{})) == 0 && len(s["match_expressions"].([]interface{})) == 0 {
+		return nil
+	}
+	// The claim is bound to the volume it names, regardless of the provisioner
+	if diff.Get("spec.0.volume_name").(string) != "" {
+		return nil
+	}
+	className := diff.Get("spec.0.storage_class_name").(string)
+	if className == "" || className == config.UnknownVariableValue {
+		return nil
+	}
+
+	kp, ok := meta.(*kubernetesProvider)
+	if !ok || kp.conn == nil {
+		return nil
+	}
+	class, err := kp.conn.StorageV1().StorageClasses().Get(className, meta_v1.GetOptions{})
+	if err != nil {
+		// Without a storage class object, the class name only matches pre-provisioned volumes
+		if !errors.IsNotFound(err) {
+			log.Printf("[WARN] Can't read storage class %q to check it against spec.0.selector: %s", className, err)
+		}
+		return nil
+	}
+	if class.Provisioner == "kubernetes.io/no-provisioner" {
+		return nil
+	}
+
+	// Label values which are only known after apply can't be matched yet
+	labelSelector, err := meta_v1.LabelSelectorAsSelector(expandLabelSelector(selector))
+	if err != nil || strings.Contains(labelSelector.String(), config.UnknownVariableValue) {
+		return nil
+	}
+	pvs, err := kp.conn.CoreV1().PersistentVolumes().List(meta_v1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		log.Printf("[WARN] Can't list the persistent volumes matching spec.0.selector: %s", err)
+		return nil
+	}
+	for _, pv := range pvs.Items {
+		if pv.Status.Phase == api.VolumeAvailable && pv.Spec.StorageClassName == className {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Persistent volume claim %q selects volumes by spec.0.selector, but no Available volume of storage class %q matches it "+
+		"and the class provisions volumes dynamically with %s. "+
+		"Volumes aren't provisioned for a claim with a selector, it would stay Pending until a volume of the class matching the selector exists. "+
+		"Either remove the selector to have a volume provisioned, use the storage class of pre-provisioned volumes "+
+		"or set spec.0.volume_name to bind the claim to an existing volume.",
+		diff.Get("metadata.0.name"), className, class.Provisioner)
 }
 
 func resourceKubernetesPersistentVolumeClaimCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec, err := expandPersistentVolumeClaimSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return err
 	}
+	// An inherited storage class isn't configured, an adopted claim may request another one
+	configured := spec
+	if spec.VolumeName != "" && spec.StorageClassName == nil {
+		pv, err := conn.CoreV1().PersistentVolumes().Get(spec.VolumeName, meta_v1.GetOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if err == nil {
+			inheritStorageClassName(&spec, pv)
+		}
+	} else if spec.Selector != nil && spec.StorageClassName == nil {
+		selector, err := meta_v1.LabelSelectorAsSelector(spec.Selector)
+		if err != nil {
+			return err
+		}
+		pvs, err := conn.CoreV1().PersistentVolumes().List(meta_v1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return err
+		}
+		inheritStorageClassName(&spec, availableVolumeOfSingleStorageClass(pvs.Items))
+	}
 
 	claim := api.PersistentVolumeClaim{
 		ObjectMeta: metadata,
@@ -50,9 +303,19 @@ func resourceKubernetesPersistentVolumeClaimCreate(d *schema.ResourceData, meta
 	}
 
 	log.Printf("[INFO] Creating new persistent volume claim: %#v", claim)
-	out, err := conn.CoreV1().PersistentVolumeClaims(metadata.Namespace).Create(&claim)
+	out, err := createPersistentVolumeClaim(conn, &claim, d.Get("spec.0.volume_attributes_class_name").(string))
 	if err != nil {
-		return err
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+		if !d.Get("adopt_existing").(bool) {
+			return alreadyExistsError(err, "kubernetes_persistent_volume_claim", metadata,
+				"Alternatively set adopt_existing = true to adopt the existing claim.")
+		}
+		out, err = adoptPersistentVolumeClaim(conn, metadata, configured)
+		if err != nil {
+			return err
+		}
 	}
 	log.Printf("[INFO] Submitted new persistent volume claim: %#v", out)
 
@@ -76,12 +339,14 @@ func resourceKubernetesPersistentVolumeClaimCreate(d *schema.ResourceData, meta
 				return out, statusPhase, nil
 			},
 		}
+		setWaitPollDelay(d, stateConf)
+		setWaitPollOptions(d, stateConf)
 		_, err = stateConf.WaitForState()
 		if err != nil {
 			var lastWarnings []api.Event
 			var wErr error
 
-			lastWarnings, wErr = getLastWarningsForObject(conn, out.ObjectMeta, "PersistentVolumeClaim", 3)
+			lastWarnings, wErr = getLastWarningsForObject(conn, out.ObjectMeta, "PersistentVolumeClaim", meta.(*kubernetesProvider).warningEventLimit)
 			if wErr != nil {
 				return wErr
 			}
@@ -89,7 +354,7 @@ func resourceKubernetesPersistentVolumeClaimCreate(d *schema.ResourceData, meta
 			if len(lastWarnings) == 0 {
 				lastWarnings, wErr = getLastWarningsForObject(conn, meta_v1.ObjectMeta{
 					Name: out.Spec.VolumeName,
-				}, "PersistentVolume", 3)
+				}, "PersistentVolume", meta.(*kubernetesProvider).warningEventLimit)
 				if wErr != nil {
 					return wErr
 				}
@@ -103,6 +368,164 @@ func resourceKubernetesPersistentVolumeClaimCreate(d *schema.ResourceData, meta
 	return resourceKubernetesPersistentVolumeClaimRead(d, meta)
 }
 
+// createPersistentVolumeClaim creates the claim. The vendored types don't have the
+// volume attributes class, so a claim with a class is created from its raw JSON.
+func createPersistentVolumeClaim(conn *kubernetes.Clientset, claim *api.PersistentVolumeClaim, volumeAttributesClassName string) (*api.PersistentVolumeClaim, error) {
+	if volumeAttributesClassName == "" {
+		return conn.CoreV1().PersistentVolumeClaims(claim.Namespace).Create(claim)
+	}
+
+	data, err := json.Marshal(claim)
+	if err != nil {
+		return nil, err
+	}
+	var body map[string]interface{}
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		return nil, err
+	}
+	body["spec"].(map[string]interface{})["volumeAttributesClassName"] = volumeAttributesClassName
+	data, err = json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := conn.CoreV1().RESTClient().Post().Namespace(claim.Namespace).Resource("persistentvolumeclaims").Body(data).DoRaw()
+	if err != nil {
+		return nil, err
+	}
+	out, _, err := decodePersistentVolumeClaim(raw)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode persistent volume claim %s: %s", buildId(claim.ObjectMeta), err)
+	}
+	return out, nil
+}
+
+// adoptPersistentVolumeClaim takes over an existing claim by merging the configured
+// labels & annotations into it. Other keys are reported as drift by the next plan.
+// The spec of a claim can't be changed: a claim whose spec differs from the configured one
+// in a field which re-creates the claim isn't adopted, the next plan would destroy it.
+func adoptPersistentVolumeClaim(conn *kubernetes.Clientset, metadata meta_v1.ObjectMeta, spec api.PersistentVolumeClaimSpec) (*api.PersistentVolumeClaim, error) {
+	log.Printf("[INFO] Adopting existing persistent volume claim %s", buildId(metadata))
+	live, err := conn.CoreV1().PersistentVolumeClaims(metadata.Namespace).Get(metadata.Name, meta_v1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read persistent volume claim %s to adopt it: %s", buildId(metadata), err)
+	}
+	if replaced := persistentVolumeClaimReplacedFieldsOf(spec, live.Spec); len(replaced) > 0 {
+		return nil, fmt.Errorf("Persistent volume claim %s can't be adopted, the following fields of the existing claim "+
+			"differ from the configuration and can't be updated (configured => live):%s\n\n"+
+			"The next plan would re-create the adopted claim, which loses the data of its volume. "+
+			"Either update the configuration to match the existing claim or delete the claim to create it from the configuration.",
+			buildId(metadata), strings.Join(replaced, ""))
+	}
+
+	// A null map would remove all existing keys in a merge patch
+	m := make(map[string]interface{}, 0)
+	if len(metadata.Labels) > 0 {
+		m["labels"] = metadata.Labels
+	}
+	if len(metadata.Annotations) > 0 {
+		m["annotations"] = metadata.Annotations
+	}
+	data, err := json.Marshal(map[string]interface{}{"metadata": m})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	out, err := patchPersistentVolumeClaimOnLatest(conn, metadata.Namespace, metadata.Name, pkgApi.MergePatchType, data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to adopt persistent volume claim %s: %s", buildId(metadata), err)
+	}
+	log.Printf("[INFO] Adopted persistent volume claim: %#v", out)
+	return out, nil
+}
+
+// persistentVolumeClaimReplacedFieldsOf lists the persistentVolumeClaimReplacedFields in which the live spec differs
+// from the configured one, as plan bullets. The fields which are computed when they aren't configured are only
+// compared when they're configured, the requests other than storage are compared like checkPersistentVolumeClaimResize does.
+func persistentVolumeClaimReplacedFieldsOf(configured, live api.PersistentVolumeClaimSpec) []string {
+	var replaced []string
+	add := func(k string, configured, live interface{}) {
+		replaced = append(replaced, fmt.Sprintf("\n   * spec.0.%s: %v => %v", k, configured, live))
+	}
+
+	configuredModes, liveModes := persistentVolumeAccessModesString(configured.AccessModes), persistentVolumeAccessModesString(live.AccessModes)
+	if configuredModes != liveModes {
+		add("access_modes", configuredModes, liveModes)
+	}
+	if !resourceListsEqual(configured.Resources.Limits, live.Resources.Limits, "") {
+		add("resources.0.limits", configured.Resources.Limits, live.Resources.Limits)
+	}
+	if !resourceListsEqual(configured.Resources.Requests, live.Resources.Requests, api.ResourceStorage) {
+		add("resources.0.requests", configured.Resources.Requests, live.Resources.Requests)
+	}
+	configuredSelector, liveSelector := labelSelectorString(configured.Selector), labelSelectorString(live.Selector)
+	if configuredSelector != liveSelector {
+		add("selector", configuredSelector, liveSelector)
+	}
+	if configured.VolumeName != "" && configured.VolumeName != live.VolumeName {
+		add("volume_name", configured.VolumeName, live.VolumeName)
+	}
+	if configured.StorageClassName != nil && *configured.StorageClassName != "" &&
+		(live.StorageClassName == nil || *live.StorageClassName != *configured.StorageClassName) {
+		liveClass := ""
+		if live.StorageClassName != nil {
+			liveClass = *live.StorageClassName
+		}
+		add("storage_class_name", *configured.StorageClassName, liveClass)
+	}
+	// Claims of API servers which don't know volume modes are Filesystem claims
+	configuredMode, liveMode := api.PersistentVolumeFilesystem, api.PersistentVolumeFilesystem
+	if configured.VolumeMode != nil {
+		configuredMode = *configured.VolumeMode
+	}
+	if live.VolumeMode != nil {
+		liveMode = *live.VolumeMode
+	}
+	if configuredMode != liveMode {
+		add("volume_mode", configuredMode, liveMode)
+	}
+	return replaced
+}
+
+func persistentVolumeAccessModesString(modes []api.PersistentVolumeAccessMode) string {
+	s := make([]string, len(modes))
+	for i, m := range modes {
+		s[i] = string(m)
+	}
+	sort.Strings(s)
+	return strings.Join(s, ",")
+}
+
+// resourceListsEqual reports whether a & b hold equivalent quantities of the same resources, but for ignored.
+func resourceListsEqual(a, b api.ResourceList, ignored api.ResourceName) bool {
+	for name, q := range a {
+		if name == ignored {
+			continue
+		}
+		if other, ok := b[name]; !ok || q.Cmp(other) != 0 {
+			return false
+		}
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok && name != ignored {
+			return false
+		}
+	}
+	return true
+}
+
+// labelSelectorString renders a selector in the label query syntax, a missing selector as well as an empty one as "".
+func labelSelectorString(s *meta_v1.LabelSelector) string {
+	if s == nil || (len(s.MatchLabels) == 0 && len(s.MatchExpressions) == 0) {
+		return ""
+	}
+	selector, err := meta_v1.LabelSelectorAsSelector(s)
+	if err != nil {
+		return s.String()
+	}
+	return selector.String()
+}
+
 func resourceKubernetesPersistentVolumeClaimRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -112,20 +535,57 @@ func resourceKubernetesPersistentVolumeClaimRead(d *schema.ResourceData, meta in
 	}
 
 	log.Printf("[INFO] Reading persistent volume claim %s", name)
-	claim, err := conn.CoreV1().PersistentVolumeClaims(namespace).Get(name, meta_v1.GetOptions{})
+	// The claim is read raw to get at status fields the vendored types don't have
+	raw, err := conn.CoreV1().RESTClient().Get().Namespace(namespace).Resource("persistentvolumeclaims").Name(name).DoRaw()
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Persistent volume claim %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
+	claim, extras, err := decodePersistentVolumeClaim(raw)
+	if err != nil {
+		return fmt.Errorf("Failed to decode persistent volume claim %s: %s", d.Id(), err)
+	}
 	log.Printf("[INFO] Received persistent volume claim: %#v", claim)
-	err = d.Set("metadata", flattenMetadata(claim.ObjectMeta, d))
+
+	err = checkControllerOwner("PersistentVolumeClaim", claim.ObjectMeta, meta)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(claim.ObjectMeta, d, meta))
+	if err != nil {
+		return err
+	}
+	spec := flattenPersistentVolumeClaimSpec(claim.Spec)
+	spec[0].(map[string]interface{})["volume_attributes_class_name"] = extras.Spec.VolumeAttributesClassName
+	err = d.Set("spec", spec)
 	if err != nil {
 		return err
 	}
-	err = d.Set("spec", flattenPersistentVolumeClaimSpec(claim.Spec))
+	err = d.Set("status", flattenPersistentVolumeClaimStatus(claim.Status, extras.Status))
 	if err != nil {
 		return err
 	}
+	volume, err := readBoundPersistentVolume(conn, claim)
+	if err != nil {
+		return err
+	}
+	err = d.Set("persistent_volume", volume)
+	if err != nil {
+		return err
+	}
+	// Set once the claim is created or updated (or first read, e.g. imported)
+	if len(d.Get("applied_spec").(map[string]interface{})) == 0 {
+		err = d.Set("applied_spec", persistentVolumeClaimAppliedSpec(d))
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -138,23 +598,138 @@ func resourceKubernetesPersistentVolumeClaimUpdate(d *schema.ResourceData, meta
 		return err
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
-	// The whole spec is ForceNew = nothing to update there
+	ops := patchMetadataWithDefaults(d, meta)
+	// The rest of the spec is ForceNew = nothing else to update there
+	resized, err := patchPersistentVolumeClaimStorage(d)
+	if err != nil {
+		return err
+	}
+	if resized != nil {
+		ops = append(ops, resized)
+	}
+	if d.HasChange("spec.0.volume_attributes_class_name") {
+		if v := d.Get("spec.0.volume_attributes_class_name").(string); v != "" {
+			ops = append(ops, &AddOperation{
+				Path:  "/spec/volumeAttributesClassName",
+				Value: v,
+			})
+		}
+	}
 	data, err := ops.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("Failed to marshal update operations: %s", err)
 	}
 
 	log.Printf("[INFO] Updating persistent volume claim: %s", ops)
-	out, err := conn.CoreV1().PersistentVolumeClaims(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	out, err := patchPersistentVolumeClaimOnLatest(conn, namespace, name, pkgApi.JSONPatchType, data)
 	if err != nil {
 		return err
 	}
 	log.Printf("[INFO] Submitted updated persistent volume claim: %#v", out)
 
+	if resized != nil && d.Get("wait_until_resized").(bool) {
+		err = waitForPersistentVolumeClaimResize(d, meta, out)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The plan passed checkPersistentVolumeClaimDrift, so the live immutable fields are the ones to keep
+	err = d.Set("applied_spec", map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+
 	return resourceKubernetesPersistentVolumeClaimRead(d, meta)
 }
 
+// forceNewPersistentVolumeClaimRequests re-creates the claim for a change of its requests other than
+// an increase of the storage, unless the provider is configured not to re-create objects.
+func forceNewPersistentVolumeClaimRequests(diff *schema.ResourceDiff, meta interface{}) error {
+	err := checkImmutableFieldChanges(diff, meta, "persistent volume claim", "spec.0.resources.0.requests")
+	if err != nil {
+		return err
+	}
+	return diff.ForceNew("spec.0.resources.0.requests")
+}
+
+// patchPersistentVolumeClaimStorage returns the operation increasing the storage request of the claim,
+// nil when it didn't change. It fails on a decrease, which the plan rejects already.
+func patchPersistentVolumeClaimStorage(d *schema.ResourceData) (PatchOperation, error) {
+	const key = "spec.0.resources.0.requests.storage"
+	if !d.HasChange(key) {
+		return nil, nil
+	}
+	oldV, newV := d.GetChange(key)
+	oldQ, err := k8sresource.ParseQuantity(oldV.(string))
+	if err != nil {
+		return nil, err
+	}
+	newQ, err := k8sresource.ParseQuantity(newV.(string))
+	if err != nil {
+		return nil, err
+	}
+	if newQ.Cmp(oldQ) < 0 {
+		return nil, fmt.Errorf("The storage request of persistent volume claim %s can't be decreased from %s to %s, Kubernetes only expands volumes",
+			d.Id(), oldV, newV)
+	}
+	log.Printf("[INFO] Expanding persistent volume claim %s from %s to %s", d.Id(), oldV, newV)
+	return &ReplaceOperation{
+		Path:  "/spec/resources/requests/storage",
+		Value: newV.(string),
+	}, nil
+}
+
+// waitForPersistentVolumeClaimResize waits until the capacity of the claim reaches its storage request.
+// The volume is expanded by the controller first, then its file system by the kubelet of the node the claim
+// is used on. The wait ends once only the file system is left, it's only resized when a pod (re)starts.
+func waitForPersistentVolumeClaimResize(d *schema.ResourceData, meta interface{}, claim *api.PersistentVolumeClaim) error {
+	conn := meta.(*kubernetesProvider).conn
+	requested := claim.Spec.Resources.Requests[api.ResourceStorage]
+
+	stateConf := &resource.StateChangeConf{
+		Target:  []string{"Resized"},
+		Pending: []string{"Resizing"},
+		Timeout: d.Timeout(schema.TimeoutUpdate),
+		Refresh: func() (interface{}, string, error) {
+			out, err := conn.CoreV1().PersistentVolumeClaims(claim.Namespace).Get(claim.Name, meta_v1.GetOptions{})
+			if err != nil {
+				log.Printf("[ERROR] Received error: %#v", err)
+				return out, "", err
+			}
+			state := persistentVolumeClaimResizeState(out, requested)
+			log.Printf("[DEBUG] Persistent volume claim %s is %s", out.Name, state)
+			return out, state, nil
+		},
+	}
+	setWaitPollOptions(d, stateConf)
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		lastWarnings, wErr := getLastWarningsForObject(conn, claim.ObjectMeta, "PersistentVolumeClaim", meta.(*kubernetesProvider).warningEventLimit)
+		if wErr != nil {
+			return wErr
+		}
+		return fmt.Errorf("Persistent volume claim %s wasn't expanded to %s: %s%s", d.Id(), requested.String(), err, stringifyEvents(lastWarnings))
+	}
+	return nil
+}
+
+// persistentVolumeClaimResizeState is `Resized` once the capacity of the claim reaches the requested
+// storage, or only the resize of the file system is pending, and `Resizing` until then.
+func persistentVolumeClaimResizeState(claim *api.PersistentVolumeClaim, requested k8sresource.Quantity) string {
+	if capacity, ok := claim.Status.Capacity[api.ResourceStorage]; ok && capacity.Cmp(requested) >= 0 {
+		return "Resized"
+	}
+	for _, c := range claim.Status.Conditions {
+		if c.Type == api.PersistentVolumeClaimFileSystemResizePending && c.Status == api.ConditionTrue {
+			log.Printf("[WARN] The file system of persistent volume claim %s/%s is resized once a pod using it is (re)started",
+				claim.Namespace, claim.Name)
+			return "Resized"
+		}
+	}
+	return "Resizing"
+}
+
 func resourceKubernetesPersistentVolumeClaimDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -163,18 +738,211 @@ func resourceKubernetesPersistentVolumeClaimDelete(d *schema.ResourceData, meta
 		return err
 	}
 
+	// The claim is gone once it's deleted, the volume has to be looked up before
+	var volume *api.PersistentVolume
+	if d.Get("wait_for_volume_release").(bool) {
+		volume, err = boundPersistentVolume(conn, namespace, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutDelete))
 	log.Printf("[INFO] Deleting persistent volume claim: %#v", name)
-	err = conn.CoreV1().PersistentVolumeClaims(namespace).Delete(name, &meta_v1.DeleteOptions{})
+	err = conn.CoreV1().PersistentVolumeClaims(namespace).Delete(name, deleteOptions(d, meta_v1.DeletePropagationBackground))
 	if err != nil {
 		return err
 	}
 
+	err = waitForPersistentVolumeClaimDeletion(time.Until(deadline), d, meta, namespace, name)
+	if err != nil {
+		return err
+	}
 	log.Printf("[INFO] Persistent volume claim %s deleted", name)
 
+	if volume != nil {
+		err = waitForPersistentVolumeRelease(time.Until(deadline), d, meta, volume)
+		if err != nil {
+			return err
+		}
+	}
+
 	d.SetId("")
 	return nil
 }
 
+// waitForPersistentVolumeClaimDeletion waits until a deleted claim is gone. A claim still used by a pod
+// is kept Terminating by the `kubernetes.io/pvc-protection` finalizer, a claim of the same name
+// created meanwhile would fail. On timeout the error tells what holds the claim.
+func waitForPersistentVolumeClaimDeletion(timeout time.Duration, d *schema.ResourceData, meta interface{}, namespace, name string) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	var last *api.PersistentVolumeClaim
+	stateConf := &resource.StateChangeConf{
+		Target:  []string{"Deleted"},
+		Pending: []string{"Terminating"},
+		Timeout: timeout,
+		Refresh: func() (interface{}, string, error) {
+			out, err := conn.CoreV1().PersistentVolumeClaims(namespace).Get(name, meta_v1.GetOptions{})
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return &api.PersistentVolumeClaim{}, "Deleted", nil
+				}
+				log.Printf("[ERROR] Received error: %#v", err)
+				return nil, "", err
+			}
+			last = out
+			log.Printf("[DEBUG] Persistent volume claim %s is terminating, finalizers: %q", out.Name, out.Finalizers)
+			return out, "Terminating", nil
+		},
+	}
+	setWaitPollOptions(d, stateConf)
+	_, err := stateConf.WaitForState()
+	if err == nil {
+		return nil
+	}
+	if last == nil {
+		return err
+	}
+
+	pods, pErr := podsUsingPersistentVolumeClaim(conn, namespace, name)
+	if pErr != nil {
+		log.Printf("[WARN] Failed to list the pods using persistent volume claim %s/%s: %s", namespace, name, pErr)
+	}
+	lastWarnings, wErr := getLastWarningsForObject(conn, last.ObjectMeta, "PersistentVolumeClaim", meta.(*kubernetesProvider).warningEventLimit)
+	if wErr != nil {
+		return wErr
+	}
+	return fmt.Errorf("%s%s%s%s", err, stringifyFinalizers(last.Finalizers), stringifyPodsUsingClaim(pods), stringifyEvents(lastWarnings))
+}
+
+// podsUsingPersistentVolumeClaim lists the names of the pods which still use the claim,
+// the pvc-protection finalizer isn't removed until they're all terminated.
+func podsUsingPersistentVolumeClaim(conn *kubernetes.Clientset, namespace, name string) ([]string, error) {
+	pods, err := conn.CoreV1().Pods(namespace).List(meta_v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, p := range pods.Items {
+		if p.Status.Phase == api.PodSucceeded || p.Status.Phase == api.PodFailed {
+			continue
+		}
+		for _, v := range p.Spec.Volumes {
+			if v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == name {
+				names = append(names, p.Name)
+				break
+			}
+		}
+	}
+	return names, nil
+}
+
+func stringifyPodsUsingClaim(pods []string) string {
+	var output string
+	for _, p := range pods {
+		output += fmt.Sprintf("\n   * used by pod %q", p)
+	}
+	return output
+}
+
+// readBoundPersistentVolume reads the volume the claim is bound to, flattened. Volumes are cluster-wide,
+// a claim managed by someone who may not read them is read without its volume.
+func readBoundPersistentVolume(conn *kubernetes.Clientset, claim *api.PersistentVolumeClaim) ([]interface{}, error) {
+	if claim.Status.Phase != api.ClaimBound || claim.Spec.VolumeName == "" {
+		return []interface{}{}, nil
+	}
+	log.Printf("[INFO] Reading persistent volume %s bound to claim %s", claim.Spec.VolumeName, buildId(claim.ObjectMeta))
+	volume, err := conn.CoreV1().PersistentVolumes().Get(claim.Spec.VolumeName, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) || errors.IsForbidden(err) {
+			log.Printf("[WARN] Persistent volume %s of claim %s can't be read: %s", claim.Spec.VolumeName, buildId(claim.ObjectMeta), err)
+			return []interface{}{}, nil
+		}
+		return nil, err
+	}
+	return flattenBoundPersistentVolume(volume), nil
+}
+
+// boundPersistentVolume returns the volume bound to the claim, nil if there's none.
+func boundPersistentVolume(conn *kubernetes.Clientset, namespace, name string) (*api.PersistentVolume, error) {
+	claim, err := conn.CoreV1().PersistentVolumeClaims(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if claim.Spec.VolumeName == "" {
+		return nil, nil
+	}
+	volume, err := conn.CoreV1().PersistentVolumes().Get(claim.Spec.VolumeName, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return volume, nil
+}
+
+// waitForPersistentVolumeRelease waits until the volume of a deleted claim is reclaimed
+// according to its reclaim policy, failing with its warning events when it can't be.
+func waitForPersistentVolumeRelease(timeout time.Duration, d *schema.ResourceData, meta interface{}, volume *api.PersistentVolume) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	policy := volume.Spec.PersistentVolumeReclaimPolicy
+	log.Printf("[DEBUG] Waiting for persistent volume %s to be reclaimed (%s)", volume.Name, policy)
+	stateConf := &resource.StateChangeConf{
+		Target:  persistentVolumeReleaseTargets(policy),
+		Pending: []string{string(api.VolumeBound), string(api.VolumeReleased), string(api.VolumeAvailable), string(api.VolumePending)},
+		Timeout: timeout,
+		Refresh: persistentVolumeReleaseRefreshFunc(conn, volume.Name),
+	}
+	setWaitPollOptions(d, stateConf)
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		lastWarnings, wErr := getLastWarningsForObject(conn, meta_v1.ObjectMeta{Name: volume.Name}, "PersistentVolume", meta.(*kubernetesProvider).warningEventLimit)
+		if wErr != nil {
+			return wErr
+		}
+		return fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+	}
+	log.Printf("[INFO] Persistent volume %s reclaimed", volume.Name)
+	return nil
+}
+
+// persistentVolumeDeleted is the state of a volume which doesn't exist anymore
+const persistentVolumeDeleted = "Deleted"
+
+// persistentVolumeReleaseTargets are the states of a reclaimed volume. A volume deleted by
+// anything else than its reclaim policy is done with too.
+func persistentVolumeReleaseTargets(policy api.PersistentVolumeReclaimPolicy) []string {
+	switch policy {
+	case api.PersistentVolumeReclaimRetain:
+		return []string{string(api.VolumeReleased), persistentVolumeDeleted}
+	case api.PersistentVolumeReclaimRecycle:
+		return []string{string(api.VolumeAvailable), persistentVolumeDeleted}
+	default:
+		return []string{persistentVolumeDeleted}
+	}
+}
+
+func persistentVolumeReleaseRefreshFunc(conn *kubernetes.Clientset, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		volume, err := conn.CoreV1().PersistentVolumes().Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return &api.PersistentVolume{}, persistentVolumeDeleted, nil
+			}
+			return nil, "", err
+		}
+		log.Printf("[DEBUG] Persistent volume %s status received: %#v", name, volume.Status.Phase)
+		if volume.Status.Phase == api.VolumeFailed {
+			return volume, "", fmt.Errorf("Persistent volume %s failed to be reclaimed (%s): %s",
+				name, volume.Spec.PersistentVolumeReclaimPolicy, volume.Status.Message)
+		}
+		return volume, string(volume.Status.Phase), nil
+	}
+}
+
 func resourceKubernetesPersistentVolumeClaimExists(d *schema.ResourceData, meta interface{}) (bool, error) {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -186,10 +954,24 @@ func resourceKubernetesPersistentVolumeClaimExists(d *schema.ResourceData, meta
 	log.Printf("[INFO] Checking persistent volume claim %s", name)
 	_, err = conn.CoreV1().PersistentVolumeClaims(namespace).Get(name, meta_v1.GetOptions{})
 	if err != nil {
-		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+		if errors.IsNotFound(err) {
 			return false, nil
 		}
+		// Any other error doesn't tell whether the claim is gone, it's kept in the state
 		log.Printf("[DEBUG] Received error: %#v", err)
+		return true, fmt.Errorf("Failed to check whether persistent volume claim %s exists: %s", d.Id(), err)
 	}
-	return true, err
+	return true, nil
+}
+
+// patchPersistentVolumeClaimOnLatest applies the patch data of an update to the latest version of the persistent volume claim, see patchOnLatest.
+func patchPersistentVolumeClaimOnLatest(conn *kubernetes.Clientset, namespace, name string, patchType pkgApi.PatchType, data []byte) (out *api.PersistentVolumeClaim, err error) {
+	client := conn.CoreV1().PersistentVolumeClaims(namespace)
+	err = patchOnLatest(patchType, data, func() (meta_v1.Object, error) {
+		return client.Get(name, meta_v1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, patchType, data)
+		return err
+	})
+	return out, err
 }
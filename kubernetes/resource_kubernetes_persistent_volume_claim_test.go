@@ -1,16 +1,28 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	api "k8s.io/api/core/v1"
 	storageapi "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
 )
 
 func TestAccKubernetesPersistentVolumeClaim_basic(t *testing.T) {
@@ -44,6 +56,7 @@ func TestAccKubernetesPersistentVolumeClaim_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_persistent_volume_claim.test", "spec.0.resources.#", "1"),
 					resource.TestCheckResourceAttr("kubernetes_persistent_volume_claim.test", "spec.0.resources.0.requests.%", "1"),
 					resource.TestCheckResourceAttr("kubernetes_persistent_volume_claim.test", "spec.0.resources.0.requests.storage", "5Gi"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume_claim.test", "spec.0.volume_mode", "Filesystem"),
 				),
 			},
 			{ // GKE specific check
@@ -128,6 +141,35 @@ func TestAccKubernetesPersistentVolumeClaim_basic(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesPersistentVolumeClaim_deletedOutOfBand(t *testing.T) {
+	var conf api.PersistentVolumeClaim
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPersistentVolumeClaimDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPersistentVolumeClaimConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPersistentVolumeClaimExists("kubernetes_persistent_volume_claim.test", &conf),
+					testAccDeleteKubernetesPersistentVolumeClaim(&conf),
+				),
+				// The refresh drops the claim from the state, so it's planned to be recreated
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccKubernetesPersistentVolumeClaimConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPersistentVolumeClaimExists("kubernetes_persistent_volume_claim.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume_claim.test", "metadata.0.name", name),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesPersistentVolumeClaim_googleCloud_importBasic(t *testing.T) {
 	resourceName := "kubernetes_persistent_volume_claim.test"
 	volumeName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
@@ -444,6 +486,306 @@ func TestAccKubernetesPersistentVolumeClaim_googleCloud_storageClass(t *testing.
 	})
 }
 
+func TestAccKubernetesPersistentVolumeClaim_eventuallyBound(t *testing.T) {
+	var conf api.PersistentVolumeClaim
+	claimName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	volumeName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPersistentVolumeClaimDestroy,
+		Steps: []resource.TestStep{
+			{
+				// Without a matching volume the claim stays pending, create must not block
+				Config: testAccKubernetesPersistentVolumeClaimConfig_eventuallyBound(claimName, volumeName, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPersistentVolumeClaimExists("kubernetes_persistent_volume_claim.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume_claim.test", "status.0.phase", "Pending"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume_claim.test", "spec.0.volume_name", ""),
+				),
+			},
+			{
+				Config: testAccKubernetesPersistentVolumeClaimConfig_eventuallyBound(claimName, volumeName, true),
+				Check:  testAccCheckKubernetesPersistentVolumeClaimPhase("kubernetes_persistent_volume_claim.test", api.ClaimBound),
+			},
+			{
+				// The refresh before this step records the claim as bound
+				Config: testAccKubernetesPersistentVolumeClaimConfig_eventuallyBound(claimName, volumeName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume_claim.test", "status.0.phase", "Bound"),
+					resource.TestCheckResourceAttr("kubernetes_persistent_volume_claim.test", "spec.0.volume_name", volumeName),
+				),
+			},
+		},
+	})
+}
+
+func TestAlreadyExistsError(t *testing.T) {
+	meta := meta_v1.ObjectMeta{Namespace: "default", Name: "data"}
+	conflict := errors.NewAlreadyExists(api.Resource("persistentvolumeclaims"), "data")
+	err := alreadyExistsError(conflict, "kubernetes_persistent_volume_claim", meta, "Alternatively set adopt_existing = true to adopt the existing claim.")
+	for _, expected := range []string{
+		"terraform import kubernetes_persistent_volume_claim.<name> default/data",
+		"\n\nAlternatively set adopt_existing = true",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected error to contain %q, given: %s", expected, err)
+		}
+	}
+	if !errors.IsAlreadyExists(err) {
+		t.Fatalf("Expected the error to keep its AlreadyExists status, given: %#v", err)
+	}
+
+	// The status is reported once the error is wrapped for the resource
+	wrapped := &apiError{Verb: "create", Kind: "persistent_volume_claim", Name: "default/data", Err: err}
+	if expected := "kubernetes: create persistent_volume_claim/default/data: 409 AlreadyExists: "; !strings.HasPrefix(wrapped.Error(), expected) {
+		t.Fatalf("Expected error to start with %q, given: %s", expected, wrapped)
+	}
+}
+
+func TestPersistentVolumeClaimExists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/persistentvolumeclaims/data":
+			fmt.Fprint(w, `{"kind": "PersistentVolumeClaim", "apiVersion": "v1", "metadata": {"name": "data", "namespace": "default"}}`)
+		case "/api/v1/namespaces/default/persistentvolumeclaims/gone":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound", "code": 404}`)
+		case "/api/v1/namespaces/default/persistentvolumeclaims/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "InternalError", "code": 500}`)
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := resourceKubernetesPersistentVolumeClaim()
+
+	cases := []struct {
+		Name          string
+		Expected      bool
+		ExpectedError string
+	}{
+		{"data", true, ""},
+		{"gone", false, ""},
+		{"broken", true, "Failed to check whether persistent volume claim default/broken exists"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := r.Data(nil)
+			d.SetId("default/" + tc.Name)
+
+			exists, err := resourceKubernetesPersistentVolumeClaimExists(d, &kubernetesProvider{conn: conn})
+			if exists != tc.Expected {
+				t.Fatalf("Expected exists to be %t, given: %t", tc.Expected, exists)
+			}
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestAdoptPersistentVolumeClaim(t *testing.T) {
+	var patch string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/default/persistentvolumeclaims/data" || (r.Method != "GET" && r.Method != "PATCH") {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"kind": "PersistentVolumeClaim", "apiVersion": "v1",
+	"metadata": {"name": "data", "namespace": "default", "resourceVersion": "7", "labels": {"other": "x"}},
+	"spec": {"accessModes": ["ReadWriteOnce"], "resources": {"requests": {"storage": "10Gi"}},
+		"storageClassName": "standard", "volumeName": "pv-data", "volumeMode": "Filesystem"}}`)
+			return
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/merge-patch+json" {
+			t.Errorf("Expected a merge patch, given: %s", ct)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		patch = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind": "PersistentVolumeClaim", "apiVersion": "v1",
+	"metadata": {"name": "data", "namespace": "default", "labels": {"app": "db", "other": "x"}}}`)
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The storage class & volume are computed when they aren't configured, the storage request may be expanded
+	spec := api.PersistentVolumeClaimSpec{
+		AccessModes: []api.PersistentVolumeAccessMode{api.ReadWriteOnce},
+		Resources: api.ResourceRequirements{
+			Requests: api.ResourceList{api.ResourceStorage: k8sresource.MustParse("5Gi")},
+		},
+	}
+	out, err := adoptPersistentVolumeClaim(conn, meta_v1.ObjectMeta{
+		Namespace: "default",
+		Name:      "data",
+		Labels:    map[string]string{"app": "db"},
+	}, spec)
+	if err != nil {
+		t.Fatalf("Expected the claim to be adopted, given: %s", err)
+	}
+	if out.Name != "data" {
+		t.Fatalf("Expected the adopted claim to be returned, given: %#v", out)
+	}
+	expected := `{"metadata":{"labels":{"app":"db"},"resourceVersion":"7"}}`
+	if patch != expected {
+		t.Fatalf("Expected patch %s, given: %s", expected, patch)
+	}
+
+	// A claim which the next plan would re-create isn't adopted
+	patch = ""
+	spec.AccessModes = []api.PersistentVolumeAccessMode{api.ReadWriteMany}
+	spec.StorageClassName = ptrToString("fast")
+	_, err = adoptPersistentVolumeClaim(conn, meta_v1.ObjectMeta{Namespace: "default", Name: "data"}, spec)
+	for _, e := range []string{"spec.0.access_modes: ReadWriteMany => ReadWriteOnce", "spec.0.storage_class_name: fast => standard"} {
+		if err == nil || !strings.Contains(err.Error(), e) {
+			t.Fatalf("Expected error to contain %q, given: %v", e, err)
+		}
+	}
+	if patch != "" {
+		t.Fatalf("Expected the claim not to be patched, given: %s", patch)
+	}
+}
+
+func TestPersistentVolumeClaimReplacedFieldsOf(t *testing.T) {
+	filesystem, block := api.PersistentVolumeFilesystem, api.PersistentVolumeBlock
+	live := api.PersistentVolumeClaimSpec{
+		AccessModes: []api.PersistentVolumeAccessMode{api.ReadWriteOnce, api.ReadOnlyMany},
+		Resources: api.ResourceRequirements{
+			Requests: api.ResourceList{api.ResourceStorage: k8sresource.MustParse("10Gi")},
+		},
+		Selector:         &meta_v1.LabelSelector{MatchLabels: map[string]string{"disk": "ssd"}},
+		StorageClassName: ptrToString("standard"),
+		VolumeName:       "pv-data",
+		VolumeMode:       &filesystem,
+	}
+
+	cases := []struct {
+		Name     string
+		Modify   func(spec *api.PersistentVolumeClaimSpec)
+		Expected []string
+	}{
+		{"same", func(*api.PersistentVolumeClaimSpec) {}, nil},
+		{"computed fields left unset", func(spec *api.PersistentVolumeClaimSpec) {
+			spec.StorageClassName = nil
+			spec.VolumeName = ""
+			spec.VolumeMode = nil
+		}, nil},
+		{"access modes in another order", func(spec *api.PersistentVolumeClaimSpec) {
+			spec.AccessModes = []api.PersistentVolumeAccessMode{api.ReadOnlyMany, api.ReadWriteOnce}
+		}, nil},
+		{"equivalent storage request", func(spec *api.PersistentVolumeClaimSpec) {
+			spec.Resources.Requests = api.ResourceList{api.ResourceStorage: k8sresource.MustParse("10240Mi")}
+		}, nil},
+		{"other request", func(spec *api.PersistentVolumeClaimSpec) {
+			spec.Resources.Requests[api.ResourceEphemeralStorage] = k8sresource.MustParse("1Gi")
+		}, []string{"spec.0.resources.0.requests"}},
+		{"limits", func(spec *api.PersistentVolumeClaimSpec) {
+			spec.Resources.Limits = api.ResourceList{api.ResourceStorage: k8sresource.MustParse("20Gi")}
+		}, []string{"spec.0.resources.0.limits"}},
+		{"selector", func(spec *api.PersistentVolumeClaimSpec) {
+			spec.Selector = nil
+		}, []string{"spec.0.selector:  => disk=ssd"}},
+		{"volume name", func(spec *api.PersistentVolumeClaimSpec) {
+			spec.VolumeName = "pv-other"
+		}, []string{"spec.0.volume_name: pv-other => pv-data"}},
+		{"volume mode", func(spec *api.PersistentVolumeClaimSpec) {
+			spec.VolumeMode = &block
+		}, []string{"spec.0.volume_mode: Block => Filesystem"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			configured := *live.DeepCopy()
+			tc.Modify(&configured)
+
+			replaced := persistentVolumeClaimReplacedFieldsOf(configured, live)
+			if len(replaced) != len(tc.Expected) {
+				t.Fatalf("Expected %d replaced fields, given: %q", len(tc.Expected), replaced)
+			}
+			for i, e := range tc.Expected {
+				if !strings.Contains(replaced[i], e) {
+					t.Fatalf("Expected %q to contain %q", replaced[i], e)
+				}
+			}
+		})
+	}
+}
+
+func TestCreatePersistentVolumeClaimWithVolumeAttributesClass(t *testing.T) {
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/namespaces/default/persistentvolumeclaims" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &created); err != nil {
+			t.Errorf("Failed to decode created claim: %s", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"kind": "PersistentVolumeClaim", "apiVersion": "v1",
+	"metadata": {"name": "data", "namespace": "default"}, "spec": %s}`, mustMarshalJSON(t, created["spec"]))
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claim := api.PersistentVolumeClaim{
+		ObjectMeta: meta_v1.ObjectMeta{Namespace: "default", Name: "data"},
+		Spec: api.PersistentVolumeClaimSpec{
+			AccessModes: []api.PersistentVolumeAccessMode{api.ReadWriteOnce},
+		},
+	}
+	out, err := createPersistentVolumeClaim(conn, &claim, "gold")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "data" || len(out.Spec.AccessModes) != 1 {
+		t.Fatalf("Expected the created claim to be returned, given: %#v", out)
+	}
+	spec := created["spec"].(map[string]interface{})
+	if spec["volumeAttributesClassName"] != "gold" || spec["accessModes"] == nil {
+		t.Fatalf("Expected the claim to be created with its volume attributes class, given: %#v", spec)
+	}
+}
+
+func mustMarshalJSON(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
 func testAccCheckKubernetesPersistentVolumeClaimDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*kubernetesProvider).conn
 
@@ -492,6 +834,49 @@ func testAccCheckKubernetesPersistentVolumeClaimExists(n string, obj *api.Persis
 	}
 }
 
+// testAccDeleteKubernetesPersistentVolumeClaim deletes the claim out of band and waits
+// for it to be gone, so refreshing it finds it missing.
+func testAccDeleteKubernetesPersistentVolumeClaim(obj *api.PersistentVolumeClaim) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+		err := conn.CoreV1().PersistentVolumeClaims(obj.Namespace).Delete(obj.Name, &meta_v1.DeleteOptions{})
+		if err != nil {
+			return err
+		}
+		return waitForDeletion(time.Minute, "persistent volume claim "+obj.Name, func() error {
+			_, err := conn.CoreV1().PersistentVolumeClaims(obj.Namespace).Get(obj.Name, meta_v1.GetOptions{})
+			return err
+		})
+	}
+}
+
+// testAccCheckKubernetesPersistentVolumeClaimPhase waits for the claim to reach the
+// given phase in the cluster, binding happens asynchronously to the apply.
+func testAccCheckKubernetesPersistentVolumeClaimPhase(n string, phase api.PersistentVolumeClaimPhase) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+		return resource.Retry(2*time.Minute, func() *resource.RetryError {
+			out, err := conn.CoreV1().PersistentVolumeClaims(namespace).Get(name, meta_v1.GetOptions{})
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+			if out.Status.Phase != phase {
+				return resource.RetryableError(fmt.Errorf("Expected claim %s to be %s, given: %s", rs.Primary.ID, phase, out.Status.Phase))
+			}
+			return nil
+		})
+	}
+}
+
 func testAccCheckClaimRef(pv *api.PersistentVolume, expected *ObjectRefStatic) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		or := pv.Spec.ClaimRef
@@ -547,6 +932,56 @@ resource "kubernetes_persistent_volume_claim" "test" {
 `, name)
 }
 
+func testAccKubernetesPersistentVolumeClaimConfig_eventuallyBound(claimName, volumeName string, withVolume bool) string {
+	volume := ""
+	if withVolume {
+		volume = fmt.Sprintf(`
+resource "kubernetes_persistent_volume" "test" {
+	metadata {
+		name = "%s"
+		labels {
+			claim = "%s"
+		}
+	}
+	spec {
+		capacity {
+			storage = "1Gi"
+		}
+		access_modes = ["ReadWriteOnce"]
+		storage_class_name = "tf-acc-test-manual"
+		persistent_volume_source {
+			host_path {
+				path = "/tmp/%s"
+			}
+		}
+	}
+}
+`, volumeName, claimName, volumeName)
+	}
+	return volume + fmt.Sprintf(`
+resource "kubernetes_persistent_volume_claim" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		access_modes = ["ReadWriteOnce"]
+		storage_class_name = "tf-acc-test-manual"
+		resources {
+			requests {
+				storage = "1Gi"
+			}
+		}
+		selector {
+			match_labels {
+				claim = "%s"
+			}
+		}
+	}
+	wait_until_bound = false
+}
+`, claimName, claimName)
+}
+
 func testAccKubernetesPersistentVolumeClaimConfig_metaModified(name string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_persistent_volume_claim" "test" {
@@ -921,3 +1356,456 @@ resource "kubernetes_persistent_volume_claim" "test" {
 }
 `, className, className, claimName)
 }
+
+func TestPersistentVolumeClaimDrift(t *testing.T) {
+	r := resourceKubernetesPersistentVolumeClaim()
+	meta := &kubernetesProvider{immutableFieldBehavior: immutableFieldBehaviorRecreate}
+
+	cases := []struct {
+		Name          string
+		Applied       string
+		Live          string
+		Configured    string
+		ExpectedError string
+	}{
+		{"no change", "5Gi", "5Gi", "5Gi", ""},
+		{"configuration changed", "5Gi", "5Gi", "10Gi", ""},
+		{"expanded outside of Terraform", "5Gi", "8Gi", "5Gi", `spec.0.resources.0.requests.storage: 5Gi => 8Gi`},
+		{"configuration updated to the live claim", "5Gi", "8Gi", "8Gi", ""},
+		{"not tracked yet", "", "8Gi", "5Gi", "can't be decreased from 8Gi to 5Gi"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "default/claim",
+				Attributes: map[string]string{
+					"metadata.#":                          "1",
+					"metadata.0.name":                     "claim",
+					"metadata.0.namespace":                "default",
+					"spec.#":                              "1",
+					"spec.0.access_modes.#":               "1",
+					"spec.0.access_modes.1245328686":      "ReadWriteOnce",
+					"spec.0.resources.#":                  "1",
+					"spec.0.resources.0.requests.%":       "1",
+					"spec.0.resources.0.requests.storage": tc.Live,
+					"spec.0.volume_mode":                  "Filesystem",
+					"wait_until_bound":                    "true",
+				},
+			}
+			if tc.Applied != "" {
+				state.Attributes["applied_spec.%"] = "2"
+				state.Attributes["applied_spec.spec.0.access_modes.1245328686"] = "ReadWriteOnce"
+				state.Attributes["applied_spec.spec.0.resources.0.requests.storage"] = tc.Applied
+			}
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "claim"}},
+				"spec": []map[string]interface{}{{
+					"access_modes": []interface{}{"ReadWriteOnce"},
+					"resources": []map[string]interface{}{{
+						"requests": map[string]interface{}{"storage": tc.Configured},
+					}},
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = r.Diff(state, terraform.NewResourceConfig(raw), meta)
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestPersistentVolumeClaimAppliedSpec(t *testing.T) {
+	r := resourceKubernetesPersistentVolumeClaim()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "claim", "labels": map[string]interface{}{"app": "db"}}},
+		"spec": []interface{}{map[string]interface{}{
+			"access_modes":       []interface{}{"ReadWriteOnce"},
+			"storage_class_name": "standard",
+			"resources": []interface{}{map[string]interface{}{
+				"requests": map[string]interface{}{"storage": "5Gi"},
+			}},
+		}},
+	})
+	d.SetId("default/claim")
+
+	applied := persistentVolumeClaimAppliedSpec(d)
+	expected := map[string]interface{}{
+		"spec.0.resources.0.requests.storage": "5Gi",
+		"spec.0.storage_class_name":           "standard",
+	}
+	for k, v := range expected {
+		if applied[k] != v {
+			t.Fatalf("Expected %s = %q to be applied, given: %#v", k, v, applied)
+		}
+	}
+	for k := range applied {
+		if strings.HasPrefix(k, "metadata.") || strings.HasSuffix(k, ".#") {
+			t.Fatalf("Expected only the immutable fields of the spec to be applied, given: %#v", applied)
+		}
+	}
+}
+
+func TestPersistentVolumeClaimResizeDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/apis/storage.k8s.io/v1/storageclasses/expandable":
+			fmt.Fprint(w, `{"kind": "StorageClass", "apiVersion": "storage.k8s.io/v1",
+	"metadata": {"name": "expandable"}, "provisioner": "pd.csi.storage.gke.io", "allowVolumeExpansion": true}`)
+		case "/apis/storage.k8s.io/v1/storageclasses/fixed":
+			fmt.Fprint(w, `{"kind": "StorageClass", "apiVersion": "storage.k8s.io/v1",
+	"metadata": {"name": "fixed"}, "provisioner": "kubernetes.io/no-provisioner"}`)
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := resourceKubernetesPersistentVolumeClaim()
+	meta := &kubernetesProvider{conn: conn, immutableFieldBehavior: immutableFieldBehaviorRecreate}
+
+	cases := []struct {
+		Name                string
+		StorageClass        string
+		Requests            map[string]interface{}
+		ExpectedRequiresNew bool
+		ExpectedError       string
+	}{
+		{"increase", "expandable", map[string]interface{}{"storage": "10Gi"}, false, ""},
+		{"equivalent", "expandable", map[string]interface{}{"storage": "5120Mi"}, false, ""},
+		{"increase without a storage class", "", map[string]interface{}{"storage": "10Gi"}, false, ""},
+		{"increase of a class without expansion", "fixed", map[string]interface{}{"storage": "10Gi"}, false,
+			`storage class "fixed" doesn't allow volume expansion`},
+		{"decrease", "expandable", map[string]interface{}{"storage": "2Gi"}, false, "can't be decreased from 5Gi to 2Gi"},
+		{"other request", "expandable", map[string]interface{}{"storage": "5Gi", "ephemeral-storage": "1Gi"}, true, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "default/claim",
+				Attributes: map[string]string{
+					"metadata.#":                          "1",
+					"metadata.0.name":                     "claim",
+					"metadata.0.namespace":                "default",
+					"spec.#":                              "1",
+					"spec.0.access_modes.#":               "1",
+					"spec.0.access_modes.1245328686":      "ReadWriteOnce",
+					"spec.0.resources.#":                  "1",
+					"spec.0.resources.0.requests.%":       "1",
+					"spec.0.resources.0.requests.storage": "5Gi",
+					"spec.0.storage_class_name":           tc.StorageClass,
+					"spec.0.volume_mode":                  "Filesystem",
+					"wait_until_bound":                    "true",
+					"wait_until_resized":                  "true",
+				},
+			}
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "claim"}},
+				"spec": []map[string]interface{}{{
+					"access_modes":       []interface{}{"ReadWriteOnce"},
+					"storage_class_name": tc.StorageClass,
+					"resources":          []map[string]interface{}{{"requests": tc.Requests}},
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff, err := r.Diff(state, terraform.NewResourceConfig(raw), meta)
+			if tc.ExpectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+					t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, given: %s", err)
+			}
+			if requiresNew := diff != nil && diff.RequiresNew(); requiresNew != tc.ExpectedRequiresNew {
+				t.Fatalf("Expected the claim to be re-created: %t, given: %#v", tc.ExpectedRequiresNew, diff)
+			}
+		})
+	}
+}
+
+func TestPersistentVolumeClaimResizeState(t *testing.T) {
+	requested := k8sresource.MustParse("10Gi")
+	claim := func(capacity string, conditions ...api.PersistentVolumeClaimConditionType) *api.PersistentVolumeClaim {
+		c := &api.PersistentVolumeClaim{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "data", Namespace: "default"},
+			Status: api.PersistentVolumeClaimStatus{
+				Capacity: api.ResourceList{api.ResourceStorage: k8sresource.MustParse(capacity)},
+			},
+		}
+		for _, t := range conditions {
+			c.Status.Conditions = append(c.Status.Conditions, api.PersistentVolumeClaimCondition{Type: t, Status: api.ConditionTrue})
+		}
+		return c
+	}
+
+	cases := []struct {
+		Name     string
+		Claim    *api.PersistentVolumeClaim
+		Expected string
+	}{
+		{"not expanded yet", claim("5Gi"), "Resizing"},
+		{"expanding the volume", claim("5Gi", api.PersistentVolumeClaimResizing), "Resizing"},
+		{"file system left to resize", claim("5Gi", api.PersistentVolumeClaimFileSystemResizePending), "Resized"},
+		{"expanded", claim("10Gi"), "Resized"},
+		{"expanded beyond the request", claim("12Gi"), "Resized"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if state := persistentVolumeClaimResizeState(tc.Claim, requested); state != tc.Expected {
+				t.Fatalf("Expected %q, given: %q", tc.Expected, state)
+			}
+		})
+	}
+}
+
+func TestWaitForPersistentVolumeRelease(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Policy        api.PersistentVolumeReclaimPolicy
+		Phases        []string
+		ExpectedError string
+	}{
+		{"deleted", api.PersistentVolumeReclaimDelete, []string{"Bound", "Released", ""}, ""},
+		{"retained", api.PersistentVolumeReclaimRetain, []string{"Bound", "Released"}, ""},
+		{"failed", api.PersistentVolumeReclaimDelete, []string{"Released", "Failed"}, "disk is still attached"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			polls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/api/v1/persistentvolumes/pv-data":
+					phase := tc.Phases[len(tc.Phases)-1]
+					if polls < len(tc.Phases) {
+						phase = tc.Phases[polls]
+					}
+					polls++
+					if phase == "" {
+						w.WriteHeader(http.StatusNotFound)
+						fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound", "code": 404}`)
+						return
+					}
+					fmt.Fprintf(w, `{"kind": "PersistentVolume", "apiVersion": "v1", "metadata": {"name": "pv-data"},
+	"spec": {"persistentVolumeReclaimPolicy": %q}, "status": {"phase": %q, "message": "disk is still attached"}}`, tc.Policy, phase)
+				case "/api/v1/events":
+					fmt.Fprint(w, `{"kind": "EventList", "apiVersion": "v1", "items": []}`)
+				default:
+					t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := schema.TestResourceDataRaw(t, resourceKubernetesPersistentVolumeClaim().Schema, map[string]interface{}{
+				"poll_interval": "10ms",
+			})
+			volume := &api.PersistentVolume{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "pv-data"},
+				Spec:       api.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: tc.Policy},
+			}
+
+			err = waitForPersistentVolumeRelease(time.Minute, d, &kubernetesProvider{conn: conn}, volume)
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected the volume to be reclaimed, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestWaitForPersistentVolumeClaimDeletion(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Terminating   int
+		ExpectedError string
+	}{
+		{"deleted", 2, ""},
+		{"held by a pod", 1000, "waiting for finalizer \"kubernetes.io/pvc-protection\"\n   * used by pod \"db-0\""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			polls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/api/v1/namespaces/default/persistentvolumeclaims/data":
+					polls++
+					if polls > tc.Terminating {
+						w.WriteHeader(http.StatusNotFound)
+						fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound", "code": 404}`)
+						return
+					}
+					fmt.Fprint(w, `{"kind": "PersistentVolumeClaim", "apiVersion": "v1",
+	"metadata": {"name": "data", "namespace": "default", "deletionTimestamp": "2018-07-01T12:30:00Z",
+		"finalizers": ["kubernetes.io/pvc-protection"]}, "status": {"phase": "Bound"}}`)
+				case "/api/v1/namespaces/default/pods":
+					fmt.Fprint(w, `{"kind": "PodList", "apiVersion": "v1", "items": [
+	{"metadata": {"name": "db-0"}, "spec": {"volumes": [{"name": "data", "persistentVolumeClaim": {"claimName": "data"}}]},
+		"status": {"phase": "Running"}},
+	{"metadata": {"name": "migrate"}, "spec": {"volumes": [{"name": "data", "persistentVolumeClaim": {"claimName": "data"}}]},
+		"status": {"phase": "Succeeded"}},
+	{"metadata": {"name": "web"}, "spec": {}, "status": {"phase": "Running"}}]}`)
+				case "/api/v1/namespaces/default/events":
+					fmt.Fprint(w, `{"kind": "EventList", "apiVersion": "v1", "items": []}`)
+				default:
+					t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := schema.TestResourceDataRaw(t, resourceKubernetesPersistentVolumeClaim().Schema, map[string]interface{}{
+				"poll_interval": "10ms",
+			})
+
+			err = waitForPersistentVolumeClaimDeletion(200*time.Millisecond, d, &kubernetesProvider{conn: conn}, "default", "data")
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected the claim to be deleted, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestBoundPersistentVolume(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/persistentvolumeclaims/data":
+			fmt.Fprint(w, `{"kind": "PersistentVolumeClaim", "apiVersion": "v1",
+	"metadata": {"name": "data", "namespace": "default"}, "spec": {"volumeName": "pv-data"}}`)
+		case "/api/v1/namespaces/default/persistentvolumeclaims/pending":
+			fmt.Fprint(w, `{"kind": "PersistentVolumeClaim", "apiVersion": "v1",
+	"metadata": {"name": "pending", "namespace": "default"}, "spec": {}}`)
+		case "/api/v1/persistentvolumes/pv-data":
+			fmt.Fprint(w, `{"kind": "PersistentVolume", "apiVersion": "v1", "metadata": {"name": "pv-data"},
+	"spec": {"persistentVolumeReclaimPolicy": "Delete"}, "status": {"phase": "Bound"}}`)
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	volume, err := boundPersistentVolume(conn, "default", "data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume == nil || volume.Name != "pv-data" || volume.Spec.PersistentVolumeReclaimPolicy != api.PersistentVolumeReclaimDelete {
+		t.Fatalf("Expected the bound volume, given: %#v", volume)
+	}
+	// A claim which isn't bound has no volume to wait for
+	volume, err = boundPersistentVolume(conn, "default", "pending")
+	if err != nil || volume != nil {
+		t.Fatalf("Expected no volume, given: %#v, %v", volume, err)
+	}
+}
+
+func TestReadBoundPersistentVolume(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/persistentvolumes/pv-data":
+			fmt.Fprint(w, `{"kind": "PersistentVolume", "apiVersion": "v1", "metadata": {"name": "pv-data"},
+	"spec": {"accessModes": ["ReadWriteOnce"], "capacity": {"storage": "10Gi"}, "storageClassName": "ssd",
+		"persistentVolumeReclaimPolicy": "Delete", "csi": {"driver": "pd.csi.storage.gke.io", "volumeHandle": "disk-1"}},
+	"status": {"phase": "Bound"}}`)
+		case "/api/v1/persistentvolumes/pv-hidden":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "Forbidden", "code": 403}`)
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim := func(volumeName string, phase api.PersistentVolumeClaimPhase) *api.PersistentVolumeClaim {
+		return &api.PersistentVolumeClaim{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "data", Namespace: "default"},
+			Spec:       api.PersistentVolumeClaimSpec{VolumeName: volumeName},
+			Status:     api.PersistentVolumeClaimStatus{Phase: phase},
+		}
+	}
+
+	volume, err := readBoundPersistentVolume(conn, claim("pv-data", api.ClaimBound))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(volume) != 1 {
+		t.Fatalf("Expected the bound volume, given: %#v", volume)
+	}
+	att := volume[0].(map[string]interface{})
+	if att["name"] != "pv-data" || att["storage_class_name"] != "ssd" || att["reclaim_policy"] != "Delete" ||
+		att["csi_driver"] != "pd.csi.storage.gke.io" || att["volume_handle"] != "disk-1" {
+		t.Fatalf("Unexpected volume: %#v", att)
+	}
+	if capacity := att["capacity"].(map[string]string); capacity["storage"] != "10Gi" {
+		t.Fatalf("Unexpected capacity: %#v", capacity)
+	}
+	if modes := att["access_modes"].(*schema.Set); modes.Len() != 1 || !modes.Contains("ReadWriteOnce") {
+		t.Fatalf("Unexpected access modes: %#v", modes.List())
+	}
+
+	// A volume which may not be read is left out, the claim is still read
+	volume, err = readBoundPersistentVolume(conn, claim("pv-hidden", api.ClaimBound))
+	if err != nil || len(volume) != 0 {
+		t.Fatalf("Expected no volume, given: %#v, %v", volume, err)
+	}
+	// A pending claim isn't bound yet even if it names its volume
+	volume, err = readBoundPersistentVolume(conn, claim("pv-data", api.ClaimPending))
+	if err != nil || len(volume) != 0 {
+		t.Fatalf("Expected no volume, given: %#v, %v", volume, err)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesPod() *schema.Resource {
@@ -22,10 +23,24 @@ func resourceKubernetesPod() *schema.Resource {
 		Delete: resourceKubernetesPodDelete,
 		Exists: resourceKubernetesPodExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateWithDefaults(map[string]interface{}{"validate_node_name": false, "force": false}),
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		CustomizeDiff: resourceKubernetesPodCustomizeDiff,
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("pod", true),
+			"metadata":             namespacedMetadataSchema("pod", true),
+			"grace_period_seconds": podGracePeriodSchema(false),
+			"force":                forcePodDeletionSchema(false),
+			"propagation_policy":   deletePropagationPolicySchema(""),
+			"validate_node_name": {
+				Type:        schema.TypeBool,
+				Description: "Whether to check at plan time that the node set in `spec.0.node_name` exists. A pod bound to a missing node is never started.",
+				Optional:    true,
+				Default:     false,
+			},
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec of the pod owned by the cluster",
@@ -41,11 +56,12 @@ func resourceKubernetesPod() *schema.Resource {
 func resourceKubernetesPodCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec, err := expandPodSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return err
 	}
+	clearUnsetSecurityContextFields(d, "spec.0.", &spec)
 
 	pod := api.Pod{
 		ObjectMeta: metadata,
@@ -62,16 +78,19 @@ func resourceKubernetesPodCreate(d *schema.ResourceData, meta interface{}) error
 
 	d.SetId(buildId(out.ObjectMeta))
 
+	// The last pod polled tells why it isn't running, the wait doesn't return it on timeout
+	var last *api.Pod
 	stateConf := &resource.StateChangeConf{
 		Target:  []string{"Running"},
 		Pending: []string{"Pending"},
-		Timeout: 5 * time.Minute,
+		Timeout: d.Timeout(schema.TimeoutCreate),
 		Refresh: func() (interface{}, string, error) {
 			out, err := conn.CoreV1().Pods(metadata.Namespace).Get(metadata.Name, metav1.GetOptions{})
 			if err != nil {
 				log.Printf("[ERROR] Received error: %#v", err)
 				return out, "Error", err
 			}
+			last = out
 
 			statusPhase := fmt.Sprintf("%v", out.Status.Phase)
 			log.Printf("[DEBUG] Pods %s status received: %#v", out.Name, statusPhase)
@@ -80,11 +99,15 @@ func resourceKubernetesPodCreate(d *schema.ResourceData, meta interface{}) error
 	}
 	_, err = stateConf.WaitForState()
 	if err != nil {
-		lastWarnings, wErr := getLastWarningsForObject(conn, out.ObjectMeta, "Pod", 3)
+		lastWarnings, wErr := getLastWarningsForObject(conn, out.ObjectMeta, "Pod", meta.(*kubernetesProvider).warningEventLimit)
 		if wErr != nil {
 			return wErr
 		}
-		return fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+		err = fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+		if last != nil {
+			err = withUnreadyPodReasons(err, *last)
+		}
+		return err
 	}
 	log.Printf("[INFO] Pod %s created", out.Name)
 
@@ -99,7 +122,7 @@ func resourceKubernetesPodUpdate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadataWithDefaults(d, meta)
 	if d.HasChange("spec") {
 		specOps, err := patchPodSpec("/spec", "spec.0.", d)
 		if err != nil {
@@ -114,7 +137,7 @@ func resourceKubernetesPodUpdate(d *schema.ResourceData, meta interface{}) error
 
 	log.Printf("[INFO] Updating pod %s: %s", d.Id(), ops)
 
-	out, err := conn.CoreV1().Pods(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	out, err := patchPodOnLatest(conn, namespace, name, data)
 	if err != nil {
 		return err
 	}
@@ -135,12 +158,22 @@ func resourceKubernetesPodRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Reading pod %s", name)
 	pod, err := conn.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Pod %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received pod: %#v", pod)
 
-	err = d.Set("metadata", flattenMetadata(pod.ObjectMeta, d))
+	err = checkControllerOwner("Pod", pod.ObjectMeta, meta)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(pod.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -171,7 +204,7 @@ func resourceKubernetesPodRead(d *schema.ResourceData, meta interface{}) error {
 			pod.Spec.Containers[ci] = removeTokenVolumeMount(c, tokenVolumeName)
 		}
 		for ci, c := range pod.Spec.InitContainers {
-			pod.Spec.Containers[ci] = removeTokenVolumeMount(c, tokenVolumeName)
+			pod.Spec.InitContainers[ci] = removeTokenVolumeMount(c, tokenVolumeName)
 		}
 	}
 
@@ -197,12 +230,12 @@ func resourceKubernetesPodDelete(d *schema.ResourceData, meta interface{}) error
 	}
 
 	log.Printf("[INFO] Deleting pod: %#v", name)
-	err = conn.CoreV1().Pods(namespace).Delete(name, nil)
+	err = conn.CoreV1().Pods(namespace).Delete(name, forcePodDeletion(d, deleteOptions(d, "")))
 	if err != nil {
 		return err
 	}
 
-	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		out, err := conn.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
 		if err != nil {
 			if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
@@ -216,7 +249,21 @@ func resourceKubernetesPodDelete(d *schema.ResourceData, meta interface{}) error
 		return resource.RetryableError(e)
 	})
 	if err != nil {
-		return err
+		var finalizers []string
+		out, gErr := conn.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
+		if gErr == nil {
+			finalizers = out.ObjectMeta.Finalizers
+		}
+
+		lastWarnings, wErr := getLastWarningsForObject(conn, metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		}, "Pod", meta.(*kubernetesProvider).warningEventLimit)
+		if wErr != nil {
+			return wErr
+		}
+
+		return fmt.Errorf("%s%s%s", err, stringifyFinalizers(finalizers), stringifyEvents(lastWarnings))
 	}
 
 	log.Printf("[INFO] Pod %s deleted", name)
@@ -258,3 +305,57 @@ func removeTokenVolumeMount(c api.Container, tokenName string) api.Container {
 	}
 	return c
 }
+
+func resourceKubernetesPodCustomizeDiff(df *schema.ResourceDiff, meta interface{}) error {
+	if err := checkVolumeMountsReferenceVolumes(df, "spec.0"); err != nil {
+		return err
+	}
+	if err := checkProjectedVolumeSources(df, "spec.0"); err != nil {
+		return err
+	}
+	if err := checkProbes(df, "spec.0"); err != nil {
+		return err
+	}
+	if err := checkTolerations(df, "spec.0"); err != nil {
+		return err
+	}
+	if err := checkDNSConfig(df, "spec.0"); err != nil {
+		return err
+	}
+
+	nodeName := df.Get("spec.0.node_name").(string)
+	if nodeName == "" {
+		return nil
+	}
+
+	if len(df.Get("spec.0.node_selector").(map[string]interface{})) > 0 {
+		log.Printf("[WARN] Pod %s sets both spec.0.node_name and spec.0.node_selector: "+
+			"the pod is bound to node %q by node_name without being scheduled, the node selector is not taken into account",
+			df.Get("metadata.0.name"), nodeName)
+	}
+
+	if !df.Get("validate_node_name").(bool) || !df.HasChange("spec.0.node_name") {
+		return nil
+	}
+	conn := meta.(*kubernetesProvider).conn
+	_, err := conn.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("Node %q set in spec.0.node_name does not exist, the pod would never be started", nodeName)
+		}
+		return err
+	}
+	return nil
+}
+
+// patchPodOnLatest applies the JSON patch data of an update to the latest version of the pod, see patchOnLatest.
+func patchPodOnLatest(conn *kubernetes.Clientset, namespace, name string, data []byte) (out *api.Pod, err error) {
+	client := conn.CoreV1().Pods(namespace)
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (metav1.Object, error) {
+		return client.Get(name, metav1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, pkgApi.JSONPatchType, data)
+		return err
+	})
+	return out, err
+}
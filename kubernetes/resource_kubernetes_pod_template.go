@@ -0,0 +1,255 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+func resourceKubernetesPodTemplate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesPodTemplateCreate,
+		Read:   resourceKubernetesPodTemplateRead,
+		Exists: resourceKubernetesPodTemplateExists,
+		Update: resourceKubernetesPodTemplateUpdate,
+		Delete: resourceKubernetesPodTemplateDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateWithDefaults(map[string]interface{}{"patch_strategy": patchStrategyJSON, "force_conflicts": false}),
+		},
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			if err := checkVolumeMountsReferenceVolumes(diff, "template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkProjectedVolumeSources(diff, "template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkProbes(diff, "template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkTolerations(diff, "template.0.spec.0"); err != nil {
+				return err
+			}
+			return checkDNSConfig(diff, "template.0.spec.0")
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata":             namespacedMetadataSchema("pod template", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
+			"patch_strategy":       patchStrategySchema(),
+			"force_conflicts":      forceConflictsSchema(),
+			"template": {
+				Type:        schema.TypeList,
+				Description: "Template defines the pods that will be created from this pod template. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-overview/#pod-templates",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: podTemplateSpecFields(true),
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesPodTemplateCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
+	template, err := expandPodTemplateSpec(d.Get("template.0").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+	clearUnsetSecurityContextFields(d, "template.0.spec.0.", &template.Spec)
+
+	pt := api.PodTemplate{
+		ObjectMeta: metadata,
+		Template:   template,
+	}
+
+	log.Printf("[INFO] Creating new pod template: %#v", pt)
+	var out *api.PodTemplate
+	if d.Get("patch_strategy").(string) == patchStrategyApply {
+		out, err = applyPodTemplate(d, conn, &pt)
+	} else {
+		out, err = conn.CoreV1().PodTemplates(metadata.Namespace).Create(&pt)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to create pod template: %s", err)
+	}
+	log.Printf("[INFO] Submitted new pod template: %#v", out)
+
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesPodTemplateRead(d, meta)
+}
+
+func resourceKubernetesPodTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading pod template %s", name)
+	pt, err := conn.CoreV1().PodTemplates(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Pod template %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received pod template: %#v", pt)
+
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(pt.ObjectMeta, d, meta))
+	if err != nil {
+		return err
+	}
+
+	template, err := flattenPodTemplateSpec(pt.Template, d, "template.0.")
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("template", template)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesPodTemplateUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var out *api.PodTemplate
+	if d.Get("patch_strategy").(string) == patchStrategyApply {
+		var template api.PodTemplateSpec
+		template, err = expandPodTemplateSpec(d.Get("template.0").(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+		clearUnsetSecurityContextFields(d, "template.0.spec.0.", &template.Spec)
+		metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
+		out, err = applyPodTemplate(d, conn, &api.PodTemplate{ObjectMeta: metadata, Template: template})
+	} else {
+		var patchType pkgApi.PatchType
+		var data []byte
+		if d.Get("patch_strategy").(string) == patchStrategyStrategic {
+			patchType, data, err = strategicMergePatchForChanges(d, meta, "template", func(m metav1.ObjectMeta, t []interface{}) (interface{}, error) {
+				if len(t) == 0 || t[0] == nil {
+					return &api.PodTemplate{ObjectMeta: m}, nil
+				}
+				template, err := expandPodTemplateSpec(t[0].(map[string]interface{}))
+				if err != nil {
+					return nil, err
+				}
+				return &api.PodTemplate{ObjectMeta: m, Template: template}, nil
+			})
+			if err != nil {
+				return err
+			}
+		} else {
+			ops := patchMetadataWithDefaults(d, meta)
+
+			if d.HasChange("template") {
+				template, err := expandPodTemplateSpec(d.Get("template.0").(map[string]interface{}))
+				if err != nil {
+					return err
+				}
+				clearUnsetSecurityContextFields(d, "template.0.spec.0.", &template.Spec)
+
+				ops = append(ops, &ReplaceOperation{
+					Path:  "/template",
+					Value: template,
+				})
+			}
+			patchType = pkgApi.JSONPatchType
+			data, err = ops.MarshalJSON()
+			if err != nil {
+				return fmt.Errorf("Failed to marshal update operations: %s", err)
+			}
+		}
+		log.Printf("[INFO] Updating pod template %q: %v", name, string(data))
+		out, err = patchPodTemplateOnLatest(conn, namespace, name, patchType, data)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to update pod template: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated pod template: %#v", out)
+
+	return resourceKubernetesPodTemplateRead(d, meta)
+}
+
+// applyPodTemplate creates or updates the pod template with server-side apply, see serverSideApply.
+func applyPodTemplate(d *schema.ResourceData, conn *kubernetes.Clientset, pt *api.PodTemplate) (*api.PodTemplate, error) {
+	pt.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "PodTemplate"}
+	out := &api.PodTemplate{}
+	err := serverSideApply(d, conn.CoreV1().RESTClient(), "podtemplates", pt, out)
+	return out, err
+}
+
+func resourceKubernetesPodTemplateDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting pod template: %#v", name)
+	err = conn.CoreV1().PodTemplates(namespace).Delete(name, deleteOptions(d, ""))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Pod template %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesPodTemplateExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking pod template %s", name)
+	_, err = conn.CoreV1().PodTemplates(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+// patchPodTemplateOnLatest applies the patch data of an update to the latest version of the pod template, see patchOnLatest.
+func patchPodTemplateOnLatest(conn *kubernetes.Clientset, namespace, name string, patchType pkgApi.PatchType, data []byte) (out *api.PodTemplate, err error) {
+	client := conn.CoreV1().PodTemplates(namespace)
+	err = patchOnLatest(patchType, data, func() (metav1.Object, error) {
+		return client.Get(name, metav1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, patchType, data)
+		return err
+	})
+	return out, err
+}
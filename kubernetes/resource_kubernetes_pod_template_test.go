@@ -0,0 +1,142 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	api "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesPodTemplate_basic(t *testing.T) {
+	var conf api.PodTemplate
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_pod_template.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesPodTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodTemplateConfig_basic(name, "alpine:3.7"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodTemplateExists("kubernetes_pod_template.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_template.test", "metadata.0.generation"),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_template.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_template.test", "metadata.0.self_link"),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_template.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.0.metadata.0.labels.app", "hello"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.0.spec.0.container.0.name", "hello"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.0.spec.0.container.0.image", "alpine:3.7"),
+				),
+			},
+			{
+				Config: testAccKubernetesPodTemplateConfig_basic(name, "alpine:3.8"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodTemplateExists("kubernetes_pod_template.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.0.spec.0.container.0.image", "alpine:3.8"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPodTemplate_importBasic(t *testing.T) {
+	resourceName := "kubernetes_pod_template.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodTemplateConfig_basic(name, "alpine:3.7"),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesPodTemplateDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_pod_template" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.CoreV1().PodTemplates(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Pod template still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesPodTemplateExists(n string, obj *api.PodTemplate) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.CoreV1().PodTemplates(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesPodTemplateConfig_basic(name, image string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod_template" "test" {
+	metadata {
+		name = "%s"
+	}
+	template {
+		metadata {
+			labels {
+				app = "hello"
+			}
+		}
+		spec {
+			container {
+				name = "hello"
+				image = "%s"
+				command = ["echo", "'hello'"]
+			}
+		}
+	}
+}`, name, image)
+}
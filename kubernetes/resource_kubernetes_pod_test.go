@@ -2,16 +2,80 @@ package kubernetes
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	api "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
 )
 
+func TestResourceKubernetesPodCustomizeDiff_nodeName(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/nodes/worker-1" {
+			fmt.Fprint(w, `{"kind": "Node", "apiVersion": "v1", "metadata": {"name": "worker-1"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound", "code": 404}`)
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &kubernetesProvider{conn: conn}
+
+	cases := []struct {
+		NodeName      string
+		Validate      bool
+		ExpectedError string
+	}{
+		{"worker-1", true, ""},
+		{"worker-2", true, `Node "worker-2" set in spec.0.node_name does not exist`},
+		{"worker-2", false, ""},
+	}
+
+	for _, tc := range cases {
+		requests = nil
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"metadata":           []map[string]interface{}{{"name": "debug"}},
+			"validate_node_name": tc.Validate,
+			"spec": []map[string]interface{}{{
+				"node_name": tc.NodeName,
+				"container": []map[string]interface{}{{"name": "debug", "image": "busybox"}},
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = resourceKubernetesPod().Diff(&terraform.InstanceState{}, terraform.NewResourceConfig(raw), meta)
+		if tc.ExpectedError != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("%s: Expected error to contain %q, given: %v", tc.NodeName, tc.ExpectedError, err)
+			}
+		} else if err != nil {
+			t.Fatalf("%s: %s", tc.NodeName, err)
+		}
+		if tc.Validate == (len(requests) == 0) {
+			t.Fatalf("%s: Expected the node to be looked up: %t, given requests: %v", tc.NodeName, tc.Validate, requests)
+		}
+	}
+}
+
 func TestAccKubernetesPod_basic(t *testing.T) {
 	var conf api.Pod
 
@@ -270,6 +334,33 @@ func TestAccKubernetesPod_with_container_lifecycle(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesPod_with_restricted_container_security_context(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithRestrictedContainerSecurityContext(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.0.allow_privilege_escalation", "false"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.0.read_only_root_filesystem", "true"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.0.run_as_non_root", "true"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.0.capabilities.0.drop.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.0.capabilities.0.drop.0", "ALL"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesPod_with_container_security_context(t *testing.T) {
 	var conf api.Pod
 
@@ -540,6 +631,34 @@ func TestAccKubernetesPod_with_DNS_config(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesPod_with_init_containers(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithInitContainers(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.name", "containername"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.init_container.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.init_container.0.name", "migrate"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.init_container.0.image", "alpine"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.init_container.1.name", "seed"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.init_container.1.command.#", "2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckKubernetesPodDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*kubernetesProvider).conn
 
@@ -845,6 +964,32 @@ resource "kubernetes_pod" "test" {
 `, podName, imageName)
 }
 
+func testAccKubernetesPodConfigWithRestrictedContainerSecurityContext(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+
+      security_context {
+        allow_privilege_escalation = false
+        read_only_root_filesystem  = true
+        run_as_non_root            = true
+        run_as_user                = 1000
+        capabilities {
+          drop = ["ALL"]
+        }
+      }
+    }
+  }
+}
+`, podName, imageName)
+}
+
 func testAccKubernetesPodConfigWithVolumeMounts(secretName, podName, imageName string) string {
 	return fmt.Sprintf(`
 
@@ -1185,3 +1330,30 @@ resource "kubernetes_pod" "test" {
 }
 `, podName, imageName)
 }
+
+func testAccKubernetesPodConfigWithInitContainers(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    init_container {
+      name    = "migrate"
+      image   = "alpine"
+      command = ["echo", "migrate"]
+    }
+    init_container {
+      name    = "seed"
+      image   = "alpine"
+      command = ["echo", "seed"]
+    }
+    container {
+      image = "%s"
+      name  = "containername"
+    }
+  }
+}
+`, podName, imageName)
+}
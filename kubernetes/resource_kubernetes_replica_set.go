@@ -0,0 +1,360 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+func resourceKubernetesReplicaSet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesReplicaSetCreate,
+		Read:   resourceKubernetesReplicaSetRead,
+		Exists: resourceKubernetesReplicaSetExists,
+		Update: resourceKubernetesReplicaSetUpdate,
+		Delete: resourceKubernetesReplicaSetDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateWithDefaults(map[string]interface{}{
+				"wait_for_rollout": true,
+				"patch_strategy":   patchStrategyJSON,
+				"force_conflicts":  false,
+			}),
+		},
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			if err := checkVolumeMountsReferenceVolumes(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkProjectedVolumeSources(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkProbes(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkTolerations(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkDNSConfig(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			return checkSelectorMatchesTemplateLabels(diff, "replica set", "spec.0.selector.0.match_labels", "spec.0.template.0.metadata.0.labels")
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata":             namespacedMetadataSchema("replica set", true),
+			"status":               workloadStatusSchema("replica set"),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(metav1.DeletePropagationForeground),
+			"patch_strategy":       patchStrategySchema(),
+			"force_conflicts":      forceConflictsSchema(),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Spec defines the specification of the desired behavior of the replica set. More info: https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#spec-and-status",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: replicaSetSpecFields(),
+				},
+			},
+			"wait_for_rollout": {
+				Type:        schema.TypeBool,
+				Description: "Wait for all replicas of the replica set to be ready when creating or updating it.",
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesReplicaSetCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
+	spec, err := expandReplicaSetSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return err
+	}
+	clearUnsetSecurityContextFields(d, "spec.0.template.0.spec.0.", &spec.Template.Spec)
+
+	rs := appsv1.ReplicaSet{
+		ObjectMeta: metadata,
+		Spec:       spec,
+	}
+
+	log.Printf("[INFO] Creating new replica set: %#v", rs)
+	var out *appsv1.ReplicaSet
+	if d.Get("patch_strategy").(string) == patchStrategyApply {
+		out, err = applyReplicaSet(d, conn, &rs)
+	} else {
+		out, err = conn.AppsV1().ReplicaSets(metadata.Namespace).Create(&rs)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to create replica set: %s", err)
+	}
+	log.Printf("[INFO] Submitted new replica set: %#v", out)
+
+	d.SetId(buildId(out.ObjectMeta))
+
+	if d.Get("wait_for_rollout").(bool) {
+		log.Printf("[DEBUG] Waiting for replica set %s to have %d ready replicas", d.Id(), *out.Spec.Replicas)
+		err = resource.Retry(d.Timeout(schema.TimeoutCreate),
+			waitForReplicaSetReadyReplicasFunc(conn, out.Namespace, out.Name))
+		err = withUnreadyPods(err, conn, out.Namespace, out.Spec.Selector)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceKubernetesReplicaSetRead(d, meta)
+}
+
+func resourceKubernetesReplicaSetRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading replica set %s", name)
+	rs, err := conn.AppsV1().ReplicaSets(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Replica set %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received replica set: %#v", rs)
+
+	err = checkControllerOwner("ReplicaSet", rs.ObjectMeta, meta)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(rs.ObjectMeta, d, meta))
+	if err != nil {
+		return err
+	}
+
+	spec, err := flattenReplicaSetSpec(rs.Spec, d)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("spec", spec)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("status", flattenWorkloadStatus(rs.Status.ObservedGeneration))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesReplicaSetUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// Changed replicas are set through the scale subresource & left out of the patch
+	scaled := d.HasChange("spec.0.replicas")
+	if scaled {
+		err = scaleReplicas(conn.AppsV1().RESTClient(), "autoscaling/v1", namespace, "replicasets", name, int32(d.Get("spec.0.replicas").(int)))
+		if err != nil {
+			return err
+		}
+	}
+
+	var out *appsv1.ReplicaSet
+	if d.Get("patch_strategy").(string) == patchStrategyApply {
+		var spec appsv1.ReplicaSetSpec
+		spec, err = expandReplicaSetSpec(d.Get("spec").([]interface{}))
+		if err != nil {
+			return err
+		}
+		clearUnsetSecurityContextFields(d, "spec.0.template.0.spec.0.", &spec.Template.Spec)
+		metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
+		out, err = applyReplicaSet(d, conn, &appsv1.ReplicaSet{ObjectMeta: metadata, Spec: spec})
+	} else {
+		var patchType pkgApi.PatchType
+		var data []byte
+		if d.Get("patch_strategy").(string) == patchStrategyStrategic {
+			patchType, data, err = strategicMergePatchForChanges(d, meta, "spec", func(m metav1.ObjectMeta, s []interface{}) (interface{}, error) {
+				spec, err := expandReplicaSetSpec(s)
+				if err != nil {
+					return nil, err
+				}
+				return &appsv1.ReplicaSet{ObjectMeta: m, Spec: spec}, nil
+			})
+			if err != nil {
+				return err
+			}
+			if scaled {
+				data, err = withoutReplicasInMergePatch(data)
+				if err != nil {
+					return fmt.Errorf("Failed to leave the scaled replicas out of the patch: %s", err)
+				}
+			}
+		} else {
+			ops := patchMetadataWithDefaults(d, meta)
+
+			if d.HasChange("spec") {
+				specOps, err := patchReplicaSetSpec("/spec", "spec.0.", d)
+				if err != nil {
+					return err
+				}
+				if scaled {
+					specOps = withoutReplicasOperation(specOps, "/spec/replicas")
+				}
+				ops = append(ops, specOps...)
+			}
+			patchType = pkgApi.JSONPatchType
+			data, err = ops.MarshalJSON()
+			if err != nil {
+				return fmt.Errorf("Failed to marshal update operations: %s", err)
+			}
+		}
+		log.Printf("[INFO] Updating replica set %q: %v", name, string(data))
+		out, err = patchReplicaSetOnLatest(conn, namespace, name, patchType, data)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to update replica set: %s", err)
+	}
+	log.Printf("[INFO] Submitted updated replica set: %#v", out)
+
+	if d.Get("wait_for_rollout").(bool) {
+		err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
+			waitForReplicaSetReadyReplicasFunc(conn, namespace, name))
+		err = withUnreadyPods(err, conn, namespace, out.Spec.Selector)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceKubernetesReplicaSetRead(d, meta)
+}
+
+// applyReplicaSet creates or updates the replica set with server-side apply, see serverSideApply.
+func applyReplicaSet(d *schema.ResourceData, conn *kubernetes.Clientset, rs *appsv1.ReplicaSet) (*appsv1.ReplicaSet, error) {
+	rs.TypeMeta = metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"}
+	out := &appsv1.ReplicaSet{}
+	err := serverSideApply(d, conn.AppsV1().RESTClient(), "replicasets", rs, out)
+	return out, err
+}
+
+func resourceKubernetesReplicaSetDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting replica set: %#v", name)
+	err = conn.AppsV1().ReplicaSets(namespace).Delete(name, deleteOptions(d, metav1.DeletePropagationForeground))
+	if err != nil {
+		return err
+	}
+
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		_, err := conn.AppsV1().ReplicaSets(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		e := fmt.Errorf("Replica set %s still exists", name)
+		return resource.RetryableError(e)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Replica set %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesReplicaSetExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetesProvider).conn
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking replica set %s", name)
+	_, err = conn.AppsV1().ReplicaSets(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+func waitForReplicaSetReadyReplicasFunc(conn *kubernetes.Clientset, ns, name string) resource.RetryFunc {
+	return func() *resource.RetryError {
+		rs, err := conn.AppsV1().ReplicaSets(ns).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		desiredReplicas := *rs.Spec.Replicas
+		log.Printf("[DEBUG] Current number of ready replicas of %q: %d (of %d)\n",
+			rs.GetName(), rs.Status.ReadyReplicas, desiredReplicas)
+
+		// The status is stale until the controller caught up with the latest spec
+		done, msg := rolloutStatus(rs.GetName(),
+			observedGenerationCheck(rs.Generation, rs.Status.ObservedGeneration),
+			func() string {
+				if rs.Status.ReadyReplicas != desiredReplicas {
+					return fmt.Sprintf("%d of %d replicas ready", rs.Status.ReadyReplicas, desiredReplicas)
+				}
+				return ""
+			},
+		)
+		if done {
+			return nil
+		}
+		return resource.RetryableError(fmt.Errorf("%s", msg))
+	}
+}
+
+// patchReplicaSetOnLatest applies the patch data of an update to the latest version of the replica set, see patchOnLatest.
+func patchReplicaSetOnLatest(conn *kubernetes.Clientset, namespace, name string, patchType pkgApi.PatchType, data []byte) (out *appsv1.ReplicaSet, err error) {
+	client := conn.AppsV1().ReplicaSets(namespace)
+	err = patchOnLatest(patchType, data, func() (metav1.Object, error) {
+		return client.Get(name, metav1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, patchType, data)
+		return err
+	})
+	return out, err
+}
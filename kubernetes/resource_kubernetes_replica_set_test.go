@@ -0,0 +1,161 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	api "k8s.io/api/apps/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesReplicaSet_basic(t *testing.T) {
+	var conf api.ReplicaSet
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_replica_set.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesReplicaSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesReplicaSetConfig_basic(name, 1, "nginx:1.7.8"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesReplicaSetExists("kubernetes_replica_set.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("kubernetes_replica_set.test", "metadata.0.generation"),
+					resource.TestCheckResourceAttrSet("kubernetes_replica_set.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("kubernetes_replica_set.test", "metadata.0.self_link"),
+					resource.TestCheckResourceAttrSet("kubernetes_replica_set.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttrPair("kubernetes_replica_set.test", "status.0.observed_generation", "kubernetes_replica_set.test", "metadata.0.generation"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.0.replicas", "1"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.0.selector.0.match_labels.app", "hello"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.0.template.0.metadata.0.labels.app", "hello"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.8"),
+				),
+			},
+			{
+				Config: testAccKubernetesReplicaSetConfig_basic(name, 2, "nginx:1.7.9"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesReplicaSetExists("kubernetes_replica_set.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.0.replicas", "2"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.0.template.0.spec.0.container.0.image", "nginx:1.7.9"),
+					testAccCheckKubernetesReplicaSetReadyReplicas(&conf, 2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesReplicaSet_importBasic(t *testing.T) {
+	resourceName := "kubernetes_replica_set.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesReplicaSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesReplicaSetConfig_basic(name, 1, "nginx:1.7.8"),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesReplicaSetReadyReplicas(obj *api.ReplicaSet, expected int32) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if obj.Status.ReadyReplicas != expected {
+			return fmt.Errorf("Expected %d ready replicas, given: %d", expected, obj.Status.ReadyReplicas)
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesReplicaSetDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_replica_set" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.AppsV1().ReplicaSets(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Replica set still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesReplicaSetExists(n string, obj *api.ReplicaSet) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetesProvider).conn
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.AppsV1().ReplicaSets(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesReplicaSetConfig_basic(name string, replicas int, image string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_replica_set" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		replicas = %d
+		selector {
+			match_labels {
+				app = "hello"
+			}
+		}
+		template {
+			metadata {
+				labels {
+					app = "hello"
+				}
+			}
+			spec {
+				container {
+					name = "hello"
+					image = "%s"
+				}
+			}
+		}
+	}
+}`, name, replicas, image)
+}
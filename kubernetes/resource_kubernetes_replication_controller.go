@@ -22,7 +22,22 @@ func resourceKubernetesReplicationController() *schema.Resource {
 		Update: resourceKubernetesReplicationControllerUpdate,
 		Delete: resourceKubernetesReplicationControllerDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateWithDefaults(map[string]interface{}{"patch_strategy": patchStrategyJSON, "force_conflicts": false}),
+		},
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			if err := checkVolumeMountsReferenceVolumes(diff, "spec.0.template.0"); err != nil {
+				return err
+			}
+			if err := checkProjectedVolumeSources(diff, "spec.0.template.0"); err != nil {
+				return err
+			}
+			if err := checkProbes(diff, "spec.0.template.0"); err != nil {
+				return err
+			}
+			if err := checkTolerations(diff, "spec.0.template.0"); err != nil {
+				return err
+			}
+			return checkDNSConfig(diff, "spec.0.template.0")
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -32,7 +47,11 @@ func resourceKubernetesReplicationController() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("replication controller", true),
+			"metadata":             namespacedMetadataSchema("replication controller", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
+			"patch_strategy":       patchStrategySchema(),
+			"force_conflicts":      forceConflictsSchema(),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the specification of the desired behavior of the replication controller. More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#spec-and-status",
@@ -48,7 +67,7 @@ func resourceKubernetesReplicationController() *schema.Resource {
 						},
 						"replicas": {
 							Type:        schema.TypeInt,
-							Description: "The number of desired replicas. Defaults to 1. More info: http://kubernetes.io/docs/user-guide/replication-controller#what-is-a-replication-controller",
+							Description: "The number of desired replicas. Defaults to 1. Changes are applied through the `scale` subresource. More info: http://kubernetes.io/docs/user-guide/replication-controller#what-is-a-replication-controller",
 							Optional:    true,
 							Default:     1,
 						},
@@ -76,11 +95,12 @@ func resourceKubernetesReplicationController() *schema.Resource {
 func resourceKubernetesReplicationControllerCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec, err := expandReplicationControllerSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return err
 	}
+	clearUnsetSecurityContextFields(d, "spec.0.template.0.", &spec.Template.Spec)
 	spec.Template.ObjectMeta.Annotations = metadata.Annotations
 
 	rc := api.ReplicationController{
@@ -89,7 +109,12 @@ func resourceKubernetesReplicationControllerCreate(d *schema.ResourceData, meta
 	}
 
 	log.Printf("[INFO] Creating new replication controller: %#v", rc)
-	out, err := conn.CoreV1().ReplicationControllers(metadata.Namespace).Create(&rc)
+	var out *api.ReplicationController
+	if d.Get("patch_strategy").(string) == patchStrategyApply {
+		out, err = applyReplicationController(d, conn, &rc)
+	} else {
+		out, err = conn.CoreV1().ReplicationControllers(metadata.Namespace).Create(&rc)
+	}
 	if err != nil {
 		return fmt.Errorf("Failed to create replication controller: %s", err)
 	}
@@ -101,6 +126,7 @@ func resourceKubernetesReplicationControllerCreate(d *schema.ResourceData, meta
 	// 10 mins should be sufficient for scheduling ~10k replicas
 	err = resource.Retry(d.Timeout(schema.TimeoutCreate),
 		waitForDesiredReplicasFunc(conn, out.GetNamespace(), out.GetName()))
+	err = withUnreadyPods(err, conn, out.GetNamespace(), &metav1.LabelSelector{MatchLabels: out.Spec.Selector})
 	if err != nil {
 		return err
 	}
@@ -124,12 +150,22 @@ func resourceKubernetesReplicationControllerRead(d *schema.ResourceData, meta in
 	log.Printf("[INFO] Reading replication controller %s", name)
 	rc, err := conn.CoreV1().ReplicationControllers(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Replication controller %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received replication controller: %#v", rc)
 
-	err = d.Set("metadata", flattenMetadata(rc.ObjectMeta, d))
+	err = checkControllerOwner("ReplicationController", rc.ObjectMeta, meta)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(rc.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -155,25 +191,70 @@ func resourceKubernetesReplicationControllerUpdate(d *schema.ResourceData, meta
 		return err
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
-
-	if d.HasChange("spec") {
-		spec, err := expandReplicationControllerSpec(d.Get("spec").([]interface{}))
+	// Changed replicas are set through the scale subresource & left out of the patch
+	scaled := d.HasChange("spec.0.replicas")
+	if scaled {
+		err = scaleReplicas(conn.CoreV1().RESTClient(), "autoscaling/v1", namespace, "replicationcontrollers", name, int32(d.Get("spec.0.replicas").(int)))
 		if err != nil {
 			return err
 		}
-
-		ops = append(ops, &ReplaceOperation{
-			Path:  "/spec",
-			Value: spec,
-		})
 	}
-	data, err := ops.MarshalJSON()
-	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
+
+	var out *api.ReplicationController
+	if d.Get("patch_strategy").(string) == patchStrategyApply {
+		var spec api.ReplicationControllerSpec
+		spec, err = expandReplicationControllerSpec(d.Get("spec").([]interface{}))
+		if err != nil {
+			return err
+		}
+		clearUnsetSecurityContextFields(d, "spec.0.template.0.", &spec.Template.Spec)
+		metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
+		spec.Template.ObjectMeta.Annotations = metadata.Annotations
+		out, err = applyReplicationController(d, conn, &api.ReplicationController{ObjectMeta: metadata, Spec: spec})
+	} else {
+		var patchType pkgApi.PatchType
+		var data []byte
+		if d.Get("patch_strategy").(string) == patchStrategyStrategic {
+			patchType, data, err = strategicMergePatchForChanges(d, meta, "spec", func(m metav1.ObjectMeta, s []interface{}) (interface{}, error) {
+				spec, err := expandReplicationControllerSpec(s)
+				if err != nil {
+					return nil, err
+				}
+				return &api.ReplicationController{ObjectMeta: m, Spec: spec}, nil
+			})
+			if err != nil {
+				return err
+			}
+			if scaled {
+				data, err = withoutReplicasInMergePatch(data)
+				if err != nil {
+					return fmt.Errorf("Failed to leave the scaled replicas out of the patch: %s", err)
+				}
+			}
+		} else {
+			ops := patchMetadataWithDefaults(d, meta)
+
+			if d.HasChange("spec") && (!scaled || specChangedBesidesReplicas(d)) {
+				spec, err := expandReplicationControllerSpec(d.Get("spec").([]interface{}))
+				if err != nil {
+					return err
+				}
+				clearUnsetSecurityContextFields(d, "spec.0.template.0.", &spec.Template.Spec)
+
+				ops = append(ops, &ReplaceOperation{
+					Path:  "/spec",
+					Value: spec,
+				})
+			}
+			patchType = pkgApi.JSONPatchType
+			data, err = ops.MarshalJSON()
+			if err != nil {
+				return fmt.Errorf("Failed to marshal update operations: %s", err)
+			}
+		}
+		log.Printf("[INFO] Updating replication controller %q: %v", name, string(data))
+		out, err = patchReplicationControllerOnLatest(conn, namespace, name, patchType, data)
 	}
-	log.Printf("[INFO] Updating replication controller %q: %v", name, string(data))
-	out, err := conn.CoreV1().ReplicationControllers(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
 		return fmt.Errorf("Failed to update replication controller: %s", err)
 	}
@@ -181,6 +262,7 @@ func resourceKubernetesReplicationControllerUpdate(d *schema.ResourceData, meta
 
 	err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
 		waitForDesiredReplicasFunc(conn, namespace, name))
+	err = withUnreadyPods(err, conn, namespace, &metav1.LabelSelector{MatchLabels: out.Spec.Selector})
 	if err != nil {
 		return err
 	}
@@ -188,6 +270,14 @@ func resourceKubernetesReplicationControllerUpdate(d *schema.ResourceData, meta
 	return resourceKubernetesReplicationControllerRead(d, meta)
 }
 
+// applyReplicationController creates or updates the replication controller with server-side apply, see serverSideApply.
+func applyReplicationController(d *schema.ResourceData, conn *kubernetes.Clientset, rc *api.ReplicationController) (*api.ReplicationController, error) {
+	rc.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "ReplicationController"}
+	out := &api.ReplicationController{}
+	err := serverSideApply(d, conn.CoreV1().RESTClient(), "replicationcontrollers", rc, out)
+	return out, err
+}
+
 func resourceKubernetesReplicationControllerDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -220,11 +310,23 @@ func resourceKubernetesReplicationControllerDelete(d *schema.ResourceData, meta
 		return err
 	}
 
-	err = conn.CoreV1().ReplicationControllers(namespace).Delete(name, &metav1.DeleteOptions{})
+	opts := deleteOptions(d, "")
+	err = conn.CoreV1().ReplicationControllers(namespace).Delete(name, opts)
 	if err != nil {
 		return err
 	}
 
+	// The replication controller is only removed once its pods are gone
+	if isForegroundDeletion(opts) {
+		err = waitForDeletion(d.Timeout(schema.TimeoutDelete), fmt.Sprintf("Replication controller %s", d.Id()), func() error {
+			_, err := conn.CoreV1().ReplicationControllers(namespace).Get(name, metav1.GetOptions{})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	log.Printf("[INFO] Replication controller %s deleted", name)
 
 	d.SetId("")
@@ -257,15 +359,41 @@ func waitForDesiredReplicasFunc(conn *kubernetes.Clientset, ns, name string) res
 			return resource.NonRetryableError(err)
 		}
 
-		desiredReplicas := *rc.Spec.Replicas
 		log.Printf("[DEBUG] Current number of labelled replicas of %q: %d (of %d)\n",
-			rc.GetName(), rc.Status.FullyLabeledReplicas, desiredReplicas)
+			rc.GetName(), rc.Status.FullyLabeledReplicas, *rc.Spec.Replicas)
 
-		if rc.Status.FullyLabeledReplicas == desiredReplicas {
+		done, msg := replicationControllerRolloutStatus(rc)
+		if done {
 			return nil
 		}
-
-		return resource.RetryableError(fmt.Errorf("Waiting for %d replicas of %q to be scheduled (%d)",
-			desiredReplicas, rc.GetName(), rc.Status.FullyLabeledReplicas))
+		return resource.RetryableError(fmt.Errorf("%s", msg))
 	}
 }
+
+// replicationControllerRolloutStatus reports whether the replication controller runs the desired
+// number of replicas, or else what it's waiting for.
+func replicationControllerRolloutStatus(rc *api.ReplicationController) (bool, string) {
+	// The status is stale until the controller caught up with the latest spec
+	return rolloutStatus(rc.GetName(),
+		observedGenerationCheck(rc.Generation, rc.Status.ObservedGeneration),
+		func() string {
+			desired := *rc.Spec.Replicas
+			if rc.Status.FullyLabeledReplicas != desired {
+				return fmt.Sprintf("%d of %d replicas scheduled", rc.Status.FullyLabeledReplicas, desired)
+			}
+			return ""
+		},
+	)
+}
+
+// patchReplicationControllerOnLatest applies the patch data of an update to the latest version of the replication controller, see patchOnLatest.
+func patchReplicationControllerOnLatest(conn *kubernetes.Clientset, namespace, name string, patchType pkgApi.PatchType, data []byte) (out *api.ReplicationController, err error) {
+	client := conn.CoreV1().ReplicationControllers(namespace)
+	err = patchOnLatest(patchType, data, func() (metav1.Object, error) {
+		return client.Get(name, metav1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, patchType, data)
+		return err
+	})
+	return out, err
+}
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesResourceQuota() *schema.Resource {
@@ -23,9 +24,25 @@ func resourceKubernetesResourceQuota() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			return checkDryRun(diff, meta, coreV1RESTClient, "resourcequotas", func(metadata meta_v1.ObjectMeta) (interface{}, error) {
+				spec, err := expandResourceQuotaSpec(diff.Get("spec").([]interface{}))
+				if err != nil {
+					return nil, err
+				}
+				return &api.ResourceQuota{ObjectMeta: metadata, Spec: spec}, nil
+			}, "spec")
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("resource quota", true),
+			"metadata":             namespacedMetadataSchema("resource quota", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the desired quota. https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#spec-and-status",
@@ -48,6 +65,49 @@ func resourceKubernetesResourceQuota() *schema.Resource {
 							Elem:        &schema.Schema{Type: schema.TypeString},
 							Set:         schema.HashString,
 						},
+						"scope_selector": {
+							Type:        schema.TypeList,
+							Description: "A collection of filters like scopes that must match each object tracked by a quota but expressed using ScopeSelectorOperator in combination with possible values. For a resource to match, both scopes AND scopeSelector (if specified in spec), must be matched.",
+							Optional:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"match_expressions": {
+										Type:        schema.TypeList,
+										Description: "A list of scope selector requirements by scope of the resources.",
+										Required:    true,
+										ForceNew:    true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"scope_name": {
+													Type:         schema.TypeString,
+													Description:  "The name of the scope that the selector applies to. Valid scopes are `Terminating`, `NotTerminating`, `BestEffort`, `NotBestEffort` and `PriorityClass`.",
+													Required:     true,
+													ForceNew:     true,
+													ValidateFunc: validateAttributeValueIsIn([]string{"Terminating", "NotTerminating", "BestEffort", "NotBestEffort", "PriorityClass"}),
+												},
+												"operator": {
+													Type:         schema.TypeString,
+													Description:  "Represents a scope's relationship to a set of values. Valid operators are `In`, `NotIn`, `Exists` and `DoesNotExist`.",
+													Required:     true,
+													ForceNew:     true,
+													ValidateFunc: validateAttributeValueIsIn([]string{"In", "NotIn", "Exists", "DoesNotExist"}),
+												},
+												"values": {
+													Type:        schema.TypeSet,
+													Description: "An array of string values. If the operator is `In` or `NotIn`, the values array must be non-empty. If the operator is `Exists` or `DoesNotExist`, the values array must be empty.",
+													Optional:    true,
+													ForceNew:    true,
+													Elem:        &schema.Schema{Type: schema.TypeString},
+													Set:         schema.HashString,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -58,7 +118,7 @@ func resourceKubernetesResourceQuota() *schema.Resource {
 func resourceKubernetesResourceQuotaCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec, err := expandResourceQuotaSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return err
@@ -75,7 +135,7 @@ func resourceKubernetesResourceQuotaCreate(d *schema.ResourceData, meta interfac
 	log.Printf("[INFO] Submitted new resource quota: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
 
-	err = resource.Retry(1*time.Minute, func() *resource.RetryError {
+	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
 		quota, err := conn.CoreV1().ResourceQuotas(out.Namespace).Get(out.Name, meta_v1.GetOptions{})
 		if err != nil {
 			return resource.NonRetryableError(err)
@@ -105,6 +165,11 @@ func resourceKubernetesResourceQuotaRead(d *schema.ResourceData, meta interface{
 	log.Printf("[INFO] Reading resource quota %s", name)
 	resQuota, err := conn.CoreV1().ResourceQuotas(namespace).Get(name, meta_v1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Resource quota %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
@@ -118,7 +183,7 @@ func resourceKubernetesResourceQuotaRead(d *schema.ResourceData, meta interface{
 		}
 	}
 
-	err = d.Set("metadata", flattenMetadata(resQuota.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(resQuota.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -138,7 +203,7 @@ func resourceKubernetesResourceQuotaUpdate(d *schema.ResourceData, meta interfac
 		return err
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadataWithDefaults(d, meta)
 	var spec api.ResourceQuotaSpec
 	waitForChangedSpec := false
 	if d.HasChange("spec") {
@@ -158,7 +223,7 @@ func resourceKubernetesResourceQuotaUpdate(d *schema.ResourceData, meta interfac
 		return fmt.Errorf("Failed to marshal update operations: %s", err)
 	}
 	log.Printf("[INFO] Updating resource quota %q: %v", name, string(data))
-	out, err := conn.CoreV1().ResourceQuotas(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	out, err := patchResourceQuotaOnLatest(conn, namespace, name, data)
 	if err != nil {
 		return fmt.Errorf("Failed to update resource quota: %s", err)
 	}
@@ -166,7 +231,7 @@ func resourceKubernetesResourceQuotaUpdate(d *schema.ResourceData, meta interfac
 	d.SetId(buildId(out.ObjectMeta))
 
 	if waitForChangedSpec {
-		err = resource.Retry(1*time.Minute, func() *resource.RetryError {
+		err = resource.Retry(d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
 			quota, err := conn.CoreV1().ResourceQuotas(namespace).Get(name, meta_v1.GetOptions{})
 			if err != nil {
 				return resource.NonRetryableError(err)
@@ -195,7 +260,7 @@ func resourceKubernetesResourceQuotaDelete(d *schema.ResourceData, meta interfac
 	}
 
 	log.Printf("[INFO] Deleting resource quota: %#v", name)
-	err = conn.CoreV1().ResourceQuotas(namespace).Delete(name, &meta_v1.DeleteOptions{})
+	err = conn.CoreV1().ResourceQuotas(namespace).Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
@@ -224,3 +289,15 @@ func resourceKubernetesResourceQuotaExists(d *schema.ResourceData, meta interfac
 	}
 	return true, err
 }
+
+// patchResourceQuotaOnLatest applies the JSON patch data of an update to the latest version of the resource quota, see patchOnLatest.
+func patchResourceQuotaOnLatest(conn *kubernetes.Clientset, namespace, name string, data []byte) (out *api.ResourceQuota, err error) {
+	client := conn.CoreV1().ResourceQuotas(namespace)
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (meta_v1.Object, error) {
+		return client.Get(name, meta_v1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, pkgApi.JSONPatchType, data)
+		return err
+	})
+	return out, err
+}
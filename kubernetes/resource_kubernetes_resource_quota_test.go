@@ -176,6 +176,33 @@ func TestAccKubernetesResourceQuota_withScopes(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesResourceQuota_withScopeSelector(t *testing.T) {
+	var conf api.ResourceQuota
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_resource_quota.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesResourceQuotaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesResourceQuotaConfig_withScopeSelector(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesResourceQuotaExists("kubernetes_resource_quota.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_resource_quota.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_resource_quota.test", "spec.0.hard.pods", "10"),
+					resource.TestCheckResourceAttr("kubernetes_resource_quota.test", "spec.0.scope_selector.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_resource_quota.test", "spec.0.scope_selector.0.match_expressions.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_resource_quota.test", "spec.0.scope_selector.0.match_expressions.0.scope_name", "PriorityClass"),
+					resource.TestCheckResourceAttr("kubernetes_resource_quota.test", "spec.0.scope_selector.0.match_expressions.0.operator", "In"),
+					resource.TestCheckResourceAttr("kubernetes_resource_quota.test", "spec.0.scope_selector.0.match_expressions.0.values.#", "2"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesResourceQuota_importBasic(t *testing.T) {
 	resourceName := "kubernetes_resource_quota.test"
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
@@ -361,3 +388,25 @@ resource "kubernetes_resource_quota" "test" {
 }
 `, name)
 }
+
+func testAccKubernetesResourceQuotaConfig_withScopeSelector(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_resource_quota" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		hard {
+			pods = 10
+		}
+		scope_selector {
+			match_expressions {
+				scope_name = "PriorityClass"
+				operator = "In"
+				values = ["high", "medium"]
+			}
+		}
+	}
+}
+`, name)
+}
@@ -22,7 +22,9 @@ func resourceKubernetesRole() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("role", true),
+			"metadata":             namespacedMetadataSchema("role", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"rule": {
 				Type:        schema.TypeList,
 				Description: "List of PolicyRules for this Role",
@@ -39,7 +41,7 @@ func resourceKubernetesRole() *schema.Resource {
 func resourceKubernetesRoleCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	cRole := api.Role{
 		ObjectMeta: metadata,
 		Rules:      expandClusterRoleRule(d.Get("rule").([]interface{})),
@@ -65,11 +67,16 @@ func resourceKubernetesRoleRead(d *schema.ResourceData, meta interface{}) error
 	log.Printf("[INFO] Reading role %s", name)
 	cRole, err := conn.RbacV1().Roles(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Role %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received role: %#v", cRole)
-	err = d.Set("metadata", flattenMetadata(cRole.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(cRole.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -86,7 +93,7 @@ func resourceKubernetesRoleUpdate(d *schema.ResourceData, meta interface{}) erro
 		return err
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	cRole := api.Role{
 		ObjectMeta: metadata,
 		Rules:      expandClusterRoleRule(d.Get("rule").([]interface{})),
@@ -111,7 +118,7 @@ func resourceKubernetesRoleDelete(d *schema.ResourceData, meta interface{}) erro
 		return err
 	}
 	log.Printf("[INFO] Deleting role: %#v", name)
-	err = conn.RbacV1().Roles(namespace).Delete(name, &metav1.DeleteOptions{})
+	err = conn.RbacV1().Roles(namespace).Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
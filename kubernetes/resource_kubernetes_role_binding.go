@@ -22,7 +22,9 @@ func resourceKubernetesRoleBinding() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("role binding", false),
+			"metadata":             namespacedMetadataSchema("role binding", false),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"role_ref": {
 				Type:        schema.TypeList,
 				Description: "RoleRef can reference a Role in the current namespace or a ClusterRole in the global namespace. If the RoleRef cannot be resolved, the Authorizer must return an error.",
@@ -49,7 +51,7 @@ func resourceKubernetesRoleBinding() *schema.Resource {
 func resourceKubernetesRoleBindingCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	rb := api.RoleBinding{
 		ObjectMeta: metadata,
 		RoleRef:    expandRoleRef(d.Get("role_ref").([]interface{})[0]),
@@ -76,11 +78,16 @@ func resourceKubernetesRoleBindingRead(d *schema.ResourceData, meta interface{})
 	log.Printf("[INFO] Reading role binding %s", name)
 	crb, err := conn.RbacV1().RoleBindings(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Role binding %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received role binding: %#v", crb)
-	err = d.Set("metadata", flattenMetadata(crb.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(crb.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -98,7 +105,7 @@ func resourceKubernetesRoleBindingUpdate(d *schema.ResourceData, meta interface{
 		return err
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	crb := api.RoleBinding{
 		ObjectMeta: metadata,
 		RoleRef:    expandRoleRef(d.Get("role_ref").([]interface{})[0]),
@@ -124,7 +131,7 @@ func resourceKubernetesRoleBindingDelete(d *schema.ResourceData, meta interface{
 		return err
 	}
 	log.Printf("[INFO] Deleting role binding: %#v", name)
-	err = conn.RbacV1().RoleBindings(namespace).Delete(name, &metav1.DeleteOptions{})
+	err = conn.RbacV1().RoleBindings(namespace).Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
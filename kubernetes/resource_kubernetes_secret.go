@@ -1,6 +1,7 @@
 package kubernetes
 
 import (
+	"encoding/base64"
 	"log"
 
 	"fmt"
@@ -10,6 +11,12 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+const (
+	secretDataModeReplace = "replace"
+	secretDataModeAppend  = "append"
 )
 
 func resourceKubernetesSecret() *schema.Resource {
@@ -20,17 +27,39 @@ func resourceKubernetesSecret() *schema.Resource {
 		Update: resourceKubernetesSecretUpdate,
 		Delete: resourceKubernetesSecretDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateWithDefaults(map[string]interface{}{"data_mode": secretDataModeReplace}),
+		},
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			// A changed type re-creates the secret
+			if diff.HasChange("type") && diff.Id() != "" {
+				return nil
+			}
+			return checkDryRun(diff, meta, coreV1RESTClient, "secrets", func(metadata meta_v1.ObjectMeta) (interface{}, error) {
+				return &api.Secret{
+					ObjectMeta: metadata,
+					Data:       expandStringMapToByteMap(diff.Get("data").(map[string]interface{})),
+					Type:       api.SecretType(diff.Get("type").(string)),
+				}, nil
+			}, "data", "type")
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("secret", true),
+			"metadata":             namespacedMetadataSchema("secret", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"data": {
 				Type:        schema.TypeMap,
 				Description: "A map of the secret data.",
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"data_mode": {
+				Type:         schema.TypeString,
+				Description:  "How the keys of `data` are managed. `replace` makes the secret data match `data` exactly. `append` only adds, updates & removes the keys listed in `data`, keys written by others (e.g. credential rotators) are left untouched.",
+				Optional:     true,
+				Default:      secretDataModeReplace,
+				ValidateFunc: validateAttributeValueIsIn([]string{secretDataModeReplace, secretDataModeAppend}),
+			},
 			"type": {
 				Type:        schema.TypeString,
 				Description: "Type of secret",
@@ -45,7 +74,7 @@ func resourceKubernetesSecret() *schema.Resource {
 func resourceKubernetesSecretCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	secret := api.Secret{
 		ObjectMeta: metadata,
 		Data:       expandStringMapToByteMap(d.Get("data").(map[string]interface{})),
@@ -78,16 +107,26 @@ func resourceKubernetesSecretRead(d *schema.ResourceData, meta interface{}) erro
 	log.Printf("[INFO] Reading secret %s", name)
 	secret, err := conn.CoreV1().Secrets(namespace).Get(name, meta_v1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Secret %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
 	log.Printf("[INFO] Received secret: %#v", secret)
-	err = d.Set("metadata", flattenMetadata(secret.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(secret.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
 
-	d.Set("data", byteMapToStringMap(secret.Data))
+	data := byteMapToStringMap(secret.Data)
+	// The data source doesn't have a data_mode
+	if mode, _ := d.Get("data_mode").(string); mode == secretDataModeAppend {
+		data = filterManagedKeys(data, d.Get("data").(map[string]interface{}))
+	}
+	d.Set("data", data)
 	d.Set("type", secret.Type)
 
 	return nil
@@ -101,16 +140,26 @@ func resourceKubernetesSecretUpdate(d *schema.ResourceData, meta interface{}) er
 		return err
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadataWithDefaults(d, meta)
 	if d.HasChange("data") {
 		oldV, newV := d.GetChange("data")
 
-		oldV = base64EncodeStringMap(oldV.(map[string]interface{}))
-		newV = base64EncodeStringMap(newV.(map[string]interface{}))
-
-		diffOps := diffStringMap("/data/", oldV.(map[string]interface{}), newV.(map[string]interface{}))
-
-		ops = append(ops, diffOps...)
+		if d.Get("data_mode").(string) == secretDataModeAppend {
+			// Diff against the live data, so the patch neither clobbers
+			// keys written by others nor fails on keys they removed
+			live, err := conn.CoreV1().Secrets(namespace).Get(name, meta_v1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			ops = append(ops, diffSecretDataKeys(live.Data, oldV.(map[string]interface{}), newV.(map[string]interface{}))...)
+		} else {
+			oldV = base64EncodeStringMap(oldV.(map[string]interface{}))
+			newV = base64EncodeStringMap(newV.(map[string]interface{}))
+
+			diffOps := diffStringMap("/data/", oldV.(map[string]interface{}), newV.(map[string]interface{}))
+
+			ops = append(ops, diffOps...)
+		}
 	}
 
 	data, err := ops.MarshalJSON()
@@ -119,7 +168,7 @@ func resourceKubernetesSecretUpdate(d *schema.ResourceData, meta interface{}) er
 	}
 
 	log.Printf("[INFO] Updating secret %q: %v", name, data)
-	out, err := conn.CoreV1().Secrets(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	out, err := patchSecretOnLatest(conn, namespace, name, data)
 	if err != nil {
 		return fmt.Errorf("Failed to update secret: %s", err)
 	}
@@ -139,7 +188,7 @@ func resourceKubernetesSecretDelete(d *schema.ResourceData, meta interface{}) er
 	}
 
 	log.Printf("[INFO] Deleting secret: %q", name)
-	err = conn.CoreV1().Secrets(namespace).Delete(name, &meta_v1.DeleteOptions{})
+	err = conn.CoreV1().Secrets(namespace).Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
@@ -170,3 +219,58 @@ func resourceKubernetesSecretExists(d *schema.ResourceData, meta interface{}) (b
 
 	return true, err
 }
+
+// diffSecretDataKeys returns one patch operation per changed key of the secret data,
+// keys which aren't (or are no longer) managed are never touched.
+func diffSecretDataKeys(live map[string][]byte, oldV, newV map[string]interface{}) PatchOperations {
+	ops := make([]PatchOperation, 0, 0)
+
+	if live == nil {
+		// There's no data map to add the keys to
+		if len(newV) > 0 {
+			ops = append(ops, &AddOperation{
+				Path:  "/data",
+				Value: base64EncodeStringMap(newV),
+			})
+		}
+		return ops
+	}
+
+	for k := range oldV {
+		if _, ok := newV[k]; ok {
+			continue
+		}
+		if _, ok := live[k]; !ok {
+			continue
+		}
+		ops = append(ops, &RemoveOperation{
+			Path: "/data/" + escapeJsonPointer(k),
+		})
+	}
+
+	for k, v := range newV {
+		value := v.(string)
+		if liveValue, ok := live[k]; ok && string(liveValue) == value {
+			continue
+		}
+		// add replaces the value of an existing key
+		ops = append(ops, &AddOperation{
+			Path:  "/data/" + escapeJsonPointer(k),
+			Value: base64.StdEncoding.EncodeToString([]byte(value)),
+		})
+	}
+
+	return ops
+}
+
+// patchSecretOnLatest applies the JSON patch data of an update to the latest version of the secret, see patchOnLatest.
+func patchSecretOnLatest(conn *kubernetes.Clientset, namespace, name string, data []byte) (out *api.Secret, err error) {
+	client := conn.CoreV1().Secrets(namespace)
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (meta_v1.Object, error) {
+		return client.Get(name, meta_v1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, pkgApi.JSONPatchType, data)
+		return err
+	})
+	return out, err
+}
@@ -424,3 +424,75 @@ resource "kubernetes_secret" "test" {
 	}
 }`, prefix)
 }
+
+func TestDiffSecretDataKeys(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Live        map[string][]byte
+		Old         map[string]interface{}
+		New         map[string]interface{}
+		ExpectedOps PatchOperations
+	}{
+		{
+			Name: "add key",
+			Live: map[string][]byte{"one": []byte("111"), "rotated": []byte("xyz")},
+			Old:  map[string]interface{}{"one": "111"},
+			New:  map[string]interface{}{"one": "111", "two": "222"},
+			ExpectedOps: []PatchOperation{
+				&AddOperation{Path: "/data/two", Value: "MjIy"},
+			},
+		},
+		{
+			Name: "replace key",
+			Live: map[string][]byte{"one": []byte("111"), "rotated": []byte("xyz")},
+			Old:  map[string]interface{}{"one": "111"},
+			New:  map[string]interface{}{"one": "abc"},
+			ExpectedOps: []PatchOperation{
+				&AddOperation{Path: "/data/one", Value: "YWJj"},
+			},
+		},
+		{
+			Name: "delete key",
+			Live: map[string][]byte{"one": []byte("111"), "two": []byte("222"), "rotated": []byte("xyz")},
+			Old:  map[string]interface{}{"one": "111", "two": "222"},
+			New:  map[string]interface{}{"two": "222"},
+			ExpectedOps: []PatchOperation{
+				&RemoveOperation{Path: "/data/one"},
+			},
+		},
+		{
+			Name:        "key already deleted by others",
+			Live:        map[string][]byte{"rotated": []byte("xyz")},
+			Old:         map[string]interface{}{"one": "111"},
+			New:         map[string]interface{}{},
+			ExpectedOps: []PatchOperation{},
+		},
+		{
+			Name: "first managed key of existing data",
+			Live: map[string][]byte{"rotated": []byte("xyz")},
+			Old:  map[string]interface{}{},
+			New:  map[string]interface{}{"one/with-slash": "111"},
+			ExpectedOps: []PatchOperation{
+				&AddOperation{Path: "/data/one~1with-slash", Value: "MTEx"},
+			},
+		},
+		{
+			Name: "no data yet",
+			Live: nil,
+			Old:  map[string]interface{}{},
+			New:  map[string]interface{}{"one": "111"},
+			ExpectedOps: []PatchOperation{
+				&AddOperation{Path: "/data", Value: map[string]interface{}{"one": "MTEx"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ops := diffSecretDataKeys(tc.Live, tc.Old, tc.New)
+			if !tc.ExpectedOps.Equal(ops) {
+				t.Fatalf("Operations don't match.\nExpected: %v\nGiven:    %v\n", tc.ExpectedOps, ops)
+			}
+		})
+	}
+}
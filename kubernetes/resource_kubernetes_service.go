@@ -3,13 +3,17 @@ package kubernetes
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesService() *schema.Resource {
@@ -20,12 +24,18 @@ func resourceKubernetesService() *schema.Resource {
 		Update: resourceKubernetesServiceUpdate,
 		Delete: resourceKubernetesServiceDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateWithDefaults(map[string]interface{}{"wait_for_endpoints": false}),
 		},
 		CustomizeDiff: resourceKubernetesServiceCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("service", true),
+			"metadata":             namespacedMetadataSchema("service", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the behavior of a service. https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#spec-and-status",
@@ -48,9 +58,10 @@ func resourceKubernetesService() *schema.Resource {
 							Set:         schema.HashString,
 						},
 						"external_name": {
-							Type:        schema.TypeString,
-							Description: "The external reference that kubedns or equivalent will return as a CNAME record for this service. No proxying will be involved. Must be a valid DNS name and requires `type` to be `ExternalName`.",
-							Optional:    true,
+							Type:         schema.TypeString,
+							Description:  "The external reference that kubedns or equivalent will return as a CNAME record for this service. No proxying will be involved. Must be a valid DNS name and requires `type` to be `ExternalName`.",
+							Optional:     true,
+							ValidateFunc: validateExternalName,
 						},
 						"external_traffic_policy": {
 							Type:        schema.TypeString,
@@ -63,17 +74,46 @@ func resourceKubernetesService() *schema.Resource {
 							Description: "healthCheckNodePort specifies the healthcheck nodePort for the service. If not specified, HealthCheckNodePort is created by the service api backend with the allocated nodePort. Will use user-specified nodePort value if specified by the client. Only effects when Type is set to LoadBalancer and ExternalTrafficPolicy is set to Local.",
 							Optional:    true,
 						},
+						"internal_traffic_policy": {
+							Type:         schema.TypeString,
+							Description:  "Denotes if this Service desires to route internal traffic, from within the cluster, to node-local or cluster-wide endpoints. `Local` only routes to the endpoints on the node the traffic originates from, avoiding a hop to another node, but drops the traffic when that node has no ready endpoint. `Cluster` routes to all the endpoints. Defaults to `Cluster`. Requires Kubernetes 1.22+.",
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateAttributeValueIsIn([]string{"Cluster", "Local"}),
+						},
 						"load_balancer_ip": {
-							Type:        schema.TypeString,
-							Description: "Only applies to `type = LoadBalancer`. LoadBalancer will get created with the IP specified in this field. This feature depends on whether the underlying cloud-provider supports specifying this field when a load balancer is created. This field will be ignored if the cloud-provider does not support the feature.",
+							Type:         schema.TypeString,
+							Description:  "Only applies to `type = LoadBalancer`. LoadBalancer will get created with the IP specified in this field. This feature depends on whether the underlying cloud-provider supports specifying this field when a load balancer is created. This field will be ignored if the cloud-provider does not support the feature. Creating or updating the service fails when the load balancer gets another IP.",
+							Optional:     true,
+							ValidateFunc: validateIPAddress,
+						},
+						"ip_families": {
+							Type:        schema.TypeList,
+							Description: "IP families assigned to the service, primary first: `IPv4` and/or `IPv6`. Defaults to the primary family of the cluster. A secondary family can be added in place, removing a family or changing the primary one re-creates the service. Requires Kubernetes 1.20+.",
 							Optional:    true,
+							Computed:    true,
+							MaxItems:    2,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validateAttributeValueIsIn([]string{"IPv4", "IPv6"}),
+							},
+						},
+						"ip_family_policy": {
+							Type:         schema.TypeString,
+							Description:  "Dual-stack-ness of the service: `SingleStack`, `PreferDualStack` (dual-stack when the cluster supports it) or `RequireDualStack`. Defaults to `SingleStack`. Requires Kubernetes 1.20+.",
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateAttributeValueIsIn([]string{"SingleStack", "PreferDualStack", "RequireDualStack"}),
 						},
 						"load_balancer_source_ranges": {
 							Type:        schema.TypeSet,
 							Description: "If specified and supported by the platform, this will restrict traffic through the cloud-provider load-balancer will be restricted to the specified client IPs. This field will be ignored if the cloud-provider does not support the feature. More info: http://kubernetes.io/docs/user-guide/services-firewalls",
 							Optional:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-							Set:         schema.HashString,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validateCIDR,
+							},
+							Set: schema.HashString,
 						},
 						"port": {
 							Type:        schema.TypeList,
@@ -105,10 +145,11 @@ func resourceKubernetesService() *schema.Resource {
 										Default:     "TCP",
 									},
 									"target_port": {
-										Type:        schema.TypeInt,
-										Description: "Number or name of the port to access on the pods targeted by the service. Number must be in the range 1 to 65535. This field is ignored for services with `cluster_ip = \"None\"`. More info: http://kubernetes.io/docs/user-guide/services#defining-a-service",
-										Optional:    true,
-										Computed:    true,
+										Type:         schema.TypeString,
+										Description:  "Number or name of the port to access on the pods targeted by the service. Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME and refers to the `name` of a container port. Defaults to the `port`. This field is ignored for services with `cluster_ip = \"None\"`. More info: http://kubernetes.io/docs/user-guide/services#defining-a-service",
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: validatePortNumOrName,
 									},
 								},
 							},
@@ -146,10 +187,11 @@ func resourceKubernetesService() *schema.Resource {
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
 												"timeout_seconds": {
-													Type:        schema.TypeInt,
-													Description: "timeoutSeconds specifies the seconds of ClientIP type session sticky time. The value must be >0 && <=86400(for 1 day) if ServiceAffinity == 'ClientIP'. Default value is 10800(for 3 hours).",
-													Optional:    true,
-													Default:     10800,
+													Type:         schema.TypeInt,
+													Description:  "timeoutSeconds specifies the seconds of ClientIP type session sticky time. The value must be >0 && <=86400(for 1 day) if ServiceAffinity == 'ClientIP'. Default value is 10800(for 3 hours).",
+													Optional:     true,
+													Default:      10800,
+													ValidateFunc: validation.IntBetween(1, 86400),
 												},
 											},
 										},
@@ -183,6 +225,12 @@ func resourceKubernetesService() *schema.Resource {
 					},
 				},
 			},
+			"wait_for_endpoints": {
+				Type:        schema.TypeBool,
+				Description: "Whether to wait for the service to have at least one ready endpoint on create. Not applicable to services of type `ExternalName` nor to services without a `selector`, whose endpoints aren't managed by Kubernetes.",
+				Optional:    true,
+				Default:     false,
+			},
 		},
 	}
 }
@@ -190,41 +238,79 @@ func resourceKubernetesService() *schema.Resource {
 func resourceKubernetesServiceCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	svc := api.Service{
 		ObjectMeta: metadata,
 		Spec:       expandServiceSpec(d.Get("spec").([]interface{})),
 	}
-	log.Printf("[INFO] Creating new service: %#v", svc)
-	out, err := conn.CoreV1().Services(metadata.Namespace).Create(&svc)
+	extras := expandServiceSpecExtras(d.Get("spec").([]interface{}))
+	data, err := encodeService(&svc, extras)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal service: %s", err)
+	}
+	log.Printf("[INFO] Creating new service: %s", string(data))
+	// Sent raw, so the fields unknown to the vendored client are included
+	raw, err := conn.CoreV1().RESTClient().Post().Namespace(metadata.Namespace).Resource("services").Body(data).DoRaw()
 	if err != nil {
 		return err
 	}
+	out, _, err := decodeService(raw)
+	if err != nil {
+		return fmt.Errorf("Failed to decode created service: %s", err)
+	}
 	log.Printf("[INFO] Submitted new service: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
 
 	if out.Spec.Type == api.ServiceTypeLoadBalancer {
 		log.Printf("[DEBUG] Waiting for load balancer to assign IP/hostname")
 
-		err = resource.Retry(10*time.Minute, func() *resource.RetryError {
-			svc, err := conn.CoreV1().Services(out.Namespace).Get(out.Name, meta_v1.GetOptions{})
-			if err != nil {
-				log.Printf("[DEBUG] Received error: %#v", err)
-				return resource.NonRetryableError(err)
-			}
-
-			lbIngress := svc.Status.LoadBalancer.Ingress
-
-			log.Printf("[INFO] Received service status: %#v", svc.Status)
-			if len(lbIngress) > 0 {
-				return nil
+		err = waitForLoadBalancerIngress(conn, out, "", d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			lastWarnings, wErr := getLastWarningsForObject(conn, out.ObjectMeta, "Service", meta.(*kubernetesProvider).warningEventLimit)
+			if wErr != nil {
+				return wErr
 			}
+			return fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+		}
+	}
 
-			return resource.RetryableError(fmt.Errorf(
-				"Waiting for service %q to assign IP/hostname for a load balancer", d.Id()))
-		})
+	waitForEndpoints := d.Get("wait_for_endpoints").(bool) && out.Spec.Type != api.ServiceTypeExternalName
+	// The endpoints of a service without a selector are only added by hand, they may never come
+	if waitForEndpoints && len(out.Spec.Selector) == 0 {
+		log.Printf("[WARN] Not waiting for service %s to have ready endpoints, it has no selector", d.Id())
+		waitForEndpoints = false
+	}
+	if waitForEndpoints {
+		log.Printf("[DEBUG] Waiting for service %s to have ready endpoints", d.Id())
+
+		stateConf := &resource.StateChangeConf{
+			Target:  []string{"Ready"},
+			Pending: []string{"Waiting"},
+			Timeout: d.Timeout(schema.TimeoutCreate),
+			Refresh: func() (interface{}, string, error) {
+				ep, err := conn.CoreV1().Endpoints(out.Namespace).Get(out.Name, meta_v1.GetOptions{})
+				if err != nil {
+					if errors.IsNotFound(err) {
+						return out, "Waiting", nil
+					}
+					log.Printf("[ERROR] Received error: %#v", err)
+					return ep, "Error", err
+				}
+
+				ready := 0
+				for _, subset := range ep.Subsets {
+					ready += len(subset.Addresses)
+				}
+				log.Printf("[DEBUG] Service %s has %d ready endpoint(s)", d.Id(), ready)
+				if ready > 0 {
+					return ep, "Ready", nil
+				}
+				return ep, "Waiting", nil
+			},
+		}
+		_, err = stateConf.WaitForState()
 		if err != nil {
-			lastWarnings, wErr := getLastWarningsForObject(conn, out.ObjectMeta, "Service", 3)
+			lastWarnings, wErr := getLastWarningsForObject(conn, out.ObjectMeta, "Service", meta.(*kubernetesProvider).warningEventLimit)
 			if wErr != nil {
 				return wErr
 			}
@@ -244,13 +330,22 @@ func resourceKubernetesServiceRead(d *schema.ResourceData, meta interface{}) err
 	}
 
 	log.Printf("[INFO] Reading service %s", name)
-	svc, err := conn.CoreV1().Services(namespace).Get(name, meta_v1.GetOptions{})
+	raw, err := conn.CoreV1().RESTClient().Get().Namespace(namespace).Resource("services").Name(name).DoRaw()
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Service %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
+	svc, extras, err := decodeService(raw)
+	if err != nil {
+		return fmt.Errorf("Failed to decode service %s: %s", d.Id(), err)
+	}
 	log.Printf("[INFO] Received service: %#v", svc)
-	err = d.Set("metadata", flattenMetadata(svc.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(svc.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -260,7 +355,7 @@ func resourceKubernetesServiceRead(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
-	flattened := flattenServiceSpec(svc.Spec)
+	flattened := flattenServiceSpecExtras(extras, flattenServiceSpec(svc.Spec))
 	log.Printf("[DEBUG] Flattened service spec: %#v", flattened)
 	err = d.Set("spec", flattened)
 	if err != nil {
@@ -273,13 +368,14 @@ func resourceKubernetesServiceRead(d *schema.ResourceData, meta interface{}) err
 func resourceKubernetesServiceUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	namespace, _, err := idParts(d.Id())
+	namespace, name, err := idParts(d.Id())
 	if err != nil {
 		return err
 	}
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec := expandServiceSpec(d.Get("spec").([]interface{}))
+	extras := expandServiceSpecExtras(d.Get("spec").([]interface{}))
 
 	if metadata.Namespace == "" {
 		metadata.Namespace = "default"
@@ -290,16 +386,99 @@ func resourceKubernetesServiceUpdate(d *schema.ResourceData, meta interface{}) e
 		Spec:       spec,
 	}
 
-	out, err := conn.CoreV1().Services(namespace).Update(service)
+	data, err := encodeService(service, extras)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal service: %s", err)
+	}
+	raw, err := conn.CoreV1().RESTClient().Put().Namespace(namespace).Resource("services").Name(name).Body(data).DoRaw()
 	if err != nil {
 		return fmt.Errorf("Failed to update service: %s", err)
 	}
+	out, _, err := decodeService(raw)
+	if err != nil {
+		return fmt.Errorf("Failed to decode updated service: %s", err)
+	}
 	log.Printf("[INFO] Submitted updated service: %#v", out)
 
 	d.SetId(buildId(out.ObjectMeta))
+
+	if out.Spec.Type == api.ServiceTypeLoadBalancer && d.HasChange("spec.0.load_balancer_ip") && out.Spec.LoadBalancerIP != "" {
+		previous, _ := d.GetChange("spec.0.load_balancer_ip")
+		log.Printf("[DEBUG] Waiting for load balancer to move to IP %s", out.Spec.LoadBalancerIP)
+
+		err = waitForLoadBalancerIngress(conn, out, previous.(string), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			lastWarnings, wErr := getLastWarningsForObject(conn, out.ObjectMeta, "Service", meta.(*kubernetesProvider).warningEventLimit)
+			if wErr != nil {
+				return wErr
+			}
+			return fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+		}
+	}
+
 	return resourceKubernetesServiceRead(d, meta)
 }
 
+// waitForLoadBalancerIngress waits for the load balancer of the service to be assigned an IP or hostname.
+// With a load_balancer_ip, it fails as soon as the load balancer is assigned another IP than the requested
+// one, e.g. because the IP is reserved in another region or the quota of static IPs is exhausted. The
+// previous IP of a service whose load_balancer_ip changed is still waited out.
+func waitForLoadBalancerIngress(conn *kubernetes.Clientset, svc *api.Service, previousIP string, timeout time.Duration) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		out, err := conn.CoreV1().Services(svc.Namespace).Get(svc.Name, meta_v1.GetOptions{})
+		if err != nil {
+			log.Printf("[DEBUG] Received error: %#v", err)
+			return resource.NonRetryableError(err)
+		}
+		log.Printf("[INFO] Received service status: %#v", out.Status)
+
+		assigned, err := loadBalancerIngressAssigned(out.Status.LoadBalancer.Ingress, svc.Spec.LoadBalancerIP, previousIP)
+		if err != nil {
+			return resource.NonRetryableError(fmt.Errorf("Service %s/%s: %s", svc.Namespace, svc.Name, err))
+		}
+		if assigned {
+			return nil
+		}
+		if previousIP != "" {
+			return resource.RetryableError(fmt.Errorf(
+				"Waiting for service %s/%s to move its load balancer from IP %s to %s, the cloud provider may not support changing it; re-create the service then",
+				svc.Namespace, svc.Name, previousIP, svc.Spec.LoadBalancerIP))
+		}
+		return resource.RetryableError(fmt.Errorf(
+			"Waiting for service %q to assign IP/hostname for a load balancer", buildId(svc.ObjectMeta)))
+	})
+}
+
+// loadBalancerIngressAssigned reports whether the load balancer got its ingress, which must include
+// the requested IP if any. Load balancers only reached through a hostname can't be checked.
+func loadBalancerIngressAssigned(ingress []api.LoadBalancerIngress, requestedIP, previousIP string) (bool, error) {
+	if len(ingress) == 0 {
+		return false, nil
+	}
+	if requestedIP == "" {
+		return true, nil
+	}
+
+	var ips []string
+	for _, ing := range ingress {
+		if ing.IP == requestedIP {
+			return true, nil
+		}
+		if ing.IP != "" {
+			ips = append(ips, ing.IP)
+		}
+	}
+	if len(ips) == 0 {
+		log.Printf("[WARN] Load balancer has no IP to compare with the requested %s, only a hostname: %#v", requestedIP, ingress)
+		return true, nil
+	}
+	if previousIP != "" && len(ips) == 1 && ips[0] == previousIP {
+		return false, nil
+	}
+	return false, fmt.Errorf("the load balancer was assigned IP %s instead of the requested load_balancer_ip %s",
+		strings.Join(ips, ", "), requestedIP)
+}
+
 func resourceKubernetesServiceDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
@@ -309,7 +488,7 @@ func resourceKubernetesServiceDelete(d *schema.ResourceData, meta interface{}) e
 	}
 
 	log.Printf("[INFO] Deleting service: %#v", name)
-	err = conn.CoreV1().Services(namespace).Delete(name, &meta_v1.DeleteOptions{})
+	err = conn.CoreV1().Services(namespace).Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
@@ -339,7 +518,7 @@ func resourceKubernetesServiceExists(d *schema.ResourceData, meta interface{}) (
 	return true, err
 }
 
-func resourceKubernetesServiceCustomizeDiff(df *schema.ResourceDiff, d interface{}) error {
+func resourceKubernetesServiceCustomizeDiff(df *schema.ResourceDiff, meta interface{}) error {
 	svcType := df.Get("spec.0.type")
 	isExternalServiceType := (svcType == api.ServiceTypeLoadBalancer || svcType == api.ServiceTypeNodePort)
 
@@ -353,5 +532,53 @@ func resourceKubernetesServiceCustomizeDiff(df *schema.ResourceDiff, d interface
 		}
 	}
 
+	if svcType != string(api.ServiceTypeLoadBalancer) && df.Get("spec.0.load_balancer_source_ranges").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("spec.0.load_balancer_source_ranges can only be set when spec.0.type is %q", api.ServiceTypeLoadBalancer)
+	}
+	if df.Get("spec.0.session_affinity").(string) != string(api.ServiceAffinityClientIP) && df.Get("spec.0.session_affinity_config.#").(int) > 0 {
+		return fmt.Errorf("spec.0.session_affinity_config can only be set when spec.0.session_affinity is %q", api.ServiceAffinityClientIP)
+	}
+	if svcType == string(api.ServiceTypeExternalName) {
+		if err := checkExternalNameService(df); err != nil {
+			return err
+		}
+	}
+	if df.Get("spec.0.ip_family_policy").(string) == "SingleStack" && len(df.Get("spec.0.ip_families").([]interface{})) > 1 {
+		return fmt.Errorf("spec.0.ip_families can only list the primary family when spec.0.ip_family_policy is \"SingleStack\"")
+	}
+
+	if df.Id() != "" && df.HasChange("spec.0.ip_families") {
+		oldFamilies, newFamilies := df.GetChange("spec.0.ip_families")
+		if isIPFamilyRemoval(oldFamilies.([]interface{}), newFamilies.([]interface{})) {
+			// Kubernetes only allows adding a secondary family to an existing service
+			if err := checkImmutableFieldChanges(df, meta, "service", "spec.0.ip_families"); err != nil {
+				return err
+			}
+			log.Printf("[WARN] Service %s must be re-created: its IP families can't change from %v to %v, only a secondary family can be added",
+				df.Id(), oldFamilies, newFamilies)
+			return df.ForceNew("spec.0.ip_families")
+		}
+	}
+
+	return nil
+}
+
+// checkExternalNameService fails the plan of an ExternalName service with the fields the API
+// server rejects for it, such a service is only a CNAME record, without any proxying.
+func checkExternalNameService(df *schema.ResourceDiff) error {
+	if v := df.Get("spec.0.external_name").(string); v == "" {
+		return fmt.Errorf("spec.0.external_name must be set when spec.0.type is %q", api.ServiceTypeExternalName)
+	}
+	if df.Get("spec.0.port.#").(int) > 0 {
+		return fmt.Errorf("spec.0.port can't be set when spec.0.type is %q", api.ServiceTypeExternalName)
+	}
+	if len(df.Get("spec.0.selector").(map[string]interface{})) > 0 {
+		return fmt.Errorf("spec.0.selector can't be set when spec.0.type is %q", api.ServiceTypeExternalName)
+	}
+	// The cluster IP is computed, an address left in the state by the previous type isn't configured
+	clusterIP := df.Get("spec.0.cluster_ip").(string)
+	if clusterIP != "" && clusterIP != config.UnknownVariableValue && (df.Id() == "" || df.HasChange("spec.0.cluster_ip")) {
+		return fmt.Errorf("spec.0.cluster_ip can't be set when spec.0.type is %q, given: %q", api.ServiceTypeExternalName, clusterIP)
+	}
 	return nil
 }
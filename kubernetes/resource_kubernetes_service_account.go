@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesServiceAccount() *schema.Resource {
@@ -25,8 +26,14 @@ func resourceKubernetesServiceAccount() *schema.Resource {
 		// any way to differentiate between default & user-defined secret
 		// after the account was created.
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Second),
+		},
+
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("service account", true),
+			"metadata":             namespacedMetadataSchema("service account", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"image_pull_secret": {
 				Type:        schema.TypeSet,
 				Description: "A list of references to secrets in the same namespace to use for pulling any images in pods that reference this Service Account. More info: http://kubernetes.io/docs/user-guide/secrets#manually-specifying-an-imagepullsecret",
@@ -66,7 +73,7 @@ func resourceKubernetesServiceAccount() *schema.Resource {
 func resourceKubernetesServiceAccountCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	svcAcc := api.ServiceAccount{
 		AutomountServiceAccountToken: ptrToBool(false),
 		ObjectMeta:                   metadata,
@@ -84,7 +91,7 @@ func resourceKubernetesServiceAccountCreate(d *schema.ResourceData, meta interfa
 	// Here we get the only chance to identify and store default secret name
 	// so we can avoid showing it in diff as it's not managed by Terraform
 	var resp *api.ServiceAccount
-	err = resource.Retry(30*time.Second, func() *resource.RetryError {
+	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
 		var err error
 		resp, err = conn.CoreV1().ServiceAccounts(out.Namespace).Get(out.Name, metav1.GetOptions{})
 		if err != nil {
@@ -135,11 +142,16 @@ func resourceKubernetesServiceAccountRead(d *schema.ResourceData, meta interface
 	log.Printf("[INFO] Reading service account %s", name)
 	svcAcc, err := conn.CoreV1().ServiceAccounts(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Service account %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received service account: %#v", svcAcc)
-	err = d.Set("metadata", flattenMetadata(svcAcc.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(svcAcc.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -162,7 +174,7 @@ func resourceKubernetesServiceAccountUpdate(d *schema.ResourceData, meta interfa
 		return err
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadataWithDefaults(d, meta)
 	if d.HasChange("image_pull_secret") {
 		v := d.Get("image_pull_secret").(*schema.Set).List()
 		ops = append(ops, &ReplaceOperation{
@@ -184,7 +196,7 @@ func resourceKubernetesServiceAccountUpdate(d *schema.ResourceData, meta interfa
 		return fmt.Errorf("Failed to marshal update operations: %s", err)
 	}
 	log.Printf("[INFO] Updating service account %q: %v", name, string(data))
-	out, err := conn.CoreV1().ServiceAccounts(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	out, err := patchServiceAccountOnLatest(conn, namespace, name, data)
 	if err != nil {
 		return fmt.Errorf("Failed to update service account: %s", err)
 	}
@@ -203,7 +215,7 @@ func resourceKubernetesServiceAccountDelete(d *schema.ResourceData, meta interfa
 	}
 
 	log.Printf("[INFO] Deleting service account: %#v", name)
-	err = conn.CoreV1().ServiceAccounts(namespace).Delete(name, &metav1.DeleteOptions{})
+	err = conn.CoreV1().ServiceAccounts(namespace).Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
@@ -232,3 +244,15 @@ func resourceKubernetesServiceAccountExists(d *schema.ResourceData, meta interfa
 	}
 	return true, err
 }
+
+// patchServiceAccountOnLatest applies the JSON patch data of an update to the latest version of the service account, see patchOnLatest.
+func patchServiceAccountOnLatest(conn *kubernetes.Clientset, namespace, name string, data []byte) (out *api.ServiceAccount, err error) {
+	client := conn.CoreV1().ServiceAccounts(namespace)
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (metav1.Object, error) {
+		return client.Get(name, metav1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, pkgApi.JSONPatchType, data)
+		return err
+	})
+	return out, err
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
@@ -274,6 +276,52 @@ func TestAccKubernetesService_noTargetPort(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesService_namedTargetPort(t *testing.T) {
+	var conf api.Service
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_service.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceConfig_targetPort(name, `"http"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.port.0.target_port", "http"),
+					testAccCheckServicePorts(&conf, []api.ServicePort{
+						{
+							Port:       int32(80),
+							Protocol:   api.ProtocolTCP,
+							TargetPort: intstr.FromString("http"),
+						},
+					}),
+				),
+			},
+			{
+				Config: testAccKubernetesServiceConfig_targetPort(name, "8080"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.port.0.target_port", "8080"),
+					testAccCheckServicePorts(&conf, []api.ServicePort{
+						{
+							Port:       int32(80),
+							Protocol:   api.ProtocolTCP,
+							TargetPort: intstr.FromInt(8080),
+						},
+					}),
+				),
+			},
+			{
+				Config:      testAccKubernetesServiceConfig_targetPort(name, `"HTTP_PORT"`),
+				ExpectError: regexp.MustCompile("target_port"),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesService_externalName(t *testing.T) {
 	var conf api.Service
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
@@ -369,7 +417,7 @@ func TestAccKubernetesService_headless(t *testing.T) {
 		CheckDestroy:  testAccCheckKubernetesServiceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccKubernetesServiceConfig_headless(name),
+				Config: testAccKubernetesServiceConfig_headless(name, true),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
 					resource.TestCheckResourceAttr("kubernetes_service.test", "metadata.0.name", name),
@@ -377,6 +425,13 @@ func TestAccKubernetesService_headless(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.publish_not_ready_addresses", "true"),
 				),
 			},
+			{
+				Config: testAccKubernetesServiceConfig_headless(name, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.publish_not_ready_addresses", "false"),
+				),
+			},
 		},
 	})
 }
@@ -392,7 +447,7 @@ func TestAccKubernetesService_sessionAffinityConfig(t *testing.T) {
 		CheckDestroy:  testAccCheckKubernetesServiceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccKubernetesServiceConfig_sessionAffinityConfig(name),
+				Config: testAccKubernetesServiceConfig_sessionAffinityConfig(name, 180),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
 					resource.TestCheckResourceAttr("kubernetes_service.test", "metadata.0.name", name),
@@ -401,6 +456,21 @@ func TestAccKubernetesService_sessionAffinityConfig(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.session_affinity_config.0.client_ip_config.0.timeout_seconds", "180"),
 				),
 			},
+			{
+				Config: testAccKubernetesServiceConfig_sessionAffinityConfig(name, 600),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.session_affinity_config.0.client_ip_config.0.timeout_seconds", "600"),
+				),
+			},
+			{
+				Config: testAccKubernetesServiceConfig_sessionAffinityNone(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.session_affinity", "None"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.session_affinity_config.#", "0"),
+				),
+			},
 		},
 	})
 }
@@ -427,6 +497,170 @@ func TestAccKubernetesService_externalTrafficPolicy(t *testing.T) {
 	})
 }
 
+func TestResourceKubernetesServiceCustomizeDiff_ipFamilies(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Families      []interface{}
+		Behavior      string
+		RequiresNew   bool
+		ExpectedError string
+	}{
+		{"add secondary family", []interface{}{"IPv4", "IPv6"}, immutableFieldBehaviorRecreate, false, ""},
+		{"remove family", []interface{}{"IPv6"}, immutableFieldBehaviorRecreate, true, ""},
+		{"swap primary family", []interface{}{"IPv6", "IPv4"}, immutableFieldBehaviorRecreate, true, ""},
+		{"remove family with error", []interface{}{"IPv6"}, immutableFieldBehaviorError, false, "spec.0.ip_families.0: IPv4 => IPv6"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "default/web",
+				Attributes: map[string]string{
+					"metadata.#":                         "1",
+					"metadata.0.name":                    "web",
+					"metadata.0.namespace":               "default",
+					"spec.#":                             "1",
+					"spec.0.ip_families.#":               "1",
+					"spec.0.ip_families.0":               "IPv4",
+					"spec.0.ip_family_policy":            "PreferDualStack",
+					"spec.0.publish_not_ready_addresses": "false",
+					"spec.0.session_affinity":            "None",
+					"spec.0.type":                        "ClusterIP",
+					"wait_for_endpoints":                 "false",
+				},
+			}
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec": []map[string]interface{}{{
+					"ip_families":      tc.Families,
+					"ip_family_policy": "PreferDualStack",
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			meta := &kubernetesProvider{immutableFieldBehavior: tc.Behavior}
+
+			// The schema is built for every case, since ForceNew sticks to it
+			diff, err := resourceKubernetesService().Diff(state, terraform.NewResourceConfig(raw), meta)
+			if tc.ExpectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+					t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff.RequiresNew() != tc.RequiresNew {
+				t.Fatalf("Expected RequiresNew to be %t, given diff: %#v", tc.RequiresNew, diff)
+			}
+		})
+	}
+}
+
+func TestResourceKubernetesServiceCustomizeDiff_externalName(t *testing.T) {
+	cases := []struct {
+		Name          string
+		State         *terraform.InstanceState
+		Spec          map[string]interface{}
+		ExpectedError string
+	}{
+		{"external name", nil, map[string]interface{}{"external_name": "db.example.com."}, ""},
+		{"no external name", nil, map[string]interface{}{}, "spec.0.external_name must be set"},
+		{"port", nil, map[string]interface{}{
+			"external_name": "db.example.com",
+			"port":          []map[string]interface{}{{"port": 5432}},
+		}, "spec.0.port can't be set"},
+		{"selector", nil, map[string]interface{}{
+			"external_name": "db.example.com",
+			"selector":      map[string]interface{}{"app": "db"},
+		}, "spec.0.selector can't be set"},
+		{"cluster IP", nil, map[string]interface{}{
+			"external_name": "db.example.com",
+			"cluster_ip":    "10.0.0.10",
+		}, `spec.0.cluster_ip can't be set when spec.0.type is "ExternalName", given: "10.0.0.10"`},
+		{"cluster IP of the previous type", &terraform.InstanceState{
+			ID: "default/db",
+			Attributes: map[string]string{
+				"metadata.#":                         "1",
+				"metadata.0.name":                    "db",
+				"metadata.0.namespace":               "default",
+				"spec.#":                             "1",
+				"spec.0.cluster_ip":                  "10.0.0.10",
+				"spec.0.publish_not_ready_addresses": "false",
+				"spec.0.session_affinity":            "None",
+				"spec.0.type":                        "ClusterIP",
+				"wait_for_endpoints":                 "false",
+			},
+		}, map[string]interface{}{"external_name": "db.example.com"}, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			spec := tc.Spec
+			spec["type"] = "ExternalName"
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "db"}},
+				"spec":     []map[string]interface{}{spec},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = resourceKubernetesService().Diff(tc.State, terraform.NewResourceConfig(raw), &kubernetesProvider{})
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerIngressAssigned(t *testing.T) {
+	cases := []struct {
+		Name             string
+		Ingress          []api.LoadBalancerIngress
+		RequestedIP      string
+		PreviousIP       string
+		ExpectedAssigned bool
+		ExpectedError    string
+	}{
+		{"pending", nil, "203.0.113.10", "", false, ""},
+		{"any IP", []api.LoadBalancerIngress{{IP: "198.51.100.7"}}, "", "", true, ""},
+		{"requested IP", []api.LoadBalancerIngress{{IP: "203.0.113.10"}}, "203.0.113.10", "", true, ""},
+		{"hostname only", []api.LoadBalancerIngress{{Hostname: "lb.example.com"}}, "203.0.113.10", "", true, ""},
+		{"another IP", []api.LoadBalancerIngress{{IP: "198.51.100.7"}}, "203.0.113.10", "", false,
+			"the load balancer was assigned IP 198.51.100.7 instead of the requested load_balancer_ip 203.0.113.10"},
+		{"still the previous IP", []api.LoadBalancerIngress{{IP: "203.0.113.9"}}, "203.0.113.10", "203.0.113.9", false, ""},
+		{"neither IP", []api.LoadBalancerIngress{{IP: "198.51.100.7"}}, "203.0.113.10", "203.0.113.9", false,
+			"assigned IP 198.51.100.7 instead"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assigned, err := loadBalancerIngressAssigned(tc.Ingress, tc.RequestedIP, tc.PreviousIP)
+			if assigned != tc.ExpectedAssigned {
+				t.Fatalf("Expected assigned to be %t, given: %t", tc.ExpectedAssigned, assigned)
+			}
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
 func TestAccKubernetesService_importGeneratedName(t *testing.T) {
 	resourceName := "kubernetes_service.test"
 	prefix := "tf-acc-test-gen-import-"
@@ -668,6 +902,25 @@ resource "kubernetes_service" "test" {
 `, name)
 }
 
+func testAccKubernetesServiceConfig_targetPort(name, targetPort string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    selector {
+      App = "MyApp"
+    }
+    port {
+      port        = 80
+      target_port = %s
+    }
+  }
+}
+`, name, targetPort)
+}
+
 func testAccKubernetesServiceConfig_externalName(name string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_service" "test" {
@@ -697,7 +950,7 @@ resource "kubernetes_service" "test" {
 }`, prefix)
 }
 
-func testAccKubernetesServiceConfig_sessionAffinityConfig(name string) string {
+func testAccKubernetesServiceConfig_sessionAffinityConfig(name string, timeout int) string {
 	return fmt.Sprintf(`
 resource "kubernetes_service" "test" {
   metadata {
@@ -714,15 +967,35 @@ resource "kubernetes_service" "test" {
 	session_affinity = "ClientIP"
 	session_affinity_config {
 		client_ip_config {
-			timeout_seconds = 180
+			timeout_seconds = %d
 		}
 	}
   }
 }
+`, name, timeout)
+}
+
+func testAccKubernetesServiceConfig_sessionAffinityNone(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    selector {
+      App = "MyOtherApp"
+    }
+    port {
+      name = "http"
+      port = 80
+    }
+	session_affinity = "None"
+  }
+}
 `, name)
 }
 
-func testAccKubernetesServiceConfig_headless(name string) string {
+func testAccKubernetesServiceConfig_headless(name string, publishNotReady bool) string {
 	return fmt.Sprintf(`
 resource "kubernetes_service" "test" {
   metadata {
@@ -734,7 +1007,7 @@ resource "kubernetes_service" "test" {
       name = "http"
       port = 80
     }
-	publish_not_ready_addresses = true
+	publish_not_ready_addresses = %t
     selector {
       App = "headlessApp"
     }
@@ -742,7 +1015,7 @@ resource "kubernetes_service" "test" {
 	type = "ClusterIP"
   }
 }
-`, name)
+`, name, publishNotReady)
 }
 
 func testAccKubernetesServiceConfig_externalTrafficPolicy(name string) string {
@@ -4,9 +4,12 @@ import (
 	errs "errors"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 	"k8s.io/api/apps/v1"
 	"k8s.io/api/apps/v1beta1"
@@ -29,12 +32,53 @@ func resourceKubernetesStatefulSet() *schema.Resource {
 		Delete: resourceKubernetesStatefulSetDelete,
 		Exists: resourceKubernetesStatefulSetExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateWithDefaults(map[string]interface{}{
+				"wait_for_rollout": true,
+			}),
 		},
 		SchemaVersion: 1,
 		MigrateState:  resourceKubernetesStatefulSetStateUpgrader,
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			// Containers mount the claims of the volume claim templates like volumes
+			if claims, ok := volumeClaimTemplateNames(diff); ok {
+				if err := checkVolumeMountsReferenceVolumes(diff, "spec.0.template.0.spec.0", claims...); err != nil {
+					return err
+				}
+			}
+			if err := checkProjectedVolumeSources(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkProbes(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkTolerations(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkDNSConfig(diff, "spec.0.template.0.spec.0"); err != nil {
+				return err
+			}
+			if err := checkCommonLabels(diff, "metadata.0.labels", "spec.0.selector", "spec.0.template.0.metadata.0.labels"); err != nil {
+				return err
+			}
+			return checkSelectorMatchesTemplateLabels(diff, "stateful set", "spec.0.selector", "spec.0.template.0.metadata.0.labels")
+		},
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("statefulset", true),
+			"metadata":             namespacedMetadataSchema("statefulset", true),
+			"status":               workloadStatusSchema("stateful set"),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
+			"common_labels":        commonLabelsSchema("stateful set"),
+			"restart_trigger": {
+				Type:        schema.TypeString,
+				Description: "Arbitrary value whose change rolls out the pods of the stateful set again, like `kubectl rollout restart`. It's set as the `kubectl.kubernetes.io/restartedAt` annotation of the pod template, unless that annotation is configured. With `wait_for_rollout` the update waits until the replicas run the new revision.",
+				Optional:    true,
+			},
+			"wait_for_rollout": {
+				Type:        schema.TypeBool,
+				Description: "Whether to wait on create & update until the replicas are ready & updated. With a partitioned rolling update only the replicas at an ordinal greater than or equal to the partition are expected to be updated. With the OrderedReady pod management policy the pods are started & updated one at a time, so the wait lasts about as many times as there are replicas the time a pod takes to be ready, the create & update timeouts may need to be raised for large sets. The wait fails right away once a pod is stuck, e.g. pulling its image fails: with OrderedReady no pod goes past it, with Parallel once every pod which isn't ready is stuck. Otherwise only the scheduling of the replicas is awaited.",
+				Optional:    true,
+				Default:     true,
+			},
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the specification of the desired behavior of the StatefulSet. More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#spec-and-status",
@@ -51,21 +95,21 @@ func resourceKubernetesStatefulSet() *schema.Resource {
 						},
 						"replicas": {
 							Type:        schema.TypeInt,
-							Description: "The number of desired replicas. Defaults to 1. More info: http://kubernetes.io/docs/user-guide/replication-controller#what-is-a-replication-controller",
+							Description: "The number of desired replicas. Defaults to 1. Changes are applied through the `scale` subresource. More info: http://kubernetes.io/docs/user-guide/replication-controller#what-is-a-replication-controller",
 							Optional:    true,
 							Default:     1,
 						},
 						"revision_history_limit": {
-							Type:        schema.TypeInt,
-							Description: "revisionHistoryLimit is the maximum number of revisions that will be maintained in the StatefulSet's revision history. The revision history consists of all revisions not represented by a currently applied StatefulSetSpec version. The default value is 10.",
-							Optional:    true,
-							Default:     10,
-							ForceNew:    true,
+							Type:         schema.TypeInt,
+							Description:  "revisionHistoryLimit is the maximum number of revisions that will be maintained in the StatefulSet's revision history. The revision history consists of all revisions not represented by a currently applied StatefulSetSpec version. The default value is 10.",
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(0),
 						},
 						"selector": {
 							Type:        schema.TypeMap,
 							Description: "A label query over pods that should match the Replicas count. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
-							Required:    true,
+							Optional:    true,
 							ForceNew:    true,
 						},
 						"service_name": {
@@ -134,7 +178,7 @@ func resourceKubernetesStatefulSet() *schema.Resource {
 											Schema: map[string]*schema.Schema{
 												"partition": {
 													Type:        schema.TypeInt,
-													Description: "Partition indicates the ordinal at which the StatefulSet should be partitioned. Default value is 0.",
+													Description: "Partition indicates the ordinal at which the StatefulSet should be partitioned. Only the replicas at an ordinal greater than or equal to the partition are updated to a new revision of the template, e.g. to roll it out to a few canary replicas first. Changing it updates the StatefulSet in place. Default value is 0.",
 													Optional:    true,
 													Default:     0,
 												},
@@ -163,11 +207,14 @@ func resourceKubernetesStatefulSetCreate(d *schema.ResourceData, meta interface{
 	kp := meta.(*kubernetesProvider)
 	conn := kp.conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	spec, err := expandStatefulSetSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return err
 	}
+	clearUnsetSecurityContextFields(d, "spec.0.template.0.spec.0.", &spec.Template.Spec)
+	setRestartTrigger(&spec.Template, d.Get("restart_trigger").(string))
+	applyCommonLabels(d, &metadata, &spec.Selector, &spec.Template.ObjectMeta)
 
 	//use name as label and selector if not set
 	if metadata.Namespace == "" {
@@ -212,17 +259,20 @@ func resourceKubernetesStatefulSetCreate(d *schema.ResourceData, meta interface{
 
 	d.SetId(buildId(outStatefulSetV1.ObjectMeta))
 
-	log.Printf("[DEBUG] Waiting for Stateful Set %s to schedule %d replicas",
-		d.Id(), *outStatefulSetV1.Spec.Replicas)
-	// 10 mins should be sufficient for scheduling ~10k replicas
-	err = resource.Retry(d.Timeout(schema.TimeoutCreate),
-		waitForStatefulSetReplicasFunc(kp, outStatefulSetV1.GetNamespace(), outStatefulSetV1.GetName()))
+	if d.Get("wait_for_rollout").(bool) {
+		log.Printf("[DEBUG] Waiting for the rollout of Stateful Set %s", d.Id())
+		err = waitForStatefulSetRollout(d.Timeout(schema.TimeoutCreate), kp, outStatefulSetV1.ObjectMeta)
+	} else {
+		log.Printf("[DEBUG] Waiting for Stateful Set %s to schedule %d replicas",
+			d.Id(), *outStatefulSetV1.Spec.Replicas)
+		// 10 mins should be sufficient for scheduling ~10k replicas
+		err = resource.Retry(d.Timeout(schema.TimeoutCreate),
+			waitForStatefulSetReplicasFunc(kp, outStatefulSetV1.GetNamespace(), outStatefulSetV1.GetName()))
+		err = withUnreadyPods(err, kp.conn, outStatefulSetV1.GetNamespace(), outStatefulSetV1.Spec.Selector)
+	}
 	if err != nil {
 		return err
 	}
-	// We could wait for all pods to actually reach Ready state
-	// but that means checking each pod status separately (which can be expensive at scale)
-	// as there's no aggregate data available from the API
 
 	log.Printf("[INFO] Submitted new statefulSet: %#v", outStatefulSetV1)
 
@@ -236,17 +286,28 @@ func resourceKubernetesStatefulSetRead(d *schema.ResourceData, meta interface{})
 	log.Printf("[INFO] Reading statefulSet %s", name)
 	statefulSet, err := readStatefulSet(kp, namespace, name)
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Stateful set %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received statefulSet: %#v", statefulSet)
 
+	err = checkControllerOwner("StatefulSet", statefulSet.ObjectMeta, meta)
+	if err != nil {
+		return err
+	}
+
+	removeCommonLabels(d, &statefulSet.ObjectMeta, statefulSet.Spec.Selector, &statefulSet.Spec.Template.ObjectMeta)
 	statefulSet.ObjectMeta.Labels = reconcileTopLevelLabels(
 		statefulSet.ObjectMeta.Labels,
 		expandMetadata(d.Get("metadata").([]interface{})),
 		expandMetadata(d.Get("spec.0.template.0.metadata").([]interface{})),
 	)
-	err = d.Set("metadata", flattenMetadata(statefulSet.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadataWithoutDefaults(statefulSet.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
@@ -261,6 +322,11 @@ func resourceKubernetesStatefulSetRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	err = d.Set("status", flattenWorkloadStatus(statefulSet.Status.ObservedGeneration))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -269,13 +335,25 @@ func resourceKubernetesStatefulSetUpdate(d *schema.ResourceData, meta interface{
 
 	namespace, name, err := idParts(d.Id())
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	// Changed replicas are set through the scale subresource & left out of the patch
+	scaled := d.HasChange("spec.0.replicas")
+	if scaled {
+		err = scaleWorkload(kp, statefulSetResourceGroupName, statefulSetAPIGroups, namespace, name, int32(d.Get("spec.0.replicas").(int)))
+		if err != nil {
+			return err
+		}
+	}
 
-	if d.HasChange("spec") {
+	ops := patchMetadataWithCommonLabels(d, meta)
+
+	if (d.HasChange("spec") && (!scaled || specChangedBesidesReplicas(d))) || d.HasChange("restart_trigger") {
 		spec, err := expandStatefulSetSpec(d.Get("spec").([]interface{}))
 		if err != nil {
 			return err
 		}
+		clearUnsetSecurityContextFields(d, "spec.0.template.0.spec.0.", &spec.Template.Spec)
+		setRestartTrigger(&spec.Template, d.Get("restart_trigger").(string))
+		applyCommonLabels(d, nil, &spec.Selector, &spec.Template.ObjectMeta)
 
 		ops = append(ops, &ReplaceOperation{
 			Path:  "/spec",
@@ -288,15 +366,21 @@ func resourceKubernetesStatefulSetUpdate(d *schema.ResourceData, meta interface{
 	}
 	log.Printf("[INFO] Updating statefulSet %q: %v", name, string(data))
 
-	out, err := patchStatefulSet(d, kp, data)
+	out, err := patchStatefulSetOnLatest(d, kp, data)
 	if err != nil {
 		return fmt.Errorf("Failed to update statefulSet: %s", err)
 	}
 
 	log.Printf("[INFO] Submitted updated statefulSet: %#v", out)
 
-	err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
-		waitForStatefulSetReplicasFunc(kp, namespace, name))
+	if d.Get("wait_for_rollout").(bool) {
+		log.Printf("[DEBUG] Waiting for the rollout of statefulSet %s", d.Id())
+		err = waitForStatefulSetRollout(d.Timeout(schema.TimeoutUpdate), kp, out.ObjectMeta)
+	} else {
+		err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
+			waitForStatefulSetReplicasFunc(kp, namespace, name))
+		err = withUnreadyPods(err, kp.conn, namespace, out.Spec.Selector)
+	}
 	if err != nil {
 		return err
 	}
@@ -334,17 +418,18 @@ func resourceKubernetesStatefulSetDelete(d *schema.ResourceData, meta interface{
 		return err
 	}
 
+	opts := deleteOptions(d, "")
 	apiGroup, err := kp.highestSupportedAPIGroup(statefulSetResourceGroupName, statefulSetAPIGroups...)
 	if err != nil {
 		return err
 	}
 	switch apiGroup {
 	case appsV1:
-		err = conn.AppsV1().StatefulSets(namespace).Delete(name, &metav1.DeleteOptions{})
+		err = conn.AppsV1().StatefulSets(namespace).Delete(name, opts)
 	case appsV1beta2:
-		err = conn.AppsV1beta2().StatefulSets(namespace).Delete(name, &metav1.DeleteOptions{})
+		err = conn.AppsV1beta2().StatefulSets(namespace).Delete(name, opts)
 	case appsV1beta1:
-		err = conn.AppsV1beta1().StatefulSets(namespace).Delete(name, &metav1.DeleteOptions{})
+		err = conn.AppsV1beta1().StatefulSets(namespace).Delete(name, opts)
 	default:
 		err = statefulSetNotSupportedError
 	}
@@ -353,6 +438,17 @@ func resourceKubernetesStatefulSetDelete(d *schema.ResourceData, meta interface{
 		return err
 	}
 
+	// The stateful set is only removed once its pods are gone
+	if isForegroundDeletion(opts) {
+		err = waitForDeletion(d.Timeout(schema.TimeoutDelete), fmt.Sprintf("StatefulSet %s", d.Id()), func() error {
+			_, err := readStatefulSet(kp, namespace, name)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	log.Printf("[INFO] StatefulSet %s deleted", name)
 
 	d.SetId("")
@@ -378,6 +474,21 @@ func resourceKubernetesStatefulSetExists(d *schema.ResourceData, meta interface{
 	return true, err
 }
 
+// patchStatefulSetOnLatest applies the JSON patch data of an update to the latest version of the stateful set, see patchOnLatest.
+func patchStatefulSetOnLatest(d *schema.ResourceData, kp *kubernetesProvider, data []byte) (out *v1.StatefulSet, err error) {
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (metav1.Object, error) {
+		return readStatefulSet(kp, namespace, name)
+	}, func(data []byte) error {
+		out, err = patchStatefulSet(d, kp, data)
+		return err
+	})
+	return out, err
+}
+
 func patchStatefulSet(d *schema.ResourceData, kp *kubernetesProvider, data []byte) (ss *v1.StatefulSet, err error) {
 	conn := kp.conn
 	ss = &v1.StatefulSet{}
@@ -475,6 +586,110 @@ func waitForStatefulSetReplicasFunc(kp *kubernetesProvider, ns, name string) res
 	}
 }
 
+// waitForStatefulSetRollout waits until all the replicas of the stateful set are ready & updated,
+// as far as its update strategy updates them.
+func waitForStatefulSetRollout(timeout time.Duration, kp *kubernetesProvider, metadata metav1.ObjectMeta) error {
+	var last *v1.StatefulSet
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		statefulSet, err := readStatefulSet(kp, metadata.Namespace, metadata.Name)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		last = statefulSet
+
+		done, msg := statefulSetRolloutStatus(statefulSet)
+		if done {
+			return nil
+		}
+
+		// Stuck pods are only looked for when the pods may be listed
+		stuck, err := stuckPods(kp.conn, statefulSet.Namespace, statefulSet.Spec.Selector)
+		if err != nil && !errors.IsForbidden(err) {
+			return resource.NonRetryableError(err)
+		}
+		if statefulSetRolloutBlocked(statefulSet, len(stuck)) {
+			return resource.NonRetryableError(fmt.Errorf("The rollout of %q can't progress, pods are stuck:%s",
+				statefulSet.GetName(), strings.Join(stuck, "")))
+		}
+		log.Printf("[DEBUG] %s", msg)
+		return resource.RetryableError(errs.New(msg))
+	})
+	if err != nil {
+		lastWarnings, wErr := getLastWarningsForObject(kp.conn, metadata, "StatefulSet", kp.warningEventLimit)
+		if wErr != nil {
+			return wErr
+		}
+		err = fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+		if last != nil {
+			err = withUnreadyPods(err, kp.conn, metadata.Namespace, last.Spec.Selector)
+		}
+		return err
+	}
+	return nil
+}
+
+// statefulSetRolloutBlocked reports whether stuck pods keep the rollout of the stateful set from
+// ever completing. With the OrderedReady policy the pods are started & updated one at a time,
+// none goes past a stuck one. With the Parallel policy the other pods still progress, the rollout
+// is only blocked once every pod which isn't ready yet is stuck.
+func statefulSetRolloutBlocked(statefulSet *v1.StatefulSet, stuck int) bool {
+	if stuck == 0 {
+		return false
+	}
+	if statefulSet.Spec.PodManagementPolicy != v1.ParallelPodManagement {
+		return true
+	}
+	var desiredReplicas int32 = 1
+	if statefulSet.Spec.Replicas != nil {
+		desiredReplicas = *statefulSet.Spec.Replicas
+	}
+	return int32(stuck) >= desiredReplicas-statefulSet.Status.ReadyReplicas
+}
+
+// statefulSetRolloutStatus reports whether the rollout of the stateful set is complete, like
+// `kubectl rollout status`, or else what it's waiting for. A partitioned rolling update only
+// updates the replicas at an ordinal >= partition, the others keep the current revision, so
+// the update revision is never reached by all replicas.
+func statefulSetRolloutStatus(statefulSet *v1.StatefulSet) (bool, string) {
+	status := statefulSet.Status
+	var desiredReplicas int32 = 1
+	if statefulSet.Spec.Replicas != nil {
+		desiredReplicas = *statefulSet.Spec.Replicas
+	}
+
+	return rolloutStatus(statefulSet.GetName(),
+		observedGenerationCheck(statefulSet.Generation, status.ObservedGeneration),
+		func() string {
+			if status.ReadyReplicas >= desiredReplicas {
+				return ""
+			}
+			if statefulSet.Spec.PodManagementPolicy == v1.ParallelPodManagement {
+				return fmt.Sprintf("%d of %d replicas ready", status.ReadyReplicas, desiredReplicas)
+			}
+			return fmt.Sprintf("%d of %d replicas ready, started one at a time (OrderedReady)", status.ReadyReplicas, desiredReplicas)
+		},
+		func() string {
+			strategy := statefulSet.Spec.UpdateStrategy
+			// Replicas are only updated once they're deleted, there's no rollout to wait on
+			if strategy.Type == v1.OnDeleteStatefulSetStrategyType {
+				return ""
+			}
+			if strategy.RollingUpdate != nil && strategy.RollingUpdate.Partition != nil && *strategy.RollingUpdate.Partition > 0 {
+				partition := *strategy.RollingUpdate.Partition
+				if expected := desiredReplicas - partition; status.UpdatedReplicas < expected {
+					return fmt.Sprintf("%d of %d replicas at ordinal %d or above updated (partitioned)",
+						status.UpdatedReplicas, expected, partition)
+				}
+				return ""
+			}
+			if status.UpdateRevision != status.CurrentRevision {
+				return fmt.Sprintf("%d of %d replicas updated to revision %s", status.UpdatedReplicas, desiredReplicas, status.UpdateRevision)
+			}
+			return ""
+		},
+	)
+}
+
 func resourceKubernetesStatefulSetStateUpgrader(
 	v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
 	if is.Empty() {
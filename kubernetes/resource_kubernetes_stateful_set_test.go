@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestAccKubernetesStatefulSet_basic(t *testing.T) {
@@ -34,6 +35,7 @@ func TestAccKubernetesStatefulSet_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("kubernetes_stateful_set.test", "metadata.0.resource_version"),
 					resource.TestCheckResourceAttrSet("kubernetes_stateful_set.test", "metadata.0.self_link"),
 					resource.TestCheckResourceAttrSet("kubernetes_stateful_set.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttrPair("kubernetes_stateful_set.test", "status.0.observed_generation", "kubernetes_stateful_set.test", "metadata.0.generation"),
 					resource.TestCheckResourceAttr("kubernetes_stateful_set.test", "spec.0.service_name", statefulSetName),
 					resource.TestCheckResourceAttr("kubernetes_stateful_set.test", "spec.0.pod_management_policy", "Parallel"),
 					resource.TestCheckResourceAttr("kubernetes_stateful_set.test", "spec.0.template.0.spec.0.container.0.image", imageName1),
@@ -51,6 +53,97 @@ func TestAccKubernetesStatefulSet_basic(t *testing.T) {
 	})
 }
 
+func TestStatefulSetRolloutStatus(t *testing.T) {
+	replicas := int32(3)
+	partition := int32(2)
+	rollingUpdate := v1.StatefulSetUpdateStrategy{Type: v1.RollingUpdateStatefulSetStrategyType}
+	partitioned := v1.StatefulSetUpdateStrategy{
+		Type:          v1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &v1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+	}
+	onDelete := v1.StatefulSetUpdateStrategy{Type: v1.OnDeleteStatefulSetStrategyType}
+
+	cases := []struct {
+		Name     string
+		Strategy v1.StatefulSetUpdateStrategy
+		Status   v1.StatefulSetStatus
+		Done     bool
+	}{
+		{"not observed", rollingUpdate, v1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 3, CurrentRevision: "a", UpdateRevision: "a"}, false},
+		{"not ready", rollingUpdate, v1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 2, CurrentRevision: "a", UpdateRevision: "a"}, false},
+		{"updating", rollingUpdate, v1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 3, UpdatedReplicas: 2, CurrentRevision: "a", UpdateRevision: "b"}, false},
+		{"updated", rollingUpdate, v1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 3, UpdatedReplicas: 3, CurrentRevision: "b", UpdateRevision: "b"}, true},
+		{"partition updating", partitioned, v1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 3, UpdatedReplicas: 0, CurrentRevision: "a", UpdateRevision: "b"}, false},
+		// Only the replica at ordinal 2 is updated, the current revision is kept by the others
+		{"partition updated", partitioned, v1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "a", UpdateRevision: "b"}, true},
+		{"on delete", onDelete, v1.StatefulSetStatus{ObservedGeneration: 2, ReadyReplicas: 3, CurrentRevision: "a", UpdateRevision: "b"}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			statefulSet := &v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Generation: 2},
+				Spec:       v1.StatefulSetSpec{Replicas: &replicas, UpdateStrategy: tc.Strategy},
+				Status:     tc.Status,
+			}
+			done, msg := statefulSetRolloutStatus(statefulSet)
+			if done != tc.Done {
+				t.Fatalf("Expected done to be %t, given: %t (%s)", tc.Done, done, msg)
+			}
+			if !done && msg == "" {
+				t.Fatal("Expected a message about what the rollout is waiting for")
+			}
+		})
+	}
+}
+
+func TestStatefulSetRolloutBlocked(t *testing.T) {
+	replicas := int32(3)
+	cases := []struct {
+		Name    string
+		Policy  v1.PodManagementPolicyType
+		Ready   int32
+		Stuck   int
+		Blocked bool
+	}{
+		{"ordered progressing", v1.OrderedReadyPodManagement, 1, 0, false},
+		{"ordered stuck", v1.OrderedReadyPodManagement, 1, 1, true},
+		// The other pods still start while one is stuck
+		{"parallel progressing", v1.ParallelPodManagement, 0, 1, false},
+		{"parallel stuck", v1.ParallelPodManagement, 2, 1, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			statefulSet := &v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "db"},
+				Spec:       v1.StatefulSetSpec{Replicas: &replicas, PodManagementPolicy: tc.Policy},
+				Status:     v1.StatefulSetStatus{ReadyReplicas: tc.Ready},
+			}
+			if blocked := statefulSetRolloutBlocked(statefulSet, tc.Stuck); blocked != tc.Blocked {
+				t.Fatalf("Expected blocked to be %t, given: %t", tc.Blocked, blocked)
+			}
+		})
+	}
+}
+
+func TestExpandStatefulSetSpecRevisionHistoryLimit(t *testing.T) {
+	spec, err := expandStatefulSetSpec([]interface{}{map[string]interface{}{
+		"replicas":               1,
+		"revision_history_limit": 2,
+		"selector":               map[string]interface{}{"app": "db"},
+		"service_name":           "db",
+		"template":               []interface{}{},
+		"volume_claim_templates": []interface{}{},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.RevisionHistoryLimit == nil || *spec.RevisionHistoryLimit != 2 {
+		t.Fatalf("Expected a revision history limit of 2, given: %v", spec.RevisionHistoryLimit)
+	}
+}
+
 func TestAccKubernetesStatefulSet_pvcTemplate(t *testing.T) {
 	var sset v1.StatefulSet
 
@@ -134,6 +227,49 @@ func TestAccKubernetesStatefulSet_updateStrategy(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesStatefulSet_restartTrigger(t *testing.T) {
+	var sset v1.StatefulSet
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesStatefulSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesStatefulSetConfig_restartTrigger(name, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesStatefulSetExists("kubernetes_stateful_set.test", &sset),
+					testAccCheckKubernetesStatefulSetRestartedAt(&sset, "1"),
+					resource.TestCheckResourceAttr("kubernetes_stateful_set.test", "restart_trigger", "1"),
+					resource.TestCheckResourceAttr("kubernetes_stateful_set.test", "spec.0.template.0.metadata.0.annotations.%", "0"),
+				),
+			},
+			{
+				Config: testAccKubernetesStatefulSetConfig_restartTrigger(name, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesStatefulSetExists("kubernetes_stateful_set.test", &sset),
+					testAccCheckKubernetesStatefulSetRestartedAt(&sset, "2"),
+					resource.TestCheckResourceAttr("kubernetes_stateful_set.test", "restart_trigger", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesStatefulSetRestartedAt(obj *v1.StatefulSet, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if v := obj.Spec.Template.Annotations[restartedAtAnnotation]; v != expected {
+			return fmt.Errorf("Expected the pod template to be annotated with %s = %q, given: %q", restartedAtAnnotation, expected, v)
+		}
+		// The rollout of the restart was awaited
+		if obj.Status.UpdateRevision != obj.Status.CurrentRevision || obj.Status.ObservedGeneration != obj.Generation {
+			return fmt.Errorf("Expected the rollout of generation %d to be complete, given status: %#v", obj.Generation, obj.Status)
+		}
+		return nil
+	}
+}
+
 func testAccCheckKubernetesStatefulSetExists(n string, obj *v1.StatefulSet) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -339,3 +475,34 @@ resource kubernetes_stateful_set "test" {
 }
 `, name, name)
 }
+
+func testAccKubernetesStatefulSetConfig_restartTrigger(name, trigger string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_stateful_set" "test" {
+  metadata {
+    name = "%s"
+  }
+  restart_trigger = "%s"
+  spec {
+    replicas     = 2
+    service_name = "%s"
+    selector {
+      app = "one"
+    }
+    template {
+      metadata {
+        labels {
+          app = "one"
+        }
+      }
+      spec {
+        container {
+          image = "nginx:1.7.9"
+          name  = "tf-acc-test"
+        }
+      }
+    }
+  }
+}
+`, name, trigger, name)
+}
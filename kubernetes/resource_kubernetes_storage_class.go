@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesStorageClass() *schema.Resource {
@@ -24,7 +25,9 @@ func resourceKubernetesStorageClass() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": metadataSchema("storage class", true),
+			"metadata":             clusterScopedMetadataSchema("storage class", true),
+			"grace_period_seconds": deleteGracePeriodSchema(),
+			"propagation_policy":   deletePropagationPolicySchema(""),
 			"reclaim_policy": {
 				Type:        schema.TypeString,
 				Description: "Reclaim policy to be applied to provisioned persistent volumes",
@@ -44,6 +47,20 @@ func resourceKubernetesStorageClass() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"volume_binding_mode": {
+				Type:         schema.TypeString,
+				Description:  "When volumes are provisioned & bound: `Immediate` or `WaitForFirstConsumer`, which delays this until a pod using the claim is scheduled",
+				Optional:     true,
+				Default:      string(api.VolumeBindingImmediate),
+				ForceNew:     true,
+				ValidateFunc: validateAttributeValueIsIn([]string{string(api.VolumeBindingImmediate), string(api.VolumeBindingWaitForFirstConsumer)}),
+			},
+			"allow_volume_expansion": {
+				Type:        schema.TypeBool,
+				Description: "Whether persistent volume claims of this storage class can be expanded",
+				Optional:    true,
+				Default:     false,
+			},
 		},
 	}
 }
@@ -51,7 +68,7 @@ func resourceKubernetesStorageClass() *schema.Resource {
 func resourceKubernetesStorageClassCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetesProvider).conn
 
-	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata := expandMetadataWithDefaults(d.Get("metadata").([]interface{}), meta)
 	storageClass := api.StorageClass{
 		ObjectMeta:  metadata,
 		Provisioner: d.Get("storage_provisioner").(string),
@@ -65,6 +82,14 @@ func resourceKubernetesStorageClassCreate(d *schema.ResourceData, meta interface
 	if v, ok := d.GetOk("parameters"); ok {
 		storageClass.Parameters = expandStringMap(v.(map[string]interface{}))
 	}
+	if v, ok := d.GetOk("volume_binding_mode"); ok {
+		mode := api.VolumeBindingMode(v.(string))
+		storageClass.VolumeBindingMode = &mode
+	}
+	if v, ok := d.GetOk("allow_volume_expansion"); ok {
+		allow := v.(bool)
+		storageClass.AllowVolumeExpansion = &allow
+	}
 
 	log.Printf("[INFO] Creating new storage class: %#v", storageClass)
 	out, err := conn.StorageV1().StorageClasses().Create(&storageClass)
@@ -84,17 +109,32 @@ func resourceKubernetesStorageClassRead(d *schema.ResourceData, meta interface{}
 	log.Printf("[INFO] Reading storage class %s", name)
 	storageClass, err := conn.StorageV1().StorageClasses().Get(name, metav1.GetOptions{})
 	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[INFO] Storage class %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received storage class: %#v", storageClass)
-	err = d.Set("metadata", flattenMetadata(storageClass.ObjectMeta, d))
+	return setStorageClassAttributes(d, meta, storageClass)
+}
+
+func setStorageClassAttributes(d *schema.ResourceData, meta interface{}, storageClass *api.StorageClass) error {
+	err := d.Set("metadata", flattenMetadataWithoutDefaults(storageClass.ObjectMeta, d, meta))
 	if err != nil {
 		return err
 	}
 	d.Set("reclaim_policy", storageClass.ReclaimPolicy)
 	d.Set("parameters", storageClass.Parameters)
 	d.Set("storage_provisioner", storageClass.Provisioner)
+	// Not returned by servers which predate the fields
+	d.Set("volume_binding_mode", string(api.VolumeBindingImmediate))
+	if storageClass.VolumeBindingMode != nil {
+		d.Set("volume_binding_mode", string(*storageClass.VolumeBindingMode))
+	}
+	d.Set("allow_volume_expansion", storageClass.AllowVolumeExpansion != nil && *storageClass.AllowVolumeExpansion)
 
 	return nil
 }
@@ -103,13 +143,19 @@ func resourceKubernetesStorageClassUpdate(d *schema.ResourceData, meta interface
 	conn := meta.(*kubernetesProvider).conn
 
 	name := d.Id()
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadataWithDefaults(d, meta)
+	if d.HasChange("allow_volume_expansion") {
+		ops = append(ops, &AddOperation{
+			Path:  "/allowVolumeExpansion",
+			Value: d.Get("allow_volume_expansion").(bool),
+		})
+	}
 	data, err := ops.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("Failed to marshal update operations: %s", err)
 	}
 	log.Printf("[INFO] Updating storage class %q: %v", name, string(data))
-	out, err := conn.StorageV1().StorageClasses().Patch(name, pkgApi.JSONPatchType, data)
+	out, err := patchStorageClassOnLatest(conn, name, data)
 	if err != nil {
 		return fmt.Errorf("Failed to update storage class: %s", err)
 	}
@@ -124,7 +170,7 @@ func resourceKubernetesStorageClassDelete(d *schema.ResourceData, meta interface
 
 	name := d.Id()
 	log.Printf("[INFO] Deleting storage class: %#v", name)
-	err := conn.StorageV1().StorageClasses().Delete(name, &metav1.DeleteOptions{})
+	err := conn.StorageV1().StorageClasses().Delete(name, deleteOptions(d, ""))
 	if err != nil {
 		return err
 	}
@@ -149,3 +195,15 @@ func resourceKubernetesStorageClassExists(d *schema.ResourceData, meta interface
 	}
 	return true, err
 }
+
+// patchStorageClassOnLatest applies the JSON patch data of an update to the latest version of the storage class, see patchOnLatest.
+func patchStorageClassOnLatest(conn *kubernetes.Clientset, name string, data []byte) (out *api.StorageClass, err error) {
+	client := conn.StorageV1().StorageClasses()
+	err = patchOnLatest(pkgApi.JSONPatchType, data, func() (metav1.Object, error) {
+		return client.Get(name, metav1.GetOptions{})
+	}, func(data []byte) error {
+		out, err = client.Patch(name, pkgApi.JSONPatchType, data)
+		return err
+	})
+	return out, err
+}
@@ -45,6 +45,8 @@ func TestAccKubernetesStorageClass_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "parameters.%", "1"),
 					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "parameters.type", "pd-ssd"),
 					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "reclaim_policy", "Retain"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "volume_binding_mode", "Immediate"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "allow_volume_expansion", "false"),
 					testAccCheckStorageClassParameters(&conf, map[string]string{"type": "pd-ssd"}),
 				),
 			},
@@ -70,6 +72,7 @@ func TestAccKubernetesStorageClass_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "parameters.type", "pd-standard"),
 					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "parameters.zones", "us-west1-a,us-west1-b"),
 					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "reclaim_policy", "Delete"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "allow_volume_expansion", "true"),
 					testAccCheckStorageClassParameters(&conf, map[string]string{"type": "pd-standard", "zones": "us-west1-a,us-west1-b"}),
 				),
 			},
@@ -262,6 +265,7 @@ resource "kubernetes_storage_class" "test" {
 	}
 	storage_provisioner = "kubernetes.io/gce-pd"
 	reclaim_policy = "Delete"
+	allow_volume_expansion = true
 	parameters {
 		type = "pd-standard"
 		zones = "us-west1-a,us-west1-b"
@@ -0,0 +1,184 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// discoveryRefreshInterval is the shortest time between two refreshes of the discovery cache
+// while waiting for a kind to be served.
+const discoveryRefreshInterval = 5 * time.Second
+
+// resourceKubernetesWait waits for an object which isn't managed by Terraform
+// (e.g. a custom resource created by an operator) to exist and to reach the given
+// conditions & field values. Like kubernetes_eviction it's an action: the wait
+// happens on create, changing any argument waits again and destroying the
+// resource doesn't do anything.
+func resourceKubernetesWait() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesWaitCreate,
+		Read:   resourceKubernetesWaitRead,
+		Delete: resourceKubernetesWaitDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"api_version": {
+				Type:        schema.TypeString,
+				Description: "API version of the object to wait for, e.g. `apps/v1` or `v1`.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"kind": {
+				Type:        schema.TypeString,
+				Description: "Kind of the object to wait for, e.g. `Deployment`.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"metadata": {
+				Type:        schema.TypeList,
+				Description: "Metadata of the object to wait for.",
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Description:  "Name of the object to wait for.",
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateName,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the object to wait for. Defaults to `default` for namespaced kinds, ignored for cluster wide kinds.",
+							Optional:    true,
+							ForceNew:    true,
+						},
+					},
+				},
+			},
+			"condition": {
+				Type:        schema.TypeList,
+				Description: "Status conditions the object must have.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Description: "Type of the condition, e.g. `Ready`.",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Description: "Expected status of the condition.",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     "True",
+						},
+					},
+				},
+			},
+			"fields": {
+				Type:         schema.TypeMap,
+				Description:  "Expected values of the object's fields, keyed by their JSONPath, e.g. `status.phase = \"Running\"`.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateJSONPathMap,
+			},
+		},
+	}
+}
+
+func resourceKubernetesWaitCreate(d *schema.ResourceData, meta interface{}) error {
+	kp := meta.(*kubernetesProvider)
+
+	apiVersion := d.Get("api_version").(string)
+	kind := d.Get("kind").(string)
+	namespace := d.Get("metadata.0.namespace").(string)
+	name := d.Get("metadata.0.name").(string)
+	conditions := expandWaitConditions(d.Get("condition").([]interface{}))
+	fields := expandStringMap(d.Get("fields").(map[string]interface{}))
+
+	var path string
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		res, err := apiResourceForKind(kp.discoClient, apiVersion, kind)
+		if err != nil {
+			if _, ok := err.(*kindNotFoundError); ok {
+				// The kind may be served in a bit, e.g. when its CRD is being installed
+				kp.discoClient.InvalidateOlderThan(discoveryRefreshInterval)
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		path, err = objectAPIPath(apiVersion, res, namespace, name)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		log.Printf("[INFO] Reading %s %s", kind, path)
+		raw, err := kp.conn.CoreV1().RESTClient().Get().AbsPath(path).DoRaw()
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return resource.RetryableError(fmt.Errorf("%s %s doesn't exist yet", kind, path))
+			}
+			return resource.NonRetryableError(err)
+		}
+		obj := make(map[string]interface{})
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return resource.NonRetryableError(fmt.Errorf("Failed to decode %s %s: %s", kind, path, err))
+		}
+
+		unmet, err := unmetWaitTargets(obj, conditions, fields)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if len(unmet) > 0 {
+			log.Printf("[DEBUG] Still waiting for %s %s: %s", kind, path, strings.Join(unmet, ", "))
+			return resource.RetryableError(fmt.Errorf("%s %s isn't ready: %s. Last observed status: %s",
+				kind, path, strings.Join(unmet, ", "), lastObservedStatus(obj)))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] %s %s is ready", kind, path)
+	d.SetId(path)
+
+	return resourceKubernetesWaitRead(d, meta)
+}
+
+func resourceKubernetesWaitRead(d *schema.ResourceData, meta interface{}) error {
+	// The wait is a one-off action, there's nothing to read back
+	return nil
+}
+
+func resourceKubernetesWaitDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+func expandWaitConditions(l []interface{}) []waitCondition {
+	conditions := make([]waitCondition, 0, len(l))
+	for _, v := range l {
+		m := v.(map[string]interface{})
+		conditions = append(conditions, waitCondition{
+			Type:   m["type"].(string),
+			Status: m["status"].(string),
+		})
+	}
+	return conditions
+}
@@ -0,0 +1,98 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesWait_basic(t *testing.T) {
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesWaitConfig_basic(podName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_wait.test", "id", "/api/v1/namespaces/default/pods/"+podName),
+					resource.TestCheckResourceAttr("kubernetes_wait.test", "condition.0.type", "Ready"),
+					resource.TestCheckResourceAttr("kubernetes_wait.test", "condition.0.status", "True"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesWait_timeout(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesWaitConfig_missing(name, 5*time.Second),
+				ExpectError: regexp.MustCompile(`ConfigMap /api/v1/namespaces/default/configmaps/` + name + ` doesn't exist yet`),
+			},
+		},
+	})
+}
+
+func testAccKubernetesWaitConfig_basic(podName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+	metadata {
+		name = "%s"
+	}
+
+	spec {
+		container {
+			image = "nginx:1.7.9"
+			name  = "containername"
+		}
+	}
+}
+
+resource "kubernetes_wait" "test" {
+	api_version = "v1"
+	kind        = "Pod"
+
+	metadata {
+		name      = "${kubernetes_pod.test.metadata.0.name}"
+		namespace = "${kubernetes_pod.test.metadata.0.namespace}"
+	}
+
+	condition {
+		type = "Ready"
+	}
+
+	fields {
+		"status.phase" = "Running"
+	}
+}
+`, podName)
+}
+
+func testAccKubernetesWaitConfig_missing(name string, timeout time.Duration) string {
+	return fmt.Sprintf(`
+resource "kubernetes_wait" "test" {
+	api_version = "v1"
+	kind        = "ConfigMap"
+
+	metadata {
+		name = "%s"
+	}
+
+	timeouts {
+		create = "%s"
+	}
+}
+`, name, timeout)
+}
@@ -0,0 +1,131 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// conflictRetryBackoff matches retry.DefaultRetry of client-go
+// (k8s.io/client-go/util/retry isn't vendored).
+var conflictRetryBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 10 * time.Millisecond,
+	Factor:   1.0,
+	Jitter:   0.1,
+}
+
+// retryOnConflict runs fn until it doesn't fail with a 409 Conflict,
+// which happens when the object was changed by someone else (e.g. a controller
+// co-editing labels) while it's being patched. Any other error is returned right away.
+func retryOnConflict(fn func() error) error {
+	var lastConflictErr error
+	err := wait.ExponentialBackoff(conflictRetryBackoff, func() (bool, error) {
+		err := fn()
+		switch {
+		case err == nil:
+			return true, nil
+		case errors.IsConflict(err):
+			log.Printf("[DEBUG] Retrying after conflict: %s", err)
+			lastConflictErr = err
+			return false, nil
+		default:
+			return false, err
+		}
+	})
+	if err == wait.ErrWaitTimeout {
+		err = lastConflictErr
+	}
+	return err
+}
+
+// patchOnLatest patches the latest version of an object with the patch of an update: get reads the
+// object & patch sends data built for it by patchOnVersion. Sending the resource version which was
+// read makes the patch fail with a 409 Conflict when the object changed in the meantime, e.g. when
+// a controller co-edits its labels, it's then read again and the patch rebuilt for it.
+func patchOnLatest(patchType pkgApi.PatchType, data []byte, get func() (metav1.Object, error), patch func(data []byte) error) error {
+	return retryOnConflict(func() error {
+		live, err := get()
+		if err != nil {
+			return err
+		}
+		versioned, err := patchOnVersion(patchType, data, live)
+		if err != nil {
+			return fmt.Errorf("Failed to build the patch for resource version %s: %s", live.GetResourceVersion(), err)
+		}
+		log.Printf("[DEBUG] Patching resource version %s: %s", live.GetResourceVersion(), versioned)
+		return patch(versioned)
+	})
+}
+
+// patchOnVersion rebuilds the patch data of an update for the live object, requiring its resource version.
+// A JSON patch of the labels & annotations of the metadata, diffed against the state, is adjusted to the
+// ones of the live object: the first key doesn't replace the keys which were added by someone else and
+// the keys which are gone already aren't removed again, which would fail the patch.
+func patchOnVersion(patchType pkgApi.PatchType, data []byte, live metav1.Object) ([]byte, error) {
+	if patchType != pkgApi.JSONPatchType {
+		var patch map[string]interface{}
+		if err := json.Unmarshal(data, &patch); err != nil {
+			return nil, err
+		}
+		metadata, _ := patch["metadata"].(map[string]interface{})
+		if metadata == nil {
+			metadata = map[string]interface{}{}
+			patch["metadata"] = metadata
+		}
+		metadata["resourceVersion"] = live.GetResourceVersion()
+		return json.Marshal(patch)
+	}
+
+	var ops []map[string]interface{}
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, err
+	}
+	liveMaps := map[string]map[string]string{
+		"/metadata/labels":      live.GetLabels(),
+		"/metadata/annotations": live.GetAnnotations(),
+	}
+	versioned := make([]map[string]interface{}, 0, len(ops)+1)
+	for _, op := range ops {
+		path, _ := op["path"].(string)
+		if m, ok := liveMaps[path]; ok && op["op"] == "add" && len(m) > 0 {
+			value, _ := op["value"].(map[string]interface{})
+			keys := make([]string, 0, len(value))
+			for k := range value {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				versioned = append(versioned, map[string]interface{}{"op": "add", "path": path + "/" + escapeJsonPointer(k), "value": value[k]})
+			}
+			continue
+		}
+		if i := strings.LastIndex(path, "/"); i > 0 {
+			if m, ok := liveMaps[path[:i]]; ok {
+				_, exists := m[unescapeJsonPointer(path[i+1:])]
+				switch op["op"] {
+				case "remove":
+					if !exists {
+						continue
+					}
+				case "replace":
+					if !exists {
+						// Adding a key replaces its value when it exists
+						op["op"] = "add"
+					}
+				}
+			}
+		}
+		versioned = append(versioned, op)
+	}
+	versioned = append(versioned, map[string]interface{}{"op": "replace", "path": "/metadata/resourceVersion", "value": live.GetResourceVersion()})
+	return json.Marshal(versioned)
+}
@@ -0,0 +1,95 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func TestRetryOnConflict(t *testing.T) {
+	conflict := errors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "test", fmt.Errorf("the object has been modified"))
+
+	cases := []struct {
+		Name          string
+		Errors        []error
+		ExpectedCalls int
+		ExpectedErr   error
+	}{
+		{"success", []error{nil}, 1, nil},
+		{"success after conflicts", []error{conflict, conflict, nil}, 3, nil},
+		{"other error", []error{fmt.Errorf("forbidden"), nil}, 1, fmt.Errorf("forbidden")},
+		{"conflicts exhausted", []error{conflict, conflict, conflict, conflict, conflict, nil}, 5, conflict},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			calls := 0
+			err := retryOnConflict(func() error {
+				err := tc.Errors[calls]
+				calls++
+				return err
+			})
+			if calls != tc.ExpectedCalls {
+				t.Fatalf("Expected %d calls, given %d", tc.ExpectedCalls, calls)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tc.ExpectedErr) {
+				t.Fatalf("Expected error %v, given %v", tc.ExpectedErr, err)
+			}
+		})
+	}
+}
+
+func TestPatchOnVersion(t *testing.T) {
+	live := &metav1.ObjectMeta{
+		ResourceVersion: "7",
+		Labels:          map[string]string{"other": "x", "app": "web"},
+	}
+
+	cases := []struct {
+		Name      string
+		PatchType pkgApi.PatchType
+		Data      string
+		Expected  string
+	}{
+		{
+			"merge patch",
+			pkgApi.MergePatchType,
+			`{"metadata":{"labels":{"app":"db"}}}`,
+			`{"metadata":{"labels":{"app":"db"},"resourceVersion":"7"}}`,
+		},
+		{
+			"added labels kept with the live ones",
+			pkgApi.JSONPatchType,
+			`[{"op":"add","path":"/metadata/labels","value":{"b":"2","a":"1"}}]`,
+			`[{"op":"add","path":"/metadata/labels/a","value":"1"},{"op":"add","path":"/metadata/labels/b","value":"2"},{"op":"replace","path":"/metadata/resourceVersion","value":"7"}]`,
+		},
+		{
+			"removed label already gone",
+			pkgApi.JSONPatchType,
+			`[{"op":"remove","path":"/metadata/labels/gone"},{"op":"remove","path":"/metadata/labels/app"}]`,
+			`[{"op":"remove","path":"/metadata/labels/app"},{"op":"replace","path":"/metadata/resourceVersion","value":"7"}]`,
+		},
+		{
+			"replaced annotation missing",
+			pkgApi.JSONPatchType,
+			`[{"op":"replace","path":"/metadata/annotations/a~1b","value":"1"}]`,
+			`[{"op":"add","path":"/metadata/annotations/a~1b","value":"1"},{"op":"replace","path":"/metadata/resourceVersion","value":"7"}]`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			out, err := patchOnVersion(tc.PatchType, []byte(tc.Data), live)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(out) != tc.Expected {
+				t.Fatalf("Expected %s, given %s", tc.Expected, out)
+			}
+		})
+	}
+}
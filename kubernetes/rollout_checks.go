@@ -0,0 +1,37 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// rolloutCheck is one of the conditions which hold once the rollout of a workload is complete,
+// it returns what the rollout is still waiting for, or "" once the condition holds.
+type rolloutCheck func() string
+
+// rolloutStatus evaluates all the checks of the rollout of the workload name on each poll,
+// the rollout is complete once all of them hold. Otherwise the message lists every check
+// which doesn't hold yet, so a wait which timed out tells all it was waiting for.
+func rolloutStatus(name string, checks ...rolloutCheck) (bool, string) {
+	unmet := make([]string, 0)
+	for _, check := range checks {
+		if msg := check(); msg != "" {
+			unmet = append(unmet, msg)
+		}
+	}
+	if len(unmet) == 0 {
+		return true, ""
+	}
+	return false, fmt.Sprintf("Waiting for the rollout of %q: %s", name, strings.Join(unmet, ", "))
+}
+
+// observedGenerationCheck holds once the controller of the workload observed its latest spec,
+// its status is stale until then.
+func observedGenerationCheck(generation, observedGeneration int64) rolloutCheck {
+	return func() string {
+		if observedGeneration == 0 || generation > observedGeneration {
+			return fmt.Sprintf("generation %d not observed yet (observed %d)", generation, observedGeneration)
+		}
+		return ""
+	}
+}
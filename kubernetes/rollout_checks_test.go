@@ -0,0 +1,101 @@
+package kubernetes
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	api "k8s.io/api/core/v1"
+)
+
+func TestRolloutStatus(t *testing.T) {
+	met := func() string { return "" }
+	unmet := func(msg string) rolloutCheck { return func() string { return msg } }
+
+	done, msg := rolloutStatus("web", met, observedGenerationCheck(2, 2))
+	if !done || msg != "" {
+		t.Fatalf("Expected the rollout to be complete, given: %t, %q", done, msg)
+	}
+
+	// All the checks are evaluated, the message tells every one which doesn't hold
+	done, msg = rolloutStatus("web", observedGenerationCheck(3, 2), met, unmet("1 of 3 replicas ready"))
+	expected := `Waiting for the rollout of "web": generation 3 not observed yet (observed 2), 1 of 3 replicas ready`
+	if done || msg != expected {
+		t.Fatalf("Expected %q, given: %t, %q", expected, done, msg)
+	}
+
+	// The status isn't observed before the first generation is
+	if done, _ := rolloutStatus("web", observedGenerationCheck(1, 0)); done {
+		t.Fatal("Expected the rollout to wait for the first generation to be observed")
+	}
+}
+
+func TestDaemonSetRolloutStatus(t *testing.T) {
+	daemonSet := &appsv1.DaemonSet{}
+	daemonSet.Name = "agent"
+	daemonSet.Generation = 2
+	daemonSet.Status = appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, CurrentNumberScheduled: 2}
+
+	done, msg := daemonSetRolloutStatus(daemonSet, false)
+	expected := `Waiting for the rollout of "agent": generation 2 not observed yet (observed 1), 2 of 3 replicas scheduled`
+	if done || msg != expected {
+		t.Fatalf("Expected %q, given: %t, %q", expected, done, msg)
+	}
+
+	// The stale status of the previous generation passes the other checks already
+	daemonSet.Status.CurrentNumberScheduled = 3
+	if done, _ := daemonSetRolloutStatus(daemonSet, false); done {
+		t.Fatal("Expected the rollout to wait for the latest generation to be observed")
+	}
+
+	daemonSet.Status.ObservedGeneration = 2
+	if done, msg := daemonSetRolloutStatus(daemonSet, false); !done {
+		t.Fatalf("Expected the rollout to be complete, given: %q", msg)
+	}
+
+	// A restart waits for the pods of the new revision
+	daemonSet.Status.UpdatedNumberScheduled = 1
+	daemonSet.Status.NumberAvailable = 3
+	done, msg = daemonSetRolloutStatus(daemonSet, true)
+	expected = `Waiting for the rollout of "agent": 1 of 3 replicas updated`
+	if done || msg != expected {
+		t.Fatalf("Expected %q, given: %t, %q", expected, done, msg)
+	}
+
+	daemonSet.Status.UpdatedNumberScheduled = 3
+	daemonSet.Status.NumberAvailable = 2
+	done, msg = daemonSetRolloutStatus(daemonSet, true)
+	expected = `Waiting for the rollout of "agent": 2 of 3 updated replicas available`
+	if done || msg != expected {
+		t.Fatalf("Expected %q, given: %t, %q", expected, done, msg)
+	}
+
+	// Pods of the OnDelete strategy are only replaced once deleted
+	daemonSet.Spec.UpdateStrategy.Type = appsv1.OnDeleteDaemonSetStrategyType
+	if done, msg := daemonSetRolloutStatus(daemonSet, true); !done {
+		t.Fatalf("Expected the rollout to be complete, given: %q", msg)
+	}
+}
+
+func TestReplicationControllerRolloutStatus(t *testing.T) {
+	replicas := int32(2)
+	rc := &api.ReplicationController{Spec: api.ReplicationControllerSpec{Replicas: &replicas}}
+	rc.Name = "web"
+	rc.Generation = 3
+	rc.Status = api.ReplicationControllerStatus{ObservedGeneration: 2, FullyLabeledReplicas: 1}
+
+	done, msg := replicationControllerRolloutStatus(rc)
+	expected := `Waiting for the rollout of "web": generation 3 not observed yet (observed 2), 1 of 2 replicas scheduled`
+	if done || msg != expected {
+		t.Fatalf("Expected %q, given: %t, %q", expected, done, msg)
+	}
+
+	rc.Status.FullyLabeledReplicas = 2
+	if done, _ := replicationControllerRolloutStatus(rc); done {
+		t.Fatal("Expected the rollout to wait for the latest generation to be observed")
+	}
+
+	rc.Status.ObservedGeneration = 3
+	if done, msg := replicationControllerRolloutStatus(rc); !done {
+		t.Fatalf("Expected the rollout to be complete, given: %q", msg)
+	}
+}
@@ -0,0 +1,99 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rest "k8s.io/client-go/rest"
+)
+
+// scaleReplicas sets the replicas of a workload through its `scale` subresource, the way the API
+// recommends changing the replica count: only the count is written, so it can't conflict with the
+// controllers & autoscalers writing other fields of the spec in the meantime.
+// The Scale served depends on the API group version, it's autoscaling/v1 for the core & apps/v1 groups.
+func scaleReplicas(c rest.Interface, scaleAPIVersion, namespace, resource, name string, replicas int32) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"apiVersion": scaleAPIVersion,
+		"kind":       "Scale",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Scaling %s %s/%s to %d replicas", resource, namespace, name, replicas)
+	err = c.Put().Namespace(namespace).Resource(resource).Name(name).SubResource("scale").Body(body).Do().Error()
+	if err != nil {
+		return fmt.Errorf("Failed to scale %s %s/%s: %s", resource, namespace, name, err)
+	}
+	return nil
+}
+
+// scaleWorkload is scaleReplicas for the workloads served by several API groups, through the highest one supported.
+func scaleWorkload(kp *kubernetesProvider, resource string, groups []APIGroup, namespace, name string, replicas int32) error {
+	apiGroup, err := kp.highestSupportedAPIGroup(resource, groups...)
+	if err != nil {
+		return err
+	}
+
+	conn := kp.conn
+	switch apiGroup {
+	case appsV1:
+		return scaleReplicas(conn.AppsV1().RESTClient(), "autoscaling/v1", namespace, resource, name, replicas)
+	case appsV1beta2:
+		return scaleReplicas(conn.AppsV1beta2().RESTClient(), apiGroup.String(), namespace, resource, name, replicas)
+	case appsV1beta1:
+		return scaleReplicas(conn.AppsV1beta1().RESTClient(), apiGroup.String(), namespace, resource, name, replicas)
+	case extensionsV1beta1:
+		return scaleReplicas(conn.ExtensionsV1beta1().RESTClient(), apiGroup.String(), namespace, resource, name, replicas)
+	}
+	return fmt.Errorf("Failed to scale %s %s/%s: no supported API group serves %s", resource, namespace, name, resource)
+}
+
+// specChangedBesidesReplicas returns whether anything but the replicas changed in the spec of a workload,
+// the spec isn't patched at all when the replicas are the only change, they're scaled instead.
+func specChangedBesidesReplicas(d *schema.ResourceData) bool {
+	for k := range d.Get("spec.0").(map[string]interface{}) {
+		if k != "replicas" && d.HasChange("spec.0."+k) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutReplicasOperation drops the operation on the replicas at path from ops, once they're scaled.
+func withoutReplicasOperation(ops PatchOperations, path string) PatchOperations {
+	out := make([]PatchOperation, 0, len(ops))
+	for _, op := range ops {
+		if op.GetPath() != path {
+			out = append(out, op)
+		}
+	}
+	return out
+}
+
+// withoutReplicasInMergePatch drops `spec.replicas` from a strategic merge patch of a workload, once they're scaled.
+func withoutReplicasInMergePatch(data []byte) ([]byte, error) {
+	patch := make(map[string]interface{})
+	err := json.Unmarshal(data, &patch)
+	if err != nil {
+		return nil, err
+	}
+	spec, ok := patch["spec"].(map[string]interface{})
+	if !ok {
+		return data, nil
+	}
+	delete(spec, "replicas")
+	if len(spec) == 0 {
+		delete(patch, "spec")
+	}
+	return json.Marshal(patch)
+}
@@ -0,0 +1,86 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestScaleReplicas(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != "PUT" || r.URL.Path != "/apis/apps/v1/namespaces/default/deployments/web/scale" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		err = json.Unmarshal(b, &body)
+		if err != nil {
+			t.Error(err)
+		}
+		w.Write(b)
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = scaleReplicas(conn.AppsV1().RESTClient(), "autoscaling/v1", "default", "deployments", "web", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"apiVersion": "autoscaling/v1",
+		"kind":       "Scale",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+		"spec":       map[string]interface{}{"replicas": float64(5)},
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Fatalf("Expected the scale %#v, given: %#v", expected, body)
+	}
+}
+
+func TestWithoutReplicasInMergePatch(t *testing.T) {
+	cases := []struct {
+		Patch    string
+		Expected string
+	}{
+		{`{"spec":{"replicas":3}}`, `{}`},
+		{`{"metadata":{"labels":{"a":"b"}},"spec":{"minReadySeconds":5,"replicas":3}}`, `{"metadata":{"labels":{"a":"b"}},"spec":{"minReadySeconds":5}}`},
+		{`{"metadata":{"labels":{"a":"b"}}}`, `{"metadata":{"labels":{"a":"b"}}}`},
+	}
+
+	for _, tc := range cases {
+		out, err := withoutReplicasInMergePatch([]byte(tc.Patch))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != tc.Expected {
+			t.Fatalf("Expected %s for %s, given: %s", tc.Expected, tc.Patch, out)
+		}
+	}
+}
+
+func TestWithoutReplicasOperation(t *testing.T) {
+	ops := PatchOperations{
+		&ReplaceOperation{Path: "/spec/minReadySeconds", Value: 5},
+		&ReplaceOperation{Path: "/spec/replicas", Value: 3},
+	}
+	expected := PatchOperations{&ReplaceOperation{Path: "/spec/minReadySeconds", Value: 5}}
+	if out := withoutReplicasOperation(ops, "/spec/replicas"); !out.Equal(expected) {
+		t.Fatalf("Expected %#v, given: %#v", expected, out)
+	}
+}
@@ -0,0 +1,66 @@
+package kubernetes
+
+import "github.com/hashicorp/terraform/helper/schema"
+
+// accessReviewFields holds the attributes of the action to review
+// and the outcome of the review, shared by the access review data sources.
+func accessReviewFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"verb": {
+			Type:        schema.TypeString,
+			Description: "The verb to check, e.g. `get`, `list`, `create`, `update`, `patch`, `delete` or `*` for all of them.",
+			Required:    true,
+		},
+		"group": {
+			Type:        schema.TypeString,
+			Description: "API group of the resource, e.g. `apps`. Empty for the core group, `*` for all groups.",
+			Optional:    true,
+		},
+		"resource": {
+			Type:        schema.TypeString,
+			Description: "The resource to check, e.g. `deployments`, `*` for all resources.",
+			Required:    true,
+		},
+		"subresource": {
+			Type:        schema.TypeString,
+			Description: "The subresource to check, e.g. `scale` or `log`.",
+			Optional:    true,
+		},
+		"namespace": {
+			Type:        schema.TypeString,
+			Description: "Namespace of the objects to check. Empty for cluster-scoped resources, or all namespaces for namespaced resources.",
+			Optional:    true,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name of the object to check. Empty for all objects.",
+			Optional:    true,
+		},
+		"fail_if_denied": {
+			Type:        schema.TypeBool,
+			Description: "Fail reading the data source with the reason of the authorizer when the action isn't allowed.",
+			Optional:    true,
+			Default:     false,
+		},
+		"allowed": {
+			Type:        schema.TypeBool,
+			Description: "Whether the action is allowed.",
+			Computed:    true,
+		},
+		"denied": {
+			Type:        schema.TypeBool,
+			Description: "Whether the action is explicitly denied. Both `allowed` and `denied` are false when no authorizer has an opinion.",
+			Computed:    true,
+		},
+		"reason": {
+			Type:        schema.TypeString,
+			Description: "Why the action is allowed or denied, if the authorizer tells.",
+			Computed:    true,
+		},
+		"evaluation_error": {
+			Type:        schema.TypeString,
+			Description: "An error the authorizer ran into while checking the access, e.g. a role binding referring to a missing role. The action may be allowed nonetheless.",
+			Computed:    true,
+		},
+	}
+}
@@ -1,6 +1,10 @@
 package kubernetes
 
-import "github.com/hashicorp/terraform/helper/schema"
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"k8s.io/api/core/v1"
+)
 
 func handlerFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
@@ -38,10 +42,11 @@ func handlerFields() map[string]*schema.Schema {
 						Description: `Path to access on the HTTP server.`,
 					},
 					"scheme": {
-						Type:        schema.TypeString,
-						Optional:    true,
-						Default:     "HTTP",
-						Description: `Scheme to use for connecting to the host.`,
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "HTTP",
+						Description:  `Scheme to use for connecting to the host.`,
+						ValidateFunc: validateAttributeValueIsIn([]string{"HTTP", "HTTPS"}),
 					},
 					"port": {
 						Type:         schema.TypeString,
@@ -74,6 +79,7 @@ func handlerFields() map[string]*schema.Schema {
 		"tcp_socket": {
 			Type:        schema.TypeList,
 			Optional:    true,
+			MaxItems:    1,
 			Description: "TCPSocket specifies an action involving a TCP port. TCP hooks not yet supported",
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
@@ -191,6 +197,13 @@ func volumeMountFields() map[string]*schema.Schema {
 			Optional:    true,
 			Description: `Path within the volume from which the container's volume should be mounted. Defaults to "" (volume's root).`,
 		},
+		"mount_propagation": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      string(v1.MountPropagationNone),
+			ValidateFunc: validation.StringInSlice([]string{string(v1.MountPropagationNone), string(v1.MountPropagationHostToContainer), string(v1.MountPropagationBidirectional)}, false),
+			Description:  "How mounts are propagated from the host to the container and the other way around. One of `None`, `HostToContainer`, `Bidirectional`. `Bidirectional` requires a privileged container. Defaults to `None`. More info: https://kubernetes.io/docs/concepts/storage/volumes/#mount-propagation",
+		},
 	}
 }
 
@@ -586,6 +599,11 @@ func probeSchema() *schema.Resource {
 
 func securityContextSchema() *schema.Resource {
 	m := map[string]*schema.Schema{
+		"allow_privilege_escalation": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether a process can gain more privileges than its parent process. This bool directly controls if the no_new_privs flag will be set on the container process. Always true when the container is run as privileged or has the `CAP_SYS_ADMIN` capability. Left to Kubernetes when unset, which allows it.",
+		},
 		"privileged": {
 			Type:        schema.TypeBool,
 			Optional:    true,
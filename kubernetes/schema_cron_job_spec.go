@@ -62,7 +62,7 @@ func cronJobSpecFields() map[string]*schema.Schema {
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Default:     false,
-			Description: "This flag tells the controller to suspend subsequent executions, it does not apply to already started executions. Defaults to false.",
+			Description: "This flag tells the controller to suspend subsequent executions, it does not apply to already started executions. Can be toggled in place, without recreating the cron job. Defaults to false.",
 		},
 	}
 
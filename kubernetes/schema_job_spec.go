@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 func jobSpecFields() map[string]*schema.Schema {
@@ -94,10 +95,34 @@ func jobSpecFields() map[string]*schema.Schema {
 		},
 	}
 
-	// fix restart_policy for job resources
-	s["template"].Elem.(*schema.Resource).
+	// fix restart_policy for job resources, whose pods must terminate
+	restartPolicy := s["template"].Elem.(*schema.Resource).
 		Schema["spec"].Elem.(*schema.Resource).
-		Schema["restart_policy"].Default = "OnFailure"
+		Schema["restart_policy"]
+	restartPolicy.Default = "OnFailure"
+	restartPolicy.ValidateFunc = validation.StringInSlice([]string{"OnFailure", "Never"}, false)
+	restartPolicy.Description = "Restart policy for all containers within the pod of a job. One of OnFailure, Never. More info: https://kubernetes.io/docs/concepts/workloads/controllers/jobs-run-to-completion/#pod-template"
 
 	return s
 }
+
+// jobResourceSpecFields returns the spec fields of the job resource. It reads & writes jobs raw,
+// so unlike the job template of a cron job it supports fields the vendored API types don't have.
+func jobResourceSpecFields() map[string]*schema.Schema {
+	s := jobSpecFields()
+	s["completion_mode"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		Default:      jobCompletionModeNonIndexed,
+		ValidateFunc: validation.StringInSlice([]string{jobCompletionModeNonIndexed, jobCompletionModeIndexed}, false),
+		Description:  "Specifies how pod completions are tracked. One of `NonIndexed`, `Indexed`. With `Indexed`, each pod gets a completion index from 0 to `completions - 1` in the `batch.kubernetes.io/job-completion-index` annotation & the `JOB_COMPLETION_INDEX` env variable, and the job completes once there's a successful pod for each index. Requires Kubernetes 1.21+. More info: https://kubernetes.io/docs/concepts/workloads/controllers/job/#completion-mode",
+	}
+	s["suspend"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether the job controller should stop creating pods. Suspending a running job terminates its active pods, resuming it starts them again. Requires Kubernetes 1.21+. More info: https://kubernetes.io/docs/concepts/workloads/controllers/job/#suspending-a-job",
+	}
+	return s
+}
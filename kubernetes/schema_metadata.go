@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -10,11 +11,17 @@ import (
 func metadataFields(objectName string) map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"annotations": {
-			Type:         schema.TypeMap,
-			Description:  fmt.Sprintf("An unstructured key value map stored with the %s that may be used to store arbitrary metadata. More info: http://kubernetes.io/docs/user-guide/annotations", objectName),
-			Optional:     true,
-			Elem:         &schema.Schema{Type: schema.TypeString},
-			ValidateFunc: validateAnnotations,
+			Type:             schema.TypeMap,
+			Description:      fmt.Sprintf("An unstructured key value map stored with the %s that may be used to store arbitrary metadata. More info: http://kubernetes.io/docs/user-guide/annotations", objectName),
+			Optional:         true,
+			Elem:             &schema.Schema{Type: schema.TypeString},
+			ValidateFunc:     validateAnnotations,
+			DiffSuppressFunc: suppressAnnotationWhitespaceDiff,
+		},
+		"creation_timestamp": {
+			Type:        schema.TypeString,
+			Description: fmt.Sprintf("The time at which the %s was created, in RFC 3339 format. Set by the server.", objectName),
+			Computed:    true,
 		},
 		"generation": {
 			Type:        schema.TypeInt,
@@ -46,6 +53,12 @@ func metadataFields(objectName string) map[string]*schema.Schema {
 			Description: fmt.Sprintf("A URL representing this %s.", objectName),
 			Computed:    true,
 		},
+		"trim_annotation_whitespace": {
+			Type:        schema.TypeBool,
+			Description: "Whether trailing whitespace & newlines of annotation values are ignored when comparing them with the live object, e.g. for multi-line values like certificates. Not sent to the API server.",
+			Optional:    true,
+			Default:     false,
+		},
 		"uid": {
 			Type:        schema.TypeString,
 			Description: fmt.Sprintf("The unique in time and space value for this %s. More info: http://kubernetes.io/docs/user-guide/identifiers#uids", objectName),
@@ -95,6 +108,40 @@ func metadataSchema(objectName string, generatableName bool) *schema.Schema {
 	}
 }
 
+// clusterScopedMetadataSchema is metadataSchema for the cluster-scoped resources. The API server ignores
+// or rejects the namespace of a cluster-scoped object, setting one fails the validation with an explanation.
+func clusterScopedMetadataSchema(objectName string, generatableName bool) *schema.Schema {
+	s := metadataSchema(objectName, generatableName)
+	s.Elem.(*schema.Resource).Schema["namespace"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: fmt.Sprintf("Can't be set: a %s is cluster-scoped, it doesn't belong to a namespace.", objectName),
+		Optional:    true,
+		ValidateFunc: func(value interface{}, key string) (ws []string, es []error) {
+			es = append(es, fmt.Errorf("%s can't be set: a %s is cluster-scoped, it doesn't belong to a namespace", key, objectName))
+			return
+		},
+	}
+	return s
+}
+
+// suppressAnnotationWhitespaceDiff ignores changes to the trailing whitespace of
+// annotation values when the metadata sets trim_annotation_whitespace = true.
+// Added & removed annotations are always reported.
+func suppressAnnotationWhitespaceDiff(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" || strings.HasSuffix(k, ".%") {
+		return false
+	}
+	// Annotation keys may contain dots, the metadata prefix is found from the left
+	i := strings.Index(k, "metadata.0.annotations.")
+	if i < 0 {
+		return false
+	}
+	if !d.Get(k[:i] + "metadata.0.trim_annotation_whitespace").(bool) {
+		return false
+	}
+	return strings.TrimRight(old, " \t\r\n") == strings.TrimRight(new, " \t\r\n")
+}
+
 func namespacedMetadataSchema(objectName string, generatableName bool) *schema.Schema {
 	fields := metadataFields(objectName)
 	fields["namespace"] = &schema.Schema{
@@ -0,0 +1,41 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestClusterScopedMetadataNamespace(t *testing.T) {
+	resources := map[string]*schema.Resource{
+		"cluster role":               resourceKubernetesClusterRole(),
+		"cluster role binding":       resourceKubernetesClusterRoleBinding(),
+		"custom resource definition": resourceKubernetesCustomResourceDefinition(),
+		"namespace":                  resourceKubernetesNamespace(),
+		"persistent volume":          resourceKubernetesPersistentVolume(),
+		"storage class":              resourceKubernetesStorageClass(),
+	}
+
+	for name, r := range resources {
+		t.Run(name, func(t *testing.T) {
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "test", "namespace": "default"}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, es := r.Validate(terraform.NewResourceConfig(raw))
+			expected := "metadata.0.namespace can't be set: a " + name + " is cluster-scoped"
+			for _, err := range es {
+				if strings.Contains(err.Error(), expected) {
+					return
+				}
+			}
+			t.Fatalf("Expected an error containing %q, given: %v", expected, es)
+		})
+	}
+}
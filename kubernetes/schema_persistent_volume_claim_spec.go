@@ -1,6 +1,9 @@
 package kubernetes
 
-import "github.com/hashicorp/terraform/helper/schema"
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 func persistentVolumeClaimSpecFields(pvcTemplate bool) map[string]*schema.Schema {
 	s := map[string]*schema.Schema{
@@ -30,16 +33,20 @@ func persistentVolumeClaimSpecFields(pvcTemplate bool) map[string]*schema.Schema
 						Elem: &schema.Resource{
 							Schema: map[string]*schema.Schema{
 								"limits": {
-									Type:        schema.TypeMap,
-									Description: "Map describing the maximum amount of compute resources allowed. More info: http://kubernetes.io/docs/user-guide/compute-resources/",
-									Optional:    true,
-									ForceNew:    true,
+									Type:             schema.TypeMap,
+									Description:      "Map describing the maximum amount of resources the volume may have, e.g. `storage`, for the storage backends which honor it. More info: http://kubernetes.io/docs/user-guide/compute-resources/",
+									Optional:         true,
+									ForceNew:         true,
+									ValidateFunc:     validateResourceList,
+									DiffSuppressFunc: suppressEquivalentResourceQuantity,
 								},
 								"requests": {
-									Type:        schema.TypeMap,
-									Description: "Map describing the minimum amount of compute resources required. If this is omitted for a container, it defaults to `limits` if that is explicitly specified, otherwise to an implementation-defined value. More info: http://kubernetes.io/docs/user-guide/compute-resources/",
-									Optional:    true,
-									ForceNew:    true,
+									Type:             schema.TypeMap,
+									Description:      requestsDescription(pvcTemplate),
+									Optional:         true,
+									ForceNew:         pvcTemplate,
+									ValidateFunc:     validateResourceList,
+									DiffSuppressFunc: suppressEquivalentResourceQuantity,
 								},
 							},
 						},
@@ -66,10 +73,11 @@ func persistentVolumeClaimSpecFields(pvcTemplate bool) map[string]*schema.Schema
 												ForceNew:    true,
 											},
 											"operator": {
-												Type:        schema.TypeString,
-												Description: "A key's relationship to a set of values. Valid operators ard `In`, `NotIn`, `Exists` and `DoesNotExist`.",
-												Optional:    true,
-												ForceNew:    true,
+												Type:         schema.TypeString,
+												Description:  "A key's relationship to a set of values. Valid operators are `In`, `NotIn`, `Exists` and `DoesNotExist`.",
+												Optional:     true,
+												ForceNew:     true,
+												ValidateFunc: validateAttributeValueIsIn([]string{"In", "NotIn", "Exists", "DoesNotExist"}),
 											},
 											"values": {
 												Type:        schema.TypeSet,
@@ -105,6 +113,14 @@ func persistentVolumeClaimSpecFields(pvcTemplate bool) map[string]*schema.Schema
 						Computed:    true,
 						ForceNew:    true,
 					},
+					"volume_mode": {
+						Type:         schema.TypeString,
+						Description:  "Defines what type of volume is required by the claim. Valid options are `Filesystem` and `Block`. Defaults to `Filesystem`.",
+						Optional:     true,
+						ForceNew:     true,
+						Default:      "Filesystem",
+						ValidateFunc: validateAttributeValueIsIn([]string{"Filesystem", "Block"}),
+					},
 				},
 			},
 		},
@@ -116,5 +132,192 @@ func persistentVolumeClaimSpecFields(pvcTemplate bool) map[string]*schema.Schema
 		},
 	}
 
+	if !pvcTemplate {
+		s["spec"].Elem.(*schema.Resource).Schema["volume_attributes_class_name"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Description:  "Name of the volume attributes class of the claim (Kubernetes 1.29+). Unlike the rest of the spec it can be changed, to have the CSI driver modify e.g. the IOPS or throughput of the volume in place. Removing it from the configuration keeps the current class.",
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validateDNSSubdomain,
+		}
+		s["grace_period_seconds"] = deleteGracePeriodSchema()
+		s["propagation_policy"] = deletePropagationPolicySchema(metav1.DeletePropagationBackground)
+		s["poll_interval"] = waitPollIntervalSchema()
+		s["min_timeout"] = waitMinTimeoutSchema()
+		s["max_poll_interval"] = waitMaxPollIntervalSchema()
+		s["poll_delay"] = waitPollDelaySchema()
+		s["adopt_existing"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Description: "Adopt a claim with the same name which already exists instead of failing to create it. Its labels & annotations are updated to the configured ones.",
+			Optional:    true,
+			Default:     false,
+		}
+		s["wait_for_volume_release"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Description: "Whether to wait on destroy until the volume bound to the claim is released as its reclaim policy says: deleted for `Delete`, `Released` for `Retain` or `Available` again for `Recycle`. Fails as soon as the volume can't be reclaimed, e.g. when deleting the backing disk failed.",
+			Optional:    true,
+			Default:     false,
+		}
+		s["wait_until_resized"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Description: "Whether to wait on update, after increasing the storage request, until the capacity of the claim reaches it. The wait ends early once only the file system is left to resize (`FileSystemResizePending`), which happens when a pod using the claim is (re)started.",
+			Optional:    true,
+			Default:     true,
+		}
+		s["persistent_volume"] = &schema.Schema{
+			Type:        schema.TypeList,
+			Description: "The volume the claim is bound to, read along with the claim once it's bound. Reading it requires the permission to get persistent volumes, it's left empty otherwise.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Description: "Name of the volume.",
+						Computed:    true,
+					},
+					"access_modes": {
+						Type:        schema.TypeSet,
+						Description: "Access modes of the volume, which may be more than the claim requested.",
+						Computed:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Set:         schema.HashString,
+					},
+					"capacity": {
+						Type:        schema.TypeMap,
+						Description: "Capacity of the volume, e.g. its `storage`.",
+						Computed:    true,
+					},
+					"storage_class_name": {
+						Type:        schema.TypeString,
+						Description: "Name of the storage class the volume belongs to.",
+						Computed:    true,
+					},
+					"reclaim_policy": {
+						Type:        schema.TypeString,
+						Description: "What happens to the volume once it's released by the claim: `Retain`, `Delete` or `Recycle`.",
+						Computed:    true,
+					},
+					"csi_driver": {
+						Type:        schema.TypeString,
+						Description: "Name of the CSI driver of the volume, unless it's not provisioned by CSI.",
+						Computed:    true,
+					},
+					"volume_handle": {
+						Type:        schema.TypeString,
+						Description: "Identifier of the volume in the CSI driver, e.g. the id of the cloud disk, unless it's not provisioned by CSI.",
+						Computed:    true,
+					},
+				},
+			},
+		}
+		s["status"] = &schema.Schema{
+			Type:        schema.TypeList,
+			Description: "Current information about the claim, as observed by the cluster.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"access_modes": {
+						Type:        schema.TypeSet,
+						Description: "Access modes the volume bound to the claim actually has, which may be more than the claim requested.",
+						Computed:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Set:         schema.HashString,
+					},
+					"allocated_resource_statuses": {
+						Type:        schema.TypeMap,
+						Description: "Status of the resize of each resource, e.g. `ControllerResizeInProgress`, `NodeResizePending` or `ControllerResizeFailed`. Only set by Kubernetes 1.24+ while a volume expansion is in flight or has failed.",
+						Computed:    true,
+					},
+					"allocated_resources": {
+						Type:        schema.TypeMap,
+						Description: "Resources allocated to the claim by the storage driver, which can be more than requested while an expansion is in progress. Only set by Kubernetes 1.24+.",
+						Computed:    true,
+					},
+					"capacity": {
+						Type:        schema.TypeMap,
+						Description: "Actual resources of the underlying volume, e.g. the size after a resize completed.",
+						Computed:    true,
+					},
+					"current_volume_attributes_class_name": {
+						Type:        schema.TypeString,
+						Description: "Name of the volume attributes class the volume currently has. Only set by Kubernetes 1.29+.",
+						Computed:    true,
+					},
+					"modify_volume_status": {
+						Type:        schema.TypeList,
+						Description: "Status of the modification of the volume to another volume attributes class, while it's in flight or has failed. Only set by Kubernetes 1.29+.",
+						Computed:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"status": {
+									Type:        schema.TypeString,
+									Description: "Status of the modification: `Pending`, `InProgress` or `Infeasible`.",
+									Computed:    true,
+								},
+								"target_volume_attributes_class_name": {
+									Type:        schema.TypeString,
+									Description: "Name of the volume attributes class the volume is being modified to.",
+									Computed:    true,
+								},
+							},
+						},
+					},
+					"condition": {
+						Type:        schema.TypeList,
+						Description: "Current conditions of the claim. A `Resizing` condition means the volume is being expanded, `FileSystemResizePending` means the file system is waiting for a pod to be (re)started to be resized on the node.",
+						Computed:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"last_probe_time": {
+									Type:        schema.TypeString,
+									Description: "Last time the condition was probed, in RFC3339 format.",
+									Computed:    true,
+								},
+								"last_transition_time": {
+									Type:        schema.TypeString,
+									Description: "Last time the condition transitioned from one status to another, in RFC3339 format.",
+									Computed:    true,
+								},
+								"message": {
+									Type:        schema.TypeString,
+									Description: "Human-readable message indicating details about the last transition.",
+									Computed:    true,
+								},
+								"reason": {
+									Type:        schema.TypeString,
+									Description: "Machine understandable reason of the last transition, e.g. `ResizeStarted`.",
+									Computed:    true,
+								},
+								"status": {
+									Type:        schema.TypeString,
+									Description: "Status of the condition: `True`, `False` or `Unknown`.",
+									Computed:    true,
+								},
+								"type": {
+									Type:        schema.TypeString,
+									Description: "Type of the condition, e.g. `Resizing` or `FileSystemResizePending`.",
+									Computed:    true,
+								},
+							},
+						},
+					},
+					"phase": {
+						Type:        schema.TypeString,
+						Description: "Phase of the claim: `Pending`, `Bound` or `Lost`.",
+						Computed:    true,
+					},
+				},
+			},
+		}
+	}
+
 	return s
 }
+
+func requestsDescription(pvcTemplate bool) string {
+	description := "Map describing the minimum amount of resources the volume should have, e.g. `storage`. If this is omitted, it defaults to `limits` if that is explicitly specified, otherwise to an implementation-defined value. More info: http://kubernetes.io/docs/user-guide/compute-resources/"
+	if pvcTemplate {
+		return description
+	}
+	return description + " Increasing `storage` expands the volume in place, if the storage class of the claim allows volume expansion. It can't be decreased, any other change re-creates the claim."
+}
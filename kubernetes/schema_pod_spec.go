@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 func podTemplateSpecFields(isUpdatable bool) map[string]*schema.Schema {
@@ -54,9 +55,13 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 				Schema: map[string]*schema.Schema{
 					"nameservers": {
 						Type:        schema.TypeList,
-						Description: "A list of DNS name server IP addresses. This will be appended to the base nameservers generated from DNSPolicy. Duplicated nameservers will be removed.",
-						Elem:        &schema.Schema{Type: schema.TypeString},
-						Optional:    true,
+						Description: "A list of DNS name server IP addresses. This will be appended to the base nameservers generated from DNSPolicy. Duplicated nameservers will be removed. At most 3 can be set.",
+						Elem: &schema.Schema{
+							Type:         schema.TypeString,
+							ValidateFunc: validateIPAddress,
+						},
+						Optional: true,
+						MaxItems: 3,
 					},
 					"options": {
 						Type:        schema.TypeMap,
@@ -74,10 +79,33 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 			},
 		},
 		"dns_policy": {
-			Type:        schema.TypeString,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "ClusterFirst",
+			ValidateFunc: validateDNSPolicy,
+			Description:  "Set DNS policy for containers within the pod. One of 'ClusterFirst', 'ClusterFirstWithHostNet', 'Default' or 'None'. DNS parameters given in 'dns_config' are merged with the policy, 'None' ignores the DNS settings of the cluster and requires 'dns_config'. Defaults to 'ClusterFirst'.",
+		},
+		"host_aliases": {
+			Type:        schema.TypeList,
 			Optional:    true,
-			Default:     "ClusterFirst",
-			Description: "Set DNS policy for containers within the pod. One of 'ClusterFirst' or 'Default'. Defaults to 'ClusterFirst'.",
+			Description: "List of hosts and IPs that will be injected into the pod's hosts file if specified. This is only valid for non-hostNetwork pods.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"hostnames": {
+						Type:        schema.TypeList,
+						Description: "Hostnames for the IP address.",
+						Required:    true,
+						MinItems:    1,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+					"ip": {
+						Type:         schema.TypeString,
+						Description:  "IP address of the host file entry.",
+						Required:     true,
+						ValidateFunc: validateIPAddress,
+					},
+				},
+			},
 		},
 		"host_ipc": {
 			Type:        schema.TypeBool,
@@ -129,21 +157,41 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 			},
 		},
 		"node_name": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Computed:    true,
-			Description: "NodeName is a request to schedule this pod onto a specific node. If it is non-empty, the scheduler simply schedules this pod onto that node, assuming that it fits resource requirements.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validateDNSSubdomain,
+			Description:  "NodeName is a request to schedule this pod onto a specific node. If it is non-empty, the scheduler simply schedules this pod onto that node, assuming that it fits resource requirements. Takes precedence over `node_selector` & `affinity`.",
 		},
 		"node_selector": {
 			Type:        schema.TypeMap,
 			Optional:    true,
 			Description: "NodeSelector is a selector which must be true for the pod to fit on a node. Selector which must match a node's labels for the pod to be scheduled on that node. More info: http://kubernetes.io/docs/user-guide/node-selection.",
 		},
+		"priority": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The priority value of the pod, resolved from `priority_class_name` by the Priority admission controller. The higher the value, the higher the priority.",
+		},
+		"priority_class_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateDNSSubdomain,
+			Description:  "If specified, indicates the pod's priority. \"system-node-critical\" and \"system-cluster-critical\" are two special keywords which indicate the highest priorities with the former being the highest priority. Any other name must be defined by creating a PriorityClass object with that name. If not specified, the pod priority will be default or zero if there is no default.",
+		},
 		"restart_policy": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Default:     "Always",
-			Description: "Restart policy for all containers within the pod. One of Always, OnFailure, Never. More info: http://kubernetes.io/docs/user-guide/pod-states#restartpolicy.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "Always",
+			ValidateFunc: validation.StringInSlice([]string{"Always", "OnFailure", "Never"}, false),
+			Description:  "Restart policy for all containers within the pod. One of Always, OnFailure, Never. More info: http://kubernetes.io/docs/user-guide/pod-states#restartpolicy.",
+		},
+		"scheduler_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validateDNSSubdomain,
+			Description:  "If specified, the pod will be dispatched by the specified scheduler. If not specified, the pod will be dispatched by the default scheduler.",
 		},
 		"security_context": {
 			Type:        schema.TypeList,
@@ -184,20 +232,41 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 							Schema: seLinuxOptionsField(),
 						},
 					},
+					"sysctl": {
+						Type:        schema.TypeList,
+						Description: "Namespaced sysctls used for the pod. Pods with unsupported sysctls (by the container runtime) might fail to launch.",
+						Optional:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Type:         schema.TypeString,
+									Description:  "Name of the sysctl, e.g. net.core.somaxconn",
+									Required:     true,
+									ValidateFunc: validateSysctlName,
+								},
+								"value": {
+									Type:        schema.TypeString,
+									Description: "Value of the sysctl",
+									Required:    true,
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		"service_account_name": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Computed:    true,
-			Description: "ServiceAccountName is the name of the ServiceAccount to use to run this pod. More info: http://releases.k8s.io/HEAD/docs/design/service_accounts.md.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validateServiceAccountName,
+			Description:  "ServiceAccountName is the name of the ServiceAccount to use to run this pod. More info: http://releases.k8s.io/HEAD/docs/design/service_accounts.md.",
 		},
 		"automount_service_account_token": {
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Default:     true,
-			Description: "In version 1.6+, you can also opt out of automounting API credentials for a particular pod",
+			Description: "Whether the token of the service account is mounted into the containers of the pod. Disable it for pods which don't call the API. Defaults to true.",
 		},
 		"subdomain": {
 			Type:        schema.TypeString,
@@ -367,65 +436,7 @@ func volumeSchema(isUpdatable bool) *schema.Resource {
 					Type:        schema.TypeList,
 					Description: `If unspecified, each key-value pair in the Data field of the referenced ConfigMap will be projected into the volume as a file whose name is the key and content is the value. If specified, the listed keys will be projected into the specified paths, and unlisted keys will not be present. If a key is specified which is not present in the ConfigMap, the volume setup will error. Paths must be relative and may not contain the '..' path or start with '..'.`,
 					Optional:    true,
-					Elem: &schema.Resource{
-						Schema: map[string]*schema.Schema{
-							"field_ref": {
-								Type:        schema.TypeList,
-								Required:    true,
-								MaxItems:    1,
-								Description: "Required: Selects a field of the pod: only annotations, labels, name and namespace are supported.",
-								Elem: &schema.Resource{
-									Schema: map[string]*schema.Schema{
-										"api_version": {
-											Type:        schema.TypeString,
-											Optional:    true,
-											Default:     "v1",
-											Description: `Version of the schema the FieldPath is written in terms of, defaults to "v1".`,
-										},
-										"field_path": {
-											Type:        schema.TypeString,
-											Optional:    true,
-											Description: "Path of the field to select in the specified API version",
-										},
-									},
-								},
-							},
-							"mode": {
-								Type:        schema.TypeInt,
-								Optional:    true,
-								Description: `Optional: mode bits to use on this file, must be a value between 0 and 0777. If not specified, the volume defaultMode will be used. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.`,
-							},
-							"path": {
-								Type:         schema.TypeString,
-								Required:     true,
-								ValidateFunc: validateAttributeValueDoesNotContain(".."),
-								Description:  `Path is the relative path name of the file to be created. Must not be absolute or contain the '..' path. Must be utf-8 encoded. The first item of the relative path must not start with '..'`,
-							},
-							"resource_field_ref": {
-								Type:        schema.TypeList,
-								Optional:    true,
-								MaxItems:    1,
-								Description: "Selects a resource of the container: only resources limits and requests (limits.cpu, limits.memory, requests.cpu and requests.memory) are currently supported.",
-								Elem: &schema.Resource{
-									Schema: map[string]*schema.Schema{
-										"container_name": {
-											Type:     schema.TypeString,
-											Required: true,
-										},
-										"quantity": {
-											Type:     schema.TypeString,
-											Optional: true,
-										},
-										"resource": {
-											Type:        schema.TypeString,
-											Required:    true,
-											Description: "Resource to select",
-										},
-									},
-								},
-							},
-						},
-					},
+					Elem:        downwardAPIVolumeFileResource(),
 				},
 			},
 		},
@@ -440,18 +451,19 @@ func volumeSchema(isUpdatable bool) *schema.Resource {
 			Schema: map[string]*schema.Schema{
 				"medium": {
 					Type:         schema.TypeString,
-					Description:  `What type of storage medium should back this directory. The default is "" which means to use the node's default medium. Must be an empty string (default) or Memory. More info: http://kubernetes.io/docs/user-guide/volumes#emptydir`,
+					Description:  `What type of storage medium should back this directory. The default is "" which means to use the node's default medium. Must be an empty string (default), Memory (tmpfs) or HugePages. More info: http://kubernetes.io/docs/user-guide/volumes#emptydir`,
 					Optional:     true,
 					Default:      "",
-					ValidateFunc: validateAttributeValueIsIn([]string{"", "Memory"}),
+					ValidateFunc: validateAttributeValueIsIn([]string{"", "Memory", "HugePages"}),
 				},
 				"size_limit": {
-					Type:         schema.TypeString,
-					Description:  `Total amount of local storage required for this EmptyDir volume. The size limit is also applicable for memory medium. The maximum usage on memory medium EmptyDir would be the minimum value between the SizeLimit specified here and the sum of memory limits of all containers in a pod. The default is nil which means that the limit is undefined. More info: http://kubernetes.io/docs/user-guide/volumes#emptydir`,
-					Optional:     true,
-					Default:      "0",
-					ForceNew:     !isUpdatable,
-					ValidateFunc: validateResourceQuantity,
+					Type:             schema.TypeString,
+					Description:      `Total amount of local storage required for this EmptyDir volume. The size limit is also applicable for memory medium. The maximum usage on memory medium EmptyDir would be the minimum value between the SizeLimit specified here and the sum of memory limits of all containers in a pod. The default is nil which means that the limit is undefined. More info: http://kubernetes.io/docs/user-guide/volumes#emptydir`,
+					Optional:         true,
+					Default:          "0",
+					ForceNew:         !isUpdatable,
+					ValidateFunc:     validateResourceQuantity,
+					DiffSuppressFunc: suppressEquivalentResourceQuantity,
 				},
 			},
 		},
@@ -466,7 +478,7 @@ func volumeSchema(isUpdatable bool) *schema.Resource {
 			Schema: map[string]*schema.Schema{
 				"claim_name": {
 					Type:        schema.TypeString,
-					Description: "ClaimName is the name of a PersistentVolumeClaim in the same ",
+					Description: "ClaimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume. Mount it into a container with a `volume_mount` of the same `name` as the volume.",
 					Optional:    true,
 				},
 				"read_only": {
@@ -479,6 +491,130 @@ func volumeSchema(isUpdatable bool) *schema.Resource {
 		},
 	}
 
+	v["projected"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Projected represents a single volume that projects several volume sources into the same directory. More info: https://kubernetes.io/docs/concepts/storage/volumes/#projected",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"default_mode": {
+					Type:         schema.TypeInt,
+					Description:  "Optional: mode bits to use on created files by default. Must be a value between 0 and 0777. Defaults to 0644. Directories within the path are not affected by this setting. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.",
+					Optional:     true,
+					Default:      0644,
+					ValidateFunc: validateModeBits,
+				},
+				"sources": {
+					Type:        schema.TypeList,
+					Description: "List of volume projections. Each projection must specify exactly one of config_map, downward_api, secret or service_account_token.",
+					Required:    true,
+					MinItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"config_map": {
+								Type:        schema.TypeList,
+								Description: "Information about the configMap data to project.",
+								Optional:    true,
+								MaxItems:    1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"items": {
+											Type:        schema.TypeList,
+											Description: "If unspecified, each key-value pair in the Data field of the referenced ConfigMap will be projected into the volume as a file whose name is the key and content is the value. If specified, the listed keys will be projected into the specified paths, and unlisted keys will not be present.",
+											Optional:    true,
+											Elem:        keyToPathResource(),
+										},
+										"name": {
+											Type:        schema.TypeString,
+											Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
+											Optional:    true,
+										},
+										"optional": {
+											Type:        schema.TypeBool,
+											Description: "Optional: Specify whether the ConfigMap or it's keys must be defined.",
+											Optional:    true,
+										},
+									},
+								},
+							},
+							"downward_api": {
+								Type:        schema.TypeList,
+								Description: "Information about the downwardAPI data to project.",
+								Optional:    true,
+								MaxItems:    1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"items": {
+											Type:        schema.TypeList,
+											Description: "Items is a list of DownwardAPIVolume file.",
+											Optional:    true,
+											Elem:        downwardAPIVolumeFileResource(),
+										},
+									},
+								},
+							},
+							"secret": {
+								Type:        schema.TypeList,
+								Description: "Information about the secret data to project.",
+								Optional:    true,
+								MaxItems:    1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"items": {
+											Type:        schema.TypeList,
+											Description: "If unspecified, each key-value pair in the Data field of the referenced Secret will be projected into the volume as a file whose name is the key and content is the value. If specified, the listed keys will be projected into the specified paths, and unlisted keys will not be present.",
+											Optional:    true,
+											Elem:        keyToPathResource(),
+										},
+										"name": {
+											Type:        schema.TypeString,
+											Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
+											Optional:    true,
+										},
+										"optional": {
+											Type:        schema.TypeBool,
+											Description: "Optional: Specify whether the Secret or it's keys must be defined.",
+											Optional:    true,
+										},
+									},
+								},
+							},
+							"service_account_token": {
+								Type:        schema.TypeList,
+								Description: "Information about the service account token to project.",
+								Optional:    true,
+								MaxItems:    1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"audience": {
+											Type:        schema.TypeString,
+											Description: "Audience is the intended audience of the token. A recipient of a token must identify itself with an identifier specified in the audience of the token, and otherwise should reject the token. Defaults to the identifier of the apiserver.",
+											Optional:    true,
+										},
+										"expiration_seconds": {
+											Type:         schema.TypeInt,
+											Description:  "The requested duration of validity of the service account token. The kubelet will start trying to rotate the token if the token is older than 80 percent of its time to live or if the token is older than 24 hours. Must be at least 600 seconds (10 minutes). Defaults to 1 hour.",
+											Optional:     true,
+											Default:      3600,
+											ValidateFunc: validation.IntAtLeast(600),
+										},
+										"path": {
+											Type:         schema.TypeString,
+											Description:  "Path is the path relative to the mount point of the file to project the token into.",
+											Required:     true,
+											ValidateFunc: validateAttributeValueDoesNotContain(".."),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
 	v["secret"] = &schema.Schema{
 		Type:        schema.TypeList,
 		Description: "Secret represents a secret that should populate this volume. More info: http://kubernetes.io/docs/user-guide/volumes#secrets",
@@ -540,3 +676,88 @@ func volumeSchema(isUpdatable bool) *schema.Resource {
 		Schema: v,
 	}
 }
+
+func downwardAPIVolumeFileResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"field_ref": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "Required: Selects a field of the pod: only annotations, labels, name and namespace are supported.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "v1",
+							Description: `Version of the schema the FieldPath is written in terms of, defaults to "v1".`,
+						},
+						"field_path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Path of the field to select in the specified API version",
+						},
+					},
+				},
+			},
+			"mode": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: `Optional: mode bits to use on this file, must be a value between 0 and 0777. If not specified, the volume defaultMode will be used. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.`,
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAttributeValueDoesNotContain(".."),
+				Description:  `Path is the relative path name of the file to be created. Must not be absolute or contain the '..' path. Must be utf-8 encoded. The first item of the relative path must not start with '..'`,
+			},
+			"resource_field_ref": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Selects a resource of the container: only resources limits and requests (limits.cpu, limits.memory, requests.cpu and requests.memory) are currently supported.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"container_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"quantity": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"resource": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Resource to select",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func keyToPathResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The key to project.",
+			},
+			"mode": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Optional: mode bits to use on this file, must be a value between 0 and 0777. If not specified, the volume defaultMode will be used. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.",
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAttributeValueDoesNotContain(".."),
+				Description:  "The relative path of the file to map the key to. May not be an absolute path. May not contain the path element '..'. May not start with the string '..'.",
+			},
+		},
+	}
+}
@@ -0,0 +1,81 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func replicaSetSpecFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"min_ready_seconds": {
+			Type:         schema.TypeInt,
+			Description:  "Minimum number of seconds for which a newly created pod should be ready without any of its container crashing, for it to be considered available. Defaults to 0 (pod will be considered available as soon as it is ready)",
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"replicas": {
+			Type:         schema.TypeInt,
+			Description:  "The number of desired replicas. Defaults to 1. Changes are applied through the `scale` subresource. More info: https://kubernetes.io/docs/concepts/workloads/controllers/replicaset/",
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"selector": {
+			Type:        schema.TypeList,
+			Description: "A label query over pods that should match the replica count. Label keys and values that must match in order to be controlled by this replica set. It must match the pod template's labels. Cannot be updated. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors",
+			Required:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"match_expressions": {
+						Type:        schema.TypeList,
+						Description: "A list of label selector requirements. The requirements are ANDed.",
+						Optional:    true,
+						ForceNew:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"key": {
+									Type:        schema.TypeString,
+									Description: "The label key that the selector applies to.",
+									Optional:    true,
+									ForceNew:    true,
+								},
+								"operator": {
+									Type:        schema.TypeString,
+									Description: "A key's relationship to a set of values. Valid operators ard `In`, `NotIn`, `Exists` and `DoesNotExist`.",
+									Optional:    true,
+									ForceNew:    true,
+								},
+								"values": {
+									Type:        schema.TypeSet,
+									Description: "An array of string values. If the operator is `In` or `NotIn`, the values array must be non-empty. If the operator is `Exists` or `DoesNotExist`, the values array must be empty. This array is replaced during a strategic merge patch.",
+									Optional:    true,
+									ForceNew:    true,
+									Elem:        &schema.Schema{Type: schema.TypeString},
+									Set:         schema.HashString,
+								},
+							},
+						},
+					},
+					"match_labels": {
+						Type:        schema.TypeMap,
+						Description: "A map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of `match_expressions`, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+						Optional:    true,
+						ForceNew:    true,
+					},
+				},
+			},
+		},
+		"template": {
+			Type:        schema.TypeList,
+			Description: "Describes the pod that will be created if insufficient replicas are detected. Changes only affect pods created afterwards. More info: https://kubernetes.io/docs/concepts/workloads/controllers/replicaset/#pod-template",
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: podTemplateSpecFields(true),
+			},
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// checkSelectorMatchesTemplateLabels is meant to be called from CustomizeDiff of the workload resources.
+// The API server rejects a workload whose selector doesn't select the labels of its own pod template
+// with a generic "`selector` does not match template `labels`" error, this fails the plan with the
+// offending labels instead. Labels which aren't known until apply are not checked.
+func checkSelectorMatchesTemplateLabels(d *schema.ResourceDiff, kind, selectorKey, labelsKey string) error {
+	selector := d.Get(selectorKey).(map[string]interface{})
+	labels := d.Get(labelsKey).(map[string]interface{})
+	// Labels computed as a whole read as empty
+	if len(selector) == 0 || len(labels) == 0 {
+		return nil
+	}
+
+	mismatches := matchLabelsMismatches(selector, labels)
+	if len(mismatches) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s must select the labels of its pod template, %s doesn't match %s:%s",
+		kind, selectorKey, labelsKey, strings.Join(mismatches, ""))
+}
+
+// matchLabelsMismatches lists (sorted by key) the selector labels missing
+// from the template labels or with another value there.
+func matchLabelsMismatches(selector, labels map[string]interface{}) []string {
+	keys := make([]string, 0, len(selector))
+	for k := range selector {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var mismatches []string
+	for _, k := range keys {
+		v := selector[k]
+		if v == config.UnknownVariableValue {
+			continue
+		}
+		lv, ok := labels[k]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, fmt.Sprintf("\n   * %s = %q is not a template label", k, v))
+		case lv == config.UnknownVariableValue:
+			continue
+		case lv != v:
+			mismatches = append(mismatches, fmt.Sprintf("\n   * %s = %q, but the template label is %s = %q", k, v, k, lv))
+		}
+	}
+	return mismatches
+}
@@ -0,0 +1,89 @@
+package kubernetes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestMatchLabelsMismatches(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Selector map[string]interface{}
+		Labels   map[string]interface{}
+		Expected []string
+	}{
+		{
+			"match",
+			map[string]interface{}{"app": "web"},
+			map[string]interface{}{"app": "web", "tier": "frontend"},
+			nil,
+		},
+		{
+			"missing and different",
+			map[string]interface{}{"tier": "frontend", "app": "web"},
+			map[string]interface{}{"app": "api"},
+			[]string{
+				"\n   * app = \"web\", but the template label is app = \"api\"",
+				"\n   * tier = \"frontend\" is not a template label",
+			},
+		},
+		{
+			"unknown",
+			map[string]interface{}{"app": config.UnknownVariableValue, "tier": "frontend"},
+			map[string]interface{}{"tier": config.UnknownVariableValue},
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			mismatches := matchLabelsMismatches(tc.Selector, tc.Labels)
+			if !reflect.DeepEqual(mismatches, tc.Expected) {
+				t.Fatalf("Expected %#v, given: %#v", tc.Expected, mismatches)
+			}
+		})
+	}
+}
+
+func TestDeploymentSelectorMatchesTemplateLabels(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Labels        map[string]interface{}
+		ExpectedError string
+	}{
+		{"match", map[string]interface{}{"app": "web"}, ""},
+		{"mismatch", map[string]interface{}{"app": "api"}, `app = "web", but the template label is app = "api"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec": []map[string]interface{}{{
+					"selector": map[string]interface{}{"app": "web"},
+					"template": []map[string]interface{}{{
+						"metadata": []map[string]interface{}{{"labels": tc.Labels}},
+					}},
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = resourceKubernetesDeployment().Diff(nil, terraform.NewResourceConfig(raw), &kubernetesProvider{})
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
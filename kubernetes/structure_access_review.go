@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	authv1 "k8s.io/api/authorization/v1"
+)
+
+func expandResourceAttributes(d *schema.ResourceData) authv1.ResourceAttributes {
+	return authv1.ResourceAttributes{
+		Verb:        d.Get("verb").(string),
+		Group:       d.Get("group").(string),
+		Resource:    d.Get("resource").(string),
+		Subresource: d.Get("subresource").(string),
+		Namespace:   d.Get("namespace").(string),
+		Name:        d.Get("name").(string),
+	}
+}
+
+func setAccessReviewStatus(d *schema.ResourceData, status *authv1.SubjectAccessReviewStatus) {
+	d.Set("allowed", status.Allowed)
+	d.Set("denied", status.Denied)
+	d.Set("reason", status.Reason)
+	d.Set("evaluation_error", status.EvaluationError)
+}
+
+// accessDeniedError explains why the subject, e.g. `User "jane" isn't`, may not perform the action.
+func accessDeniedError(subject string, attrs authv1.ResourceAttributes, status *authv1.SubjectAccessReviewStatus) error {
+	msg := fmt.Sprintf("%s allowed to %s", subject, describeResourceAttributes(attrs))
+	if status.Reason != "" {
+		msg += ": " + status.Reason
+	}
+	if status.EvaluationError != "" {
+		msg += fmt.Sprintf(" (evaluation error: %s)", status.EvaluationError)
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// describeResourceAttributes describes the action in the terms of `kubectl auth can-i`,
+// e.g. `create deployments.apps/scale "web" in namespace "default"`.
+func describeResourceAttributes(attrs authv1.ResourceAttributes) string {
+	resource := attrs.Resource
+	if attrs.Group != "" {
+		resource += "." + attrs.Group
+	}
+	if attrs.Subresource != "" {
+		resource += "/" + attrs.Subresource
+	}
+	desc := attrs.Verb + " " + resource
+	if attrs.Name != "" {
+		desc += fmt.Sprintf(" %q", attrs.Name)
+	}
+	if attrs.Namespace != "" {
+		desc += fmt.Sprintf(" in namespace %q", attrs.Namespace)
+	}
+	return desc
+}
+
+func resourceAttributesId(attrs authv1.ResourceAttributes) string {
+	return strings.Join([]string{attrs.Verb, attrs.Group, attrs.Resource, attrs.Subresource, attrs.Namespace, attrs.Name}, "/")
+}
@@ -35,6 +35,12 @@ func flattenCronJobSpec(in batchv1beta1.CronJobSpec, d *schema.ResourceData) ([]
 		att["successful_jobs_history_limit"] = 3
 	}
 
+	if in.Suspend != nil {
+		att["suspend"] = *in.Suspend
+	} else {
+		att["suspend"] = false
+	}
+
 	return []interface{}{att}, nil
 }
 
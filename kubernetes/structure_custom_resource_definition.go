@@ -0,0 +1,201 @@
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The apiextensions.k8s.io types & client aren't vendored,
+// so custom resource definitions are sent & decoded as these.
+
+type customResourceDefinition struct {
+	meta_v1.TypeMeta `json:",inline"`
+	ObjectMeta       meta_v1.ObjectMeta             `json:"metadata,omitempty"`
+	Spec             customResourceDefinitionSpec   `json:"spec"`
+	Status           customResourceDefinitionStatus `json:"status,omitempty"`
+}
+
+type customResourceDefinitionSpec struct {
+	Group    string                            `json:"group"`
+	Names    customResourceDefinitionNames     `json:"names"`
+	Scope    string                            `json:"scope"`
+	Versions []customResourceDefinitionVersion `json:"versions"`
+}
+
+type customResourceDefinitionNames struct {
+	Plural     string   `json:"plural"`
+	Singular   string   `json:"singular,omitempty"`
+	Kind       string   `json:"kind"`
+	ListKind   string   `json:"listKind,omitempty"`
+	ShortNames []string `json:"shortNames,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+type customResourceDefinitionVersion struct {
+	Name         string                      `json:"name"`
+	Served       bool                        `json:"served"`
+	Storage      bool                        `json:"storage"`
+	Schema       *customResourceValidation   `json:"schema,omitempty"`
+	Subresources *customResourceSubresources `json:"subresources,omitempty"`
+}
+
+type customResourceValidation struct {
+	OpenAPIV3Schema json.RawMessage `json:"openAPIV3Schema,omitempty"`
+}
+
+type customResourceSubresources struct {
+	Status *struct{}                       `json:"status,omitempty"`
+	Scale  *customResourceSubresourceScale `json:"scale,omitempty"`
+}
+
+type customResourceSubresourceScale struct {
+	SpecReplicasPath   string `json:"specReplicasPath"`
+	StatusReplicasPath string `json:"statusReplicasPath"`
+	LabelSelectorPath  string `json:"labelSelectorPath,omitempty"`
+}
+
+type customResourceDefinitionStatus struct {
+	Conditions []customResourceDefinitionCondition `json:"conditions,omitempty"`
+}
+
+type customResourceDefinitionCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+const customResourceDefinitionsAPIPath = "/apis/apiextensions.k8s.io/v1/customresourcedefinitions"
+
+// Flatteners
+
+func flattenCustomResourceDefinitionSpec(in customResourceDefinitionSpec) ([]interface{}, error) {
+	att := make(map[string]interface{})
+	att["group"] = in.Group
+	att["scope"] = in.Scope
+	att["names"] = []interface{}{map[string]interface{}{
+		"plural":      in.Names.Plural,
+		"singular":    in.Names.Singular,
+		"kind":        in.Names.Kind,
+		"list_kind":   in.Names.ListKind,
+		"short_names": in.Names.ShortNames,
+		"categories":  in.Names.Categories,
+	}}
+
+	versions := make([]interface{}, len(in.Versions))
+	for i, v := range in.Versions {
+		m := map[string]interface{}{
+			"name":    v.Name,
+			"served":  v.Served,
+			"storage": v.Storage,
+		}
+		if v.Schema != nil && len(v.Schema.OpenAPIV3Schema) > 0 {
+			var buf bytes.Buffer
+			if err := json.Compact(&buf, v.Schema.OpenAPIV3Schema); err != nil {
+				return nil, fmt.Errorf("Invalid schema of version %q: %s", v.Name, err)
+			}
+			m["schema"] = buf.String()
+		}
+		if v.Subresources != nil {
+			m["status_subresource"] = v.Subresources.Status != nil
+			if s := v.Subresources.Scale; s != nil {
+				m["scale_subresource"] = []interface{}{map[string]interface{}{
+					"spec_replicas_path":   s.SpecReplicasPath,
+					"status_replicas_path": s.StatusReplicasPath,
+					"label_selector_path":  s.LabelSelectorPath,
+				}}
+			}
+		}
+		versions[i] = m
+	}
+	att["version"] = versions
+
+	return []interface{}{att}, nil
+}
+
+// Expanders
+
+func expandCustomResourceDefinitionSpec(l []interface{}) (customResourceDefinitionSpec, error) {
+	obj := customResourceDefinitionSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	obj.Group = in["group"].(string)
+	obj.Scope = in["scope"].(string)
+	if v, ok := in["names"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		names := v[0].(map[string]interface{})
+		obj.Names = customResourceDefinitionNames{
+			Plural:     names["plural"].(string),
+			Singular:   names["singular"].(string),
+			Kind:       names["kind"].(string),
+			ListKind:   names["list_kind"].(string),
+			ShortNames: expandStringSlice(names["short_names"].([]interface{})),
+			Categories: expandStringSlice(names["categories"].([]interface{})),
+		}
+	}
+
+	versions, err := expandCustomResourceDefinitionVersions(in["version"].([]interface{}))
+	if err != nil {
+		return obj, err
+	}
+	obj.Versions = versions
+
+	return obj, nil
+}
+
+func expandCustomResourceDefinitionVersions(l []interface{}) ([]customResourceDefinitionVersion, error) {
+	versions := make([]customResourceDefinitionVersion, len(l))
+	for i, v := range l {
+		in := v.(map[string]interface{})
+		version := customResourceDefinitionVersion{
+			Name:    in["name"].(string),
+			Served:  in["served"].(bool),
+			Storage: in["storage"].(bool),
+		}
+		if s := in["schema"].(string); s != "" {
+			if !json.Valid([]byte(s)) {
+				return nil, fmt.Errorf("Schema of version %q is not valid JSON", version.Name)
+			}
+			version.Schema = &customResourceValidation{OpenAPIV3Schema: json.RawMessage(s)}
+		}
+
+		subresources := &customResourceSubresources{}
+		if in["status_subresource"].(bool) {
+			subresources.Status = &struct{}{}
+		}
+		if s, ok := in["scale_subresource"].([]interface{}); ok && len(s) > 0 && s[0] != nil {
+			scale := s[0].(map[string]interface{})
+			subresources.Scale = &customResourceSubresourceScale{
+				SpecReplicasPath:   scale["spec_replicas_path"].(string),
+				StatusReplicasPath: scale["status_replicas_path"].(string),
+				LabelSelectorPath:  scale["label_selector_path"].(string),
+			}
+		}
+		if subresources.Status != nil || subresources.Scale != nil {
+			version.Subresources = subresources
+		}
+
+		versions[i] = version
+	}
+	return versions, nil
+}
+
+// customResourceDefinitionEstablishedState reports whether the API server serves the
+// custom resources yet. Names conflicting with another definition are returned as an error,
+// the definition won't be established until they're changed.
+func customResourceDefinitionEstablishedState(crd *customResourceDefinition) (string, error) {
+	for _, c := range crd.Status.Conditions {
+		switch {
+		case c.Type == "Established" && c.Status == "True":
+			return "Established", nil
+		case c.Type == "NamesAccepted" && c.Status == "False":
+			return "NamesNotAccepted", fmt.Errorf("Names of custom resource definition %s were not accepted: %s", crd.ObjectMeta.Name, c.Message)
+		}
+	}
+	return "Pending", nil
+}
@@ -0,0 +1,142 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCustomResourceDefinitionSpecRoundTrip(t *testing.T) {
+	in := []interface{}{map[string]interface{}{
+		"group": "stable.example.com",
+		"scope": "Namespaced",
+		"names": []interface{}{map[string]interface{}{
+			"plural":      "crontabs",
+			"singular":    "crontab",
+			"kind":        "CronTab",
+			"list_kind":   "CronTabList",
+			"short_names": []interface{}{"ct"},
+			"categories":  []interface{}{"all"},
+		}},
+		"version": []interface{}{
+			map[string]interface{}{
+				"name":               "v1",
+				"served":             true,
+				"storage":            true,
+				"schema":             `{"type": "object", "x-kubernetes-preserve-unknown-fields": true}`,
+				"status_subresource": true,
+				"scale_subresource": []interface{}{map[string]interface{}{
+					"spec_replicas_path":   ".spec.replicas",
+					"status_replicas_path": ".status.replicas",
+					"label_selector_path":  "",
+				}},
+			},
+			map[string]interface{}{
+				"name":               "v1beta1",
+				"served":             false,
+				"storage":            false,
+				"schema":             "",
+				"status_subresource": false,
+				"scale_subresource":  []interface{}{},
+			},
+		},
+	}}
+
+	spec, err := expandCustomResourceDefinitionSpec(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Versions[0].Subresources == nil || spec.Versions[0].Subresources.Status == nil {
+		t.Fatalf("Expected the status subresource of v1 to be enabled, given: %#v", spec.Versions[0].Subresources)
+	}
+	if spec.Versions[1].Subresources != nil || spec.Versions[1].Schema != nil {
+		t.Fatalf("Expected v1beta1 to have neither subresources nor a schema, given: %#v", spec.Versions[1])
+	}
+
+	out, err := flattenCustomResourceDefinitionSpec(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions := out[0].(map[string]interface{})["version"].([]interface{})
+	schema := versions[0].(map[string]interface{})["schema"]
+	if schema != `{"type":"object","x-kubernetes-preserve-unknown-fields":true}` {
+		t.Fatalf("Expected the schema to be compacted, given: %q", schema)
+	}
+	if _, ok := versions[1].(map[string]interface{})["schema"]; ok {
+		t.Fatalf("Expected no schema for v1beta1, given: %#v", versions[1])
+	}
+
+	names := out[0].(map[string]interface{})["names"].([]interface{})[0].(map[string]interface{})
+	if !reflect.DeepEqual(names["short_names"], []string{"ct"}) {
+		t.Fatalf("Unexpected short names: %#v", names["short_names"])
+	}
+
+	_, err = expandCustomResourceDefinitionVersions([]interface{}{map[string]interface{}{
+		"name":               "v1",
+		"served":             true,
+		"storage":            true,
+		"schema":             `{"type": `,
+		"status_subresource": false,
+		"scale_subresource":  []interface{}{},
+	}})
+	if err == nil {
+		t.Fatal("Expected an error for a schema which isn't valid JSON")
+	}
+}
+
+func TestCustomResourceDefinitionEstablishedState(t *testing.T) {
+	cases := []struct {
+		conditions []customResourceDefinitionCondition
+		state      string
+		err        bool
+	}{
+		{nil, "Pending", false},
+		{
+			[]customResourceDefinitionCondition{
+				{Type: "NamesAccepted", Status: "True"},
+				{Type: "Established", Status: "False"},
+			},
+			"Pending", false,
+		},
+		{
+			[]customResourceDefinitionCondition{
+				{Type: "NamesAccepted", Status: "True"},
+				{Type: "Established", Status: "True"},
+			},
+			"Established", false,
+		},
+		{
+			[]customResourceDefinitionCondition{
+				{Type: "NamesAccepted", Status: "False", Message: `"crontabs" is already in use`},
+			},
+			"NamesNotAccepted", true,
+		},
+	}
+
+	for i, tc := range cases {
+		crd := &customResourceDefinition{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "crontabs.stable.example.com"},
+			Status:     customResourceDefinitionStatus{Conditions: tc.conditions},
+		}
+		state, err := customResourceDefinitionEstablishedState(crd)
+		if state != tc.state {
+			t.Errorf("%d: expected state %q, given: %q", i, tc.state, state)
+		}
+		if (err != nil) != tc.err {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+	}
+}
+
+func TestSuppressEquivalentJSON(t *testing.T) {
+	if !suppressEquivalentJSON("", `{"type":"object","required":["spec"]}`, `{
+  "required": ["spec"],
+  "type": "object"
+}`, nil) {
+		t.Fatal("Expected reformatted JSON to be equivalent")
+	}
+	if suppressEquivalentJSON("", `{"required":["spec"]}`, `{"required":["status"]}`, nil) {
+		t.Fatal("Expected different JSON values not to be equivalent")
+	}
+}
@@ -0,0 +1,307 @@
+package kubernetes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	api "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Expanders
+
+func expandHorizontalPodAutoscalerV2Spec(in []interface{}) (api.HorizontalPodAutoscalerSpec, error) {
+	if len(in) == 0 || in[0] == nil {
+		return api.HorizontalPodAutoscalerSpec{}, nil
+	}
+	spec := api.HorizontalPodAutoscalerSpec{}
+	m := in[0].(map[string]interface{})
+	if v, ok := m["max_replicas"]; ok {
+		spec.MaxReplicas = int32(v.(int))
+	}
+	if v, ok := m["min_replicas"].(int); ok && v > 0 {
+		spec.MinReplicas = ptrToInt32(int32(v))
+	}
+	if v, ok := m["scale_target_ref"]; ok {
+		spec.ScaleTargetRef = expandCrossVersionObjectReferenceV2(v.([]interface{}))
+	}
+	if v, ok := m["metric"].([]interface{}); ok && len(v) > 0 {
+		var err error
+		spec.Metrics, err = expandMetricSpecs(v)
+		if err != nil {
+			return spec, err
+		}
+	}
+
+	return spec, nil
+}
+
+func expandMetricSpecs(in []interface{}) ([]api.MetricSpec, error) {
+	metrics := make([]api.MetricSpec, len(in))
+	for i, c := range in {
+		if c == nil {
+			return metrics, fmt.Errorf("metric %d: type must be specified", i)
+		}
+		m := c.(map[string]interface{})
+		metric := api.MetricSpec{
+			Type: api.MetricSourceType(m["type"].(string)),
+		}
+
+		if v, ok := m["external"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			ext := v[0].(map[string]interface{})
+			metric.External = &api.ExternalMetricSource{
+				MetricName: ext["metric_name"].(string),
+			}
+			if s, ok := ext["metric_selector"].([]interface{}); ok && len(s) > 0 {
+				metric.External.MetricSelector = expandLabelSelector(s)
+			}
+			var err error
+			metric.External.TargetValue, err = expandOptionalQuantity(ext["target_value"])
+			if err != nil {
+				return metrics, err
+			}
+			metric.External.TargetAverageValue, err = expandOptionalQuantity(ext["target_average_value"])
+			if err != nil {
+				return metrics, err
+			}
+		}
+		if v, ok := m["object"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			obj := v[0].(map[string]interface{})
+			q, err := resource.ParseQuantity(obj["target_value"].(string))
+			if err != nil {
+				return metrics, err
+			}
+			metric.Object = &api.ObjectMetricSource{
+				MetricName:  obj["metric_name"].(string),
+				Target:      expandCrossVersionObjectReferenceV2(obj["target"].([]interface{})),
+				TargetValue: q,
+			}
+		}
+		if v, ok := m["pods"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			pods := v[0].(map[string]interface{})
+			q, err := resource.ParseQuantity(pods["target_average_value"].(string))
+			if err != nil {
+				return metrics, err
+			}
+			metric.Pods = &api.PodsMetricSource{
+				MetricName:         pods["metric_name"].(string),
+				TargetAverageValue: q,
+			}
+		}
+		if v, ok := m["resource"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			res := v[0].(map[string]interface{})
+			metric.Resource = &api.ResourceMetricSource{
+				Name: v1.ResourceName(res["name"].(string)),
+			}
+			if u, ok := res["target_average_utilization"].(int); ok && u > 0 {
+				metric.Resource.TargetAverageUtilization = ptrToInt32(int32(u))
+			}
+			var err error
+			metric.Resource.TargetAverageValue, err = expandOptionalQuantity(res["target_average_value"])
+			if err != nil {
+				return metrics, err
+			}
+		}
+
+		if err := validateMetricSpec(metric); err != nil {
+			return metrics, fmt.Errorf("metric %d: %s", i, err)
+		}
+		metrics[i] = metric
+	}
+	return metrics, nil
+}
+
+// validateMetricSpec checks that exactly the block matching the metric type is set
+func validateMetricSpec(metric api.MetricSpec) error {
+	sources := map[api.MetricSourceType]bool{
+		api.ExternalMetricSourceType: metric.External != nil,
+		api.ObjectMetricSourceType:   metric.Object != nil,
+		api.PodsMetricSourceType:     metric.Pods != nil,
+		api.ResourceMetricSourceType: metric.Resource != nil,
+	}
+	set, ok := sources[metric.Type]
+	if !ok {
+		return fmt.Errorf("unknown type %q", metric.Type)
+	}
+	if !set {
+		return fmt.Errorf("type is %q but no matching block was specified", metric.Type)
+	}
+	for t, s := range sources {
+		if s && t != metric.Type {
+			return fmt.Errorf("type is %q but a block for %q was specified", metric.Type, t)
+		}
+	}
+
+	if metric.External != nil && (metric.External.TargetValue == nil) == (metric.External.TargetAverageValue == nil) {
+		return fmt.Errorf("exactly one of target_value or target_average_value must be specified for an external metric")
+	}
+	if metric.Resource != nil && (metric.Resource.TargetAverageUtilization == nil) == (metric.Resource.TargetAverageValue == nil) {
+		return fmt.Errorf("exactly one of target_average_utilization or target_average_value must be specified for a resource metric")
+	}
+	return nil
+}
+
+func expandOptionalQuantity(in interface{}) (*resource.Quantity, error) {
+	v, ok := in.(string)
+	if !ok || v == "" {
+		return nil, nil
+	}
+	q, err := resource.ParseQuantity(v)
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+func expandCrossVersionObjectReferenceV2(in []interface{}) api.CrossVersionObjectReference {
+	if len(in) == 0 || in[0] == nil {
+		return api.CrossVersionObjectReference{}
+	}
+	ref := api.CrossVersionObjectReference{}
+	m := in[0].(map[string]interface{})
+
+	if v, ok := m["api_version"]; ok {
+		ref.APIVersion = v.(string)
+	}
+	if v, ok := m["kind"]; ok {
+		ref.Kind = v.(string)
+	}
+	if v, ok := m["name"]; ok {
+		ref.Name = v.(string)
+	}
+	return ref
+}
+
+// Flatteners
+
+func flattenHorizontalPodAutoscalerV2Spec(spec api.HorizontalPodAutoscalerSpec) []interface{} {
+	m := make(map[string]interface{}, 0)
+	m["max_replicas"] = spec.MaxReplicas
+	if spec.MinReplicas != nil {
+		m["min_replicas"] = *spec.MinReplicas
+	}
+	m["scale_target_ref"] = flattenCrossVersionObjectReferenceV2(spec.ScaleTargetRef)
+	if len(spec.Metrics) > 0 {
+		m["metric"] = flattenMetricSpecs(spec.Metrics)
+	}
+	return []interface{}{m}
+}
+
+func flattenMetricSpecs(in []api.MetricSpec) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, metric := range in {
+		m := map[string]interface{}{
+			"type": string(metric.Type),
+		}
+		if metric.External != nil {
+			ext := map[string]interface{}{
+				"metric_name": metric.External.MetricName,
+			}
+			if metric.External.MetricSelector != nil {
+				ext["metric_selector"] = flattenLabelSelector(metric.External.MetricSelector)
+			}
+			if metric.External.TargetValue != nil {
+				ext["target_value"] = metric.External.TargetValue.String()
+			}
+			if metric.External.TargetAverageValue != nil {
+				ext["target_average_value"] = metric.External.TargetAverageValue.String()
+			}
+			m["external"] = []interface{}{ext}
+		}
+		if metric.Object != nil {
+			m["object"] = []interface{}{map[string]interface{}{
+				"metric_name":  metric.Object.MetricName,
+				"target":       flattenCrossVersionObjectReferenceV2(metric.Object.Target),
+				"target_value": metric.Object.TargetValue.String(),
+			}}
+		}
+		if metric.Pods != nil {
+			m["pods"] = []interface{}{map[string]interface{}{
+				"metric_name":          metric.Pods.MetricName,
+				"target_average_value": metric.Pods.TargetAverageValue.String(),
+			}}
+		}
+		if metric.Resource != nil {
+			res := map[string]interface{}{
+				"name": string(metric.Resource.Name),
+			}
+			if metric.Resource.TargetAverageUtilization != nil {
+				res["target_average_utilization"] = int(*metric.Resource.TargetAverageUtilization)
+			}
+			if metric.Resource.TargetAverageValue != nil {
+				res["target_average_value"] = metric.Resource.TargetAverageValue.String()
+			}
+			m["resource"] = []interface{}{res}
+		}
+		att[i] = m
+	}
+	return att
+}
+
+func flattenCrossVersionObjectReferenceV2(ref api.CrossVersionObjectReference) []interface{} {
+	m := make(map[string]interface{}, 0)
+	if ref.APIVersion != "" {
+		m["api_version"] = ref.APIVersion
+	}
+	if ref.Kind != "" {
+		m["kind"] = ref.Kind
+	}
+	if ref.Name != "" {
+		m["name"] = ref.Name
+	}
+	return []interface{}{m}
+}
+
+func flattenHorizontalPodAutoscalerV2Status(status api.HorizontalPodAutoscalerStatus) []interface{} {
+	m := map[string]interface{}{
+		"current_replicas": int(status.CurrentReplicas),
+		"desired_replicas": int(status.DesiredReplicas),
+	}
+	if status.LastScaleTime != nil {
+		m["last_scale_time"] = status.LastScaleTime.UTC().Format(time.RFC3339)
+	}
+	if status.ObservedGeneration != nil {
+		m["observed_generation"] = int(*status.ObservedGeneration)
+	}
+	return []interface{}{m}
+}
+
+// Patchers
+
+func patchHorizontalPodAutoscalerV2Spec(prefix string, pathPrefix string, d *schema.ResourceData) ([]PatchOperation, error) {
+	ops := make([]PatchOperation, 0)
+
+	if d.HasChange(prefix + "max_replicas") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  pathPrefix + "/maxReplicas",
+			Value: d.Get(prefix + "max_replicas").(int),
+		})
+	}
+	if d.HasChange(prefix + "min_replicas") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  pathPrefix + "/minReplicas",
+			Value: d.Get(prefix + "min_replicas").(int),
+		})
+	}
+	if d.HasChange(prefix + "scale_target_ref") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  pathPrefix + "/scaleTargetRef",
+			Value: expandCrossVersionObjectReferenceV2(d.Get(prefix + "scale_target_ref").([]interface{})),
+		})
+	}
+	if d.HasChange(prefix + "metric") {
+		metrics, err := expandMetricSpecs(d.Get(prefix + "metric").([]interface{}))
+		if err != nil {
+			return ops, err
+		}
+		// "add" replaces the metrics if they're already set
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "/metrics",
+			Value: metrics,
+		})
+	}
+
+	return ops, nil
+}
@@ -1,10 +1,73 @@
 package kubernetes
 
 import (
+	"encoding/json"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	batchv1 "k8s.io/api/batch/v1"
 )
 
+const (
+	jobCompletionModeNonIndexed = "NonIndexed"
+	jobCompletionModeIndexed    = "Indexed"
+)
+
+// jobExtras holds the fields of a job which the vendored API types don't know about yet.
+type jobExtras struct {
+	Spec jobSpecExtras `json:"spec"`
+}
+
+// jobSpecExtras holds the completion mode (Kubernetes 1.21+) and the suspension
+// (Kubernetes 1.21+) of a job.
+type jobSpecExtras struct {
+	CompletionMode string `json:"completionMode,omitempty"`
+	Suspend        *bool  `json:"suspend,omitempty"`
+}
+
+// decodeJob decodes the raw job returned by the API server
+// along with the fields the vendored types don't have.
+func decodeJob(raw []byte) (*batchv1.Job, jobExtras, error) {
+	var job batchv1.Job
+	var extras jobExtras
+	if err := json.Unmarshal(raw, &job); err != nil {
+		return nil, extras, err
+	}
+	if err := json.Unmarshal(raw, &extras); err != nil {
+		return nil, extras, err
+	}
+	return &job, extras, nil
+}
+
+// expandJobSpecExtras leaves out the defaults, so a job which doesn't
+// use the newer fields can be sent through the vendored types.
+func expandJobSpecExtras(j []interface{}) jobSpecExtras {
+	obj := jobSpecExtras{}
+	if len(j) == 0 || j[0] == nil {
+		return obj
+	}
+	in := j[0].(map[string]interface{})
+
+	if v, ok := in["completion_mode"].(string); ok && v != "" && v != jobCompletionModeNonIndexed {
+		obj.CompletionMode = v
+	}
+	if v, ok := in["suspend"].(bool); ok && v {
+		obj.Suspend = ptrToBool(v)
+	}
+	return obj
+}
+
+// flattenJobSpecExtras sets the newer fields into the flattened spec. A cluster
+// older than 1.21 doesn't return them, which reads as their defaults.
+func flattenJobSpecExtras(in jobSpecExtras, spec []interface{}) {
+	att := spec[0].(map[string]interface{})
+
+	att["completion_mode"] = jobCompletionModeNonIndexed
+	if in.CompletionMode != "" {
+		att["completion_mode"] = in.CompletionMode
+	}
+	att["suspend"] = in.Suspend != nil && *in.Suspend
+}
+
 func flattenJobSpec(in batchv1.JobSpec, d *schema.ResourceData) ([]interface{}, error) {
 	att := make(map[string]interface{})
 
@@ -1,6 +1,9 @@
 package kubernetes
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"k8s.io/api/core/v1"
 )
@@ -20,6 +23,108 @@ func flattenPersistentVolumeClaimSpec(in v1.PersistentVolumeClaimSpec) []interfa
 	if in.StorageClassName != nil {
 		att["storage_class_name"] = *in.StorageClassName
 	}
+	// Clusters without the BlockVolume feature don't return the volume mode
+	att["volume_mode"] = string(v1.PersistentVolumeFilesystem)
+	if in.VolumeMode != nil {
+		att["volume_mode"] = string(*in.VolumeMode)
+	}
+	return []interface{}{att}
+}
+
+// persistentVolumeClaimExtras holds the fields of a claim which the vendored
+// API types don't know about yet.
+type persistentVolumeClaimExtras struct {
+	Spec   persistentVolumeClaimSpecExtras   `json:"spec"`
+	Status persistentVolumeClaimStatusExtras `json:"status"`
+}
+
+// persistentVolumeClaimSpecExtras holds the volume attributes class of a claim
+// (Kubernetes 1.29+), which can be changed to modify e.g. the IOPS of the volume.
+type persistentVolumeClaimSpecExtras struct {
+	VolumeAttributesClassName string `json:"volumeAttributesClassName,omitempty"`
+}
+
+// persistentVolumeClaimStatusExtras holds the status fields set by Kubernetes 1.24+
+// during a volume expansion and by Kubernetes 1.29+ during a volume modification.
+type persistentVolumeClaimStatusExtras struct {
+	AllocatedResources               v1.ResourceList                          `json:"allocatedResources,omitempty"`
+	AllocatedResourceStatuses        map[string]string                        `json:"allocatedResourceStatuses,omitempty"`
+	CurrentVolumeAttributesClassName string                                   `json:"currentVolumeAttributesClassName,omitempty"`
+	ModifyVolumeStatus               *persistentVolumeClaimModifyVolumeStatus `json:"modifyVolumeStatus,omitempty"`
+}
+
+type persistentVolumeClaimModifyVolumeStatus struct {
+	TargetVolumeAttributesClassName string `json:"targetVolumeAttributesClassName,omitempty"`
+	Status                          string `json:"status"`
+}
+
+// decodePersistentVolumeClaim decodes the raw claim returned by the API server
+// along with the fields the vendored types don't have.
+func decodePersistentVolumeClaim(raw []byte) (*v1.PersistentVolumeClaim, persistentVolumeClaimExtras, error) {
+	var claim v1.PersistentVolumeClaim
+	var extras persistentVolumeClaimExtras
+	if err := json.Unmarshal(raw, &claim); err != nil {
+		return nil, extras, err
+	}
+	if err := json.Unmarshal(raw, &extras); err != nil {
+		return nil, extras, err
+	}
+	return &claim, extras, nil
+}
+
+func flattenBoundPersistentVolume(in *v1.PersistentVolume) []interface{} {
+	att := make(map[string]interface{})
+	att["name"] = in.Name
+	att["access_modes"] = flattenPersistentVolumeAccessModes(in.Spec.AccessModes)
+	att["capacity"] = flattenResourceList(in.Spec.Capacity)
+	att["storage_class_name"] = in.Spec.StorageClassName
+	att["reclaim_policy"] = string(in.Spec.PersistentVolumeReclaimPolicy)
+	if in.Spec.CSI != nil {
+		att["csi_driver"] = in.Spec.CSI.Driver
+		att["volume_handle"] = in.Spec.CSI.VolumeHandle
+	}
+	return []interface{}{att}
+}
+
+func flattenPersistentVolumeClaimStatus(in v1.PersistentVolumeClaimStatus, extras persistentVolumeClaimStatusExtras) []interface{} {
+	att := make(map[string]interface{})
+	att["phase"] = string(in.Phase)
+	if len(in.AccessModes) > 0 {
+		att["access_modes"] = flattenPersistentVolumeAccessModes(in.AccessModes)
+	}
+	if len(in.Capacity) > 0 {
+		att["capacity"] = flattenResourceList(in.Capacity)
+	}
+	if len(extras.AllocatedResources) > 0 {
+		att["allocated_resources"] = flattenResourceList(extras.AllocatedResources)
+	}
+	if len(extras.AllocatedResourceStatuses) > 0 {
+		att["allocated_resource_statuses"] = extras.AllocatedResourceStatuses
+	}
+	att["current_volume_attributes_class_name"] = extras.CurrentVolumeAttributesClassName
+	if s := extras.ModifyVolumeStatus; s != nil {
+		att["modify_volume_status"] = []interface{}{map[string]interface{}{
+			"target_volume_attributes_class_name": s.TargetVolumeAttributesClassName,
+			"status":                              s.Status,
+		}}
+	}
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		m := map[string]interface{}{
+			"type":    string(c.Type),
+			"status":  string(c.Status),
+			"reason":  c.Reason,
+			"message": c.Message,
+		}
+		if !c.LastProbeTime.IsZero() {
+			m["last_probe_time"] = c.LastProbeTime.UTC().Format(time.RFC3339)
+		}
+		if !c.LastTransitionTime.IsZero() {
+			m["last_transition_time"] = c.LastTransitionTime.UTC().Format(time.RFC3339)
+		}
+		conditions[i] = m
+	}
+	att["condition"] = conditions
 	return []interface{}{att}
 }
 
@@ -58,9 +163,44 @@ func expandPersistentVolumeClaimSpec(l []interface{}) (v1.PersistentVolumeClaimS
 	if v, ok := in["storage_class_name"].(string); ok && v != "" {
 		obj.StorageClassName = ptrToString(v)
 	}
+	if v, ok := in["volume_mode"].(string); ok && v != "" {
+		volumeMode := v1.PersistentVolumeMode(v)
+		obj.VolumeMode = &volumeMode
+	}
 	return obj, nil
 }
 
+// inheritStorageClassName sets the storage class of a claim which doesn't request
+// any class to the one of the volume it binds to, or may select. Terraform can't tell an
+// explicitly empty storage_class_name apart from an unset one, but binding to a
+// pre-provisioned volume without a class requires an empty (not nil) class,
+// otherwise the default storage class gets assigned and the binding fails.
+func inheritStorageClassName(spec *v1.PersistentVolumeClaimSpec, pv *v1.PersistentVolume) {
+	if spec.StorageClassName != nil || pv == nil {
+		return
+	}
+	spec.StorageClassName = ptrToString(pv.Spec.StorageClassName)
+}
+
+// availableVolumeOfSingleStorageClass returns one of the available volumes of pvs,
+// e.g. those matching the selector of a claim, when they all belong to the same
+// storage class, for the claim to inherit it with inheritStorageClassName.
+// The class to request is ambiguous otherwise, nil is returned.
+func availableVolumeOfSingleStorageClass(pvs []v1.PersistentVolume) *v1.PersistentVolume {
+	var found *v1.PersistentVolume
+	for i, pv := range pvs {
+		if pv.Status.Phase != v1.VolumeAvailable {
+			continue
+		}
+		if found == nil {
+			found = &pvs[i]
+		} else if found.Spec.StorageClassName != pv.Spec.StorageClassName {
+			return nil
+		}
+	}
+	return found
+}
+
 func expandResourceRequirements(l []interface{}) (v1.ResourceRequirements, error) {
 	if len(l) == 0 || l[0] == nil {
 		return v1.ResourceRequirements{}, nil
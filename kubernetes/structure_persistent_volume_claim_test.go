@@ -0,0 +1,617 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestInheritStorageClassName(t *testing.T) {
+	cases := map[string]struct {
+		StorageClassName string
+		VolumeClassName  string
+		Expected         string
+	}{
+		"empty class of pre-provisioned volume": {"", "", ""},
+		"class of volume":                       {"", "manual", "manual"},
+		"class of claim wins":                   {"fast", "manual", "fast"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			spec, err := expandPersistentVolumeClaimSpec([]interface{}{
+				map[string]interface{}{
+					"access_modes":       schema.NewSet(schema.HashString, []interface{}{"ReadWriteOnce"}),
+					"resources":          []interface{}{},
+					"volume_name":        "pre-provisioned",
+					"storage_class_name": tc.StorageClassName,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			inheritStorageClassName(&spec, &v1.PersistentVolume{
+				Spec: v1.PersistentVolumeSpec{StorageClassName: tc.VolumeClassName},
+			})
+			if spec.StorageClassName == nil {
+				t.Fatalf("Expected storage class name %q, given: nil", tc.Expected)
+			}
+			if *spec.StorageClassName != tc.Expected {
+				t.Fatalf("Expected storage class name %q, given: %q", tc.Expected, *spec.StorageClassName)
+			}
+		})
+	}
+}
+
+func TestAvailableVolumeOfSingleStorageClass(t *testing.T) {
+	volume := func(name, class string, phase v1.PersistentVolumePhase) v1.PersistentVolume {
+		return v1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1.PersistentVolumeSpec{StorageClassName: class},
+			Status:     v1.PersistentVolumeStatus{Phase: phase},
+		}
+	}
+	cases := map[string]struct {
+		Volumes  []v1.PersistentVolume
+		Expected string
+	}{
+		"none":           {nil, ""},
+		"single class":   {[]v1.PersistentVolume{volume("a", "manual", v1.VolumeAvailable), volume("b", "manual", v1.VolumeAvailable)}, "a"},
+		"empty class":    {[]v1.PersistentVolume{volume("a", "", v1.VolumeAvailable)}, "a"},
+		"mixed classes":  {[]v1.PersistentVolume{volume("a", "manual", v1.VolumeAvailable), volume("b", "", v1.VolumeAvailable)}, ""},
+		"bound ignored":  {[]v1.PersistentVolume{volume("a", "", v1.VolumeBound), volume("b", "manual", v1.VolumeAvailable)}, "b"},
+		"none available": {[]v1.PersistentVolume{volume("a", "manual", v1.VolumeReleased)}, ""},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pv := availableVolumeOfSingleStorageClass(tc.Volumes)
+			given := ""
+			if pv != nil {
+				given = pv.Name
+			}
+			if given != tc.Expected {
+				t.Fatalf("Expected volume %q, given: %q", tc.Expected, given)
+			}
+		})
+	}
+}
+
+func TestPersistentVolumeClaimVolumeMode(t *testing.T) {
+	block := v1.PersistentVolumeBlock
+	cases := map[string]struct {
+		Configured string
+		Expected   *v1.PersistentVolumeMode
+		Flattened  string
+	}{
+		"block":   {"Block", &block, "Block"},
+		"not set": {"", nil, "Filesystem"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			spec, err := expandPersistentVolumeClaimSpec([]interface{}{
+				map[string]interface{}{
+					"access_modes": schema.NewSet(schema.HashString, []interface{}{"ReadWriteOnce"}),
+					"resources":    []interface{}{},
+					"volume_mode":  tc.Configured,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (spec.VolumeMode == nil) != (tc.Expected == nil) || (spec.VolumeMode != nil && *spec.VolumeMode != *tc.Expected) {
+				t.Fatalf("Expected volume mode %v, given: %v", tc.Expected, spec.VolumeMode)
+			}
+
+			// Clusters without the BlockVolume feature don't return the mode, it's their default
+			flattened := flattenPersistentVolumeClaimSpec(spec)[0].(map[string]interface{})
+			if flattened["volume_mode"] != tc.Flattened {
+				t.Fatalf("Expected flattened volume mode %q, given: %q", tc.Flattened, flattened["volume_mode"])
+			}
+		})
+	}
+}
+
+func TestPersistentVolumeClaimSelectorRoundTrip(t *testing.T) {
+	cases := map[string][]interface{}{
+		"match_labels only": {map[string]interface{}{
+			"match_labels": map[string]interface{}{"tier": "ssd"},
+		}},
+		"match_expressions": {map[string]interface{}{
+			"match_labels": map[string]interface{}{"tier": "ssd"},
+			"match_expressions": []interface{}{map[string]interface{}{
+				"key":      "zone",
+				"operator": "In",
+				"values":   schema.NewSet(schema.HashString, []interface{}{"europe-west1-b", "europe-west1-c"}),
+			}},
+		}},
+	}
+
+	for name, selector := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"access_modes": schema.NewSet(schema.HashString, []interface{}{"ReadWriteOnce"}),
+				"resources":    []interface{}{},
+				"selector":     selector,
+			}
+			spec, err := expandPersistentVolumeClaimSpec([]interface{}{raw})
+			if err != nil {
+				t.Fatal(err)
+			}
+			flattened := flattenPersistentVolumeClaimSpec(spec)[0].(map[string]interface{})["selector"].([]interface{})[0].(map[string]interface{})
+			expected := selector[0].(map[string]interface{})
+
+			// No empty match_expressions shows up on a selector without any
+			if _, ok := expected["match_expressions"]; !ok {
+				if _, ok := flattened["match_expressions"]; ok || spec.Selector.MatchExpressions != nil {
+					t.Fatalf("Expected no match expressions, given: %#v", flattened)
+				}
+				return
+			}
+			requirements := flattened["match_expressions"].([]interface{})
+			if len(requirements) != 1 {
+				t.Fatalf("Expected 1 match expression, given: %#v", requirements)
+			}
+			r := requirements[0].(map[string]interface{})
+			if r["key"] != "zone" || fmt.Sprint(r["operator"]) != "In" || r["values"].(*schema.Set).Len() != 2 {
+				t.Fatalf("Unexpected match expression: %#v", r)
+			}
+		})
+	}
+}
+
+func TestPersistentVolumeClaimSelectorOperatorValidation(t *testing.T) {
+	for operator, valid := range map[string]bool{"In": true, "DoesNotExist": true, "Equals": false, "in": false} {
+		t.Run(operator, func(t *testing.T) {
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "claim"}},
+				"spec": []map[string]interface{}{{
+					"access_modes": []interface{}{"ReadWriteOnce"},
+					"resources":    []map[string]interface{}{{"requests": map[string]interface{}{"storage": "5Gi"}}},
+					"selector": []map[string]interface{}{{
+						"match_expressions": []map[string]interface{}{{"key": "zone", "operator": operator}},
+					}},
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, es := resourceKubernetesPersistentVolumeClaim().Validate(terraform.NewResourceConfig(raw))
+			if valid && len(es) > 0 {
+				t.Fatalf("Expected operator %q to be valid, given: %v", operator, es)
+			}
+			if !valid && len(es) == 0 {
+				t.Fatalf("Expected operator %q to be rejected", operator)
+			}
+		})
+	}
+}
+
+func TestFlattenPersistentVolumeClaimStatus(t *testing.T) {
+	transition := time.Date(2018, 7, 1, 12, 30, 0, 0, time.UTC)
+	in := v1.PersistentVolumeClaimStatus{
+		Phase:       v1.ClaimBound,
+		AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce, v1.ReadOnlyMany},
+		Capacity:    v1.ResourceList{v1.ResourceStorage: resource.MustParse("10Gi")},
+		Conditions: []v1.PersistentVolumeClaimCondition{
+			{
+				Type:               v1.PersistentVolumeClaimFileSystemResizePending,
+				Status:             v1.ConditionTrue,
+				Message:            "Waiting for user to (re-)start a pod to finish file system resize of volume on node.",
+				LastTransitionTime: metav1.NewTime(transition),
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, persistentVolumeClaimSpecFields(false), map[string]interface{}{})
+	if err := d.Set("status", flattenPersistentVolumeClaimStatus(in, persistentVolumeClaimStatusExtras{})); err != nil {
+		t.Fatalf("Failed to set flattened status: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"status.0.phase":                            "Bound",
+		"status.0.access_modes.#":                   2,
+		"status.0.capacity.storage":                 "10Gi",
+		"status.0.condition.#":                      1,
+		"status.0.condition.0.type":                 "FileSystemResizePending",
+		"status.0.condition.0.status":               "True",
+		"status.0.condition.0.last_transition_time": "2018-07-01T12:30:00Z",
+		"status.0.condition.0.last_probe_time":      "",
+	}
+	for k, v := range expected {
+		if given := d.Get(k); given != v {
+			t.Fatalf("Expected %s to be %#v, given %#v", k, v, given)
+		}
+	}
+}
+
+func TestDecodePersistentVolumeClaimExtras(t *testing.T) {
+	raw := []byte(`{"kind": "PersistentVolumeClaim", "apiVersion": "v1",
+	"metadata": {"name": "data", "namespace": "default"},
+	"spec": {"accessModes": ["ReadWriteOnce"], "resources": {"requests": {"storage": "20Gi"}}},
+	"status": {"phase": "Bound", "capacity": {"storage": "10Gi"},
+		"allocatedResources": {"storage": "20Gi"},
+		"allocatedResourceStatuses": {"storage": "ControllerResizeInProgress"},
+		"currentVolumeAttributesClassName": "silver",
+		"modifyVolumeStatus": {"targetVolumeAttributesClassName": "gold", "status": "InProgress"}}}`)
+
+	claim, extras, err := decodePersistentVolumeClaim(raw)
+	if err != nil {
+		t.Fatalf("Failed to decode claim: %s", err)
+	}
+	if claim.Name != "data" || claim.Status.Phase != v1.ClaimBound {
+		t.Fatalf("Claim wasn't decoded: %#v", claim)
+	}
+
+	d := schema.TestResourceDataRaw(t, persistentVolumeClaimSpecFields(false), map[string]interface{}{})
+	if err := d.Set("status", flattenPersistentVolumeClaimStatus(claim.Status, extras.Status)); err != nil {
+		t.Fatalf("Failed to set flattened status: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"status.0.capacity.storage":                                           "10Gi",
+		"status.0.allocated_resources.storage":                                "20Gi",
+		"status.0.allocated_resource_statuses.storage":                        "ControllerResizeInProgress",
+		"status.0.current_volume_attributes_class_name":                       "silver",
+		"status.0.modify_volume_status.0.target_volume_attributes_class_name": "gold",
+		"status.0.modify_volume_status.0.status":                              "InProgress",
+	}
+	for k, v := range expected {
+		if given := d.Get(k); given != v {
+			t.Fatalf("Expected %s to be %#v, given %#v", k, v, given)
+		}
+	}
+}
+
+func TestPersistentVolumeClaimResourcesRoundTrip(t *testing.T) {
+	in := v1.PersistentVolumeClaimSpec{
+		AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+		Resources: v1.ResourceRequirements{
+			Limits:   v1.ResourceList{v1.ResourceStorage: resource.MustParse("10Gi")},
+			Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("5Gi")},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, persistentVolumeClaimSpecFields(false), map[string]interface{}{})
+	if err := d.Set("spec", flattenPersistentVolumeClaimSpec(in)); err != nil {
+		t.Fatalf("Failed to set flattened spec: %s", err)
+	}
+	if given := d.Get("spec.0.resources.0.limits.storage"); given != "10Gi" {
+		t.Fatalf("Expected the storage limit to be flattened, given: %#v", given)
+	}
+
+	out, err := expandPersistentVolumeClaimSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatalf("Failed to expand spec: %s", err)
+	}
+	limit, request := out.Resources.Limits[v1.ResourceStorage], out.Resources.Requests[v1.ResourceStorage]
+	if limit.Cmp(resource.MustParse("10Gi")) != 0 || request.Cmp(resource.MustParse("5Gi")) != 0 {
+		t.Fatalf("Resources did not survive round trip.\nExpected: %#v\nGiven:    %#v", in.Resources, out.Resources)
+	}
+}
+
+func TestPersistentVolumeClaimResourcesDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "default/claim",
+		Attributes: map[string]string{
+			"metadata.#":                          "1",
+			"metadata.0.name":                     "claim",
+			"metadata.0.namespace":                "default",
+			"spec.#":                              "1",
+			"spec.0.access_modes.#":               "1",
+			"spec.0.access_modes.1245328686":      "ReadWriteOnce",
+			"spec.0.resources.#":                  "1",
+			"spec.0.resources.0.limits.%":         "1",
+			"spec.0.resources.0.limits.storage":   "10Gi",
+			"spec.0.resources.0.requests.%":       "1",
+			"spec.0.resources.0.requests.storage": "5Gi",
+			"spec.0.volume_mode":                  "Filesystem",
+			"wait_until_bound":                    "true",
+		},
+	}
+	r := resourceKubernetesPersistentVolumeClaim()
+	meta := &kubernetesProvider{immutableFieldBehavior: immutableFieldBehaviorRecreate}
+
+	cases := []struct {
+		Name          string
+		Limit         string
+		Request       string
+		ExpectedForce bool
+	}{
+		{"equivalent quantities", "10240Mi", "5Gi", false},
+		{"raised limit", "20Gi", "5Gi", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "claim"}},
+				"spec": []map[string]interface{}{{
+					"access_modes": []interface{}{"ReadWriteOnce"},
+					"resources": []map[string]interface{}{{
+						"limits":   map[string]interface{}{"storage": tc.Limit},
+						"requests": map[string]interface{}{"storage": tc.Request},
+					}},
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff, err := r.Diff(state, terraform.NewResourceConfig(raw), meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, changed := diff.GetAttribute("spec.0.resources.0.limits.storage")
+			if diff.RequiresNew() != tc.ExpectedForce || changed != tc.ExpectedForce {
+				t.Fatalf("Expected a change of the limit requiring a new claim: %t, given: %#v", tc.ExpectedForce, diff)
+			}
+		})
+	}
+}
+
+func TestPersistentVolumeClaimAccessModesOrder(t *testing.T) {
+	// Kubernetes may return the modes in any order
+	state := &terraform.InstanceState{
+		ID: "default/claim",
+		Attributes: map[string]string{
+			"metadata.#":            "1",
+			"metadata.0.name":       "claim",
+			"metadata.0.namespace":  "default",
+			"spec.#":                "1",
+			"spec.0.access_modes.#": "2",
+			"spec.0.access_modes." + strconv.Itoa(schema.HashString("ReadWriteOnce")): "ReadWriteOnce",
+			"spec.0.access_modes." + strconv.Itoa(schema.HashString("ReadOnlyMany")):  "ReadOnlyMany",
+			"spec.0.resources.#":                  "1",
+			"spec.0.resources.0.requests.%":       "1",
+			"spec.0.resources.0.requests.storage": "5Gi",
+			"spec.0.volume_mode":                  "Filesystem",
+			"wait_until_bound":                    "true",
+		},
+	}
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"metadata": []map[string]interface{}{{"name": "claim"}},
+		"spec": []map[string]interface{}{{
+			"access_modes": []interface{}{"ReadOnlyMany", "ReadWriteOnce"},
+			"resources": []map[string]interface{}{{
+				"requests": map[string]interface{}{"storage": "5Gi"},
+			}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &kubernetesProvider{immutableFieldBehavior: immutableFieldBehaviorRecreate}
+
+	diff, err := resourceKubernetesPersistentVolumeClaim().Diff(state, terraform.NewResourceConfig(raw), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k := range diff.CopyAttributes() {
+		if strings.HasPrefix(k, "spec.0.access_modes") {
+			t.Fatalf("Expected reordered access modes to show no diff, given: %#v", diff)
+		}
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("Expected reordered access modes not to recreate the claim, given: %#v", diff)
+	}
+}
+
+func TestPersistentVolumeClaimDefaultStorageClassDiff(t *testing.T) {
+	// The DefaultStorageClass admission plugin set the class of a claim created without one
+	state := &terraform.InstanceState{
+		ID: "default/claim",
+		Attributes: map[string]string{
+			"metadata.#":                          "1",
+			"metadata.0.name":                     "claim",
+			"metadata.0.namespace":                "default",
+			"spec.#":                              "1",
+			"spec.0.access_modes.#":               "1",
+			"spec.0.access_modes.1245328686":      "ReadWriteOnce",
+			"spec.0.resources.#":                  "1",
+			"spec.0.resources.0.requests.%":       "1",
+			"spec.0.resources.0.requests.storage": "5Gi",
+			"spec.0.storage_class_name":           "standard",
+			"spec.0.volume_mode":                  "Filesystem",
+			"wait_until_bound":                    "true",
+		},
+	}
+	r := resourceKubernetesPersistentVolumeClaim()
+	meta := &kubernetesProvider{immutableFieldBehavior: immutableFieldBehaviorRecreate}
+
+	cases := []struct {
+		Name          string
+		ClassName     interface{}
+		ExpectedForce bool
+	}{
+		{"unset", nil, false},
+		{"empty", "", false},
+		{"same class", "standard", false},
+		{"other class", "fast", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			spec := map[string]interface{}{
+				"access_modes": []interface{}{"ReadWriteOnce"},
+				"resources": []map[string]interface{}{{
+					"requests": map[string]interface{}{"storage": "5Gi"},
+				}},
+			}
+			if tc.ClassName != nil {
+				spec["storage_class_name"] = tc.ClassName
+			}
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "claim"}},
+				"spec":     []map[string]interface{}{spec},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff, err := r.Diff(state, terraform.NewResourceConfig(raw), meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, changed := diff.GetAttribute("spec.0.storage_class_name")
+			if changed != tc.ExpectedForce || diff.RequiresNew() != tc.ExpectedForce {
+				t.Fatalf("Expected a change of the storage class requiring a new claim: %t, given: %#v", tc.ExpectedForce, diff)
+			}
+		})
+	}
+}
+
+func TestPersistentVolumeClaimVolumeAttributesClassDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "default/claim",
+		Attributes: map[string]string{
+			"metadata.#":                          "1",
+			"metadata.0.name":                     "claim",
+			"metadata.0.namespace":                "default",
+			"spec.#":                              "1",
+			"spec.0.access_modes.#":               "1",
+			"spec.0.access_modes.1245328686":      "ReadWriteOnce",
+			"spec.0.resources.#":                  "1",
+			"spec.0.resources.0.requests.%":       "1",
+			"spec.0.resources.0.requests.storage": "5Gi",
+			"spec.0.volume_attributes_class_name": "silver",
+			"spec.0.volume_mode":                  "Filesystem",
+			"wait_until_bound":                    "true",
+		},
+	}
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"metadata": []map[string]interface{}{{"name": "claim"}},
+		"spec": []map[string]interface{}{{
+			"access_modes": []interface{}{"ReadWriteOnce"},
+			"resources": []map[string]interface{}{{
+				"requests": map[string]interface{}{"storage": "5Gi"},
+			}},
+			"volume_attributes_class_name": "gold",
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The class is changed in place, even when re-creates aren't allowed
+	meta := &kubernetesProvider{immutableFieldBehavior: immutableFieldBehaviorError}
+	diff, err := resourceKubernetesPersistentVolumeClaim().Diff(state, terraform.NewResourceConfig(raw), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attr, ok := diff.GetAttribute("spec.0.volume_attributes_class_name")
+	if !ok || attr.New != "gold" {
+		t.Fatalf("Expected the volume attributes class to change, given: %#v", diff)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("Expected the volume attributes class to be updated in place, given: %#v", diff)
+	}
+}
+
+func TestPersistentVolumeClaimSelectorWithProvisionerDiff(t *testing.T) {
+	var volumes string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/persistentvolumes" {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"kind": "PersistentVolumeList", "apiVersion": "v1", "items": [%s]}`, volumes)
+			return
+		}
+		provisioners := map[string]string{
+			"/apis/storage.k8s.io/v1/storageclasses/fast":  "kubernetes.io/gce-pd",
+			"/apis/storage.k8s.io/v1/storageclasses/local": "kubernetes.io/no-provisioner",
+		}
+		provisioner, ok := provisioners[r.URL.Path]
+		w.Header().Set("Content-Type", "application/json")
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound", "code": 404}`)
+			return
+		}
+		fmt.Fprintf(w, `{"kind": "StorageClass", "apiVersion": "storage.k8s.io/v1", "metadata": {"name": %q}, "provisioner": %q}`,
+			path.Base(r.URL.Path), provisioner)
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		StorageClass string
+		Selector     bool
+		VolumeName   string
+		Volumes      string
+		Error        bool
+	}{
+		"dynamic provisioning":      {StorageClass: "fast"},
+		"selector with provisioner": {StorageClass: "fast", Selector: true, Error: true},
+		"selector of an available volume": {StorageClass: "fast", Selector: true,
+			Volumes: `{"metadata": {"name": "pv-1"}, "spec": {"storageClassName": "fast"}, "status": {"phase": "Available"}}`},
+		"selector of a bound volume": {StorageClass: "fast", Selector: true, Error: true,
+			Volumes: `{"metadata": {"name": "pv-1"}, "spec": {"storageClassName": "fast"}, "status": {"phase": "Bound"}}`},
+		"selector of a volume of another class": {StorageClass: "fast", Selector: true, Error: true,
+			Volumes: `{"metadata": {"name": "pv-1"}, "spec": {"storageClassName": "slow"}, "status": {"phase": "Available"}}`},
+		"selector with named volume":   {StorageClass: "fast", Selector: true, VolumeName: "pv-1"},
+		"selector without provisioner": {StorageClass: "local", Selector: true},
+		"selector without class":       {StorageClass: "manual", Selector: true},
+		"selector of default class":    {Selector: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			volumes = tc.Volumes
+			spec := map[string]interface{}{
+				"access_modes": []interface{}{"ReadWriteOnce"},
+				"resources": []map[string]interface{}{{
+					"requests": map[string]interface{}{"storage": "5Gi"},
+				}},
+			}
+			if tc.StorageClass != "" {
+				spec["storage_class_name"] = tc.StorageClass
+			}
+			if tc.Selector {
+				spec["selector"] = []map[string]interface{}{{
+					"match_labels": map[string]interface{}{"disk": "ssd"},
+				}}
+			}
+			if tc.VolumeName != "" {
+				spec["volume_name"] = tc.VolumeName
+			}
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "claim"}},
+				"spec":     []map[string]interface{}{spec},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = resourceKubernetesPersistentVolumeClaim().Diff(nil, terraform.NewResourceConfig(raw), &kubernetesProvider{conn: conn})
+			if tc.Error {
+				if err == nil || !strings.Contains(err.Error(), `no Available volume of storage class "fast" matches it and the class provisions volumes dynamically with kubernetes.io/gce-pd`) {
+					t.Fatalf("Expected an error about the provisioner of the storage class, given: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, given: %s", err)
+			}
+		})
+	}
+}
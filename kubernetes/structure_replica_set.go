@@ -0,0 +1,88 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// Flatteners
+
+func flattenReplicaSetSpec(in appsv1.ReplicaSetSpec, d *schema.ResourceData) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	att["min_ready_seconds"] = in.MinReadySeconds
+	if in.Replicas != nil {
+		att["replicas"] = *in.Replicas
+	}
+	if in.Selector != nil {
+		att["selector"] = flattenLabelSelector(in.Selector)
+	}
+
+	template, err := flattenPodTemplateSpec(in.Template, d, "spec.0.template.0.")
+	if err != nil {
+		return nil, err
+	}
+	att["template"] = template
+
+	return []interface{}{att}, nil
+}
+
+// Expanders
+
+func expandReplicaSetSpec(l []interface{}) (appsv1.ReplicaSetSpec, error) {
+	obj := appsv1.ReplicaSetSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	obj.MinReadySeconds = int32(in["min_ready_seconds"].(int))
+	obj.Replicas = ptrToInt32(int32(in["replicas"].(int)))
+
+	if v, ok := in["selector"].([]interface{}); ok && len(v) > 0 {
+		obj.Selector = expandLabelSelector(v)
+	}
+
+	for _, v := range in["template"].([]interface{}) {
+		template, err := expandPodTemplateSpec(v.(map[string]interface{}))
+		if err != nil {
+			return obj, err
+		}
+		obj.Template = template
+	}
+
+	return obj, nil
+}
+
+// Patchers
+
+func patchReplicaSetSpec(pathPrefix, prefix string, d *schema.ResourceData) (PatchOperations, error) {
+	ops := make([]PatchOperation, 0)
+
+	if d.HasChange(prefix + "min_ready_seconds") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  pathPrefix + "/minReadySeconds",
+			Value: d.Get(prefix + "min_ready_seconds").(int),
+		})
+	}
+
+	if d.HasChange(prefix + "replicas") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  pathPrefix + "/replicas",
+			Value: d.Get(prefix + "replicas").(int),
+		})
+	}
+
+	if d.HasChange(prefix + "template") {
+		template, err := expandPodTemplateSpec(d.Get(prefix + "template.0").(map[string]interface{}))
+		if err != nil {
+			return ops, err
+		}
+		ops = append(ops, &ReplaceOperation{
+			Path:  pathPrefix + "/template",
+			Value: template,
+		})
+	}
+
+	return ops, nil
+}
@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"encoding/json"
+
 	gversion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform/helper/schema"
 	"k8s.io/api/core/v1"
@@ -21,7 +23,7 @@ func flattenServicePort(in []v1.ServicePort) []interface{} {
 		m["name"] = n.Name
 		m["protocol"] = string(n.Protocol)
 		m["port"] = int(n.Port)
-		m["target_port"] = flattenIntOrString(n.TargetPort)
+		m["target_port"] = n.TargetPort.String()
 		m["node_port"] = int(n.NodePort)
 
 		att[i] = m
@@ -73,6 +75,30 @@ func flattenServiceSpec(in v1.ServiceSpec) []interface{} {
 	return []interface{}{att}
 }
 
+// serviceSpecExtras holds the fields of a service spec which the vendored API types
+// don't know about yet: the dual-stack fields (Kubernetes 1.20+) & the internal traffic
+// policy (Kubernetes 1.22+). They're sent & read by (de)serializing the service next to
+// these fields.
+type serviceSpecExtras struct {
+	IPFamilies            []string `json:"ipFamilies,omitempty"`
+	IPFamilyPolicy        string   `json:"ipFamilyPolicy,omitempty"`
+	InternalTrafficPolicy string   `json:"internalTrafficPolicy,omitempty"`
+}
+
+func flattenServiceSpecExtras(in serviceSpecExtras, spec []interface{}) []interface{} {
+	att := spec[0].(map[string]interface{})
+	if len(in.IPFamilies) > 0 {
+		att["ip_families"] = in.IPFamilies
+	}
+	if in.IPFamilyPolicy != "" {
+		att["ip_family_policy"] = in.IPFamilyPolicy
+	}
+	if in.InternalTrafficPolicy != "" {
+		att["internal_traffic_policy"] = in.InternalTrafficPolicy
+	}
+	return spec
+}
+
 func flattenLoadBalancerIngress(in []v1.LoadBalancerIngress) []interface{} {
 	out := make([]interface{}, len(in), len(in))
 	for i, ingress := range in {
@@ -116,8 +142,11 @@ func expandServicePort(l []interface{}) []v1.ServicePort {
 	for i, n := range l {
 		cfg := n.(map[string]interface{})
 		obj[i] = v1.ServicePort{
-			Port:       int32(cfg["port"].(int)),
-			TargetPort: expandIntOrString(cfg["target_port"].(int)),
+			Port: int32(cfg["port"].(int)),
+		}
+		// Left empty, the API server defaults it to the port
+		if v, ok := cfg["target_port"].(string); ok && v != "" {
+			obj[i].TargetPort = expandPort(v)
 		}
 		if v, ok := cfg["name"].(string); ok {
 			obj[i].Name = v
@@ -183,6 +212,77 @@ func expandServiceSpec(l []interface{}) v1.ServiceSpec {
 	return obj
 }
 
+func expandServiceSpecExtras(l []interface{}) serviceSpecExtras {
+	obj := serviceSpecExtras{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+	if v, ok := in["ip_families"].([]interface{}); ok && len(v) > 0 {
+		obj.IPFamilies = sliceOfString(v)
+	}
+	if v, ok := in["ip_family_policy"].(string); ok {
+		obj.IPFamilyPolicy = v
+	}
+	if v, ok := in["internal_traffic_policy"].(string); ok {
+		obj.InternalTrafficPolicy = v
+	}
+	return obj
+}
+
+// encodeService serializes the service along with the fields unknown to the vendored API types.
+func encodeService(svc *v1.Service, extras serviceSpecExtras) ([]byte, error) {
+	obj, err := toJSONMap(svc)
+	if err != nil {
+		return nil, err
+	}
+	spec, ok := obj["spec"].(map[string]interface{})
+	if !ok {
+		spec = make(map[string]interface{})
+		obj["spec"] = spec
+	}
+	if len(extras.IPFamilies) > 0 {
+		spec["ipFamilies"] = extras.IPFamilies
+	}
+	if extras.IPFamilyPolicy != "" {
+		spec["ipFamilyPolicy"] = extras.IPFamilyPolicy
+	}
+	if extras.InternalTrafficPolicy != "" {
+		spec["internalTrafficPolicy"] = extras.InternalTrafficPolicy
+	}
+	return json.Marshal(obj)
+}
+
+// decodeService decodes the raw service returned by the API server along with the fields
+// unknown to the vendored API types.
+func decodeService(raw []byte) (*v1.Service, serviceSpecExtras, error) {
+	var svc v1.Service
+	var extras struct {
+		Spec serviceSpecExtras `json:"spec"`
+	}
+	if err := json.Unmarshal(raw, &svc); err != nil {
+		return nil, extras.Spec, err
+	}
+	if err := json.Unmarshal(raw, &extras); err != nil {
+		return nil, extras.Spec, err
+	}
+	return &svc, extras.Spec, nil
+}
+
+// isIPFamilyRemoval reports whether the new IP families drop or reorder any of the old ones.
+// Only a secondary family can be added to the families of an existing service.
+func isIPFamilyRemoval(oldFamilies, newFamilies []interface{}) bool {
+	if len(newFamilies) < len(oldFamilies) {
+		return true
+	}
+	for i, f := range oldFamilies {
+		if newFamilies[i] != f {
+			return true
+		}
+	}
+	return false
+}
+
 func expandSessionAffinityConfig(l []interface{}) *v1.SessionAffinityConfig {
 	if len(l) == 0 || l[0] == nil {
 		return nil
@@ -230,6 +330,20 @@ func patchServiceSpec(keyPrefix, pathPrefix string, d *schema.ResourceData, v *v
 			Value: d.Get(keyPrefix + "session_affinity").(string),
 		})
 	}
+	if d.HasChange(keyPrefix+"session_affinity") || d.HasChange(keyPrefix+"session_affinity_config") {
+		// The config must be removed when the affinity is `None`, which
+		// sending null does. The API server defaults it for `ClientIP`.
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "sessionAffinityConfig",
+			Value: expandSessionAffinityConfig(d.Get(keyPrefix + "session_affinity_config").([]interface{})),
+		})
+	}
+	if d.HasChange(keyPrefix + "publish_not_ready_addresses") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "publishNotReadyAddresses",
+			Value: d.Get(keyPrefix + "publish_not_ready_addresses").(bool),
+		})
+	}
 	if d.HasChange(keyPrefix + "load_balancer_ip") {
 		ops = append(ops, &ReplaceOperation{
 			Path:  pathPrefix + "loadBalancerIP",
@@ -273,6 +387,24 @@ func patchServiceSpec(keyPrefix, pathPrefix string, d *schema.ResourceData, v *v
 			Value: d.Get(keyPrefix + "external_ips").(*schema.Set).List(),
 		})
 	}
+	if d.HasChange(keyPrefix + "ip_families") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "ipFamilies",
+			Value: d.Get(keyPrefix + "ip_families").([]interface{}),
+		})
+	}
+	if d.HasChange(keyPrefix + "ip_family_policy") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "ipFamilyPolicy",
+			Value: d.Get(keyPrefix + "ip_family_policy").(string),
+		})
+	}
+	if d.HasChange(keyPrefix + "internal_traffic_policy") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "internalTrafficPolicy",
+			Value: d.Get(keyPrefix + "internal_traffic_policy").(string),
+		})
+	}
 	if d.HasChange(keyPrefix + "external_name") {
 		ops = append(ops, &ReplaceOperation{
 			Path:  pathPrefix + "externalName",
@@ -0,0 +1,173 @@
+package kubernetes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+func TestPatchServiceSpec_sessionAffinityAndPublishNotReadyAddresses(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceKubernetesService().Schema, map[string]interface{}{
+		"metadata": []interface{}{
+			map[string]interface{}{"name": "web"},
+		},
+		"spec": []interface{}{
+			map[string]interface{}{
+				"publish_not_ready_addresses": true,
+				"session_affinity":            "ClientIP",
+				"session_affinity_config": []interface{}{
+					map[string]interface{}{
+						"client_ip_config": []interface{}{
+							map[string]interface{}{"timeout_seconds": 60},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	ops, err := patchServiceSpec("spec.0.", "/spec/", d, &version.Info{GitVersion: "v1.11.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := make(map[string]interface{})
+	for _, op := range ops {
+		if add, ok := op.(*AddOperation); ok {
+			values[add.Path] = add.Value
+		}
+	}
+
+	if v, ok := values["/spec/publishNotReadyAddresses"]; !ok || v != true {
+		t.Fatalf("Expected publishNotReadyAddresses to be patched to true, given: %#v", ops)
+	}
+	cfg, ok := values["/spec/sessionAffinityConfig"].(*v1.SessionAffinityConfig)
+	if !ok || cfg == nil || cfg.ClientIP == nil || *cfg.ClientIP.TimeoutSeconds != 60 {
+		t.Fatalf("Expected sessionAffinityConfig to be patched with a timeout of 60 seconds, given: %#v", ops)
+	}
+}
+
+func TestServiceIPFamiliesRoundTrip(t *testing.T) {
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.ServiceSpec{
+			Type:  v1.ServiceTypeClusterIP,
+			Ports: []v1.ServicePort{{Port: 80}},
+		},
+	}
+	families := serviceSpecExtras{
+		IPFamilies:            []string{"IPv6", "IPv4"},
+		IPFamilyPolicy:        "RequireDualStack",
+		InternalTrafficPolicy: "Local",
+	}
+
+	data, err := encodeService(svc, families)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"ipFamilies":["IPv6","IPv4"]`) || !strings.Contains(string(data), `"ipFamilyPolicy":"RequireDualStack"`) {
+		t.Fatalf("Expected the encoded service to include the IP families, given: %s", data)
+	}
+	if !strings.Contains(string(data), `"internalTrafficPolicy":"Local"`) {
+		t.Fatalf("Expected the encoded service to include the internal traffic policy, given: %s", data)
+	}
+
+	out, outFamilies, err := decodeService(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "web" || out.Spec.Ports[0].Port != 80 {
+		t.Fatalf("Service wasn't decoded: %#v", out)
+	}
+	if !reflect.DeepEqual(families, outFamilies) {
+		t.Fatalf("Expected IP families %#v, given: %#v", families, outFamilies)
+	}
+
+	spec := flattenServiceSpecExtras(outFamilies, flattenServiceSpec(out.Spec))
+	att := spec[0].(map[string]interface{})
+	if att["ip_family_policy"] != "RequireDualStack" || !reflect.DeepEqual(att["ip_families"], []string{"IPv6", "IPv4"}) {
+		t.Fatalf("Expected the IP families to be flattened, given: %#v", att)
+	}
+	if att["internal_traffic_policy"] != "Local" {
+		t.Fatalf("Expected the internal traffic policy to be flattened, given: %#v", att)
+	}
+
+	data, err = encodeService(svc, serviceSpecExtras{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "ipFamil") || strings.Contains(string(data), "internalTrafficPolicy") {
+		t.Fatalf("Expected no IP families or internal traffic policy to be sent to clusters which predate them, given: %s", data)
+	}
+}
+
+func TestIsIPFamilyRemoval(t *testing.T) {
+	cases := []struct {
+		Old, New []interface{}
+		Expected bool
+	}{
+		{[]interface{}{}, []interface{}{"IPv4"}, false},
+		{[]interface{}{"IPv4"}, []interface{}{"IPv4", "IPv6"}, false},
+		{[]interface{}{"IPv4", "IPv6"}, []interface{}{"IPv4"}, true},
+		{[]interface{}{"IPv4"}, []interface{}{"IPv6"}, true},
+		{[]interface{}{"IPv4", "IPv6"}, []interface{}{"IPv6", "IPv4"}, true},
+	}
+	for _, tc := range cases {
+		if given := isIPFamilyRemoval(tc.Old, tc.New); given != tc.Expected {
+			t.Fatalf("Expected removal of %v => %v to be %t", tc.Old, tc.New, tc.Expected)
+		}
+	}
+}
+
+func TestServicePortTargetPortRoundTrip(t *testing.T) {
+	cases := []struct {
+		TargetPort string
+		Expected   intstr.IntOrString
+	}{
+		{"8080", intstr.FromInt(8080)},
+		{"http", intstr.FromString("http")},
+		// Defaulted to the port by the API server
+		{"", intstr.IntOrString{}},
+	}
+
+	for _, tc := range cases {
+		ports := expandServicePort([]interface{}{map[string]interface{}{
+			"name":        "web",
+			"port":        80,
+			"target_port": tc.TargetPort,
+			"node_port":   0,
+			"protocol":    "TCP",
+		}})
+		if ports[0].TargetPort != tc.Expected {
+			t.Fatalf("%q: expected target port %#v, given: %#v", tc.TargetPort, tc.Expected, ports[0].TargetPort)
+		}
+		if tc.TargetPort == "" {
+			continue
+		}
+		if flattened := flattenServicePort(ports)[0].(map[string]interface{})["target_port"]; flattened != tc.TargetPort {
+			t.Fatalf("%q: expected the target port to be flattened as is, given: %#v", tc.TargetPort, flattened)
+		}
+	}
+}
+
+func TestServicePortTargetPortValidation(t *testing.T) {
+	valid := []string{"1", "8080", "65535", "http", "metrics-1"}
+	for _, v := range valid {
+		if _, es := validatePortNumOrName(v, "target_port"); len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", v, es)
+		}
+	}
+
+	invalid := []string{"0", "65536", "HTTP", "http_port", "-http", "a-very-long-port-name", "1234-5"}
+	for _, v := range invalid {
+		if _, es := validatePortNumOrName(v, "target_port"); len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", v)
+		}
+	}
+}
@@ -6,16 +6,24 @@ import (
 	"log"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/mitchellh/copystructure"
 	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sSchema "k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// idParts splits the id of an object into its namespace and name.
+// A bare name is the id of a cluster-scoped object, e.g. an imported one.
 func idParts(id string) (string, string, error) {
 	parts := strings.Split(id, "/")
+	if len(parts) == 1 && parts[0] != "" {
+		return "", parts[0], nil
+	}
 	if len(parts) != 2 {
 		err := fmt.Errorf("Unexpected ID format (%q), expected %q.", id, "namespace/name")
 		return "", "", err
@@ -28,6 +36,40 @@ func buildId(meta metav1.ObjectMeta) string {
 	return meta.Namespace + "/" + meta.Name
 }
 
+// alreadyExistsError replaces the message of err, the raw 409 AlreadyExists error of a create,
+// explaining how to bring the existing object under Terraform's management. Each of hints, e.g. an
+// argument of the resource adopting the object, is appended as a paragraph of its own.
+func alreadyExistsError(err error, resourceType string, meta metav1.ObjectMeta, hints ...string) error {
+	return &objectExistsError{err: err, resourceType: resourceType, id: buildId(meta), hints: hints}
+}
+
+// objectExistsError is the error of alreadyExistsError. It keeps the status of the
+// failed create, so the error is still reported as a 409 AlreadyExists.
+type objectExistsError struct {
+	err          error
+	resourceType string
+	id           string
+	hints        []string
+}
+
+func (e *objectExistsError) Error() string {
+	msg := fmt.Sprintf("%s %q already exists in the cluster. To manage it with Terraform, import it into the state instead of creating it:\n\n  terraform import %s.<name> %s",
+		e.resourceType, e.id, e.resourceType, e.id)
+	for _, h := range e.hints {
+		msg += "\n\n" + h
+	}
+	return msg
+}
+
+// Status returns the status of the failed create, or the one of a 409 AlreadyExists
+// when err isn't an API error, so errors.IsAlreadyExists keeps working with the error.
+func (e *objectExistsError) Status() metav1.Status {
+	if status, ok := e.err.(errors.APIStatus); ok {
+		return status.Status()
+	}
+	return errors.NewAlreadyExists(k8sSchema.GroupResource{}, e.id).ErrStatus
+}
+
 func expandMetadata(in []interface{}) metav1.ObjectMeta {
 	meta := metav1.ObjectMeta{}
 	if len(in) < 1 {
@@ -54,21 +96,85 @@ func expandMetadata(in []interface{}) metav1.ObjectMeta {
 	return meta
 }
 
-func patchMetadata(keyPrefix, pathPrefix string, d *schema.ResourceData) PatchOperations {
+// expandMetadataWithDefaults expands the metadata block and merges in the
+// provider level default_labels & default_annotations.
+// Values set on the resource take precedence over the provider defaults.
+func expandMetadataWithDefaults(in []interface{}, meta interface{}) metav1.ObjectMeta {
+	obj := expandMetadata(in)
+	kp, ok := meta.(*kubernetesProvider)
+	if !ok {
+		return obj
+	}
+	obj.Labels = mergeDefaultStringMap(kp.defaultLabels, obj.Labels)
+	obj.Annotations = mergeDefaultStringMap(kp.defaultAnnotations, obj.Annotations)
+	return obj
+}
+
+func mergeDefaultStringMap(defaults, m map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return m
+	}
+	out := make(map[string]string, len(defaults)+len(m))
+	for k, v := range defaults {
+		out[k] = v
+	}
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// patchMetadataWithDefaults patches the labels & annotations of the metadata of resources which merge in the
+// provider level default_labels & default_annotations. The defaults are on the object but hidden from the state,
+// so they're diffed along with the configured keys: the first configured key doesn't replace the whole map on the object
+// and removing a key which overrode a default restores the default.
+func patchMetadataWithDefaults(d *schema.ResourceData, meta interface{}) PatchOperations {
+	labels, annotations := providerDefaultMetadata(meta)
+	return patchMetadataOnto(d, labels, annotations)
+}
+
+func providerDefaultMetadata(meta interface{}) (map[string]string, map[string]string) {
+	kp, ok := meta.(*kubernetesProvider)
+	if !ok {
+		return nil, nil
+	}
+	return kp.defaultLabels, kp.defaultAnnotations
+}
+
+// patchMetadataOnto diffs the labels & annotations of the metadata merged onto the ones which are
+// on the object without being in the state.
+func patchMetadataOnto(d *schema.ResourceData, labels, annotations map[string]string) PatchOperations {
 	ops := make([]PatchOperation, 0, 0)
-	if d.HasChange(keyPrefix + "annotations") {
-		oldV, newV := d.GetChange(keyPrefix + "annotations")
-		diffOps := diffStringMap(pathPrefix+"annotations", oldV.(map[string]interface{}), newV.(map[string]interface{}))
-		ops = append(ops, diffOps...)
+	if d.HasChange("metadata.0.annotations") {
+		oldV, newV := d.GetChange("metadata.0.annotations")
+		ops = append(ops, diffStringMapOnto("/metadata/annotations", annotations, oldV.(map[string]interface{}), newV.(map[string]interface{}))...)
 	}
-	if d.HasChange(keyPrefix + "labels") {
-		oldV, newV := d.GetChange(keyPrefix + "labels")
-		diffOps := diffStringMap(pathPrefix+"labels", oldV.(map[string]interface{}), newV.(map[string]interface{}))
-		ops = append(ops, diffOps...)
+	if d.HasChange("metadata.0.labels") {
+		oldV, newV := d.GetChange("metadata.0.labels")
+		ops = append(ops, diffStringMapOnto("/metadata/labels", labels, oldV.(map[string]interface{}), newV.(map[string]interface{}))...)
 	}
 	return ops
 }
 
+// diffStringMapOnto is diffStringMap for maps which are merged onto base on the object.
+func diffStringMapOnto(pathPrefix string, base map[string]string, oldV, newV map[string]interface{}) PatchOperations {
+	return diffStringMap(pathPrefix, mergeOntoStringMap(base, oldV), mergeOntoStringMap(base, newV))
+}
+
+func mergeOntoStringMap(base map[string]string, m map[string]interface{}) map[string]interface{} {
+	if len(base) == 0 {
+		return m
+	}
+	out := make(map[string]interface{}, len(base)+len(m))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
 func expandStringMap(m map[string]interface{}) map[string]string {
 	result := make(map[string]string)
 	for k, v := range m {
@@ -105,6 +211,8 @@ func flattenMetadata(meta metav1.ObjectMeta, d *schema.ResourceData, metaPrefix
 	}
 	configAnnotations := d.Get(prefix + "metadata.0.annotations").(map[string]interface{})
 	m["annotations"] = removeInternalKeys(meta.Annotations, configAnnotations)
+	// Only kept in the state, it's not part of the object
+	m["trim_annotation_whitespace"], _ = d.Get(prefix + "metadata.0.trim_annotation_whitespace").(bool)
 	if meta.GenerateName != "" {
 		m["generate_name"] = meta.GenerateName
 	}
@@ -115,6 +223,9 @@ func flattenMetadata(meta metav1.ObjectMeta, d *schema.ResourceData, metaPrefix
 	m["self_link"] = meta.SelfLink
 	m["uid"] = fmt.Sprintf("%v", meta.UID)
 	m["generation"] = meta.Generation
+	if !meta.CreationTimestamp.IsZero() {
+		m["creation_timestamp"] = meta.CreationTimestamp.UTC().Format(time.RFC3339)
+	}
 
 	if meta.Namespace != "" {
 		m["namespace"] = meta.Namespace
@@ -123,17 +234,46 @@ func flattenMetadata(meta metav1.ObjectMeta, d *schema.ResourceData, metaPrefix
 	return []map[string]interface{}{m}
 }
 
+// flattenMetadataWithoutDefaults flattens the metadata and hides the labels &
+// annotations injected via the provider level defaults, so they're not reported
+// as drift. Keys which are also set on the resource are kept.
+func flattenMetadataWithoutDefaults(om metav1.ObjectMeta, d *schema.ResourceData, meta interface{}) []map[string]interface{} {
+	m := flattenMetadata(om, d)
+	kp, ok := meta.(*kubernetesProvider)
+	if !ok {
+		return m
+	}
+	m[0]["labels"] = removeDefaultKeys(m[0]["labels"].(map[string]string), kp.defaultLabels,
+		d.Get("metadata.0.labels").(map[string]interface{}))
+	m[0]["annotations"] = removeDefaultKeys(m[0]["annotations"].(map[string]string), kp.defaultAnnotations,
+		d.Get("metadata.0.annotations").(map[string]interface{}))
+	return m
+}
+
+func removeDefaultKeys(m, defaults map[string]string, config map[string]interface{}) map[string]string {
+	for k := range defaults {
+		if !isKeyInMap(k, config) {
+			delete(m, k)
+		}
+	}
+	return m
+}
+
 func flattenSubMetadata(meta metav1.ObjectMeta, d *schema.ResourceData, prefix string) []map[string]interface{} {
 	m := make(map[string]interface{})
 
 	configAnnotations := d.Get(prefix + ".metadata.0.annotations").(map[string]interface{})
 	m["annotations"] = removeInternalKeys(meta.Annotations, configAnnotations)
+	m["trim_annotation_whitespace"], _ = d.Get(prefix + ".metadata.0.trim_annotation_whitespace").(bool)
 	m["labels"] = meta.Labels
 	m["name"] = meta.Name
 	m["resource_version"] = meta.ResourceVersion
 	m["self_link"] = meta.SelfLink
 	m["uid"] = fmt.Sprintf("%v", meta.UID)
 	m["generation"] = meta.Generation
+	if !meta.CreationTimestamp.IsZero() {
+		m["creation_timestamp"] = meta.CreationTimestamp.UTC().Format(time.RFC3339)
+	}
 
 	if meta.Namespace != "" {
 		m["namespace"] = meta.Namespace
@@ -277,6 +417,9 @@ func flattenResourceQuotaSpec(in api.ResourceQuotaSpec) []interface{} {
 	m := make(map[string]interface{}, 0)
 	m["hard"] = flattenResourceList(in.Hard)
 	m["scopes"] = flattenResourceQuotaScopes(in.Scopes)
+	if in.ScopeSelector != nil {
+		m["scope_selector"] = flattenResourceQuotaScopeSelector(in.ScopeSelector)
+	}
 
 	out[0] = m
 	return out
@@ -301,9 +444,84 @@ func expandResourceQuotaSpec(s []interface{}) (api.ResourceQuotaSpec, error) {
 		out.Scopes = expandResourceQuotaScopes(v.(*schema.Set).List())
 	}
 
+	if v, ok := m["scope_selector"].([]interface{}); ok && len(v) > 0 {
+		selector, err := expandResourceQuotaScopeSelector(v)
+		if err != nil {
+			return out, err
+		}
+		out.ScopeSelector = selector
+	}
+
 	return out, nil
 }
 
+func flattenResourceQuotaScopeSelector(in *api.ScopeSelector) []interface{} {
+	exprs := make([]interface{}, len(in.MatchExpressions), len(in.MatchExpressions))
+	for i, e := range in.MatchExpressions {
+		exprs[i] = map[string]interface{}{
+			"scope_name": string(e.ScopeName),
+			"operator":   string(e.Operator),
+			"values":     newStringSet(schema.HashString, e.Values),
+		}
+	}
+	return []interface{}{map[string]interface{}{
+		"match_expressions": exprs,
+	}}
+}
+
+func expandResourceQuotaScopeSelector(l []interface{}) (*api.ScopeSelector, error) {
+	obj := &api.ScopeSelector{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	exprs := in["match_expressions"].([]interface{})
+	obj.MatchExpressions = make([]api.ScopedResourceSelectorRequirement, len(exprs), len(exprs))
+	for i, e := range exprs {
+		if e == nil {
+			return nil, fmt.Errorf("scope_selector.match_expressions.%d must not be empty", i)
+		}
+		m := e.(map[string]interface{})
+		req := api.ScopedResourceSelectorRequirement{
+			ScopeName: api.ResourceQuotaScope(m["scope_name"].(string)),
+			Operator:  api.ScopeSelectorOperator(m["operator"].(string)),
+		}
+		if v, ok := m["values"].(*schema.Set); ok {
+			req.Values = sliceOfString(v.List())
+		}
+		if err := validateScopedResourceSelectorRequirement(req); err != nil {
+			return nil, fmt.Errorf("scope_selector.match_expressions.%d: %s", i, err)
+		}
+		obj.MatchExpressions[i] = req
+	}
+	return obj, nil
+}
+
+// validateScopedResourceSelectorRequirement checks the combination of scope,
+// operator & values in the same way the API server would, so mistakes surface
+// before anything gets created.
+func validateScopedResourceSelectorRequirement(req api.ScopedResourceSelectorRequirement) error {
+	switch req.Operator {
+	case api.ScopeSelectorOpIn, api.ScopeSelectorOpNotIn:
+		if len(req.Values) == 0 {
+			return fmt.Errorf("values must be specified when operator is %q", req.Operator)
+		}
+	case api.ScopeSelectorOpExists, api.ScopeSelectorOpDoesNotExist:
+		if len(req.Values) > 0 {
+			return fmt.Errorf("values must be empty when operator is %q", req.Operator)
+		}
+	default:
+		return fmt.Errorf("unsupported operator %q", req.Operator)
+	}
+
+	// Only the PriorityClass scope takes values, the others can only be required
+	if req.ScopeName != api.ResourceQuotaScopePriorityClass && req.Operator != api.ScopeSelectorOpExists {
+		return fmt.Errorf("operator must be %q for scope %q", api.ScopeSelectorOpExists, req.ScopeName)
+	}
+	return nil
+}
+
 func flattenResourceQuotaScopes(in []api.ResourceQuotaScope) *schema.Set {
 	out := make([]string, len(in), len(in))
 	for i, scope := range in {
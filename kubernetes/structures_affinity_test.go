@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAffinityRoundTrip(t *testing.T) {
+	cases := map[string]*v1.Affinity{
+		"node_affinity": {
+			NodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{
+						{
+							MatchExpressions: []v1.NodeSelectorRequirement{
+								{
+									Key:      "kubernetes.io/e2e-az-name",
+									Operator: v1.NodeSelectorOpIn,
+									Values:   []string{"e2e-az1"},
+								},
+							},
+						},
+					},
+				},
+				PreferredDuringSchedulingIgnoredDuringExecution: []v1.PreferredSchedulingTerm{
+					{
+						Weight: 1,
+						Preference: v1.NodeSelectorTerm{
+							MatchExpressions: []v1.NodeSelectorRequirement{
+								{
+									Key:      "another-node-label-key",
+									Operator: v1.NodeSelectorOpExists,
+									Values:   []string{},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"pod_affinity": {
+			PodAffinity: &v1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{
+					{
+						LabelSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{
+									Key:      "security",
+									Operator: metav1.LabelSelectorOpIn,
+									Values:   []string{"S1"},
+								},
+							},
+						},
+						Namespaces:  []string{},
+						TopologyKey: "failure-domain.beta.kubernetes.io/zone",
+					},
+				},
+				PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+					{
+						Weight: 100,
+						PodAffinityTerm: v1.PodAffinityTerm{
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"app": "web"},
+							},
+							Namespaces:  []string{"default"},
+							TopologyKey: "kubernetes.io/hostname",
+						},
+					},
+				},
+			},
+		},
+		"pod_anti_affinity": {
+			PodAntiAffinity: &v1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{
+					{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "db"},
+						},
+						Namespaces:  []string{},
+						TopologyKey: "kubernetes.io/hostname",
+					},
+				},
+				PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+					{
+						Weight: 50,
+						PodAffinityTerm: v1.PodAffinityTerm{
+							LabelSelector: &metav1.LabelSelector{
+								MatchExpressions: []metav1.LabelSelectorRequirement{
+									{
+										Key:      "security",
+										Operator: metav1.LabelSelectorOpNotIn,
+										Values:   []string{"S2"},
+									},
+								},
+							},
+							Namespaces:  []string{},
+							TopologyKey: "failure-domain.beta.kubernetes.io/zone",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	s := map[string]*schema.Schema{
+		"affinity": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: affinityFields(),
+			},
+		},
+	}
+
+	for name, in := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+			if err := d.Set("affinity", flattenAffinity(in)); err != nil {
+				t.Fatalf("Failed to set flattened affinity: %s", err)
+			}
+
+			out, err := expandAffinity(d.Get("affinity").([]interface{}))
+			if err != nil {
+				t.Fatalf("Failed to expand affinity: %s", err)
+			}
+			if !reflect.DeepEqual(in, out) {
+				t.Fatalf("Affinity did not survive round trip.\nExpected: %#v\nGiven:    %#v", in, out)
+			}
+		})
+	}
+}
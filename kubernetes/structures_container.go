@@ -1,8 +1,10 @@
 package kubernetes
 
 import (
+	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform/helper/schema"
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -18,6 +20,9 @@ func flattenCapability(in []v1.Capability) []string {
 func flattenContainerSecurityContext(in *v1.SecurityContext) []interface{} {
 	att := make(map[string]interface{})
 
+	if in.AllowPrivilegeEscalation != nil {
+		att["allow_privilege_escalation"] = *in.AllowPrivilegeEscalation
+	}
 	if in.Privileged != nil {
 		att["privileged"] = *in.Privileged
 	}
@@ -280,6 +285,10 @@ func flattenContainerVolumeMounts(in []v1.VolumeMount) ([]interface{}, error) {
 		if v.SubPath != "" {
 			m["sub_path"] = v.SubPath
 		}
+		m["mount_propagation"] = string(v1.MountPropagationNone)
+		if v.MountPropagation != nil {
+			m["mount_propagation"] = string(*v.MountPropagation)
+		}
 		att[i] = m
 	}
 	return att, nil
@@ -538,12 +547,34 @@ func expandHTTPHeaders(l []interface{}) []v1.HTTPHeader {
 	}
 	return headers
 }
+
+// clearUnsetSecurityContextFields unsets the security context fields of the containers of spec, found at
+// prefix in d, which aren't configured. An unset bool of a nested block reads as false, which would
+// otherwise be sent to Kubernetes instead of leaving it to its default.
+func clearUnsetSecurityContextFields(d *schema.ResourceData, prefix string, spec *v1.PodSpec) {
+	clear := func(key string, containers []v1.Container) {
+		for i, c := range containers {
+			if c.SecurityContext == nil {
+				continue
+			}
+			if _, ok := d.GetOkExists(fmt.Sprintf("%s%s.%d.security_context.0.allow_privilege_escalation", prefix, key, i)); !ok {
+				c.SecurityContext.AllowPrivilegeEscalation = nil
+			}
+		}
+	}
+	clear("container", spec.Containers)
+	clear("init_container", spec.InitContainers)
+}
+
 func expandContainerSecurityContext(l []interface{}) *v1.SecurityContext {
 	if len(l) == 0 || l[0] == nil {
 		return &v1.SecurityContext{}
 	}
 	in := l[0].(map[string]interface{})
 	obj := v1.SecurityContext{}
+	if v, ok := in["allow_privilege_escalation"]; ok {
+		obj.AllowPrivilegeEscalation = ptrToBool(v.(bool))
+	}
 	if v, ok := in["privileged"]; ok {
 		obj.Privileged = ptrToBool(v.(bool))
 	}
@@ -657,7 +688,6 @@ func expandProbe(l []interface{}) *v1.Probe {
 	if v, ok := in["timeout_seconds"].(int); ok {
 		obj.TimeoutSeconds = int32(v)
 	}
-
 	return &obj
 }
 
@@ -713,6 +743,11 @@ func expandContainerVolumeMounts(in []interface{}) ([]v1.VolumeMount, error) {
 		if subPath, ok := p["sub_path"]; ok {
 			vmp[i].SubPath = subPath.(string)
 		}
+		// The API server treats an unset propagation as None
+		if mp, ok := p["mount_propagation"].(string); ok && mp != "" && mp != string(v1.MountPropagationNone) {
+			mode := v1.MountPropagationMode(mp)
+			vmp[i].MountPropagation = &mode
+		}
 	}
 	return vmp, nil
 }
@@ -0,0 +1,70 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/api/core/v1"
+)
+
+func TestContainerSecurityContextRoundTrip(t *testing.T) {
+	in := &v1.SecurityContext{
+		AllowPrivilegeEscalation: ptrToBool(false),
+		Privileged:               ptrToBool(false),
+		ReadOnlyRootFilesystem:   ptrToBool(true),
+		RunAsNonRoot:             ptrToBool(true),
+		RunAsUser:                ptrToInt64(1000),
+		Capabilities: &v1.Capabilities{
+			Add:  []v1.Capability{"NET_BIND_SERVICE"},
+			Drop: []v1.Capability{"ALL"},
+		},
+	}
+
+	flattened := flattenContainerSecurityContext(in)
+	att := flattened[0].(map[string]interface{})
+	// Lists come back from the schema as []interface{}
+	caps := att["capabilities"].([]interface{})[0].(map[string]interface{})
+	caps["add"] = []interface{}{"NET_BIND_SERVICE"}
+	caps["drop"] = []interface{}{"ALL"}
+	att["run_as_user"] = int(*in.RunAsUser)
+
+	out := expandContainerSecurityContext(flattened)
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("Security context did not survive round trip.\nExpected: %#v\nGiven:    %#v", in, out)
+	}
+}
+
+func TestClearUnsetSecurityContextFields(t *testing.T) {
+	securityContext := func(sc map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"spec": []interface{}{map[string]interface{}{
+				"container": []interface{}{map[string]interface{}{
+					"name":             "app",
+					"image":            "nginx",
+					"security_context": []interface{}{sc},
+				}},
+			}},
+		}
+	}
+	cases := []struct {
+		Raw      map[string]interface{}
+		Expected *bool
+	}{
+		{securityContext(map[string]interface{}{"privileged": true}), nil},
+		{securityContext(map[string]interface{}{"allow_privilege_escalation": false}), ptrToBool(false)},
+		{securityContext(map[string]interface{}{"allow_privilege_escalation": true}), ptrToBool(true)},
+	}
+	for i, tc := range cases {
+		d := schema.TestResourceDataRaw(t, resourceKubernetesPod().Schema, tc.Raw)
+		spec, err := expandPodSpec(d.Get("spec").([]interface{}))
+		if err != nil {
+			t.Fatalf("case %d: %s", i, err)
+		}
+		clearUnsetSecurityContextFields(d, "spec.0.", &spec)
+		got := spec.Containers[0].SecurityContext.AllowPrivilegeEscalation
+		if !reflect.DeepEqual(got, tc.Expected) {
+			t.Fatalf("case %d: expected %v, got %v", i, tc.Expected, got)
+		}
+	}
+}
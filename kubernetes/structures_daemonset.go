@@ -13,6 +13,12 @@ import (
 func flattenDaemonSetSpec(in appsv1.DaemonSetSpec, d *schema.ResourceData) ([]interface{}, error) {
 	att := make(map[string]interface{})
 	att["min_ready_seconds"] = in.MinReadySeconds
+	if in.RevisionHistoryLimit != nil {
+		att["revision_history_limit"] = *in.RevisionHistoryLimit
+	} else {
+		// The default of the API server
+		att["revision_history_limit"] = 10
+	}
 
 	att["selector"] = in.Selector.MatchLabels
 	att["strategy"] = flattenDaemonSetStrategy(in.UpdateStrategy)
@@ -61,6 +67,9 @@ func expandDaemonSetSpec(deployment []interface{}) (appsv1.DaemonSetSpec, error)
 	}
 	in := deployment[0].(map[string]interface{})
 	obj.MinReadySeconds = int32(in["min_ready_seconds"].(int))
+	if v, ok := in["revision_history_limit"].(int); ok {
+		obj.RevisionHistoryLimit = ptrToInt32(int32(v))
+	}
 	if v, ok := in["selector"]; ok {
 		obj.Selector = &metav1.LabelSelector{
 			MatchLabels: expandStringMap(v.(map[string]interface{})),
@@ -1,10 +1,12 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"strconv"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -152,3 +154,49 @@ func expandRollingUpdateDeploymentIntOrString(v string) *intstr.IntOrString {
 		IntVal: int32(i),
 	}
 }
+
+// restartedAtAnnotation is the pod template annotation `kubectl rollout restart` sets.
+// Like any change of the pod template, changing it rolls out new pods.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// setRestartTrigger annotates the pod template with the restart trigger, unless
+// the annotation is configured explicitly.
+func setRestartTrigger(template *v1.PodTemplateSpec, trigger string) {
+	if trigger == "" {
+		return
+	}
+	if _, ok := template.Annotations[restartedAtAnnotation]; ok {
+		return
+	}
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+	template.Annotations[restartedAtAnnotation] = trigger
+}
+
+// patchRestartTrigger adds the change of the restart trigger to a strategic merge patch
+// of the deployment. The annotation is removed when the trigger is unset.
+func patchRestartTrigger(data []byte, trigger string) ([]byte, error) {
+	patch := make(map[string]interface{})
+	err := json.Unmarshal(data, &patch)
+	if err != nil {
+		return nil, err
+	}
+
+	m := patch
+	for _, k := range []string{"spec", "template", "metadata", "annotations"} {
+		next, ok := m[k].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			m[k] = next
+		}
+		m = next
+	}
+	if trigger == "" {
+		m[restartedAtAnnotation] = nil
+	} else {
+		m[restartedAtAnnotation] = trigger
+	}
+
+	return json.Marshal(patch)
+}
@@ -0,0 +1,39 @@
+package kubernetes
+
+import (
+	networking "k8s.io/api/networking/v1"
+)
+
+// expandDefaultDenyNetworkPolicySpec builds a network policy spec selecting all pods
+// of the namespace with no rules, which denies all traffic of the given policy types.
+func expandDefaultDenyNetworkPolicySpec(denyIngress, denyEgress bool) networking.NetworkPolicySpec {
+	spec := networking.NetworkPolicySpec{
+		PolicyTypes: []networking.PolicyType{},
+	}
+	if denyIngress {
+		spec.PolicyTypes = append(spec.PolicyTypes, networking.PolicyTypeIngress)
+	}
+	if denyEgress {
+		spec.PolicyTypes = append(spec.PolicyTypes, networking.PolicyTypeEgress)
+	}
+	return spec
+}
+
+// flattenDefaultDenyNetworkPolicySpec reports which traffic the spec still denies
+// to all pods of the namespace. Rules or a pod selector added outside of
+// Terraform show up as a diff, since the policy no longer denies everything.
+func flattenDefaultDenyNetworkPolicySpec(in networking.NetworkPolicySpec) (bool, bool) {
+	if len(in.PodSelector.MatchLabels) > 0 || len(in.PodSelector.MatchExpressions) > 0 {
+		return false, false
+	}
+	var denyIngress, denyEgress bool
+	for _, t := range in.PolicyTypes {
+		switch t {
+		case networking.PolicyTypeIngress:
+			denyIngress = len(in.Ingress) == 0
+		case networking.PolicyTypeEgress:
+			denyEgress = len(in.Egress) == 0
+		}
+	}
+	return denyIngress, denyEgress
+}
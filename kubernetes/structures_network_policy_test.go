@@ -0,0 +1,60 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExpandDefaultDenyNetworkPolicySpec(t *testing.T) {
+	cases := []struct {
+		DenyIngress bool
+		DenyEgress  bool
+		Expected    []networking.PolicyType
+	}{
+		{true, true, []networking.PolicyType{networking.PolicyTypeIngress, networking.PolicyTypeEgress}},
+		{true, false, []networking.PolicyType{networking.PolicyTypeIngress}},
+		{false, true, []networking.PolicyType{networking.PolicyTypeEgress}},
+	}
+
+	for _, tc := range cases {
+		spec := expandDefaultDenyNetworkPolicySpec(tc.DenyIngress, tc.DenyEgress)
+		if !reflect.DeepEqual(spec.PolicyTypes, tc.Expected) {
+			t.Fatalf("Expected policy types %#v, given: %#v", tc.Expected, spec.PolicyTypes)
+		}
+		if len(spec.Ingress) > 0 || len(spec.Egress) > 0 {
+			t.Fatalf("Expected a spec without rules, given: %#v", spec)
+		}
+
+		denyIngress, denyEgress := flattenDefaultDenyNetworkPolicySpec(spec)
+		if denyIngress != tc.DenyIngress || denyEgress != tc.DenyEgress {
+			t.Fatalf("Expected deny ingress/egress %t/%t after round trip, given: %t/%t",
+				tc.DenyIngress, tc.DenyEgress, denyIngress, denyEgress)
+		}
+	}
+}
+
+func TestFlattenDefaultDenyNetworkPolicySpec_openedUp(t *testing.T) {
+	cases := map[string]networking.NetworkPolicySpec{
+		"pod_selector": {
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress, networking.PolicyTypeEgress},
+		},
+		"rules": {
+			PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress, networking.PolicyTypeEgress},
+			Ingress:     []networking.NetworkPolicyIngressRule{{}},
+			Egress:      []networking.NetworkPolicyEgressRule{{}},
+		},
+	}
+
+	for name, spec := range cases {
+		t.Run(name, func(t *testing.T) {
+			denyIngress, denyEgress := flattenDefaultDenyNetworkPolicySpec(spec)
+			if denyIngress || denyEgress {
+				t.Fatalf("Expected a policy which allows traffic not to be reported as denying it, given: %t/%t", denyIngress, denyEgress)
+			}
+		})
+	}
+}
@@ -32,6 +32,10 @@ func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
 
 	att["dns_policy"] = in.DNSPolicy
 
+	if len(in.HostAliases) > 0 {
+		att["host_aliases"] = flattenHostAliases(in.HostAliases)
+	}
+
 	att["host_ipc"] = in.HostIPC
 	att["host_network"] = in.HostNetwork
 	att["host_pid"] = in.HostPID
@@ -53,9 +57,18 @@ func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
 	if len(in.NodeSelector) > 0 {
 		att["node_selector"] = in.NodeSelector
 	}
+	if in.Priority != nil {
+		att["priority"] = int(*in.Priority)
+	}
+	if in.PriorityClassName != "" {
+		att["priority_class_name"] = in.PriorityClassName
+	}
 	if in.RestartPolicy != "" {
 		att["restart_policy"] = in.RestartPolicy
 	}
+	if in.SchedulerName != "" {
+		att["scheduler_name"] = in.SchedulerName
+	}
 
 	if in.SecurityContext != nil {
 		att["security_context"] = flattenPodSecurityContext(in.SecurityContext)
@@ -64,6 +77,8 @@ func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
 		att["service_account_name"] = in.ServiceAccountName
 	}
 
+	// Unset, the token is mounted
+	att["automount_service_account_token"] = true
 	if in.AutomountServiceAccountToken != nil {
 		att["automount_service_account_token"] = *in.AutomountServiceAccountToken
 	}
@@ -101,7 +116,11 @@ func flattenDNSConfig(in *v1.PodDNSConfig) interface{} {
 
 		optMap := make(map[string]string, len(in.Options))
 		for _, opt := range in.Options {
-			optMap[opt.Name] = *opt.Value
+			// Options such as "rotate" have no value
+			optMap[opt.Name] = ""
+			if opt.Value != nil {
+				optMap[opt.Name] = *opt.Value
+			}
 		}
 		att["options"] = optMap
 
@@ -111,10 +130,21 @@ func flattenDNSConfig(in *v1.PodDNSConfig) interface{} {
 	return nil
 }
 
-func flattenPodTemplateSpec(in v1.PodTemplateSpec, d *schema.ResourceData) ([]interface{}, error) {
+func flattenHostAliases(in []v1.HostAlias) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, v := range in {
+		att[i] = map[string]interface{}{
+			"ip":        v.IP,
+			"hostnames": v.Hostnames,
+		}
+	}
+	return att
+}
+
+func flattenPodTemplateSpec(in v1.PodTemplateSpec, d *schema.ResourceData, metaPrefix ...string) ([]interface{}, error) {
 	att := make(map[string]interface{})
 
-	meta := flattenMetadata(in.ObjectMeta, d)
+	meta := flattenMetadata(in.ObjectMeta, d, metaPrefix...)
 	att["metadata"] = meta
 
 	podSpec, err := flattenPodSpec(in.Spec)
@@ -148,6 +178,9 @@ func flattenPodSecurityContext(in *v1.PodSecurityContext) []interface{} {
 	if in.SELinuxOptions != nil {
 		att["se_linux_options"] = flattenSeLinuxOptions(in.SELinuxOptions)
 	}
+	if len(in.Sysctls) > 0 {
+		att["sysctl"] = flattenSysctls(in.Sysctls)
+	}
 
 	if len(att) > 0 {
 		return []interface{}{att}
@@ -155,6 +188,17 @@ func flattenPodSecurityContext(in *v1.PodSecurityContext) []interface{} {
 	return []interface{}{}
 }
 
+func flattenSysctls(in []v1.Sysctl) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, v := range in {
+		att[i] = map[string]interface{}{
+			"name":  v.Name,
+			"value": v.Value,
+		}
+	}
+	return att
+}
+
 func flattenSeLinuxOptions(in *v1.SELinuxOptions) []interface{} {
 	att := make(map[string]interface{})
 	if in.User != "" {
@@ -219,6 +263,9 @@ func flattenVolumes(volumes []v1.Volume) ([]interface{}, error) {
 		if v.PersistentVolumeClaim != nil {
 			obj["persistent_volume_claim"] = flattenPersistentVolumeClaimVolumeSource(v.PersistentVolumeClaim)
 		}
+		if v.Projected != nil {
+			obj["projected"] = flattenProjectedVolumeSource(v.Projected)
+		}
 		if v.Secret != nil {
 			obj["secret"] = flattenSecretVolumeSource(v.Secret)
 		}
@@ -322,7 +369,7 @@ func flattenDownwardAPIVolumeFile(in []v1.DownwardAPIVolumeFile) []interface{} {
 			m["field_ref"] = flattenObjectFieldSelector(v.FieldRef)
 		}
 		if v.Mode != nil {
-			m["mode"] = *v.Mode
+			m["mode"] = int(*v.Mode)
 		}
 		if v.Path != "" {
 			m["path"] = v.Path
@@ -361,6 +408,8 @@ func flattenConfigMapVolumeSource(in *v1.ConfigMapVolumeSource) []interface{} {
 func flattenEmptyDirVolumeSource(in *v1.EmptyDirVolumeSource) []interface{} {
 	att := make(map[string]interface{})
 	att["medium"] = in.Medium
+	// No limit is represented by the "0" default of the schema
+	att["size_limit"] = "0"
 	if in.SizeLimit != nil {
 		att["size_limit"] = in.SizeLimit.String()
 	}
@@ -394,6 +443,75 @@ func flattenSecretVolumeSource(in *v1.SecretVolumeSource) []interface{} {
 	return []interface{}{att}
 }
 
+func flattenProjectedVolumeSource(in *v1.ProjectedVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	if in.DefaultMode != nil {
+		att["default_mode"] = int(*in.DefaultMode)
+	}
+	sources := make([]interface{}, len(in.Sources))
+	for i, v := range in.Sources {
+		m := map[string]interface{}{}
+		if v.ConfigMap != nil {
+			cm := map[string]interface{}{
+				"name": v.ConfigMap.Name,
+			}
+			if len(v.ConfigMap.Items) > 0 {
+				cm["items"] = flattenKeyPath(v.ConfigMap.Items)
+			}
+			if v.ConfigMap.Optional != nil {
+				cm["optional"] = *v.ConfigMap.Optional
+			}
+			m["config_map"] = []interface{}{cm}
+		}
+		if v.DownwardAPI != nil {
+			dapi := map[string]interface{}{}
+			if len(v.DownwardAPI.Items) > 0 {
+				dapi["items"] = flattenDownwardAPIVolumeFile(v.DownwardAPI.Items)
+			}
+			m["downward_api"] = []interface{}{dapi}
+		}
+		if v.Secret != nil {
+			sec := map[string]interface{}{
+				"name": v.Secret.Name,
+			}
+			if len(v.Secret.Items) > 0 {
+				sec["items"] = flattenKeyPath(v.Secret.Items)
+			}
+			if v.Secret.Optional != nil {
+				sec["optional"] = *v.Secret.Optional
+			}
+			m["secret"] = []interface{}{sec}
+		}
+		if v.ServiceAccountToken != nil {
+			sat := map[string]interface{}{
+				"audience": v.ServiceAccountToken.Audience,
+				"path":     v.ServiceAccountToken.Path,
+			}
+			if v.ServiceAccountToken.ExpirationSeconds != nil {
+				sat["expiration_seconds"] = int(*v.ServiceAccountToken.ExpirationSeconds)
+			}
+			m["service_account_token"] = []interface{}{sat}
+		}
+		sources[i] = m
+	}
+	att["sources"] = sources
+	return []interface{}{att}
+}
+
+func flattenKeyPath(in []v1.KeyToPath) []interface{} {
+	items := make([]interface{}, len(in))
+	for i, v := range in {
+		m := map[string]interface{}{}
+		m["key"] = v.Key
+		if v.Mode != nil {
+			m["mode"] = int(*v.Mode)
+		}
+		m["path"] = v.Path
+		items[i] = m
+	}
+	return items
+}
+
 // Expanders
 
 func expandPodTemplateSpec(template map[string]interface{}) (v1.PodTemplateSpec, error) {
@@ -448,6 +566,10 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 		obj.DNSPolicy = v1.DNSPolicy(v)
 	}
 
+	if v, ok := in["host_aliases"].([]interface{}); ok && len(v) > 0 {
+		obj.HostAliases = expandHostAliases(v)
+	}
+
 	if v, ok := in["host_ipc"]; ok {
 		obj.HostIPC = v.(bool)
 	}
@@ -491,10 +613,20 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 		obj.NodeSelector = nodeSelectors
 	}
 
+	// priority is set by the admission controller, sending it
+	// would be rejected if it doesn't match the priority class
+	if v, ok := in["priority_class_name"].(string); ok {
+		obj.PriorityClassName = v
+	}
+
 	if v, ok := in["restart_policy"].(string); ok {
 		obj.RestartPolicy = v1.RestartPolicy(v)
 	}
 
+	if v, ok := in["scheduler_name"].(string); ok {
+		obj.SchedulerName = v
+	}
+
 	if v, ok := in["security_context"].([]interface{}); ok && len(v) > 0 {
 		obj.SecurityContext = expandPodSecurityContext(v)
 	}
@@ -544,6 +676,18 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 	return obj, nil
 }
 
+func expandHostAliases(l []interface{}) []v1.HostAlias {
+	obj := make([]v1.HostAlias, len(l))
+	for i, v := range l {
+		in := v.(map[string]interface{})
+		obj[i] = v1.HostAlias{
+			IP:        in["ip"].(string),
+			Hostnames: expandStringSlice(in["hostnames"].([]interface{})),
+		}
+	}
+	return obj
+}
+
 func expandDNSConfig(l []interface{}) *v1.PodDNSConfig {
 	if len(l) == 0 || l[0] == nil {
 		return nil
@@ -559,10 +703,11 @@ func expandDNSConfig(l []interface{}) *v1.PodDNSConfig {
 	if v, ok := in["options"]; ok {
 		optMap := v.(map[string]interface{})
 		for optKey, optVal := range optMap {
-			obj.Options = append(obj.Options, v1.PodDNSConfigOption{
-				Name:  optKey,
-				Value: ptrToString(optVal.(string)),
-			})
+			opt := v1.PodDNSConfigOption{Name: optKey}
+			if optVal.(string) != "" {
+				opt.Value = ptrToString(optVal.(string))
+			}
+			obj.Options = append(obj.Options, opt)
 		}
 	}
 
@@ -595,10 +740,25 @@ func expandPodSecurityContext(l []interface{}) *v1.PodSecurityContext {
 	if v, ok := in["se_linux_options"].([]interface{}); ok && len(v) > 0 {
 		obj.SELinuxOptions = expandSeLinuxOptions(v)
 	}
+	if v, ok := in["sysctl"].([]interface{}); ok && len(v) > 0 {
+		obj.Sysctls = expandSysctls(v)
+	}
 
 	return obj
 }
 
+func expandSysctls(l []interface{}) []v1.Sysctl {
+	obj := make([]v1.Sysctl, len(l))
+	for i, v := range l {
+		in := v.(map[string]interface{})
+		obj[i] = v1.Sysctl{
+			Name:  in["name"].(string),
+			Value: in["value"].(string),
+		}
+	}
+	return obj
+}
+
 func expandSeLinuxOptions(l []interface{}) *v1.SELinuxOptions {
 	if len(l) == 0 || l[0] == nil {
 		return &v1.SELinuxOptions{}
@@ -630,7 +790,7 @@ func expandKeyPath(in []interface{}) []v1.KeyToPath {
 		if v, ok := p["key"].(string); ok {
 			keyPaths[i].Key = v
 		}
-		if v, ok := p["mode"].(int); ok {
+		if v, ok := p["mode"].(int); ok && v != 0 {
 			keyPaths[i].Mode = ptrToInt32(int32(v))
 		}
 		if v, ok := p["path"].(string); ok {
@@ -649,7 +809,7 @@ func expandDownwardAPIVolumeFile(in []interface{}) ([]v1.DownwardAPIVolumeFile,
 	dapivf := make([]v1.DownwardAPIVolumeFile, len(in))
 	for i, c := range in {
 		p := c.(map[string]interface{})
-		if v, ok := p["mode"].(int); ok {
+		if v, ok := p["mode"].(int); ok && v != 0 {
 			dapivf[i].Mode = ptrToInt32(int32(v))
 		}
 		if v, ok := p["path"].(string); ok {
@@ -735,18 +895,18 @@ func expandEmptyDirVolumeSource(l []interface{}) (*v1.EmptyDirVolumeSource, erro
 	}
 	in := l[0].(map[string]interface{})
 
-	var quantity resource.Quantity
+	obj := &v1.EmptyDirVolumeSource{
+		Medium: v1.StorageMedium(in["medium"].(string)),
+	}
 	if cfg, ok := in["size_limit"].(string); ok && len(cfg) > 0 {
-		var err error
-		quantity, err = resource.ParseQuantity(cfg)
+		quantity, err := resource.ParseQuantity(cfg)
 		if err != nil {
 			return &v1.EmptyDirVolumeSource{}, err
 		}
-	}
-
-	obj := &v1.EmptyDirVolumeSource{
-		Medium:    v1.StorageMedium(in["medium"].(string)),
-		SizeLimit: &quantity,
+		// A zero limit means the volume isn't limited
+		if !quantity.IsZero() {
+			obj.SizeLimit = &quantity
+		}
 	}
 	return obj, nil
 }
@@ -781,6 +941,77 @@ func expandSecretVolumeSource(l []interface{}) *v1.SecretVolumeSource {
 	return obj
 }
 
+func expandProjectedVolumeSource(l []interface{}) (*v1.ProjectedVolumeSource, error) {
+	if len(l) == 0 || l[0] == nil {
+		return &v1.ProjectedVolumeSource{}, nil
+	}
+	in := l[0].(map[string]interface{})
+	obj := &v1.ProjectedVolumeSource{
+		DefaultMode: ptrToInt32(int32(in["default_mode"].(int))),
+	}
+	sources, _ := in["sources"].([]interface{})
+	obj.Sources = make([]v1.VolumeProjection, len(sources))
+	for i, s := range sources {
+		// Sources which don't specify exactly one projection fail the plan, see checkProjectedVolumeSources
+		src, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := src["config_map"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			cm := v[0].(map[string]interface{})
+			p := &v1.ConfigMapProjection{
+				LocalObjectReference: v1.LocalObjectReference{Name: cm["name"].(string)},
+			}
+			if items, ok := cm["items"].([]interface{}); ok && len(items) > 0 {
+				p.Items = expandKeyPath(items)
+			}
+			if opt, ok := cm["optional"].(bool); ok && opt {
+				p.Optional = ptrToBool(opt)
+			}
+			obj.Sources[i].ConfigMap = p
+		}
+		if v, ok := src["downward_api"].([]interface{}); ok && len(v) > 0 {
+			p := &v1.DownwardAPIProjection{}
+			if v[0] != nil {
+				dapi := v[0].(map[string]interface{})
+				if items, ok := dapi["items"].([]interface{}); ok && len(items) > 0 {
+					var err error
+					p.Items, err = expandDownwardAPIVolumeFile(items)
+					if err != nil {
+						return obj, err
+					}
+				}
+			}
+			obj.Sources[i].DownwardAPI = p
+		}
+		if v, ok := src["secret"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			sec := v[0].(map[string]interface{})
+			p := &v1.SecretProjection{
+				LocalObjectReference: v1.LocalObjectReference{Name: sec["name"].(string)},
+			}
+			if items, ok := sec["items"].([]interface{}); ok && len(items) > 0 {
+				p.Items = expandKeyPath(items)
+			}
+			if opt, ok := sec["optional"].(bool); ok && opt {
+				p.Optional = ptrToBool(opt)
+			}
+			obj.Sources[i].Secret = p
+		}
+		if v, ok := src["service_account_token"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			sat := v[0].(map[string]interface{})
+			p := &v1.ServiceAccountTokenProjection{
+				Audience: sat["audience"].(string),
+				Path:     sat["path"].(string),
+			}
+			if exp, ok := sat["expiration_seconds"].(int); ok && exp > 0 {
+				p.ExpirationSeconds = ptrToInt64(int64(exp))
+			}
+			obj.Sources[i].ServiceAccountToken = p
+		}
+	}
+	return obj, nil
+}
+
 func expandVolumes(volumes []interface{}) ([]v1.Volume, error) {
 	if len(volumes) == 0 {
 		return []v1.Volume{}, nil
@@ -818,6 +1049,13 @@ func expandVolumes(volumes []interface{}) ([]v1.Volume, error) {
 		if value, ok := m["persistent_volume_claim"].([]interface{}); ok && len(value) > 0 {
 			vl[i].PersistentVolumeClaim = expandPersistentVolumeClaimVolumeSource(value)
 		}
+		if value, ok := m["projected"].([]interface{}); ok && len(value) > 0 {
+			var err error
+			vl[i].Projected, err = expandProjectedVolumeSource(value)
+			if err != nil {
+				return vl, err
+			}
+		}
 		if value, ok := m["secret"].([]interface{}); ok && len(value) > 0 {
 			vl[i].Secret = expandSecretVolumeSource(value)
 		}
@@ -0,0 +1,296 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestProjectedVolumeRoundTrip(t *testing.T) {
+	in := []v1.Volume{
+		{
+			Name: "projected",
+			VolumeSource: v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{
+					DefaultMode: ptrToInt32(0440),
+					Sources: []v1.VolumeProjection{
+						{
+							ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+								Audience:          "vault",
+								ExpirationSeconds: ptrToInt64(7200),
+								Path:              "token",
+							},
+						},
+						{
+							ConfigMap: &v1.ConfigMapProjection{
+								LocalObjectReference: v1.LocalObjectReference{Name: "app-config"},
+								Items: []v1.KeyToPath{
+									{Key: "config.yaml", Path: "config/app.yaml", Mode: ptrToInt32(0400)},
+								},
+							},
+						},
+						{
+							Secret: &v1.SecretProjection{
+								LocalObjectReference: v1.LocalObjectReference{Name: "app-secret"},
+								Optional:             ptrToBool(true),
+							},
+						},
+						{
+							DownwardAPI: &v1.DownwardAPIProjection{
+								Items: []v1.DownwardAPIVolumeFile{
+									{
+										Path: "labels",
+										FieldRef: &v1.ObjectFieldSelector{
+											APIVersion: "v1",
+											FieldPath:  "metadata.labels",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	s := map[string]*schema.Schema{
+		"volume": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     volumeSchema(true),
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	flattened, err := flattenVolumes(in)
+	if err != nil {
+		t.Fatalf("Failed to flatten volumes: %s", err)
+	}
+	if err := d.Set("volume", flattened); err != nil {
+		t.Fatalf("Failed to set flattened volumes: %s", err)
+	}
+
+	out, err := expandVolumes(d.Get("volume").([]interface{}))
+	if err != nil {
+		t.Fatalf("Failed to expand volumes: %s", err)
+	}
+	if !reflect.DeepEqual(in[0].Projected, out[0].Projected) {
+		t.Fatalf("Projected volume did not survive round trip.\nExpected: %#v\nGiven:    %#v", in[0].Projected, out[0].Projected)
+	}
+}
+
+func TestPodSpecHostAliasesAndSysctlsRoundTrip(t *testing.T) {
+	in := v1.PodSpec{
+		HostAliases: []v1.HostAlias{
+			{IP: "10.1.2.3", Hostnames: []string{"foo.local", "bar.local"}},
+			{IP: "fe80::1", Hostnames: []string{"baz.local"}},
+		},
+		SecurityContext: &v1.PodSecurityContext{
+			Sysctls: []v1.Sysctl{
+				{Name: "net.core.somaxconn", Value: "1024"},
+				{Name: "kernel.shm_rmid_forced", Value: "0"},
+			},
+		},
+	}
+
+	s := map[string]*schema.Schema{
+		"spec": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: podSpecFields(true),
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	flattened, err := flattenPodSpec(in)
+	if err != nil {
+		t.Fatalf("Failed to flatten pod spec: %s", err)
+	}
+	if err := d.Set("spec", flattened); err != nil {
+		t.Fatalf("Failed to set flattened pod spec: %s", err)
+	}
+
+	out, err := expandPodSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatalf("Failed to expand pod spec: %s", err)
+	}
+	if !reflect.DeepEqual(in.HostAliases, out.HostAliases) {
+		t.Fatalf("Host aliases did not survive round trip.\nExpected: %#v\nGiven:    %#v", in.HostAliases, out.HostAliases)
+	}
+	if !reflect.DeepEqual(in.SecurityContext.Sysctls, out.SecurityContext.Sysctls) {
+		t.Fatalf("Sysctls did not survive round trip.\nExpected: %#v\nGiven:    %#v", in.SecurityContext.Sysctls, out.SecurityContext.Sysctls)
+	}
+}
+
+func TestPodSpecInitContainersOrder(t *testing.T) {
+	in := v1.PodSpec{
+		InitContainers: []v1.Container{
+			{Name: "migrate", Image: "alpine", Command: []string{"echo", "migrate"}},
+			{Name: "seed", Image: "alpine", Command: []string{"echo", "seed"}},
+			{Name: "warmup", Image: "busybox", Command: []string{"sleep", "1"}},
+		},
+		Containers: []v1.Container{
+			{Name: "app", Image: "nginx"},
+		},
+	}
+
+	s := map[string]*schema.Schema{
+		"spec": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: podSpecFields(true),
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	flattened, err := flattenPodSpec(in)
+	if err != nil {
+		t.Fatalf("Failed to flatten pod spec: %s", err)
+	}
+	if err := d.Set("spec", flattened); err != nil {
+		t.Fatalf("Failed to set flattened pod spec: %s", err)
+	}
+
+	out, err := expandPodSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatalf("Failed to expand pod spec: %s", err)
+	}
+	if len(out.InitContainers) != len(in.InitContainers) {
+		t.Fatalf("Expected %d init containers, given: %d", len(in.InitContainers), len(out.InitContainers))
+	}
+	for i, c := range in.InitContainers {
+		if out.InitContainers[i].Name != c.Name {
+			t.Fatalf("Expected init container %d to be %q, given: %q", i, c.Name, out.InitContainers[i].Name)
+		}
+		if !reflect.DeepEqual(out.InitContainers[i].Command, c.Command) {
+			t.Fatalf("Expected command of init container %q to be %#v, given: %#v", c.Name, c.Command, out.InitContainers[i].Command)
+		}
+	}
+	if len(out.Containers) != 1 || out.Containers[0].Name != "app" {
+		t.Fatalf("Expected the containers to be left alone, given: %#v", out.Containers)
+	}
+}
+
+func TestEmptyDirVolumeSourceRoundTrip(t *testing.T) {
+	limit := resource.MustParse("256Mi")
+	cases := []struct {
+		in       *v1.EmptyDirVolumeSource
+		expected map[string]interface{}
+	}{
+		{
+			&v1.EmptyDirVolumeSource{},
+			map[string]interface{}{"medium": v1.StorageMediumDefault, "size_limit": "0"},
+		},
+		{
+			&v1.EmptyDirVolumeSource{Medium: v1.StorageMediumMemory, SizeLimit: &limit},
+			map[string]interface{}{"medium": v1.StorageMediumMemory, "size_limit": "256Mi"},
+		},
+	}
+
+	for i, tc := range cases {
+		flattened := flattenEmptyDirVolumeSource(tc.in)
+		if !reflect.DeepEqual(flattened[0], tc.expected) {
+			t.Fatalf("%d: unexpected flattened empty dir.\nExpected: %#v\nGiven:    %#v", i, tc.expected, flattened[0])
+		}
+		// The schema stores the medium as a plain string
+		flattened[0].(map[string]interface{})["medium"] = string(tc.in.Medium)
+
+		out, err := expandEmptyDirVolumeSource(flattened)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out.Medium != tc.in.Medium {
+			t.Fatalf("%d: expected medium %q, given: %q", i, tc.in.Medium, out.Medium)
+		}
+		if (out.SizeLimit == nil) != (tc.in.SizeLimit == nil) ||
+			(out.SizeLimit != nil && out.SizeLimit.Cmp(*tc.in.SizeLimit) != 0) {
+			t.Fatalf("%d: expected size limit %v, given: %v", i, tc.in.SizeLimit, out.SizeLimit)
+		}
+	}
+}
+
+func TestPodSpecDNSConfigRoundTrip(t *testing.T) {
+	in := v1.PodSpec{
+		DNSPolicy: v1.DNSNone,
+		DNSConfig: &v1.PodDNSConfig{
+			Nameservers: []string{"1.2.3.4"},
+			Searches:    []string{"ns1.svc.cluster.local"},
+			Options: []v1.PodDNSConfigOption{
+				{Name: "ndots", Value: ptrToString("2")},
+				{Name: "rotate"},
+			},
+		},
+	}
+
+	s := map[string]*schema.Schema{
+		"spec": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: podSpecFields(true),
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	flattened, err := flattenPodSpec(in)
+	if err != nil {
+		t.Fatalf("Failed to flatten pod spec: %s", err)
+	}
+	if err := d.Set("spec", flattened); err != nil {
+		t.Fatalf("Failed to set flattened pod spec: %s", err)
+	}
+
+	out, err := expandPodSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		t.Fatalf("Failed to expand pod spec: %s", err)
+	}
+	if out.DNSPolicy != in.DNSPolicy {
+		t.Fatalf("Expected DNS policy %q, given: %q", in.DNSPolicy, out.DNSPolicy)
+	}
+	if !reflect.DeepEqual(in.DNSConfig.Nameservers, out.DNSConfig.Nameservers) ||
+		!reflect.DeepEqual(in.DNSConfig.Searches, out.DNSConfig.Searches) {
+		t.Fatalf("DNS config did not survive round trip.\nExpected: %#v\nGiven:    %#v", in.DNSConfig, out.DNSConfig)
+	}
+	// Options are kept in a map, so their order isn't preserved
+	options := make(map[string]*string, len(out.DNSConfig.Options))
+	for _, opt := range out.DNSConfig.Options {
+		options[opt.Name] = opt.Value
+	}
+	if len(options) != 2 || options["ndots"] == nil || *options["ndots"] != "2" || options["rotate"] != nil {
+		t.Fatalf("DNS options did not survive round trip.\nGiven: %#v", out.DNSConfig.Options)
+	}
+}
+
+func TestFlattenPodSpecAutomountServiceAccountToken(t *testing.T) {
+	cases := []struct {
+		In       *bool
+		Expected bool
+	}{
+		{nil, true},
+		{ptrToBool(true), true},
+		{ptrToBool(false), false},
+	}
+
+	for _, tc := range cases {
+		flattened, err := flattenPodSpec(v1.PodSpec{AutomountServiceAccountToken: tc.In, ServiceAccountName: "ci"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec := flattened[0].(map[string]interface{})
+		if spec["automount_service_account_token"] != tc.Expected || spec["service_account_name"] != "ci" {
+			t.Fatalf("Expected automount_service_account_token %t, given: %#v", tc.Expected, spec)
+		}
+	}
+}
@@ -82,6 +82,18 @@ func expandSubjects(in []interface{}) []v1.Subject {
 	return subs
 }
 
+func expandClusterRoleAggregationRule(in []interface{}) *v1.AggregationRule {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	m := in[0].(map[string]interface{})
+	rule := &v1.AggregationRule{}
+	for _, s := range m["cluster_role_selectors"].([]interface{}) {
+		rule.ClusterRoleSelectors = append(rule.ClusterRoleSelectors, *expandLabelSelector([]interface{}{s}))
+	}
+	return rule
+}
+
 // Flatteners
 func flattenClusterRoleRules(in []v1.PolicyRule) []interface{} {
 	att := make([]interface{}, len(in), len(in))
@@ -100,6 +112,24 @@ func flattenClusterRoleRules(in []v1.PolicyRule) []interface{} {
 	return att
 }
 
+func flattenClusterRoleAggregationRule(in *v1.AggregationRule) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+	selectors := make([]interface{}, 0, len(in.ClusterRoleSelectors))
+	for i := range in.ClusterRoleSelectors {
+		// An empty selector is kept, it matches all the cluster roles
+		selector := flattenLabelSelector(&in.ClusterRoleSelectors[i])
+		if len(selector) == 0 {
+			selector = []interface{}{map[string]interface{}{}}
+		}
+		selectors = append(selectors, selector...)
+	}
+	return []interface{}{map[string]interface{}{
+		"cluster_role_selectors": selectors,
+	}}
+}
+
 func flattenRoleRef(in v1.RoleRef) []interface{} {
 	m := make(map[string]interface{})
 
@@ -85,6 +85,9 @@ func expandStatefulSetSpec(statefulSet []interface{}) (appsv1.StatefulSetSpec, e
 	}
 
 	obj.Replicas = ptrToInt32(int32(in["replicas"].(int)))
+	if v, ok := in["revision_history_limit"].(int); ok {
+		obj.RevisionHistoryLimit = ptrToInt32(int32(v))
+	}
 	obj.Selector = &metav1.LabelSelector{
 		MatchLabels: expandStringMap(in["selector"].(map[string]interface{})),
 	}
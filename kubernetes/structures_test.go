@@ -2,7 +2,15 @@ package kubernetes
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestIsInternalKey(t *testing.T) {
@@ -30,3 +38,217 @@ func TestIsInternalKey(t *testing.T) {
 		})
 	}
 }
+
+func TestExpandMetadataWithDefaults(t *testing.T) {
+	meta := &kubernetesProvider{
+		defaultLabels:      map[string]string{"cost-center": "1234", "environment": "prod"},
+		defaultAnnotations: map[string]string{"owner": "platform"},
+	}
+	in := []interface{}{
+		map[string]interface{}{
+			"name":        "test",
+			"labels":      map[string]interface{}{"environment": "staging", "app": "web"},
+			"annotations": map[string]interface{}{},
+		},
+	}
+
+	out := expandMetadataWithDefaults(in, meta)
+
+	expectedLabels := map[string]string{"cost-center": "1234", "environment": "staging", "app": "web"}
+	if !reflect.DeepEqual(out.Labels, expectedLabels) {
+		t.Fatalf("Expected labels %#v, given: %#v", expectedLabels, out.Labels)
+	}
+	expectedAnnotations := map[string]string{"owner": "platform"}
+	if !reflect.DeepEqual(out.Annotations, expectedAnnotations) {
+		t.Fatalf("Expected annotations %#v, given: %#v", expectedAnnotations, out.Annotations)
+	}
+}
+
+func TestPatchMetadataWithDefaults(t *testing.T) {
+	meta := &kubernetesProvider{
+		defaultLabels:      map[string]string{"cost-center": "1234"},
+		defaultAnnotations: map[string]string{"owner": "platform"},
+	}
+	// Reads hide the defaults, the state has no labels before the first one is configured
+	d := schema.TestResourceDataRaw(t, resourceKubernetesConfigMap().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "test", "labels": map[string]interface{}{"app": "web"}}},
+	})
+
+	ops := patchMetadataWithDefaults(d, meta)
+	expected := PatchOperations{&AddOperation{Path: "/metadata/labels/app", Value: "web"}}
+	if !reflect.DeepEqual(ops, expected) {
+		t.Fatalf("Expected %#v, given: %#v", expected, ops)
+	}
+}
+
+func TestDiffStringMapOnto(t *testing.T) {
+	defaults := map[string]string{"cost-center": "1234"}
+	cases := []struct {
+		Name     string
+		Old      map[string]interface{}
+		New      map[string]interface{}
+		Expected PatchOperations
+	}{
+		{
+			"first configured key",
+			map[string]interface{}{},
+			map[string]interface{}{"app": "web"},
+			PatchOperations{&AddOperation{Path: "/metadata/labels/app", Value: "web"}},
+		},
+		{
+			"default overridden",
+			map[string]interface{}{},
+			map[string]interface{}{"cost-center": "5678"},
+			PatchOperations{&ReplaceOperation{Path: "/metadata/labels/cost-center", Value: "5678"}},
+		},
+		{
+			"overridden default removed",
+			map[string]interface{}{"cost-center": "5678"},
+			map[string]interface{}{},
+			PatchOperations{&ReplaceOperation{Path: "/metadata/labels/cost-center", Value: "1234"}},
+		},
+		{
+			"last configured key removed",
+			map[string]interface{}{"app": "web"},
+			map[string]interface{}{},
+			PatchOperations{&RemoveOperation{Path: "/metadata/labels/app"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ops := diffStringMapOnto("/metadata/labels", defaults, tc.Old, tc.New)
+			if !reflect.DeepEqual(ops, tc.Expected) {
+				t.Fatalf("Expected %#v, given: %#v", tc.Expected, ops)
+			}
+		})
+	}
+}
+
+func TestFlattenMetadata_serverFields(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"metadata": namespacedMetadataSchema("test", true),
+	}
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+
+	created := metav1.NewTime(time.Date(2018, 7, 1, 12, 30, 0, 0, time.UTC))
+	om := metav1.ObjectMeta{
+		Name:              "test",
+		Namespace:         "default",
+		UID:               "0d3e5c7a-7d3e-11e8-9e4b-42010a840002",
+		ResourceVersion:   "1234",
+		Generation:        2,
+		CreationTimestamp: created,
+	}
+	if err := d.Set("metadata", flattenMetadata(om, d)); err != nil {
+		t.Fatalf("Failed to set flattened metadata: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"metadata.0.uid":                "0d3e5c7a-7d3e-11e8-9e4b-42010a840002",
+		"metadata.0.resource_version":   "1234",
+		"metadata.0.generation":         2,
+		"metadata.0.creation_timestamp": "2018-07-01T12:30:00Z",
+	}
+	for k, v := range expected {
+		if given := d.Get(k); given != v {
+			t.Fatalf("Expected %s to be %#v, given: %#v", k, v, given)
+		}
+	}
+}
+
+func TestSuppressAnnotationWhitespaceDiff(t *testing.T) {
+	cases := []struct {
+		Trim         bool
+		Config       string
+		ExpectedDiff bool
+	}{
+		{true, "-----BEGIN CERTIFICATE-----\nabc\n-----END CERTIFICATE-----\n", false},
+		{true, "-----BEGIN CERTIFICATE-----\nabc\n-----END CERTIFICATE-----  \n\n", false},
+		{true, "-----BEGIN CERTIFICATE-----\nxyz\n-----END CERTIFICATE-----\n", true},
+		{false, "-----BEGIN CERTIFICATE-----\nabc\n-----END CERTIFICATE-----\n", true},
+	}
+
+	for i, tc := range cases {
+		r := &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"metadata": namespacedMetadataSchema("test", true),
+			},
+		}
+		state := &terraform.InstanceState{
+			ID: "default/test",
+			Attributes: map[string]string{
+				"metadata.#":                            "1",
+				"metadata.0.name":                       "test",
+				"metadata.0.namespace":                  "default",
+				"metadata.0.trim_annotation_whitespace": fmt.Sprintf("%t", tc.Trim),
+				"metadata.0.annotations.%":              "1",
+				// Trimmed by the server
+				"metadata.0.annotations.example.com/ca": "-----BEGIN CERTIFICATE-----\nabc\n-----END CERTIFICATE-----",
+			},
+		}
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"metadata": []map[string]interface{}{{
+				"name":                       "test",
+				"trim_annotation_whitespace": tc.Trim,
+				"annotations":                map[string]interface{}{"example.com/ca": tc.Config},
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		diff, err := r.Diff(state, terraform.NewResourceConfig(raw), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		changed := false
+		if diff != nil {
+			_, changed = diff.Attributes["metadata.0.annotations.example.com/ca"]
+		}
+		if changed != tc.ExpectedDiff {
+			t.Fatalf("%d: Expected diff: %t, given: %#v", i, tc.ExpectedDiff, diff)
+		}
+	}
+}
+
+func TestRemoveDefaultKeys(t *testing.T) {
+	live := map[string]string{"cost-center": "1234", "environment": "staging", "app": "web"}
+	defaults := map[string]string{"cost-center": "1234", "environment": "prod"}
+	config := map[string]interface{}{"environment": "staging", "app": "web"}
+
+	out := removeDefaultKeys(live, defaults, config)
+
+	expected := map[string]string{"environment": "staging", "app": "web"}
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Expected %#v, given: %#v", expected, out)
+	}
+}
+
+func TestValidateScopedResourceSelectorRequirement(t *testing.T) {
+	testCases := []struct {
+		Req           api.ScopedResourceSelectorRequirement
+		ExpectedError bool
+	}{
+		{api.ScopedResourceSelectorRequirement{ScopeName: "PriorityClass", Operator: "In", Values: []string{"high"}}, false},
+		{api.ScopedResourceSelectorRequirement{ScopeName: "PriorityClass", Operator: "NotIn", Values: []string{"low"}}, false},
+		{api.ScopedResourceSelectorRequirement{ScopeName: "PriorityClass", Operator: "DoesNotExist"}, false},
+		{api.ScopedResourceSelectorRequirement{ScopeName: "BestEffort", Operator: "Exists"}, false},
+		{api.ScopedResourceSelectorRequirement{ScopeName: "PriorityClass", Operator: "In"}, true},
+		{api.ScopedResourceSelectorRequirement{ScopeName: "PriorityClass", Operator: "Exists", Values: []string{"high"}}, true},
+		{api.ScopedResourceSelectorRequirement{ScopeName: "BestEffort", Operator: "DoesNotExist"}, true},
+		{api.ScopedResourceSelectorRequirement{ScopeName: "Terminating", Operator: "In", Values: []string{"x"}}, true},
+		{api.ScopedResourceSelectorRequirement{ScopeName: "PriorityClass", Operator: "Gt", Values: []string{"1"}}, true},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			err := validateScopedResourceSelectorRequirement(tc.Req)
+			if tc.ExpectedError && err == nil {
+				t.Fatalf("Expected %#v to be invalid", tc.Req)
+			}
+			if !tc.ExpectedError && err != nil {
+				t.Fatalf("Expected %#v to be valid, given: %s", tc.Req, err)
+			}
+		})
+	}
+}
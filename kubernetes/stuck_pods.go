@@ -0,0 +1,169 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+// stuckContainerReasons are the reasons a container waits for which don't go away on their own,
+// they need a change of the spec (or of the registry, or of a referenced object) first.
+var stuckContainerReasons = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// stuckPodRestarts is how many times a container in CrashLoopBackOff must have restarted before its
+// pod is considered stuck, a container crashing while e.g. its database starts up recovers on its own.
+const stuckPodRestarts = 3
+
+// stuckPods lists the pods selected by selector in namespace which can't become ready on their own,
+// as messages like `"\n   * pod web-1: container web is waiting: ImagePullBackOff: ..."`.
+func stuckPods(conn *kubernetes.Clientset, namespace string, selector *metav1.LabelSelector) ([]string, error) {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := conn.CoreV1().Pods(namespace).List(metav1.ListOptions{LabelSelector: s.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	var stuck []string
+	for _, pod := range pods.Items {
+		if reason := stuckPodReason(pod); reason != "" {
+			stuck = append(stuck, fmt.Sprintf("\n   * pod %s: %s", pod.Name, reason))
+		}
+	}
+	sort.Strings(stuck)
+	return stuck, nil
+}
+
+// stuckPodReason returns why the pod can't become ready on its own, empty if it may still.
+func stuckPodReason(pod api.Pod) string {
+	statuses := append(append([]api.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, s := range statuses {
+		w := s.State.Waiting
+		if w == nil {
+			continue
+		}
+		if stuckContainerReasons[w.Reason] || (w.Reason == "CrashLoopBackOff" && s.RestartCount >= stuckPodRestarts) {
+			return fmt.Sprintf("container %s is waiting: %s: %s", s.Name, w.Reason, w.Message)
+		}
+	}
+	return ""
+}
+
+// withUnreadyPods appends to the error of a failed wait on a workload why its pods selected by selector
+// in namespace aren't ready, e.g. a container waiting on ImagePullBackOff or terminated as OOMKilled,
+// which tells more than the timeout. The error is returned as is when the pods can't be listed.
+func withUnreadyPods(err error, conn *kubernetes.Clientset, namespace string, selector *metav1.LabelSelector) error {
+	if err == nil || selector == nil {
+		return err
+	}
+	s, sErr := metav1.LabelSelectorAsSelector(selector)
+	if sErr != nil {
+		return err
+	}
+	pods, lErr := conn.CoreV1().Pods(namespace).List(metav1.ListOptions{LabelSelector: s.String()})
+	if lErr != nil {
+		log.Printf("[WARN] Can't list the pods of the workload to tell why they aren't ready: %s", lErr)
+		return err
+	}
+	return withUnreadyPodReasons(err, pods.Items...)
+}
+
+// withUnreadyPodReasons appends to err why the given pods aren't ready, if any of them isn't.
+func withUnreadyPodReasons(err error, pods ...api.Pod) error {
+	var unready []string
+	for _, pod := range pods {
+		if reason := unreadyPodReason(pod); reason != "" {
+			unready = append(unready, fmt.Sprintf("\n   * pod %s: %s", pod.Name, reason))
+		}
+	}
+	if len(unready) == 0 {
+		return err
+	}
+	sort.Strings(unready)
+	return fmt.Errorf("%s\n\nPods which aren't ready:%s", err, strings.Join(unready, ""))
+}
+
+// unreadyPodReason returns why the pod isn't ready from the statuses of its containers,
+// or from its scheduling while it has none. It's empty for a ready pod.
+func unreadyPodReason(pod api.Pod) string {
+	if pod.Status.Phase == api.PodSucceeded {
+		return ""
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == api.PodReady && c.Status == api.ConditionTrue {
+			return ""
+		}
+		if c.Type == api.PodScheduled && c.Status == api.ConditionFalse {
+			return fmt.Sprintf("not scheduled: %s: %s", c.Reason, c.Message)
+		}
+	}
+
+	var reasons []string
+	for _, s := range pod.Status.InitContainerStatuses {
+		// Init containers are done once they exited successfully
+		if t := s.State.Terminated; t != nil && t.ExitCode == 0 {
+			continue
+		}
+		if reason := unreadyContainerReason(s); reason != "" {
+			reasons = append(reasons, "init "+reason)
+		}
+	}
+	for _, s := range pod.Status.ContainerStatuses {
+		if reason := unreadyContainerReason(s); reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+	if len(reasons) == 0 && pod.Status.Reason != "" {
+		return fmt.Sprintf("%s: %s", pod.Status.Reason, pod.Status.Message)
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// unreadyContainerReason describes the state of a container which isn't ready, along with
+// how it last terminated, e.g. `container web is waiting: CrashLoopBackOff: ..., last terminated: Error (exit code 1)`.
+func unreadyContainerReason(s api.ContainerStatus) string {
+	if s.Ready {
+		return ""
+	}
+	var reason string
+	switch {
+	case s.State.Waiting != nil:
+		reason = fmt.Sprintf("container %s is waiting: %s", s.Name, s.State.Waiting.Reason)
+		if s.State.Waiting.Message != "" {
+			reason += ": " + s.State.Waiting.Message
+		}
+	case s.State.Terminated != nil:
+		reason = fmt.Sprintf("container %s terminated: %s", s.Name, terminatedReason(s.State.Terminated))
+	default:
+		reason = fmt.Sprintf("container %s is running but not ready", s.Name)
+	}
+	if t := s.LastTerminationState.Terminated; t != nil {
+		reason += fmt.Sprintf(", last terminated: %s after %d restarts", terminatedReason(t), s.RestartCount)
+	}
+	return reason
+}
+
+func terminatedReason(t *api.ContainerStateTerminated) string {
+	reason := t.Reason
+	if reason == "" {
+		reason = "Terminated"
+	}
+	reason += fmt.Sprintf(" (exit code %d)", t.ExitCode)
+	if t.Message != "" {
+		reason += ": " + t.Message
+	}
+	return reason
+}
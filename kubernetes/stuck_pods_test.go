@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestStuckPods(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/v1/namespaces/default/pods" || r.URL.Query().Get("labelSelector") != "app=db" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"kind": "PodList", "apiVersion": "v1", "items": [
+	{"metadata": {"name": "db-0"}, "status": {"containerStatuses": [{"name": "db", "ready": true, "state": {"running": {}}}]}},
+	{"metadata": {"name": "db-1"}, "status": {"containerStatuses": [
+		{"name": "db", "state": {"waiting": {"reason": "ImagePullBackOff", "message": "Back-off pulling image \"db:nope\""}}}]}},
+	{"metadata": {"name": "db-2"}, "status": {"containerStatuses": [
+		{"name": "db", "restartCount": 1, "state": {"waiting": {"reason": "CrashLoopBackOff", "message": "back-off 10s"}}}]}},
+	{"metadata": {"name": "db-3"}, "status": {"initContainerStatuses": [
+		{"name": "migrate", "restartCount": 5, "state": {"waiting": {"reason": "CrashLoopBackOff", "message": "back-off 5m0s"}}}]}}
+]}`)
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stuck, err := stuckPods(conn, "default", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A container which only crashed once may still recover
+	expected := []string{
+		"\n   * pod db-1: container db is waiting: ImagePullBackOff: Back-off pulling image \"db:nope\"",
+		"\n   * pod db-3: container migrate is waiting: CrashLoopBackOff: back-off 5m0s",
+	}
+	if !reflect.DeepEqual(stuck, expected) {
+		t.Fatalf("Expected %q, given: %q", expected, stuck)
+	}
+}
+
+func TestUnreadyPodReason(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Status   api.PodStatus
+		Expected string
+	}{
+		{"ready", api.PodStatus{
+			Conditions:        []api.PodCondition{{Type: api.PodReady, Status: api.ConditionTrue}},
+			ContainerStatuses: []api.ContainerStatus{{Name: "web", Ready: true}},
+		}, ""},
+		{"succeeded", api.PodStatus{Phase: api.PodSucceeded, ContainerStatuses: []api.ContainerStatus{
+			{Name: "migrate", State: api.ContainerState{Terminated: &api.ContainerStateTerminated{Reason: "Completed"}}},
+		}}, ""},
+		{"unschedulable", api.PodStatus{Conditions: []api.PodCondition{
+			{Type: api.PodScheduled, Status: api.ConditionFalse, Reason: "Unschedulable", Message: "0/3 nodes are available: 3 Insufficient memory."},
+		}}, "not scheduled: Unschedulable: 0/3 nodes are available: 3 Insufficient memory."},
+		{"image pull", api.PodStatus{ContainerStatuses: []api.ContainerStatus{
+			{Name: "web", State: api.ContainerState{Waiting: &api.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: `Back-off pulling image "web:nope"`}}},
+		}}, `container web is waiting: ImagePullBackOff: Back-off pulling image "web:nope"`},
+		{"crash loop", api.PodStatus{ContainerStatuses: []api.ContainerStatus{{
+			Name:                 "web",
+			RestartCount:         4,
+			State:                api.ContainerState{Waiting: &api.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: api.ContainerState{Terminated: &api.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+		}}}, "container web is waiting: CrashLoopBackOff, last terminated: OOMKilled (exit code 137) after 4 restarts"},
+		{"init & readiness", api.PodStatus{
+			InitContainerStatuses: []api.ContainerStatus{
+				{Name: "done", State: api.ContainerState{Terminated: &api.ContainerStateTerminated{Reason: "Completed"}}},
+				{Name: "migrate", State: api.ContainerState{Terminated: &api.ContainerStateTerminated{Reason: "Error", ExitCode: 1, Message: "no such table"}}},
+			},
+			ContainerStatuses: []api.ContainerStatus{
+				{Name: "web", State: api.ContainerState{Running: &api.ContainerStateRunning{}}},
+			},
+		}, "init container migrate terminated: Error (exit code 1): no such table; container web is running but not ready"},
+		{"evicted", api.PodStatus{Phase: api.PodFailed, Reason: "Evicted", Message: "The node was low on resource: memory."},
+			"Evicted: The node was low on resource: memory."},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if reason := unreadyPodReason(api.Pod{Status: tc.Status}); reason != tc.Expected {
+				t.Fatalf("Expected %q, given: %q", tc.Expected, reason)
+			}
+		})
+	}
+}
+
+func TestWithUnreadyPods(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/v1/namespaces/default/pods" || r.URL.Query().Get("labelSelector") != "app=web" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"kind": "PodList", "apiVersion": "v1", "items": [
+	{"metadata": {"name": "web-b"}, "status": {"containerStatuses": [
+		{"name": "web", "state": {"waiting": {"reason": "ImagePullBackOff"}}}]}},
+	{"metadata": {"name": "web-a"}, "status": {"conditions": [{"type": "Ready", "status": "True"}]}}
+]}`)
+	}))
+	defer server.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+
+	err = withUnreadyPods(errors.New("timeout"), conn, "default", selector)
+	expected := "timeout\n\nPods which aren't ready:\n   * pod web-b: container web is waiting: ImagePullBackOff"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, given: %v", expected, err)
+	}
+	if err := withUnreadyPods(nil, conn, "default", selector); err != nil {
+		t.Fatalf("Expected no error after a successful wait, given: %s", err)
+	}
+}
@@ -0,0 +1,36 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// checkTolerations is meant to be called from CustomizeDiff of the resources with a pod spec,
+// at podSpecKey. It fails the plan with the offending tolerations when a `value` is set along
+// with the `Exists` operator, which matches any value, or `toleration_seconds` with an effect
+// other than `NoExecute`, the only one which evicts pods. Unknown values read as empty and are
+// not checked, neither is an empty effect: the API server rejects that one with a clear error.
+func checkTolerations(d *schema.ResourceDiff, podSpecKey string) error {
+	var invalid []string
+	for i, t := range d.Get(podSpecKey + ".toleration").([]interface{}) {
+		m, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if m["operator"].(string) == "Exists" && m["value"].(string) != "" {
+			invalid = append(invalid, fmt.Sprintf("\n   * %s.toleration.%d: value must be empty with the Exists operator, given %q",
+				podSpecKey, i, m["value"]))
+		}
+		effect := m["effect"].(string)
+		if m["toleration_seconds"].(int) > 0 && effect != "" && effect != "NoExecute" {
+			invalid = append(invalid, fmt.Sprintf("\n   * %s.toleration.%d: toleration_seconds can only be set with the NoExecute effect, given %s",
+				podSpecKey, i, effect))
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	return fmt.Errorf("Invalid tolerations:%s", strings.Join(invalid, ""))
+}
@@ -0,0 +1,69 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestPodTolerations(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Toleration    map[string]interface{}
+		ExpectedError string
+	}{
+		{
+			"equal",
+			map[string]interface{}{"key": "spot", "operator": "Equal", "value": "true", "effect": "NoSchedule"},
+			"",
+		},
+		{
+			"exists",
+			map[string]interface{}{"key": "nvidia.com/gpu", "operator": "Exists", "effect": "NoExecute", "toleration_seconds": 300},
+			"",
+		},
+		{
+			"exists with value",
+			map[string]interface{}{"key": "spot", "operator": "Exists", "value": "true"},
+			`spec.0.toleration.0: value must be empty with the Exists operator, given "true"`,
+		},
+		{
+			"seconds without NoExecute",
+			map[string]interface{}{"key": "spot", "operator": "Equal", "value": "true", "effect": "PreferNoSchedule", "toleration_seconds": 60},
+			"spec.0.toleration.0: toleration_seconds can only be set with the NoExecute effect, given PreferNoSchedule",
+		},
+		{
+			"unknown effect",
+			map[string]interface{}{"key": "spot", "operator": "Exists", "effect": config.UnknownVariableValue, "toleration_seconds": 60},
+			"",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec": []map[string]interface{}{{
+					"container":  []map[string]interface{}{{"name": "web", "image": "nginx"}},
+					"toleration": []map[string]interface{}{tc.Toleration},
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = resourceKubernetesPod().Diff(nil, terraform.NewResourceConfig(raw), &kubernetesProvider{})
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
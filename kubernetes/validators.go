@@ -1,15 +1,24 @@
 package kubernetes
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	apiValidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	utilValidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/jsonpath"
 )
 
 func validateAnnotations(value interface{}, key string) (ws []string, es []error) {
@@ -25,6 +34,34 @@ func validateAnnotations(value interface{}, key string) (ws []string, es []error
 	return
 }
 
+func validateBase64EncodedMap(value interface{}, key string) (ws []string, es []error) {
+	m := value.(map[string]interface{})
+	for k, v := range m {
+		if _, err := base64.StdEncoding.DecodeString(v.(string)); err != nil {
+			es = append(es, fmt.Errorf("%s (%q) must be base64 encoded: %s", key, k, err))
+		}
+	}
+	return
+}
+
+func validateJSONPathMap(value interface{}, key string) (ws []string, es []error) {
+	m := value.(map[string]interface{})
+	for k := range m {
+		if err := jsonpath.New(key).Parse(normalizeJSONPath(k)); err != nil {
+			es = append(es, fmt.Errorf("%s (%q) must be a JSONPath expression: %s", key, k, err))
+		}
+	}
+	return
+}
+
+func validateJSON(value interface{}, key string) (ws []string, es []error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(value.(string)), &v); err != nil {
+		es = append(es, fmt.Errorf("%s must be valid JSON: %s", key, err))
+	}
+	return
+}
+
 func validateName(value interface{}, key string) (ws []string, es []error) {
 	v := value.(string)
 
@@ -37,6 +74,70 @@ func validateName(value interface{}, key string) (ws []string, es []error) {
 	return
 }
 
+func validateNamespacedName(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+
+	parts := strings.Split(v, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		es = append(es, fmt.Errorf("%s (%q) must be given as <namespace>/<name>", key, v))
+		return
+	}
+	for _, e := range utilValidation.IsDNS1123Label(parts[0]) {
+		es = append(es, fmt.Errorf("%s namespace (%q) %s", key, parts[0], e))
+	}
+	for _, e := range utilValidation.IsDNS1123Subdomain(parts[1]) {
+		es = append(es, fmt.Errorf("%s name (%q) %s", key, parts[1], e))
+	}
+	return
+}
+
+func validateProxyURL(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	if v == "" {
+		return
+	}
+
+	u, err := url.Parse(v)
+	if err != nil {
+		es = append(es, fmt.Errorf("%s (%q) is not a valid URL: %s", key, v, err))
+		return
+	}
+	if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5" {
+		es = append(es, fmt.Errorf("%s (%q) must use the http, https or socks5 scheme", key, v))
+	}
+	if u.Host == "" {
+		es = append(es, fmt.Errorf("%s (%q) must include the host of the proxy", key, v))
+	}
+	return
+}
+
+func validateDNSSubdomain(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	for _, e := range utilValidation.IsDNS1123Subdomain(v) {
+		es = append(es, fmt.Errorf("%s (%q) %s", key, v, e))
+	}
+	return
+}
+
+// validateServiceAccountName accepts the name of a service account, which the API server
+// validates as a DNS subdomain, or "" for the default service account of the namespace.
+func validateServiceAccountName(value interface{}, key string) (ws []string, es []error) {
+	if value.(string) == "" {
+		return
+	}
+	return validateDNSSubdomain(value, key)
+}
+
+// validateExternalName accepts the DNS name of an ExternalName service,
+// which may be fully qualified with a trailing dot.
+func validateExternalName(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	for _, e := range utilValidation.IsDNS1123Subdomain(strings.TrimSuffix(v, ".")) {
+		es = append(es, fmt.Errorf("%s (%q) %s", key, v, e))
+	}
+	return
+}
+
 func validateGenerateName(value interface{}, key string) (ws []string, es []error) {
 	v := value.(string)
 
@@ -67,6 +168,22 @@ func validateLabels(value interface{}, key string) (ws []string, es []error) {
 	return
 }
 
+func validateLabelSelectorString(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	if _, err := labels.Parse(v); err != nil {
+		es = append(es, fmt.Errorf("%s (%q) is not a valid label selector: %s", key, v, err))
+	}
+	return
+}
+
+func validateFieldSelectorString(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	if _, err := fields.ParseSelector(v); err != nil {
+		es = append(es, fmt.Errorf("%s (%q) is not a valid field selector: %s", key, v, err))
+	}
+	return
+}
+
 func validatePortNum(value interface{}, key string) (ws []string, es []error) {
 	errors := utilValidation.IsValidPortNum(value.(int))
 	if len(errors) > 0 {
@@ -144,8 +261,11 @@ func validatePositiveInteger(value interface{}, key string) (ws []string, es []e
 
 func validateDNSPolicy(value interface{}, key string) (ws []string, es []error) {
 	v := value.(string)
-	if v != "ClusterFirst" && v != "Default" {
-		es = append(es, fmt.Errorf("%s must be either ClusterFirst or Default", key))
+	switch v {
+	case "ClusterFirst", "ClusterFirstWithHostNet", "Default", "None":
+		return
+	default:
+		es = append(es, fmt.Errorf("%s must be one of ClusterFirst, ClusterFirstWithHostNet, Default or None", key))
 	}
 	return
 }
@@ -169,6 +289,70 @@ func validateTerminationGracePeriodSeconds(value interface{}, key string) (ws []
 	return
 }
 
+func validateDuration(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		es = append(es, fmt.Errorf("%s (%q) must be a duration, e.g. 10s: %s", key, v, err))
+	} else if d <= 0 {
+		es = append(es, fmt.Errorf("%s (%q) must be a positive duration", key, v))
+	}
+	return
+}
+
+// validateNonNegativeDuration validates a duration which may be 0, e.g. to disable a delay.
+func validateNonNegativeDuration(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		es = append(es, fmt.Errorf("%s (%q) must be a duration, e.g. 10s: %s", key, v, err))
+	} else if d < 0 {
+		es = append(es, fmt.Errorf("%s (%q) must not be a negative duration", key, v))
+	}
+	return
+}
+
+// validatePollInterval validates a duration which resource.StateChangeConf
+// honors as poll interval, it ignores 3 minutes or more.
+func validatePollInterval(value interface{}, key string) (ws []string, es []error) {
+	ws, es = validateDuration(value, key)
+	if len(es) > 0 {
+		return
+	}
+	if d, _ := time.ParseDuration(value.(string)); d >= 3*time.Minute {
+		es = append(es, fmt.Errorf("%s (%q) must be shorter than 3m", key, value))
+	}
+	return
+}
+
+func validateIPAddress(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	for _, e := range utilValidation.IsValidIP(v) {
+		es = append(es, fmt.Errorf("%s (%q) %s", key, v, e))
+	}
+	return
+}
+
+func validateCIDR(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	if _, _, err := net.ParseCIDR(v); err != nil {
+		es = append(es, fmt.Errorf("%s (%q) must be a CIDR block, e.g. 10.0.0.0/8: %s", key, v, err))
+	}
+	return
+}
+
+// sysctlNameRegexp matches the sysctl names accepted by the API server,
+// e.g. net.core.somaxconn or kernel/shm_rmid_forced
+var sysctlNameRegexp = regexp.MustCompile(`^([a-z0-9]([-_a-z0-9]*[a-z0-9])?[\./])*[a-z0-9]([-_a-z0-9]*[a-z0-9])?$`)
+
+func validateSysctlName(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	if len(v) > 253 || !sysctlNameRegexp.MatchString(v) {
+		es = append(es, fmt.Errorf("%s (%q) must be a valid sysctl name made of lowercase segments separated by '.' or '/', e.g. net.core.somaxconn", key, v))
+	}
+	return
+}
+
 func validateModeBits(value interface{}, key string) (ws []string, es []error) {
 	v := value.(int)
 	if v < 0 || v > 0777 {
@@ -0,0 +1,123 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// checkVolumeMountsReferenceVolumes is meant to be called from CustomizeDiff of the resources
+// with a pod spec, at podSpecKey. The API server rejects a pod whose containers mount a volume
+// the pod doesn't declare with a "volumeMounts[0].name: Not found" error per mount, this fails
+// the plan with the offending mounts instead. extraVolumes are the names of volumes declared
+// outside of the pod spec, like the volume claim templates of a stateful set.
+// Volume names which aren't known until apply skip the check, unknown mount names aren't checked.
+func checkVolumeMountsReferenceVolumes(d *schema.ResourceDiff, podSpecKey string, extraVolumes ...string) error {
+	volumes := make(map[string]bool, len(extraVolumes))
+	for _, name := range extraVolumes {
+		volumes[name] = true
+	}
+	for _, v := range d.Get(podSpecKey + ".volume").([]interface{}) {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// Names are required, an empty one isn't known until apply
+		name := m["name"].(string)
+		if name == "" || name == config.UnknownVariableValue {
+			return nil
+		}
+		volumes[name] = true
+	}
+
+	var mismatches []string
+	for _, key := range []string{"init_container", "container"} {
+		for i, c := range d.Get(podSpecKey + "." + key).([]interface{}) {
+			cm, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			mounts, _ := cm["volume_mount"].([]interface{})
+			for j, vm := range mounts {
+				mm, ok := vm.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				name := mm["name"].(string)
+				if name == "" || name == config.UnknownVariableValue || volumes[name] {
+					continue
+				}
+				mismatches = append(mismatches, fmt.Sprintf("\n   * %s.%s.%d.volume_mount.%d: %q (container %q)",
+					podSpecKey, key, i, j, name, cm["name"]))
+			}
+		}
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+	return fmt.Errorf("Every volume_mount must reference a volume declared in %s.volume by name, no volume is declared for:%s",
+		podSpecKey, strings.Join(mismatches, ""))
+}
+
+// checkProjectedVolumeSources is meant to be called from CustomizeDiff of the resources with a pod
+// spec, at podSpecKey, next to checkVolumeMountsReferenceVolumes. Each source of a projected volume
+// projects exactly one of config_map, downward_api, secret or service_account_token, this fails the
+// plan with the offending sources instead of the apply.
+func checkProjectedVolumeSources(d *schema.ResourceDiff, podSpecKey string) error {
+	var invalid []string
+	for i, v := range d.Get(podSpecKey + ".volume").([]interface{}) {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		projected, _ := m["projected"].([]interface{})
+		if len(projected) == 0 || projected[0] == nil {
+			continue
+		}
+		sources, _ := projected[0].(map[string]interface{})["sources"].([]interface{})
+		for j, s := range sources {
+			count := 0
+			if src, ok := s.(map[string]interface{}); ok {
+				for _, k := range []string{"config_map", "downward_api", "secret", "service_account_token"} {
+					// An empty downward_api block projects nothing, but it's specified
+					if l, ok := src[k].([]interface{}); ok && len(l) > 0 && (l[0] != nil || k == "downward_api") {
+						count++
+					}
+				}
+			}
+			if count != 1 {
+				invalid = append(invalid, fmt.Sprintf("\n   * %s.volume.%d.projected.0.sources.%d: %d specified (volume %q)",
+					podSpecKey, i, j, count, m["name"]))
+			}
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	return fmt.Errorf("Every projected volume source must specify exactly one of config_map, downward_api, secret or service_account_token:%s",
+		strings.Join(invalid, ""))
+}
+
+// volumeClaimTemplateNames returns the names of the volume claim templates of a stateful set,
+// which its containers mount like volumes. It's false if any of the names isn't known until apply.
+func volumeClaimTemplateNames(d *schema.ResourceDiff) ([]string, bool) {
+	var names []string
+	for _, t := range d.Get("spec.0.volume_claim_templates").([]interface{}) {
+		m, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		metadata, _ := m["metadata"].([]interface{})
+		if len(metadata) == 0 || metadata[0] == nil {
+			continue
+		}
+		name := metadata[0].(map[string]interface{})["name"].(string)
+		if name == "" || name == config.UnknownVariableValue {
+			return nil, false
+		}
+		names = append(names, name)
+	}
+	return names, true
+}
@@ -0,0 +1,220 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestPodVolumeMountsReferenceVolumes(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Volumes       []map[string]interface{}
+		Mounts        []map[string]interface{}
+		ExpectedError string
+	}{
+		{
+			"claim volume",
+			[]map[string]interface{}{{
+				"name":                    "data",
+				"persistent_volume_claim": []map[string]interface{}{{"claim_name": "data", "read_only": true}},
+			}},
+			[]map[string]interface{}{{"name": "data", "mount_path": "/data", "sub_path": "app", "mount_propagation": "HostToContainer"}},
+			"",
+		},
+		{
+			"undeclared volume",
+			[]map[string]interface{}{{"name": "data", "empty_dir": []map[string]interface{}{{}}}},
+			[]map[string]interface{}{{"name": "data", "mount_path": "/data"}, {"name": "cache", "mount_path": "/cache"}},
+			`spec.0.container.0.volume_mount.1: "cache" (container "web")`,
+		},
+		{
+			"unknown volume name",
+			[]map[string]interface{}{{"name": config.UnknownVariableValue, "empty_dir": []map[string]interface{}{{}}}},
+			[]map[string]interface{}{{"name": "data", "mount_path": "/data"}},
+			"",
+		},
+		{
+			"unknown mount name",
+			nil,
+			[]map[string]interface{}{{"name": config.UnknownVariableValue, "mount_path": "/data"}},
+			"",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			spec := map[string]interface{}{
+				"container": []map[string]interface{}{{"name": "web", "image": "nginx", "volume_mount": tc.Mounts}},
+			}
+			if tc.Volumes != nil {
+				spec["volume"] = tc.Volumes
+			}
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec":     []map[string]interface{}{spec},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = resourceKubernetesPod().Diff(nil, terraform.NewResourceConfig(raw), &kubernetesProvider{})
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestPodProjectedVolumeSources(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Sources       []map[string]interface{}
+		ExpectedError string
+	}{
+		{
+			"one projection per source",
+			[]map[string]interface{}{
+				{"secret": []map[string]interface{}{{"name": "app-secret"}}},
+				{"downward_api": []map[string]interface{}{{}}},
+				{"service_account_token": []map[string]interface{}{{"path": "token"}}},
+			},
+			"",
+		},
+		{
+			"two projections",
+			[]map[string]interface{}{{
+				"config_map": []map[string]interface{}{{"name": "app-config"}},
+				"secret":     []map[string]interface{}{{"name": "app-secret"}},
+			}},
+			`spec.0.volume.0.projected.0.sources.0: 2 specified (volume "projected")`,
+		},
+		{
+			"no projection",
+			[]map[string]interface{}{
+				{"secret": []map[string]interface{}{{"name": "app-secret"}}},
+				{},
+			},
+			`spec.0.volume.0.projected.0.sources.1: 0 specified (volume "projected")`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "web"}},
+				"spec": []map[string]interface{}{{
+					"container": []map[string]interface{}{{"name": "web", "image": "nginx"}},
+					"volume": []map[string]interface{}{{
+						"name":      "projected",
+						"projected": []map[string]interface{}{{"sources": tc.Sources}},
+					}},
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = resourceKubernetesPod().Diff(nil, terraform.NewResourceConfig(raw), &kubernetesProvider{})
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestStatefulSetVolumeMountsReferenceClaimTemplates(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Mount         string
+		ExpectedError string
+	}{
+		{"claim template", "data", ""},
+		{"undeclared volume", "logs", `spec.0.template.0.spec.0.container.0.volume_mount.0: "logs" (container "db")`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			raw, err := config.NewRawConfig(map[string]interface{}{
+				"metadata": []map[string]interface{}{{"name": "db"}},
+				"spec": []map[string]interface{}{{
+					"service_name": "db",
+					"selector":     map[string]interface{}{"app": "db"},
+					"template": []map[string]interface{}{{
+						"metadata": []map[string]interface{}{{"labels": map[string]interface{}{"app": "db"}}},
+						"spec": []map[string]interface{}{{
+							"container": []map[string]interface{}{{
+								"name":         "db",
+								"image":        "postgres",
+								"volume_mount": []map[string]interface{}{{"name": tc.Mount, "mount_path": "/var/lib/postgresql"}},
+							}},
+						}},
+					}},
+					"volume_claim_templates": []map[string]interface{}{{
+						"metadata": []map[string]interface{}{{"name": "data"}},
+						"spec": []map[string]interface{}{{
+							"access_modes": []interface{}{"ReadWriteOnce"},
+							"resources":    []map[string]interface{}{{"requests": map[string]interface{}{"storage": "1Gi"}}},
+						}},
+					}},
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = resourceKubernetesStatefulSet().Diff(nil, terraform.NewResourceConfig(raw), &kubernetesProvider{})
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected error to contain %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestExpandContainerVolumeMountsPropagation(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{"name": "data", "mount_path": "/data", "mount_propagation": "None"},
+		map[string]interface{}{"name": "host", "mount_path": "/host", "mount_propagation": "HostToContainer"},
+	}
+	mounts, err := expandContainerVolumeMounts(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mounts[0].MountPropagation != nil {
+		t.Fatalf("Expected no mount propagation for None, given: %q", *mounts[0].MountPropagation)
+	}
+	if mounts[1].MountPropagation == nil || *mounts[1].MountPropagation != "HostToContainer" {
+		t.Fatalf("Expected HostToContainer mount propagation, given: %#v", mounts[1].MountPropagation)
+	}
+
+	flattened, err := flattenContainerVolumeMounts(mounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range flattened {
+		expected := in[i].(map[string]interface{})["mount_propagation"]
+		if given := m.(map[string]interface{})["mount_propagation"]; given != expected {
+			t.Fatalf("Expected mount propagation %q, given: %q", expected, given)
+		}
+	}
+}
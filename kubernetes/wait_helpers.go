@@ -0,0 +1,150 @@
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sSchema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// The generic wait machinery: objects of any kind (including custom resources)
+// are read as raw JSON, since the dynamic client isn't vendored, and
+// checked against status conditions & JSONPath field values.
+
+type waitCondition struct {
+	Type   string
+	Status string
+}
+
+// kindNotFoundError is returned while the API server doesn't serve the kind
+// (yet), e.g. because the CRD defining it hasn't been installed.
+type kindNotFoundError struct {
+	APIVersion string
+	Kind       string
+}
+
+func (e *kindNotFoundError) Error() string {
+	return fmt.Sprintf("The API server doesn't serve kind %q in %q", e.Kind, e.APIVersion)
+}
+
+// apiResourceForKind finds the resource (e.g. `deployments`) serving the given kind.
+func apiResourceForKind(disco discovery.ServerResourcesInterface, apiVersion, kind string) (*meta_v1.APIResource, error) {
+	resList, err := disco.ServerResourcesForGroupVersion(apiVersion)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, &kindNotFoundError{APIVersion: apiVersion, Kind: kind}
+		}
+		return nil, err
+	}
+	for _, r := range resList.APIResources {
+		// Subresources like deployments/status have the kind of their parent
+		if r.Kind == kind && !strings.Contains(r.Name, "/") {
+			return &r, nil
+		}
+	}
+	return nil, &kindNotFoundError{APIVersion: apiVersion, Kind: kind}
+}
+
+// objectAPIPath returns the path of the named object of the given resource.
+// The namespace is ignored for resources which aren't namespaced.
+func objectAPIPath(apiVersion string, res *meta_v1.APIResource, namespace, name string) (string, error) {
+	gv, err := k8sSchema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return "", err
+	}
+	path := "/apis/" + gv.String()
+	if gv.Group == "" {
+		path = "/api/" + gv.Version
+	}
+	if res.Namespaced {
+		if namespace == "" {
+			namespace = "default"
+		}
+		path += "/namespaces/" + namespace
+	}
+	return path + "/" + res.Name + "/" + name, nil
+}
+
+// normalizeJSONPath accepts both `status.phase` and `{.status.phase}`
+func normalizeJSONPath(path string) string {
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	return "{." + strings.TrimPrefix(path, ".") + "}"
+}
+
+func lookupJSONPath(obj interface{}, path string) (string, error) {
+	j := jsonpath.New("wait")
+	j.AllowMissingKeys(true)
+	if err := j.Parse(normalizeJSONPath(path)); err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	if err := j.Execute(buf, obj); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// unmetWaitTargets lists the conditions & fields of the object which don't have
+// the expected values (yet). The object is ready to use when the list is empty.
+func unmetWaitTargets(obj map[string]interface{}, conditions []waitCondition, fields map[string]string) ([]string, error) {
+	unmet := make([]string, 0)
+
+	for _, c := range conditions {
+		status := ""
+		if st, ok := obj["status"].(map[string]interface{}); ok {
+			list, _ := st["conditions"].([]interface{})
+			for _, v := range list {
+				item, ok := v.(map[string]interface{})
+				if ok && item["type"] == c.Type {
+					status, _ = item["status"].(string)
+					break
+				}
+			}
+		}
+		if status != c.Status {
+			if status == "" {
+				status = "missing"
+			}
+			unmet = append(unmet, fmt.Sprintf("condition %s is %s, expected %s", c.Type, status, c.Status))
+		}
+	}
+
+	paths := make([]string, 0, len(fields))
+	for path := range fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		value, err := lookupJSONPath(obj, path)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to evaluate %q: %s", path, err)
+		}
+		if value != fields[path] {
+			unmet = append(unmet, fmt.Sprintf("%s is %q, expected %q", path, value, fields[path]))
+		}
+	}
+
+	return unmet, nil
+}
+
+// lastObservedStatus renders the status of the object for error messages.
+func lastObservedStatus(obj map[string]interface{}) string {
+	st, ok := obj["status"]
+	if !ok {
+		return "none"
+	}
+	b, err := json.Marshal(st)
+	if err != nil {
+		return fmt.Sprintf("%v", st)
+	}
+	return string(b)
+}
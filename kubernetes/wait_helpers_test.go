@@ -0,0 +1,131 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestApiResourceForKind(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1":
+			fmt.Fprint(w, `{"kind": "APIResourceList", "groupVersion": "v1", "resources": [
+	{"name": "namespaces", "namespaced": false, "kind": "Namespace", "verbs": ["get"]},
+	{"name": "pods", "namespaced": true, "kind": "Pod", "verbs": ["get"]},
+	{"name": "pods/status", "namespaced": true, "kind": "Pod", "verbs": ["get"]}
+]}`)
+		case "/apis/example.com/v1":
+			fmt.Fprint(w, `{"kind": "APIResourceList", "groupVersion": "example.com/v1", "resources": [
+	{"name": "widgets/status", "namespaced": true, "kind": "Widget", "verbs": ["get"]},
+	{"name": "widgets", "namespaced": true, "kind": "Widget", "verbs": ["get"]}
+]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound", "code": 404}`)
+		}
+	}))
+	defer server.Close()
+
+	disco, err := discovery.NewDiscoveryClientForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		APIVersion string
+		Kind       string
+		Namespace  string
+		Expected   string
+	}{
+		{"example.com/v1", "Widget", "team-a", "/apis/example.com/v1/namespaces/team-a/widgets/test"},
+		{"example.com/v1", "Widget", "", "/apis/example.com/v1/namespaces/default/widgets/test"},
+		{"v1", "Pod", "kube-system", "/api/v1/namespaces/kube-system/pods/test"},
+		{"v1", "Namespace", "ignored", "/api/v1/namespaces/test"},
+	}
+	for _, tc := range cases {
+		res, err := apiResourceForKind(disco, tc.APIVersion, tc.Kind)
+		if err != nil {
+			t.Fatalf("Failed to find %s %s: %s", tc.APIVersion, tc.Kind, err)
+		}
+		path, err := objectAPIPath(tc.APIVersion, res, tc.Namespace, "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path != tc.Expected {
+			t.Fatalf("Expected path %q for %s %s, given: %q", tc.Expected, tc.APIVersion, tc.Kind, path)
+		}
+	}
+
+	for _, tc := range [][]string{{"example.com/v1", "Gadget"}, {"missing.example.com/v1", "Widget"}} {
+		_, err := apiResourceForKind(disco, tc[0], tc[1])
+		if _, ok := err.(*kindNotFoundError); !ok {
+			t.Fatalf("Expected a kindNotFoundError for %s %s, given: %#v", tc[0], tc[1], err)
+		}
+	}
+}
+
+func TestUnmetWaitTargets(t *testing.T) {
+	obj := make(map[string]interface{})
+	err := json.Unmarshal([]byte(`{
+	"metadata": {"name": "test"},
+	"status": {
+		"phase": "Running",
+		"replicas": 3,
+		"conditions": [
+			{"type": "Ready", "status": "True"},
+			{"type": "Degraded", "status": "False"}
+		]
+	}
+}`), &obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		Conditions []waitCondition
+		Fields     map[string]string
+		Expected   []string
+	}{
+		{
+			Conditions: []waitCondition{{Type: "Ready", Status: "True"}, {Type: "Degraded", Status: "False"}},
+			Fields:     map[string]string{"status.phase": "Running", "{.status.replicas}": "3"},
+			Expected:   []string{},
+		},
+		{
+			Conditions: []waitCondition{{Type: "Ready", Status: "False"}, {Type: "Available", Status: "True"}},
+			Fields:     map[string]string{"status.phase": "Succeeded", "status.missing": "x"},
+			Expected: []string{
+				"condition Ready is True, expected False",
+				"condition Available is missing, expected True",
+				`status.missing is "", expected "x"`,
+				`status.phase is "Running", expected "Succeeded"`,
+			},
+		},
+	}
+	for i, tc := range cases {
+		unmet, err := unmetWaitTargets(obj, tc.Conditions, tc.Fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(unmet, tc.Expected) {
+			t.Fatalf("%d: Expected unmet targets %#v, given: %#v", i, tc.Expected, unmet)
+		}
+	}
+
+	expected := `{"conditions":[{"status":"True","type":"Ready"},{"status":"False","type":"Degraded"}],"phase":"Running","replicas":3}`
+	if s := lastObservedStatus(obj); s != expected {
+		t.Fatalf("Expected last observed status %s, given: %s", expected, s)
+	}
+	if s := lastObservedStatus(map[string]interface{}{"metadata": meta_v1.ObjectMeta{}}); s != "none" {
+		t.Fatalf("Expected no last observed status, given: %s", s)
+	}
+}
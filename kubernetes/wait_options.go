@@ -0,0 +1,121 @@
+package kubernetes
+
+import (
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func waitPollIntervalSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "How often the object is polled while waiting for it, as a duration like `10s`. Must be shorter than `3m`. Defaults to an exponential backoff from 100ms up to 10s.",
+		Optional:     true,
+		ValidateFunc: validatePollInterval,
+	}
+}
+
+func waitMaxPollIntervalSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeString,
+		Description:   "Enables an exponential backoff with random jitter up to this duration between two polls while waiting for the object, as a duration like `30s`, so many waits started at once don't poll in lockstep. It starts from `min_timeout`. Must be shorter than `3m`.",
+		Optional:      true,
+		ValidateFunc:  validatePollInterval,
+		ConflictsWith: []string{"poll_interval"},
+	}
+}
+
+func waitPollDelaySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "How long to wait before the first poll while waiting for the object to be bound, as a duration like `30s`, e.g. for objects known to take a while. The other waits poll right away. Defaults to `0s`, polling right away.",
+		Optional:     true,
+		ValidateFunc: validateNonNegativeDuration,
+	}
+}
+
+func waitMinTimeoutSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "The shortest time between two polls of the backoff while waiting for the object, as a duration like `2s`. Ignored when `poll_interval` is set.",
+		Optional:     true,
+		ValidateFunc: validateDuration,
+	}
+}
+
+// setWaitPollDelay delays the first poll of conf by the `poll_delay` argument of the resource.
+// It's only meant for the wait until the object is bound: the polls of the other waits can succeed right away.
+func setWaitPollDelay(d *schema.ResourceData, conf *resource.StateChangeConf) {
+	// Validated as a duration
+	if v, ok := d.GetOk("poll_delay"); ok {
+		conf.Delay, _ = time.ParseDuration(v.(string))
+	}
+}
+
+// setWaitPollOptions tunes how often conf polls the API server from the
+// `poll_interval`, `min_timeout` & `max_poll_interval` arguments of the resource.
+// It must be called once the Refresh of conf is set.
+func setWaitPollOptions(d *schema.ResourceData, conf *resource.StateChangeConf) {
+	// All are validated as durations
+	if v, ok := d.GetOk("poll_interval"); ok {
+		conf.PollInterval, _ = time.ParseDuration(v.(string))
+	}
+	if v, ok := d.GetOk("min_timeout"); ok {
+		conf.MinTimeout, _ = time.ParseDuration(v.(string))
+	}
+	if v, ok := d.GetOk("max_poll_interval"); ok {
+		max, _ := time.ParseDuration(v.(string))
+		log.Printf("[DEBUG] Polling with a jittered backoff from %s up to %s while waiting", conf.MinTimeout, max)
+		// The backoff of conf can't be jittered, nor capped above 10s:
+		// the delays are slept before each refresh instead, conf itself barely waits.
+		backoff := newJitteredBackoff(conf.MinTimeout, max)
+		refresh := conf.Refresh
+		polled := false
+		conf.Refresh = func() (interface{}, string, error) {
+			if polled {
+				time.Sleep(backoff.delay())
+			}
+			polled = true
+			return refresh()
+		}
+		conf.PollInterval = time.Millisecond
+		conf.MinTimeout = 0
+		return
+	}
+	log.Printf("[DEBUG] Polling every %s, at least every %s while waiting", conf.PollInterval, conf.MinTimeout)
+}
+
+// jitteredBackoff is an exponential backoff between the polls of a wait. The delay doubles up to max,
+// each one is randomized over its upper half so concurrent waits spread out their polls.
+type jitteredBackoff struct {
+	next time.Duration
+	max  time.Duration
+	rand *rand.Rand
+}
+
+func newJitteredBackoff(min, max time.Duration) *jitteredBackoff {
+	if min <= 0 {
+		min = 100 * time.Millisecond
+	}
+	if min > max {
+		min = max
+	}
+	return &jitteredBackoff{
+		next: min,
+		max:  max,
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (b *jitteredBackoff) delay() time.Duration {
+	d := b.next
+	b.next *= 2
+	if b.next > b.max {
+		b.next = b.max
+	}
+	half := int64(d / 2)
+	return time.Duration(half + b.rand.Int63n(int64(d)-half+1))
+}
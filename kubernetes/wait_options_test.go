@@ -0,0 +1,140 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestSetWaitPollOptions(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"poll_interval": waitPollIntervalSchema(),
+		"min_timeout":   waitMinTimeoutSchema(),
+		"poll_delay":    waitPollDelaySchema(),
+	}
+
+	cases := map[string]struct {
+		Config       map[string]interface{}
+		PollInterval time.Duration
+		MinTimeout   time.Duration
+	}{
+		"defaults": {
+			Config: map[string]interface{}{},
+		},
+		"configured": {
+			Config:       map[string]interface{}{"poll_interval": "15s", "min_timeout": "500ms", "poll_delay": "30s"},
+			PollInterval: 15 * time.Second,
+			MinTimeout:   500 * time.Millisecond,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, s, tc.Config)
+			conf := &resource.StateChangeConf{}
+			setWaitPollOptions(d, conf)
+
+			if conf.PollInterval != tc.PollInterval {
+				t.Fatalf("Expected poll interval %s, given: %s", tc.PollInterval, conf.PollInterval)
+			}
+			if conf.MinTimeout != tc.MinTimeout {
+				t.Fatalf("Expected min timeout %s, given: %s", tc.MinTimeout, conf.MinTimeout)
+			}
+			// Only the wait until bound is delayed, see setWaitPollDelay
+			if conf.Delay != 0 {
+				t.Fatalf("Expected no delay, given: %s", conf.Delay)
+			}
+		})
+	}
+}
+
+func TestSetWaitPollDelay(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"poll_delay": waitPollDelaySchema(),
+	}
+
+	cases := map[string]struct {
+		Config map[string]interface{}
+		Delay  time.Duration
+	}{
+		"defaults": {
+			Config: map[string]interface{}{},
+		},
+		"configured": {
+			Config: map[string]interface{}{"poll_delay": "30s"},
+			Delay:  30 * time.Second,
+		},
+		"no delay": {
+			Config: map[string]interface{}{"poll_delay": "0s"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, s, tc.Config)
+			conf := &resource.StateChangeConf{}
+			setWaitPollDelay(d, conf)
+
+			if conf.Delay != tc.Delay {
+				t.Fatalf("Expected delay %s, given: %s", tc.Delay, conf.Delay)
+			}
+		})
+	}
+}
+
+func TestJitteredBackoff(t *testing.T) {
+	b := newJitteredBackoff(100*time.Millisecond, 400*time.Millisecond)
+
+	// The delays double up to the cap, each within the upper half of its step
+	steps := []time.Duration{100, 200, 400, 400, 400}
+	for i, step := range steps {
+		step *= time.Millisecond
+		if d := b.delay(); d < step/2 || d > step {
+			t.Fatalf("Expected delay %d between %s and %s, given: %s", i, step/2, step, d)
+		}
+	}
+
+	// Without a min timeout the backoff starts from 100ms
+	if b := newJitteredBackoff(0, time.Minute); b.next != 100*time.Millisecond {
+		t.Fatalf("Expected the backoff to start from 100ms, given: %s", b.next)
+	}
+}
+
+func TestSetWaitPollOptionsWithJitter(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"poll_interval":     waitPollIntervalSchema(),
+		"min_timeout":       waitMinTimeoutSchema(),
+		"max_poll_interval": waitMaxPollIntervalSchema(),
+	}
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{"min_timeout": "20ms", "max_poll_interval": "40ms"})
+
+	refreshes := 0
+	conf := &resource.StateChangeConf{
+		Pending: []string{"Pending"},
+		Target:  []string{"Bound"},
+		Timeout: time.Minute,
+		Refresh: func() (interface{}, string, error) {
+			refreshes++
+			if refreshes < 4 {
+				return refreshes, "Pending", nil
+			}
+			return refreshes, "Bound", nil
+		},
+	}
+	setWaitPollOptions(d, conf)
+	if conf.MinTimeout != 0 {
+		t.Fatalf("Expected the backoff of the conf to be disabled, given min timeout %s", conf.MinTimeout)
+	}
+
+	start := time.Now()
+	_, err := conf.WaitForState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Three delays of the backoff, of at least 10ms, 20ms & 20ms
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("Expected the polls to back off, given: %s", elapsed)
+	}
+}
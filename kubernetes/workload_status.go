@@ -0,0 +1,33 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// workloadStatusSchema is the computed status of the workload resources. Its observed generation
+// tells whether the controller has acted on the latest spec, the one in `metadata.0.generation`:
+// the rest of the status of the workload is stale until both are equal.
+func workloadStatusSchema(objectName string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: fmt.Sprintf("Current status of the %s, as observed by its controller.", objectName),
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"observed_generation": {
+					Type:        schema.TypeInt,
+					Description: fmt.Sprintf("The generation of the %s its controller last acted on. It's behind `metadata.0.generation` until the controller caught up with the latest spec.", objectName),
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func flattenWorkloadStatus(observedGeneration int64) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"observed_generation": int(observedGeneration),
+	}}
+}